@@ -2,27 +2,37 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
-	"github.com/golang/glog"
+	// glog registers the -v command-line argument, which sets the level of the logger, and the other logging arguments
+	_ "github.com/golang/glog"
+	"github.com/nginxinc/kubernetes-ingress/internal/acme"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	"github.com/nginxinc/kubernetes-ingress/internal/tracing"
 	cr_validation "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	conf_scheme "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned/scheme"
@@ -182,107 +192,167 @@ var (
 
 	readyStatusPort = flag.Int("ready-status-port", 8081, "Set the port where the readiness endpoint is exposed. [1024 - 65535]")
 
+	enableDebugEndpoint = flag.Bool("enable-debug-endpoint", false,
+		`Enable the debug endpoint '/debug/'. The endpoint returns JSON with the in-memory model of the Ingress Controller:
+	the hosts and listeners with their owners, the configuration problems, the metadata of the secrets, the references of the resources
+	and the generated config of a resource. The endpoint doesn't require authentication, so don't expose its port outside of the cluster`)
+
+	debugEndpointPort = flag.Int("debug-endpoint-port", 8082, "Set the port where the debug endpoint is exposed. [1024 - 65535]")
+
 	enableLatencyMetrics = flag.Bool("enable-latency-metrics", false,
 		"Enable collection of latency metrics for upstreams. Requires -enable-prometheus-metrics")
 
+	enableDynamicSSLReload = flag.Bool("ssl-dynamic-reload", false,
+		`Enable updating TLS certificates and keys of Ingress and VirtualServer resources without reloading NGINX.
+	NGINX loads a certificate and key from the file system during every TLS handshake, which increases the CPU usage per handshake.`)
+
+	enableACME = flag.Bool("enable-acme", false,
+		`Enable issuing TLS certificates for VirtualServer resources from ACME servers using the HTTP-01 challenge. Requires -enable-custom-resources`)
+
+	acmeAccountSecret = flag.String("acme-account-secret", "",
+		`A Secret with the ACME account key. If the Secret doesn't exist, the Ingress Controller creates it with a new key. Format: <namespace>/<name>.
+	(default "<namespace of the Ingress Controller>/nginx-ingress-acme-account"). Requires -enable-acme`)
+
+	acmeEmail = flag.String("acme-email", "",
+		`A contact email of the ACME account. Requires -enable-acme`)
+
+	acmeCABundle = flag.String("acme-ca-bundle", "",
+		`A path to a file with PEM-encoded CA certificates to verify the TLS certificates of ACME servers, for example, of a local test ACME server.
+	If not set, the system CA certificates are used. Requires -enable-acme`)
+
+	acmeRenewBefore = flag.Duration("acme-renew-before", 720*time.Hour,
+		`How long before the expiration a certificate issued by an ACME server is renewed. Requires -enable-acme`)
+
+	enableTopologyAwareRouting = flag.Bool("enable-topology-aware-routing", false,
+		`Enable topology aware routing for the upstreams of VirtualServer, VirtualServerRoute and TransportServer resources.
+	The Ingress Controller finds its zone and the zones of the endpoints from the topology.kubernetes.io/zone label of the nodes.
+	Requires the NODE_NAME environment variable set to the node of the Ingress Controller pod`)
+
+	otelExporterEndpoint = flag.String("otel-exporter-endpoint", "",
+		`Enable tracing of the Ingress Controller with spans exported to the OTLP/HTTP traces endpoint, for example, http://otel-collector:4318/v1/traces.
+	The spans cover the processing of resource changes, including the generation of the NGINX configuration and NGINX reloads`)
+
+	otelServiceName = flag.String("otel-service-name", "nginx-ingress-controller",
+		`The service name of the spans of the Ingress Controller. Requires -otel-exporter-endpoint`)
+
+	logFormat = flag.String("log-format", nl.FormatGlog,
+		`The format of the log messages: glog, json or text. The json and text formats output every message with the fields that identify its resource,
+	such as the kind, namespace and name, as separate keys`)
+
 	startupCheckFn func() error
 )
 
 func main() {
 	flag.Parse()
 
-	err := flag.Lookup("logtostderr").Value.Set("true")
+	l, err := createLogger()
 	if err != nil {
-		glog.Fatalf("Error setting logtostderr to true: %v", err)
+		nl.Fatalf(nil, "Error creating the logger: %v", err)
 	}
+	slog.SetDefault(l)
 
 	versionInfo := fmt.Sprintf("Version=%v GitCommit=%v Date=%v Arch=%v/%v", version, commit, date, runtime.GOOS, runtime.GOARCH)
 	if *versionFlag {
 		fmt.Println(versionInfo)
 		os.Exit(0)
 	}
-	glog.Infof("Starting NGINX Ingress controller %v PlusFlag=%v", versionInfo, *nginxPlus)
+	nl.Infof(l, "Starting NGINX Ingress controller %v PlusFlag=%v", versionInfo, *nginxPlus)
 
 	if startupCheckFn != nil {
 		err := startupCheckFn()
 		if err != nil {
-			glog.Fatalf("Failed startup check: %v", err)
+			nl.Fatalf(l, "Failed startup check: %v", err)
 		}
 	}
 
 	healthStatusURIValidationError := validateLocation(*healthStatusURI)
 	if healthStatusURIValidationError != nil {
-		glog.Fatalf("Invalid value for health-status-uri: %v", healthStatusURIValidationError)
+		nl.Fatalf(l, "Invalid value for health-status-uri: %v", healthStatusURIValidationError)
 	}
 
 	statusLockNameValidationError := validateResourceName(*leaderElectionLockName)
 	if statusLockNameValidationError != nil {
-		glog.Fatalf("Invalid value for leader-election-lock-name: %v", statusLockNameValidationError)
+		nl.Fatalf(l, "Invalid value for leader-election-lock-name: %v", statusLockNameValidationError)
 	}
 
 	statusPortValidationError := validatePort(*nginxStatusPort)
 	if statusPortValidationError != nil {
-		glog.Fatalf("Invalid value for nginx-status-port: %v", statusPortValidationError)
+		nl.Fatalf(l, "Invalid value for nginx-status-port: %v", statusPortValidationError)
 	}
 
 	metricsPortValidationError := validatePort(*prometheusMetricsListenPort)
 	if metricsPortValidationError != nil {
-		glog.Fatalf("Invalid value for prometheus-metrics-listen-port: %v", metricsPortValidationError)
+		nl.Fatalf(l, "Invalid value for prometheus-metrics-listen-port: %v", metricsPortValidationError)
 	}
 
 	readyStatusPortValidationError := validatePort(*readyStatusPort)
 	if readyStatusPortValidationError != nil {
-		glog.Fatalf("Invalid value for ready-status-port: %v", readyStatusPortValidationError)
+		nl.Fatalf(l, "Invalid value for ready-status-port: %v", readyStatusPortValidationError)
+	}
+
+	debugEndpointPortValidationError := validatePort(*debugEndpointPort)
+	if debugEndpointPortValidationError != nil {
+		nl.Fatalf(l, "Invalid value for debug-endpoint-port: %v", debugEndpointPortValidationError)
 	}
 
 	allowedCIDRs, err := parseNginxStatusAllowCIDRs(*nginxStatusAllowCIDRs)
 	if err != nil {
-		glog.Fatalf(`Invalid value for nginx-status-allow-cidrs: %v`, err)
+		nl.Fatalf(l, `Invalid value for nginx-status-allow-cidrs: %v`, err)
 	}
 
 	if *enableTLSPassthrough && !*enableCustomResources {
-		glog.Fatal("enable-tls-passthrough flag requires -enable-custom-resources")
+		nl.Fatal(l, "enable-tls-passthrough flag requires -enable-custom-resources")
 	}
 
 	if *appProtect && !*nginxPlus {
-		glog.Fatal("NGINX App Protect support is for NGINX Plus only")
+		nl.Fatal(l, "NGINX App Protect support is for NGINX Plus only")
 	}
 
 	if *appProtectDos && !*nginxPlus {
-		glog.Fatal("NGINX App Protect Dos support is for NGINX Plus only")
+		nl.Fatal(l, "NGINX App Protect Dos support is for NGINX Plus only")
 	}
 
 	if *appProtectDosDebug && !*appProtectDos && !*nginxPlus {
-		glog.Fatal("NGINX App Protect Dos debug support is for NGINX Plus only and App Protect Dos is enable")
+		nl.Fatal(l, "NGINX App Protect Dos debug support is for NGINX Plus only and App Protect Dos is enable")
 	}
 
 	if *appProtectDosMaxDaemons != 0 && !*appProtectDos && !*nginxPlus {
-		glog.Fatal("NGINX App Protect Dos max daemons support is for NGINX Plus only and App Protect Dos is enable")
+		nl.Fatal(l, "NGINX App Protect Dos max daemons support is for NGINX Plus only and App Protect Dos is enable")
 	}
 
 	if *appProtectDosMaxWorkers != 0 && !*appProtectDos && !*nginxPlus {
-		glog.Fatal("NGINX App Protect Dos max workers support is for NGINX Plus and App Protect Dos is enable")
+		nl.Fatal(l, "NGINX App Protect Dos max workers support is for NGINX Plus and App Protect Dos is enable")
 	}
 
 	if *appProtectDosMemory != 0 && !*appProtectDos && !*nginxPlus {
-		glog.Fatal("NGINX App Protect Dos memory support is for NGINX Plus and App Protect Dos is enable")
+		nl.Fatal(l, "NGINX App Protect Dos memory support is for NGINX Plus and App Protect Dos is enable")
 	}
 
 	if *spireAgentAddress != "" && !*nginxPlus {
-		glog.Fatal("spire-agent-address support is for NGINX Plus only")
+		nl.Fatal(l, "spire-agent-address support is for NGINX Plus only")
 	}
 
 	if *enableInternalRoutes && *spireAgentAddress == "" {
-		glog.Fatal("enable-internal-routes flag requires spire-agent-address")
+		nl.Fatal(l, "enable-internal-routes flag requires spire-agent-address")
 	}
 
 	if *enableLatencyMetrics && !*enablePrometheusMetrics {
-		glog.Warning("enable-latency-metrics flag requires enable-prometheus-metrics, latency metrics will not be collected")
+		nl.Warn(l, "enable-latency-metrics flag requires enable-prometheus-metrics, latency metrics will not be collected")
 		*enableLatencyMetrics = false
 	}
 
 	if *ingressLink != "" && *externalService != "" {
-		glog.Fatal("ingresslink and external-service cannot both be set")
+		nl.Fatal(l, "ingresslink and external-service cannot both be set")
+	}
+
+	if *enableACME && !*enableCustomResources {
+		nl.Fatal(l, "enable-acme flag requires -enable-custom-resources")
+	}
+
+	if *otelExporterEndpoint != "" {
+		if err := validateOtelExporterEndpoint(*otelExporterEndpoint); err != nil {
+			nl.Fatalf(l, "Invalid value for otel-exporter-endpoint: %v", err)
+		}
 	}
 
 	var config *rest.Config
@@ -295,61 +365,61 @@ func main() {
 				},
 			}).ClientConfig()
 		if err != nil {
-			glog.Fatalf("error creating client configuration: %v", err)
+			nl.Fatalf(l, "error creating client configuration: %v", err)
 		}
 	} else {
 		if config, err = rest.InClusterConfig(); err != nil {
-			glog.Fatalf("error creating client configuration: %v", err)
+			nl.Fatalf(l, "error creating client configuration: %v", err)
 		}
 	}
 
 	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		glog.Fatalf("Failed to create client: %v.", err)
+		nl.Fatalf(l, "Failed to create client: %v.", err)
 	}
 
 	k8sVersion, err := k8s.GetK8sVersion(kubeClient)
 	if err != nil {
-		glog.Fatalf("error retrieving k8s version: %v", err)
+		nl.Fatalf(l, "error retrieving k8s version: %v", err)
 	}
-	glog.Infof("Kubernetes version: %v", k8sVersion)
+	nl.Infof(l, "Kubernetes version: %v", k8sVersion)
 
 	minK8sVersion, err := util_version.ParseGeneric("1.19.0")
 	if err != nil {
-		glog.Fatalf("unexpected error parsing minimum supported version: %v", err)
+		nl.Fatalf(l, "unexpected error parsing minimum supported version: %v", err)
 	}
 
 	if !k8sVersion.AtLeast(minK8sVersion) {
-		glog.Fatalf("Versions of Kubernetes < %v are not supported, please refer to the documentation for details on supported versions and legacy controller support.", minK8sVersion)
+		nl.Fatalf(l, "Versions of Kubernetes < %v are not supported, please refer to the documentation for details on supported versions and legacy controller support.", minK8sVersion)
 	}
 
 	ingressClassRes, err := kubeClient.NetworkingV1().IngressClasses().Get(context.TODO(), *ingressClass, meta_v1.GetOptions{})
 	if err != nil {
-		glog.Fatalf("Error when getting IngressClass %v: %v", *ingressClass, err)
+		nl.Fatalf(l, "Error when getting IngressClass %v: %v", *ingressClass, err)
 	}
 
 	if ingressClassRes.Spec.Controller != k8s.IngressControllerName {
-		glog.Fatalf("IngressClass with name %v has an invalid Spec.Controller %v", ingressClassRes.Name, ingressClassRes.Spec.Controller)
+		nl.Fatalf(l, "IngressClass with name %v has an invalid Spec.Controller %v", ingressClassRes.Name, ingressClassRes.Spec.Controller)
 	}
 
 	var dynClient dynamic.Interface
 	if *appProtectDos || *appProtect || *ingressLink != "" {
 		dynClient, err = dynamic.NewForConfig(config)
 		if err != nil {
-			glog.Fatalf("Failed to create dynamic client: %v.", err)
+			nl.Fatalf(l, "Failed to create dynamic client: %v.", err)
 		}
 	}
 	var confClient k8s_nginx.Interface
 	if *enableCustomResources {
 		confClient, err = k8s_nginx.NewForConfig(config)
 		if err != nil {
-			glog.Fatalf("Failed to create a conf client: %v", err)
+			nl.Fatalf(l, "Failed to create a conf client: %v", err)
 		}
 
 		// required for emitting Events for VirtualServer
 		err = conf_scheme.AddToScheme(scheme.Scheme)
 		if err != nil {
-			glog.Fatalf("Failed to add configuration types to the scheme: %v", err)
+			nl.Fatalf(l, "Failed to add configuration types to the scheme: %v", err)
 		}
 	}
 
@@ -395,22 +465,22 @@ func main() {
 
 		err = managerCollector.Register(registry)
 		if err != nil {
-			glog.Errorf("Error registering Manager Prometheus metrics: %v", err)
+			nl.Errorf(l, "Error registering Manager Prometheus metrics: %v", err)
 		}
 
 		err = controllerCollector.Register(registry)
 		if err != nil {
-			glog.Errorf("Error registering Controller Prometheus metrics: %v", err)
+			nl.Errorf(l, "Error registering Controller Prometheus metrics: %v", err)
 		}
 
 		err = processCollector.Register(registry)
 		if err != nil {
-			glog.Errorf("Error registering NginxProcess Prometheus metrics: %v", err)
+			nl.Errorf(l, "Error registering NginxProcess Prometheus metrics: %v", err)
 		}
 
 		err = workQueueCollector.Register(registry)
 		if err != nil {
-			glog.Errorf("Error registering WorkQueue Prometheus metrics: %v", err)
+			nl.Errorf(l, "Error registering WorkQueue Prometheus metrics: %v", err)
 		}
 	}
 
@@ -420,26 +490,26 @@ func main() {
 		nginxManager = nginx.NewFakeManager("/etc/nginx")
 	} else {
 		timeout := time.Duration(*nginxReloadTimeout) * time.Millisecond
-		nginxManager = nginx.NewLocalManager("/etc/nginx/", *nginxDebug, managerCollector, timeout)
+		nginxManager = nginx.NewLocalManager("/etc/nginx/", *nginxDebug, managerCollector, timeout, l)
 	}
 	nginxVersion := nginxManager.Version()
 	isPlus := strings.Contains(nginxVersion, "plus")
-	glog.Infof("Using %s", nginxVersion)
+	nl.Infof(l, "Using %s", nginxVersion)
 
 	if *nginxPlus && !isPlus {
-		glog.Fatal("NGINX Plus flag enabled (-nginx-plus) without NGINX Plus binary")
+		nl.Fatal(l, "NGINX Plus flag enabled (-nginx-plus) without NGINX Plus binary")
 	} else if !*nginxPlus && isPlus {
-		glog.Fatal("NGINX Plus binary found without NGINX Plus flag (-nginx-plus)")
+		nl.Fatal(l, "NGINX Plus binary found without NGINX Plus flag (-nginx-plus)")
 	}
 
 	templateExecutor, err := version1.NewTemplateExecutor(nginxConfTemplatePath, nginxIngressTemplatePath)
 	if err != nil {
-		glog.Fatalf("Error creating TemplateExecutor: %v", err)
+		nl.Fatalf(l, "Error creating TemplateExecutor: %v", err)
 	}
 
 	templateExecutorV2, err := version2.NewTemplateExecutor(nginxVirtualServerTemplatePath, nginxTransportServerTemplatePath)
 	if err != nil {
-		glog.Fatalf("Error creating TemplateExecutorV2: %v", err)
+		nl.Fatalf(l, "Error creating TemplateExecutorV2: %v", err)
 	}
 
 	var aPPluginDone chan error
@@ -465,7 +535,7 @@ func main() {
 	if *defaultServerSecret != "" {
 		secret, err := getAndValidateSecret(kubeClient, *defaultServerSecret)
 		if err != nil {
-			glog.Fatalf("Error trying to get the default server TLS secret %v: %v", *defaultServerSecret, err)
+			nl.Fatalf(l, "Error trying to get the default server TLS secret %v: %v", *defaultServerSecret, err)
 		}
 
 		bytes := configs.GenerateCertAndKeyFileContent(secret)
@@ -477,7 +547,7 @@ func main() {
 				// file doesn't exist - it is OK! we will reject TLS connections in the default server
 				sslRejectHandshake = true
 			} else {
-				glog.Fatalf("Error checking the default server TLS cert and key in %s: %v", configs.DefaultServerSecretPath, err)
+				nl.Fatalf(l, "Error checking the default server TLS cert and key in %s: %v", configs.DefaultServerSecretPath, err)
 			}
 		}
 	}
@@ -485,7 +555,7 @@ func main() {
 	if *wildcardTLSSecret != "" {
 		secret, err := getAndValidateSecret(kubeClient, *wildcardTLSSecret)
 		if err != nil {
-			glog.Fatalf("Error trying to get the wildcard TLS secret %v: %v", *wildcardTLSSecret, err)
+			nl.Fatalf(l, "Error trying to get the wildcard TLS secret %v: %v", *wildcardTLSSecret, err)
 		}
 
 		bytes := configs.GenerateCertAndKeyFileContent(secret)
@@ -496,7 +566,7 @@ func main() {
 	if *prometheusTLSSecretName != "" {
 		prometheusSecret, err = getAndValidateSecret(kubeClient, *prometheusTLSSecretName)
 		if err != nil {
-			glog.Fatalf("Error trying to get the prometheus TLS secret %v: %v", *prometheusTLSSecretName, err)
+			nl.Fatalf(l, "Error trying to get the prometheus TLS secret %v: %v", *prometheusTLSSecretName, err)
 		}
 	}
 
@@ -505,12 +575,43 @@ func main() {
 	if *globalConfiguration != "" {
 		_, _, err := k8s.ParseNamespaceName(*globalConfiguration)
 		if err != nil {
-			glog.Fatalf("Error parsing the global-configuration argument: %v", err)
+			nl.Fatalf(l, "Error parsing the global-configuration argument: %v", err)
 		}
 
 		if !*enableCustomResources {
-			glog.Fatal("global-configuration flag requires -enable-custom-resources")
+			nl.Fatal(l, "global-configuration flag requires -enable-custom-resources")
+		}
+	}
+
+	var acmeConfig *acme.Config
+	var acmeThumbprint string
+	if *enableACME {
+		acmeConfig, err = createACMEConfig(kubeClient)
+		if err != nil {
+			nl.Fatalf(l, "Error configuring ACME: %v", err)
+		}
+
+		acmeThumbprint, err = acme.Thumbprint(acmeConfig.AccountKey)
+		if err != nil {
+			nl.Fatalf(l, "Error computing the thumbprint of the ACME account key: %v", err)
+		}
+	}
+
+	var controllerZone string
+	if *enableTopologyAwareRouting {
+		controllerZone, err = getControllerZone(kubeClient)
+		if err != nil {
+			nl.Fatalf(l, "Error configuring topology aware routing: %v", err)
 		}
+		nl.Infof(l, "Topology aware routing prefers the endpoints in zone %v", controllerZone)
+	}
+
+	var tracer *tracing.Tracer
+	if *otelExporterEndpoint != "" {
+		tracer = tracing.NewTracer(tracing.Config{
+			Endpoint:    *otelExporterEndpoint,
+			ServiceName: *otelServiceName,
+		})
 	}
 
 	cfgParams := configs.NewDefaultConfigParams(*nginxPlus)
@@ -518,17 +619,17 @@ func main() {
 	if *nginxConfigMaps != "" {
 		ns, name, err := k8s.ParseNamespaceName(*nginxConfigMaps)
 		if err != nil {
-			glog.Fatalf("Error parsing the nginx-configmaps argument: %v", err)
+			nl.Fatalf(l, "Error parsing the nginx-configmaps argument: %v", err)
 		}
 		cfm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(context.TODO(), name, meta_v1.GetOptions{})
 		if err != nil {
-			glog.Fatalf("Error when getting %v: %v", *nginxConfigMaps, err)
+			nl.Fatalf(l, "Error when getting %v: %v", *nginxConfigMaps, err)
 		}
 		cfgParams = configs.ParseConfigMap(cfm, *nginxPlus, *appProtect, *appProtectDos)
 		if cfgParams.MainServerSSLDHParamFileContent != nil {
 			fileName, err := nginxManager.CreateDHParam(*cfgParams.MainServerSSLDHParamFileContent)
 			if err != nil {
-				glog.Fatalf("Configmap %s/%s: Could not update dhparams: %v", ns, name, err)
+				nl.Fatalf(l, "Configmap %s/%s: Could not update dhparams: %v", ns, name, err)
 			} else {
 				cfgParams.MainServerSSLDHParam = fileName
 			}
@@ -536,13 +637,13 @@ func main() {
 		if cfgParams.MainTemplate != nil {
 			err = templateExecutor.UpdateMainTemplate(cfgParams.MainTemplate)
 			if err != nil {
-				glog.Fatalf("Error updating NGINX main template: %v", err)
+				nl.Fatalf(l, "Error updating NGINX main template: %v", err)
 			}
 		}
 		if cfgParams.IngressTemplate != nil {
 			err = templateExecutor.UpdateIngressTemplate(cfgParams.IngressTemplate)
 			if err != nil {
-				glog.Fatalf("Error updating ingress template: %v", err)
+				nl.Fatalf(l, "Error updating ingress template: %v", err)
 			}
 		}
 	}
@@ -561,12 +662,15 @@ func main() {
 		EnableLatencyMetrics:           *enableLatencyMetrics,
 		EnablePreviewPolicies:          *enablePreviewPolicies,
 		SSLRejectHandshake:             sslRejectHandshake,
+		DynamicSSLReload:               *enableDynamicSSLReload,
+		StaticSSLPath:                  "/etc/nginx/secrets",
+		ACMEAccountThumbprint:          acmeThumbprint,
 	}
 
 	ngxConfig := configs.GenerateNginxMainConfig(staticCfgParams, cfgParams)
 	content, err := templateExecutor.ExecuteMainConfigTemplate(ngxConfig)
 	if err != nil {
-		glog.Fatalf("Error generating NGINX main config: %v", err)
+		nl.Fatalf(l, "Error generating NGINX main config: %v", err)
 	}
 	nginxManager.CreateMainConfig(content)
 
@@ -577,7 +681,7 @@ func main() {
 	if ngxConfig.OpenTracingLoadModule {
 		err := nginxManager.CreateOpenTracingTracerConfig(cfgParams.MainOpenTracingTracerConfig)
 		if err != nil {
-			glog.Fatalf("Error creating OpenTracing tracer config file: %v", err)
+			nl.Fatalf(l, "Error creating OpenTracing tracer config file: %v", err)
 		}
 	}
 
@@ -595,7 +699,7 @@ func main() {
 		httpClient := getSocketClient("/var/lib/nginx/nginx-plus-api.sock")
 		plusClient, err = client.NewNginxClient(httpClient, "http://nginx-plus-api/api")
 		if err != nil {
-			glog.Fatalf("Failed to create NginxClient for Plus: %v", err)
+			nl.Fatalf(l, "Failed to create NginxClient for Plus: %v", err)
 		}
 		nginxManager.SetPlusClients(plusClient, httpClient)
 	}
@@ -623,14 +727,14 @@ func main() {
 			httpClient := getSocketClient("/var/lib/nginx/nginx-status.sock")
 			client, err := metrics.NewNginxMetricsClient(httpClient)
 			if err != nil {
-				glog.Errorf("Error creating the Nginx client for Prometheus metrics: %v", err)
+				nl.Errorf(l, "Error creating the Nginx client for Prometheus metrics: %v", err)
 			}
 			go metrics.RunPrometheusListenerForNginx(*prometheusMetricsListenPort, client, registry, constLabels, prometheusSecret)
 		}
 		if *enableLatencyMetrics {
 			latencyCollector = collectors.NewLatencyMetricsCollector(constLabels, upstreamServerVariableLabels, upstreamServerPeerVariableLabelNames)
 			if err := latencyCollector.Register(registry); err != nil {
-				glog.Errorf("Error registering Latency Prometheus metrics: %v", err)
+				nl.Errorf(l, "Error registering Latency Prometheus metrics: %v", err)
 			}
 			syslogListener = metrics.NewLatencyMetricsListener("/var/lib/nginx/nginx-syslog.sock", latencyCollector)
 			go syslogListener.Run()
@@ -639,7 +743,7 @@ func main() {
 
 	isWildcardEnabled := *wildcardTLSSecret != ""
 	cnf := configs.NewConfigurator(nginxManager, staticCfgParams, cfgParams, templateExecutor,
-		templateExecutorV2, *nginxPlus, isWildcardEnabled, plusCollector, *enablePrometheusMetrics, latencyCollector, *enableLatencyMetrics)
+		templateExecutorV2, *nginxPlus, isWildcardEnabled, plusCollector, *enablePrometheusMetrics, latencyCollector, *enableLatencyMetrics, controllerCollector)
 	controllerNamespace := os.Getenv("POD_NAMESPACE")
 
 	transportServerValidator := cr_validation.NewTransportServerValidator(*enableTLSPassthrough, *enableSnippets, *nginxPlus)
@@ -678,6 +782,11 @@ func main() {
 		IsLatencyMetricsEnabled:      *enableLatencyMetrics,
 		IsTLSPassthroughEnabled:      *enableTLSPassthrough,
 		SnippetsEnabled:              *enableSnippets,
+		IsDynamicSSLReloadEnabled:    *enableDynamicSSLReload,
+		ACMEConfig:                   acmeConfig,
+		ControllerZone:               controllerZone,
+		Tracer:                       tracer,
+		Logger:                       l,
 	}
 
 	lbc := k8s.NewLoadBalancerController(lbcInput)
@@ -687,7 +796,16 @@ func main() {
 			port := fmt.Sprintf(":%v", *readyStatusPort)
 			s := http.NewServeMux()
 			s.HandleFunc("/nginx-ready", ready(lbc))
-			glog.Fatal(http.ListenAndServe(port, s))
+			nl.Fatal(l, http.ListenAndServe(port, s))
+		}()
+	}
+
+	if *enableDebugEndpoint {
+		go func() {
+			port := fmt.Sprintf(":%v", *debugEndpointPort)
+			s := http.NewServeMux()
+			s.Handle("/debug/", lbc.DebugHandler())
+			nl.Fatal(l, http.ListenAndServe(port, s))
 		}()
 	}
 
@@ -700,11 +818,20 @@ func main() {
 	lbc.Run()
 
 	for {
-		glog.Info("Waiting for the controller to exit...")
+		nl.Info(l, "Waiting for the controller to exit...")
 		time.Sleep(30 * time.Second)
 	}
 }
 
+func createLogger() (*slog.Logger, error) {
+	verbosity, err := strconv.Atoi(flag.Lookup("v").Value.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for v: %w", err)
+	}
+
+	return nl.New(os.Stderr, *logFormat, nl.LevelFromVerbosity(verbosity))
+}
+
 func createGlobalConfigurationValidator() *cr_validation.GlobalConfigurationValidator {
 	forbiddenListenerPorts := map[int]bool{
 		80:  true,
@@ -731,27 +858,27 @@ func handleTermination(lbc *k8s.LoadBalancerController, nginxManager nginx.Manag
 	select {
 	case err := <-nginxDone:
 		if err != nil {
-			glog.Errorf("nginx command exited with an error: %v", err)
+			nl.Errorf(slog.Default(), "nginx command exited with an error: %v", err)
 			exitStatus = 1
 		} else {
-			glog.Info("nginx command exited successfully")
+			nl.Info(slog.Default(), "nginx command exited successfully")
 		}
 		exited = true
 	case <-signalChan:
-		glog.Info("Received SIGTERM, shutting down")
+		nl.Info(slog.Default(), "Received SIGTERM, shutting down")
 	}
 
-	glog.Info("Shutting down the controller")
+	nl.Info(slog.Default(), "Shutting down the controller")
 	lbc.Stop()
 
 	if !exited {
-		glog.Info("Shutting down NGINX")
+		nl.Info(slog.Default(), "Shutting down NGINX")
 		nginxManager.Quit()
 		<-nginxDone
 	}
 	listener.Stop()
 
-	glog.Infof("Exiting with a status: %v", exitStatus)
+	nl.Infof(slog.Default(), "Exiting with a status: %v", exitStatus)
 	os.Exit(exitStatus)
 }
 
@@ -783,6 +910,21 @@ func validatePort(port int) error {
 	return nil
 }
 
+// validateOtelExporterEndpoint makes sure a given string is an absolute http or https URL.
+func validateOtelExporterEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%v must use the http or https scheme", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%v must include a host", endpoint)
+	}
+	return nil
+}
+
 // parseNginxStatusAllowCIDRs converts a comma separated CIDR/IP address string into an array of CIDR/IP addresses.
 // It returns an array of the valid CIDR/IP addresses or an error if given an invalid address.
 func parseNginxStatusAllowCIDRs(input string) (cidrs []string, err error) {
@@ -832,6 +974,75 @@ func getAndValidateSecret(kubeClient *kubernetes.Clientset, secretNsName string)
 	return secret, nil
 }
 
+// getControllerZone returns the zone of the node of the Ingress Controller pod.
+func getControllerZone(kubeClient kubernetes.Interface) (string, error) {
+	nodeName := os.Getenv("NODE_NAME")
+	if nodeName == "" {
+		return "", errors.New("the NODE_NAME environment variable is not set")
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, meta_v1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("could not get node %v: %w", nodeName, err)
+	}
+
+	zone := node.Labels[api_v1.LabelTopologyZone]
+	if zone == "" {
+		return "", fmt.Errorf("node %v doesn't have the %v label", nodeName, api_v1.LabelTopologyZone)
+	}
+
+	return zone, nil
+}
+
+// createACMEConfig creates the configuration for issuing certificates from ACME servers from the command-line arguments.
+func createACMEConfig(kubeClient kubernetes.Interface) (*acme.Config, error) {
+	accountSecret := *acmeAccountSecret
+	if accountSecret == "" {
+		accountSecret = os.Getenv("POD_NAMESPACE") + "/nginx-ingress-acme-account"
+	}
+
+	ns, name, err := k8s.ParseNamespaceName(accountSecret)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the acme-account-secret argument: %w", err)
+	}
+
+	accountKey, err := acme.GetOrCreateAccountKey(kubeClient, ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	if *acmeCABundle != "" {
+		caBundle, err := os.ReadFile(*acmeCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the acme-ca-bundle file: %w", err)
+		}
+
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("the acme-ca-bundle file %v doesn't include PEM-encoded certificates", *acmeCABundle)
+		}
+
+		httpClient.Transport = &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				MinVersion: tls.VersionTLS12,
+			},
+		}
+	}
+
+	return &acme.Config{
+		AccountKey:  accountKey,
+		Email:       *acmeEmail,
+		HTTPClient:  httpClient,
+		RenewBefore: *acmeRenewBefore,
+	}, nil
+}
+
 const (
 	locationFmt    = `/[^\s{};]*`
 	locationErrMsg = "must start with / and must not include any whitespace character, `{`, `}` or `;`"
@@ -856,15 +1067,15 @@ func handleTerminationWithAppProtect(lbc *k8s.LoadBalancerController, nginxManag
 
 	select {
 	case err := <-nginxDone:
-		glog.Fatalf("nginx command exited unexpectedly with status: %v", err)
+		nl.Fatalf(slog.Default(), "nginx command exited unexpectedly with status: %v", err)
 	case err := <-pluginDone:
-		glog.Fatalf("AppProtectPlugin command exited unexpectedly with status: %v", err)
+		nl.Fatalf(slog.Default(), "AppProtectPlugin command exited unexpectedly with status: %v", err)
 	case err := <-agentDone:
-		glog.Fatalf("AppProtectAgent command exited unexpectedly with status: %v", err)
+		nl.Fatalf(slog.Default(), "AppProtectAgent command exited unexpectedly with status: %v", err)
 	case err := <-agentDosDone:
-		glog.Fatalf("AppProtectDosAgent command exited unexpectedly with status: %v", err)
+		nl.Fatalf(slog.Default(), "AppProtectDosAgent command exited unexpectedly with status: %v", err)
 	case <-signalChan:
-		glog.Infof("Received SIGTERM, shutting down")
+		nl.Infof(slog.Default(), "Received SIGTERM, shutting down")
 		lbc.Stop()
 		nginxManager.Quit()
 		<-nginxDone
@@ -880,7 +1091,7 @@ func handleTerminationWithAppProtect(lbc *k8s.LoadBalancerController, nginxManag
 		}
 		listener.Stop()
 	}
-	glog.Info("Exiting successfully")
+	nl.Info(slog.Default(), "Exiting successfully")
 	os.Exit(0)
 }
 
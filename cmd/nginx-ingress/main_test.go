@@ -123,3 +123,29 @@ func TestValidateLocation(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateOtelExporterEndpoint(t *testing.T) {
+	badEndpoints := []string{
+		"otel-collector:4318",
+		"grpc://otel-collector:4317",
+		"http://",
+		"/v1/traces",
+	}
+	for _, badEndpoint := range badEndpoints {
+		err := validateOtelExporterEndpoint(badEndpoint)
+		if err == nil {
+			t.Errorf("validateOtelExporterEndpoint(%v) returned no error when it should have returned an error", badEndpoint)
+		}
+	}
+
+	goodEndpoints := []string{
+		"http://otel-collector:4318/v1/traces",
+		"https://otel-collector.example.com/v1/traces",
+	}
+	for _, goodEndpoint := range goodEndpoints {
+		err := validateOtelExporterEndpoint(goodEndpoint)
+		if err != nil {
+			t.Errorf("validateOtelExporterEndpoint(%v) returned an error when it should have returned no error: %v", goodEndpoint, err)
+		}
+	}
+}
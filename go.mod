@@ -1,6 +1,6 @@
 module github.com/nginxinc/kubernetes-ingress
 
-go 1.17
+go 1.21
 
 require (
 	github.com/aws/aws-sdk-go-v2/config v1.14.0
@@ -12,6 +12,7 @@ require (
 	github.com/nginxinc/nginx-prometheus-exporter v0.10.0
 	github.com/prometheus/client_golang v1.12.1
 	github.com/spiffe/go-spiffe v1.1.0
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
 	k8s.io/api v0.23.4
 	k8s.io/apimachinery v0.23.4
 	k8s.io/client-go v0.23.4
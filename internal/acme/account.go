@@ -0,0 +1,105 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	xacme "golang.org/x/crypto/acme"
+	api_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AccountKeyKey is the key of the data field of a Secret where the ACME account key must be stored.
+const AccountKeyKey = "account.key"
+
+// GetOrCreateAccountKey returns the ACME account key stored in the Secret with the given namespace and name.
+// If the Secret doesn't exist, a new key is generated and stored in a new Secret.
+// All replicas of the Ingress Controller share the key, so that each of them can respond to HTTP-01 challenges.
+func GetOrCreateAccountKey(kubeClient kubernetes.Interface, namespace string, name string) (crypto.Signer, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+	if err == nil {
+		return parseAccountKey(secret)
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting the ACME account Secret %s/%s: %w", namespace, name, err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating an ACME account key: %w", err)
+	}
+
+	keyPEM, err := encodeECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret = &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: api_v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			AccountKeyKey: keyPEM,
+		},
+	}
+
+	_, err = kubeClient.CoreV1().Secrets(namespace).Create(context.TODO(), secret, meta_v1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		// another replica created the Secret first
+		return GetOrCreateAccountKey(kubeClient, namespace, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating the ACME account Secret %s/%s: %w", namespace, name, err)
+	}
+
+	nl.Tracef(slog.Default(), "Created the ACME account Secret %s/%s", namespace, name)
+
+	return key, nil
+}
+
+// Thumbprint returns the JWK thumbprint of the account key as defined in RFC 7638.
+// The key authorization of an HTTP-01 challenge is the token of the challenge followed by a dot and the thumbprint.
+func Thumbprint(key crypto.Signer) (string, error) {
+	return xacme.JWKThumbprint(key.Public())
+}
+
+func parseAccountKey(secret *api_v1.Secret) (crypto.Signer, error) {
+	data, exists := secret.Data[AccountKeyKey]
+	if !exists {
+		return nil, fmt.Errorf("the ACME account Secret %s/%s must have the data field %v", secret.Namespace, secret.Name, AccountKeyKey)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("the ACME account key must be PEM-encoded")
+	}
+
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the ACME account key: %w", err)
+	}
+
+	return key, nil
+}
+
+func encodeECPrivateKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding a private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
@@ -0,0 +1,62 @@
+package acme
+
+import (
+	"context"
+	"testing"
+
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetOrCreateAccountKey(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	key, err := GetOrCreateAccountKey(kubeClient, "nginx-ingress", "acme-account")
+	if err != nil {
+		t.Fatalf("GetOrCreateAccountKey() returned unexpected error: %v", err)
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets("nginx-ingress").Get(context.TODO(), "acme-account", meta_v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("GetOrCreateAccountKey() didn't create the Secret: %v", err)
+	}
+	if _, exists := secret.Data[AccountKeyKey]; !exists {
+		t.Errorf("GetOrCreateAccountKey() created a Secret without the data field %v", AccountKeyKey)
+	}
+
+	storedKey, err := GetOrCreateAccountKey(kubeClient, "nginx-ingress", "acme-account")
+	if err != nil {
+		t.Fatalf("GetOrCreateAccountKey() returned unexpected error: %v", err)
+	}
+
+	thumbprint, err := Thumbprint(key)
+	if err != nil {
+		t.Fatalf("Thumbprint() returned unexpected error: %v", err)
+	}
+	storedThumbprint, err := Thumbprint(storedKey)
+	if err != nil {
+		t.Fatalf("Thumbprint() returned unexpected error: %v", err)
+	}
+
+	if thumbprint != storedThumbprint {
+		t.Errorf("GetOrCreateAccountKey() returned a different key for the existing Secret")
+	}
+}
+
+func TestGetOrCreateAccountKeyFails(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "acme-account",
+			Namespace: "nginx-ingress",
+		},
+		Data: map[string][]byte{
+			AccountKeyKey: []byte("invalid"),
+		},
+	})
+
+	_, err := GetOrCreateAccountKey(kubeClient, "nginx-ingress", "acme-account")
+	if err == nil {
+		t.Errorf("GetOrCreateAccountKey() returned no error for a Secret with an invalid key")
+	}
+}
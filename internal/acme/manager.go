@@ -0,0 +1,425 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	xacme "golang.org/x/crypto/acme"
+	api_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConditionTypeCertificateReady is the type of the VirtualServer status condition
+// that reports the progress of the certificate issuance.
+const ConditionTypeCertificateReady = "CertificateReady"
+
+// Reasons of the CertificateReady condition.
+const (
+	ReasonIssuing       = "Issuing"
+	ReasonIssued        = "Issued"
+	ReasonIssuingFailed = "IssuingFailed"
+)
+
+const (
+	// checkPeriod is how often the Manager checks if the certificates need to be renewed or issuing must be retried.
+	checkPeriod = time.Hour
+	// issueTimeout limits the time of a single certificate issuance including the validation of the challenges.
+	issueTimeout = 5 * time.Minute
+)
+
+// Config holds the configuration of the Manager.
+type Config struct {
+	// AccountKey is the key of the ACME account. See GetOrCreateAccountKey.
+	AccountKey crypto.Signer
+	// Email is an optional contact email of the ACME account.
+	Email string
+	// HTTPClient is used to connect to ACME servers.
+	HTTPClient *http.Client
+	// RenewBefore is how long before the expiration a certificate is renewed.
+	RenewBefore time.Duration
+}
+
+// certificate is a certificate requested by a VirtualServer.
+type certificate struct {
+	namespace string
+	name      string
+	hosts     []string
+	issuer    string
+	secret    string
+}
+
+// Manager obtains and renews TLS certificates for the hosts of VirtualServers from ACME servers.
+// NGINX responds to the HTTP-01 challenges (see version2.ACMEChallenge), and the issued certificates
+// are stored in TLS Secrets, which the Ingress Controller handles like any other TLS Secret.
+type Manager struct {
+	ctx          context.Context
+	kubeClient   kubernetes.Interface
+	config       Config
+	isLeader     func() bool
+	updateStatus func(namespace string, name string, condition meta_v1.Condition)
+
+	mu         sync.Mutex
+	certs      map[string]certificate
+	inProgress map[string]bool
+	clients    map[string]*xacme.Client
+}
+
+// NewManager creates a new Manager.
+// Certificates are only issued when isLeader returns true. The progress is reported with updateStatus.
+func NewManager(ctx context.Context, kubeClient kubernetes.Interface, config Config, isLeader func() bool,
+	updateStatus func(namespace string, name string, condition meta_v1.Condition)) *Manager {
+	return &Manager{
+		ctx:          ctx,
+		kubeClient:   kubeClient,
+		config:       config,
+		isLeader:     isLeader,
+		updateStatus: updateStatus,
+		certs:        make(map[string]certificate),
+		inProgress:   make(map[string]bool),
+		clients:      make(map[string]*xacme.Client),
+	}
+}
+
+// Run periodically renews the certificates until the context of the Manager is done.
+func (m *Manager) Run() {
+	ticker := time.NewTicker(checkPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			certs := make(map[string]certificate, len(m.certs))
+			for key, cert := range m.certs {
+				certs[key] = cert
+			}
+			m.mu.Unlock()
+
+			for key, cert := range certs {
+				m.ensureCertificate(key, cert)
+			}
+		}
+	}
+}
+
+// AddOrUpdateVirtualServer starts managing the certificate of a VirtualServer that enables ACME.
+// The certificate is issued for the host and the valid aliases of the VirtualServer.
+// If the certificate doesn't exist or needs to be renewed, it is issued in the background.
+func (m *Manager) AddOrUpdateVirtualServer(vs *conf_v1.VirtualServer, validAliases map[string]bool) {
+	key := vs.Namespace + "/" + vs.Name
+
+	if vs.Spec.TLS == nil || vs.Spec.TLS.ACME == nil {
+		m.DeleteVirtualServer(key)
+		return
+	}
+
+	cert := certificate{
+		namespace: vs.Namespace,
+		name:      vs.Name,
+		hosts:     getCertificateHosts(vs, validAliases),
+		issuer:    vs.Spec.TLS.ACME.Issuer,
+		secret:    vs.Spec.TLS.Secret,
+	}
+
+	m.mu.Lock()
+	m.certs[key] = cert
+	m.mu.Unlock()
+
+	go m.ensureCertificate(key, cert)
+}
+
+func getCertificateHosts(vs *conf_v1.VirtualServer, validAliases map[string]bool) []string {
+	hosts := []string{vs.Spec.Host}
+
+	for _, alias := range vs.Spec.Aliases {
+		if validAliases[alias] {
+			hosts = append(hosts, alias)
+		}
+	}
+
+	return hosts
+}
+
+// DeleteVirtualServer stops managing the certificate of a VirtualServer.
+// The Secret with the certificate is not deleted.
+func (m *Manager) DeleteVirtualServer(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.certs, key)
+}
+
+func (m *Manager) ensureCertificate(key string, cert certificate) {
+	if !m.isLeader() {
+		return
+	}
+
+	m.mu.Lock()
+	if m.inProgress[key] {
+		m.mu.Unlock()
+		return
+	}
+	m.inProgress[key] = true
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.inProgress, key)
+		m.mu.Unlock()
+	}()
+
+	secret, err := m.kubeClient.CoreV1().Secrets(cert.namespace).Get(m.ctx, cert.secret, meta_v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		nl.Errorf(slog.Default(), "Error getting the Secret %s/%s for the ACME certificate of VirtualServer %s: %v", cert.namespace, cert.secret, key, err)
+		return
+	}
+
+	if err == nil {
+		notAfter, valid := getValidCertificateExpiry(secret, cert.hosts)
+		if valid && time.Now().Add(m.config.RenewBefore).Before(notAfter) {
+			m.updateStatus(cert.namespace, cert.name, newCondition(meta_v1.ConditionTrue, ReasonIssued,
+				fmt.Sprintf("Certificate for %s is valid until %s", strings.Join(cert.hosts, ", "), notAfter.UTC().Format(time.RFC3339))))
+			return
+		}
+	}
+
+	nl.Tracef(slog.Default(), "Issuing an ACME certificate for hosts %s of VirtualServer %s from %s", strings.Join(cert.hosts, ", "), key, cert.issuer)
+	m.updateStatus(cert.namespace, cert.name, newCondition(meta_v1.ConditionFalse, ReasonIssuing,
+		fmt.Sprintf("Issuing a certificate for %s from %s", strings.Join(cert.hosts, ", "), cert.issuer)))
+
+	ctx, cancel := context.WithTimeout(m.ctx, issueTimeout)
+	defer cancel()
+
+	certPEM, keyPEM, err := m.obtainCertificate(ctx, cert)
+	if err == nil {
+		err = m.saveCertificate(ctx, cert, certPEM, keyPEM)
+	}
+	if err != nil {
+		nl.Errorf(slog.Default(), "Error issuing an ACME certificate for hosts %s of VirtualServer %s: %v", strings.Join(cert.hosts, ", "), key, err)
+		m.updateStatus(cert.namespace, cert.name, newCondition(meta_v1.ConditionFalse, ReasonIssuingFailed,
+			fmt.Sprintf("Failed to issue a certificate for %s: %v", strings.Join(cert.hosts, ", "), err)))
+		return
+	}
+
+	nl.Tracef(slog.Default(), "Issued an ACME certificate for hosts %s of VirtualServer %s", strings.Join(cert.hosts, ", "), key)
+
+	issued, err := parseCertificate(certPEM)
+	if err != nil {
+		nl.Errorf(slog.Default(), "Error parsing the issued ACME certificate for hosts %s of VirtualServer %s: %v", strings.Join(cert.hosts, ", "), key, err)
+		m.updateStatus(cert.namespace, cert.name, newCondition(meta_v1.ConditionFalse, ReasonIssuingFailed,
+			fmt.Sprintf("Failed to parse the issued certificate for %s: %v", strings.Join(cert.hosts, ", "), err)))
+		return
+	}
+
+	m.updateStatus(cert.namespace, cert.name, newCondition(meta_v1.ConditionTrue, ReasonIssued,
+		fmt.Sprintf("Certificate for %s is valid until %s", strings.Join(cert.hosts, ", "), issued.NotAfter.UTC().Format(time.RFC3339))))
+}
+
+func (m *Manager) obtainCertificate(ctx context.Context, cert certificate) (certPEM []byte, keyPEM []byte, err error) {
+	client, err := m.getClient(ctx, cert.issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order, err := client.AuthorizeOrder(ctx, xacme.DomainIDs(cert.hosts...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating an order: %w", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		authz, err := client.GetAuthorization(ctx, authzURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting an authorization: %w", err)
+		}
+
+		if authz.Status == xacme.StatusValid {
+			continue
+		}
+
+		var challenge *xacme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == "http-01" {
+				challenge = c
+				break
+			}
+		}
+		if challenge == nil {
+			return nil, nil, errors.New("the ACME server doesn't offer the http-01 challenge")
+		}
+
+		if _, err := client.Accept(ctx, challenge); err != nil {
+			return nil, nil, fmt.Errorf("error accepting the http-01 challenge: %w", err)
+		}
+
+		if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+			return nil, nil, fmt.Errorf("error validating the http-01 challenge: %w", err)
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error waiting for the order: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error generating a private key: %w", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cert.hosts[0]},
+		DNSNames: cert.hosts,
+	}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating a certificate request: %w", err)
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error finalizing the order: %w", err)
+	}
+
+	for _, der := range chain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	keyPEM, err = encodeECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certPEM, keyPEM, nil
+}
+
+// getClient returns a client for an ACME server. The account is registered on the first use.
+func (m *Manager) getClient(ctx context.Context, directoryURL string) (*xacme.Client, error) {
+	m.mu.Lock()
+	client, exists := m.clients[directoryURL]
+	m.mu.Unlock()
+
+	if exists {
+		return client, nil
+	}
+
+	client = &xacme.Client{
+		Key:          m.config.AccountKey,
+		DirectoryURL: directoryURL,
+		HTTPClient:   m.config.HTTPClient,
+		UserAgent:    "nginx-ingress-controller",
+	}
+
+	account := &xacme.Account{}
+	if m.config.Email != "" {
+		account.Contact = []string{"mailto:" + m.config.Email}
+	}
+
+	_, err := client.Register(ctx, account, xacme.AcceptTOS)
+	if err != nil && !errors.Is(err, xacme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("error registering an account with %s: %w", directoryURL, err)
+	}
+
+	m.mu.Lock()
+	m.clients[directoryURL] = client
+	m.mu.Unlock()
+
+	return client, nil
+}
+
+func (m *Manager) saveCertificate(ctx context.Context, cert certificate, certPEM []byte, keyPEM []byte) error {
+	data := map[string][]byte{
+		api_v1.TLSCertKey:       certPEM,
+		api_v1.TLSPrivateKeyKey: keyPEM,
+	}
+
+	secrets := m.kubeClient.CoreV1().Secrets(cert.namespace)
+
+	secret, err := secrets.Get(ctx, cert.secret, meta_v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		secret = &api_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      cert.secret,
+				Namespace: cert.namespace,
+			},
+			Type: api_v1.SecretTypeTLS,
+			Data: data,
+		}
+
+		_, err = secrets.Create(ctx, secret, meta_v1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("error creating the Secret %s/%s: %w", cert.namespace, cert.secret, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting the Secret %s/%s: %w", cert.namespace, cert.secret, err)
+	}
+
+	secretCopy := secret.DeepCopy()
+	secretCopy.Data = data
+
+	_, err = secrets.Update(ctx, secretCopy, meta_v1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating the Secret %s/%s: %w", cert.namespace, cert.secret, err)
+	}
+
+	return nil
+}
+
+// getValidCertificateExpiry returns the expiry of the certificate in a TLS Secret.
+// The returned bool is false if the Secret doesn't include a certificate for all the hosts.
+func getValidCertificateExpiry(secret *api_v1.Secret, hosts []string) (time.Time, bool) {
+	if secret.Type != api_v1.SecretTypeTLS {
+		return time.Time{}, false
+	}
+
+	cert, err := parseCertificate(secret.Data[api_v1.TLSCertKey])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for _, host := range hosts {
+		if err := cert.VerifyHostname(host); err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	return cert.NotAfter, true
+}
+
+// parseCertificate parses the first (leaf) certificate of a PEM-encoded chain.
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newCondition(status meta_v1.ConditionStatus, reason string, message string) meta_v1.Condition {
+	return meta_v1.Condition{
+		Type:    ConditionTypeCertificateReady,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
@@ -0,0 +1,420 @@
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func createTLSSecret(t *testing.T, host string, notAfter time.Time) *api_v1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPEM, err := encodeECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-secret",
+			Namespace: "default",
+		},
+		Type: api_v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			api_v1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			api_v1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+}
+
+func TestGetValidCertificateExpiry(t *testing.T) {
+	notAfter := time.Now().Add(60 * 24 * time.Hour).Truncate(time.Second)
+	secret := createTLSSecret(t, "cafe.example.com", notAfter)
+
+	tests := []struct {
+		secret        *api_v1.Secret
+		hosts         []string
+		expectedValid bool
+		msg           string
+	}{
+		{
+			secret:        secret,
+			hosts:         []string{"cafe.example.com"},
+			expectedValid: true,
+			msg:           "valid certificate",
+		},
+		{
+			secret:        secret,
+			hosts:         []string{"tea.example.com"},
+			expectedValid: false,
+			msg:           "certificate for another host",
+		},
+		{
+			secret:        secret,
+			hosts:         []string{"cafe.example.com", "www.cafe.example.com"},
+			expectedValid: false,
+			msg:           "certificate without an alias",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeTLS,
+				Data: map[string][]byte{
+					api_v1.TLSCertKey: []byte("invalid"),
+				},
+			},
+			hosts:         []string{"cafe.example.com"},
+			expectedValid: false,
+			msg:           "invalid certificate",
+		},
+		{
+			secret: &api_v1.Secret{
+				Type: api_v1.SecretTypeOpaque,
+			},
+			hosts:         []string{"cafe.example.com"},
+			expectedValid: false,
+			msg:           "wrong type",
+		},
+	}
+
+	for _, test := range tests {
+		result, valid := getValidCertificateExpiry(test.secret, test.hosts)
+		if valid != test.expectedValid {
+			t.Errorf("getValidCertificateExpiry() returned %v but expected %v for the case of %s", valid, test.expectedValid, test.msg)
+		}
+		if valid && !result.Equal(notAfter) {
+			t.Errorf("getValidCertificateExpiry() returned %v but expected %v for the case of %s", result, notAfter, test.msg)
+		}
+	}
+}
+
+func TestEnsureCertificateWithValidCertificate(t *testing.T) {
+	secret := createTLSSecret(t, "cafe.example.com", time.Now().Add(60*24*time.Hour))
+	kubeClient := fake.NewSimpleClientset(secret)
+
+	var conditions []meta_v1.Condition
+	updateStatus := func(_ string, _ string, condition meta_v1.Condition) {
+		conditions = append(conditions, condition)
+	}
+	isLeader := func() bool { return true }
+
+	m := NewManager(context.Background(), kubeClient, Config{RenewBefore: 30 * 24 * time.Hour}, isLeader, updateStatus)
+
+	cert := certificate{
+		namespace: "default",
+		name:      "cafe",
+		hosts:     []string{"cafe.example.com"},
+		issuer:    "https://acme.example.com/directory",
+		secret:    "cafe-secret",
+	}
+
+	m.ensureCertificate("default/cafe", cert)
+
+	if len(conditions) != 1 {
+		t.Fatalf("ensureCertificate() reported %d conditions but expected 1", len(conditions))
+	}
+	if conditions[0].Status != meta_v1.ConditionTrue || conditions[0].Reason != ReasonIssued {
+		t.Errorf("ensureCertificate() reported the condition %+v but expected the reason %v", conditions[0], ReasonIssued)
+	}
+}
+
+func TestEnsureCertificateNotLeader(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	updateStatus := func(_ string, _ string, _ meta_v1.Condition) {
+		t.Errorf("ensureCertificate() updated the status on a replica that is not the leader")
+	}
+	isLeader := func() bool { return false }
+
+	m := NewManager(context.Background(), kubeClient, Config{}, isLeader, updateStatus)
+
+	m.ensureCertificate("default/cafe", certificate{namespace: "default", name: "cafe", hosts: []string{"cafe.example.com"}, secret: "cafe-secret"})
+
+	if len(kubeClient.Actions()) != 0 {
+		t.Errorf("ensureCertificate() made %d API requests on a replica that is not the leader", len(kubeClient.Actions()))
+	}
+}
+
+// newTestACMEServer starts a minimal ACME server that accepts any http-01 challenge
+// and issues certificates signed by a self-signed CA.
+func newTestACMEServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var identifiers []map[string]string
+	var challengeAccepted bool
+	var certPEM []byte
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+
+	writeJSON := func(w http.ResponseWriter, status int, location string, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if location != "" {
+			w.Header().Set("Location", server.URL+location)
+		}
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Error(err)
+		}
+	}
+
+	order := func(status string) map[string]interface{} {
+		o := map[string]interface{}{
+			"status":         status,
+			"identifiers":    identifiers,
+			"authorizations": []string{server.URL + "/authz/1"},
+			"finalize":       server.URL + "/finalize/1",
+		}
+		if status == "valid" {
+			o["certificate"] = server.URL + "/cert/1"
+		}
+		return o
+	}
+
+	readPayload := func(r *http.Request, v interface{}) {
+		var jws struct {
+			Payload string `json:"payload"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+			t.Error(err)
+			return
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err := json.Unmarshal(payload, v); err != nil {
+			t.Error(err)
+		}
+	}
+
+	mux.HandleFunc("/directory", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, "", map[string]string{
+			"newNonce":   server.URL + "/new-nonce",
+			"newAccount": server.URL + "/new-account",
+			"newOrder":   server.URL + "/new-order",
+		})
+	})
+	mux.HandleFunc("/new-nonce", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/new-account", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusCreated, "/account/1", map[string]string{"status": "valid"})
+	})
+	mux.HandleFunc("/new-order", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Identifiers []map[string]string `json:"identifiers"`
+		}
+		readPayload(r, &req)
+
+		mu.Lock()
+		defer mu.Unlock()
+		identifiers = req.Identifiers
+		writeJSON(w, http.StatusCreated, "/order/1", order("pending"))
+	})
+	mux.HandleFunc("/authz/1", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		status := "pending"
+		if challengeAccepted {
+			status = "valid"
+		}
+		writeJSON(w, http.StatusOK, "", map[string]interface{}{
+			"status":     status,
+			"identifier": identifiers[0],
+			"challenges": []map[string]string{
+				{"type": "http-01", "url": server.URL + "/challenge/1", "token": "token", "status": status},
+			},
+		})
+	})
+	mux.HandleFunc("/challenge/1", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		challengeAccepted = true
+		writeJSON(w, http.StatusOK, "", map[string]string{
+			"type": "http-01", "url": server.URL + "/challenge/1", "token": "token", "status": "processing",
+		})
+	})
+	mux.HandleFunc("/order/1", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		status := "pending"
+		if certPEM != nil {
+			status = "valid"
+		} else if challengeAccepted {
+			status = "ready"
+		}
+		writeJSON(w, http.StatusOK, "/order/1", order(status))
+	})
+	mux.HandleFunc("/finalize/1", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CSR string `json:"csr"`
+		}
+		readPayload(r, &req)
+
+		csrDER, err := base64.RawURLEncoding.DecodeString(req.CSR)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		csr, err := x509.ParseCertificateRequest(csrDER)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      csr.Subject,
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(90 * 24 * time.Hour).Truncate(time.Second),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, csr.PublicKey, caKey)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		certPEM = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+		writeJSON(w, http.StatusOK, "/order/1", order("valid"))
+	})
+	mux.HandleFunc("/cert/1", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(certPEM)
+	})
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "nonce-"+strconv.FormatInt(time.Now().UnixNano(), 10))
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestEnsureCertificateIssuesCertificate(t *testing.T) {
+	server := newTestACMEServer(t)
+	kubeClient := fake.NewSimpleClientset()
+
+	accountKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conditions []meta_v1.Condition
+	updateStatus := func(_ string, _ string, condition meta_v1.Condition) {
+		conditions = append(conditions, condition)
+	}
+	isLeader := func() bool { return true }
+
+	config := Config{
+		AccountKey:  accountKey,
+		HTTPClient:  server.Client(),
+		RenewBefore: 30 * 24 * time.Hour,
+	}
+	m := NewManager(context.Background(), kubeClient, config, isLeader, updateStatus)
+
+	cert := certificate{
+		namespace: "default",
+		name:      "cafe",
+		hosts:     []string{"cafe.example.com", "www.cafe.example.com"},
+		issuer:    server.URL + "/directory",
+		secret:    "cafe-secret",
+	}
+
+	m.ensureCertificate("default/cafe", cert)
+
+	expectedReasons := []string{ReasonIssuing, ReasonIssued}
+	if len(conditions) != len(expectedReasons) {
+		t.Fatalf("ensureCertificate() reported the conditions %+v but expected the reasons %v", conditions, expectedReasons)
+	}
+	for i, reason := range expectedReasons {
+		if conditions[i].Reason != reason {
+			t.Errorf("ensureCertificate() reported the condition %+v but expected the reason %v", conditions[i], reason)
+		}
+	}
+	if conditions[1].Status != meta_v1.ConditionTrue {
+		t.Errorf("ensureCertificate() reported the condition %+v but expected the status %v", conditions[1], meta_v1.ConditionTrue)
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets("default").Get(context.Background(), "cafe-secret", meta_v1.GetOptions{})
+	if err != nil {
+		t.Fatalf("ensureCertificate() didn't create the Secret: %v", err)
+	}
+
+	notAfter, valid := getValidCertificateExpiry(secret, cert.hosts)
+	if !valid {
+		t.Fatalf("ensureCertificate() saved a certificate that isn't valid for hosts %v", cert.hosts)
+	}
+
+	expectedMessage := "Certificate for cafe.example.com, www.cafe.example.com is valid until " + notAfter.UTC().Format(time.RFC3339)
+	if conditions[1].Message != expectedMessage {
+		t.Errorf("ensureCertificate() reported the message %q but expected %q", conditions[1].Message, expectedMessage)
+	}
+	if len(secret.Data[api_v1.TLSPrivateKeyKey]) == 0 {
+		t.Errorf("ensureCertificate() saved a Secret without a private key")
+	}
+}
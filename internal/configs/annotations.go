@@ -1,7 +1,10 @@
 package configs
 
 import (
-	"github.com/golang/glog"
+	"log/slog"
+
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 )
 
 // JWTKeyAnnotation is the annotation where the Secret with a JWK is specified.
@@ -19,15 +22,33 @@ const AppProtectLogConfDstAnnotation = "appprotect.f5.com/app-protect-security-l
 // AppProtectDosProtectedAnnotation is the namespace/name reference of a DosProtectedResource
 const AppProtectDosProtectedAnnotation = "appprotectdos.f5.com/app-protect-dos-resource"
 
+// ErrorPagesAnnotation is the annotation where the ConfigMap with the custom error pages is specified.
+const ErrorPagesAnnotation = "nginx.org/error-pages"
+
+// UpstreamCASecretAnnotation is the annotation where the Secret with the CA for verifying the certificates of the backends is specified.
+const UpstreamCASecretAnnotation = "nginx.org/ca"
+
+// UpstreamTLSSecretAnnotation is the annotation where the Secret with the client certificate for the backends is specified.
+const UpstreamTLSSecretAnnotation = "nginx.org/upstream-tls-secret"
+
+// SessionAffinityModeAnnotation is the annotation where the mode of the session persistence of the nginx.com/sticky-cookie-services
+// annotation is specified: sticky (the default), which requires NGINX Plus, or hash.
+const SessionAffinityModeAnnotation = "nginx.org/session-affinity-mode"
+
 // nginxMeshInternalRoute specifies if the ingress resource is an internal route.
 const nginxMeshInternalRouteAnnotation = "nsm.nginx.com/internal-route"
 
 var masterBlacklist = map[string]bool{
 	"nginx.org/rewrites":                      true,
+	"nginx.org/path-regex":                    true,
+	"nginx.org/path-overrides":                true,
 	"nginx.org/ssl-services":                  true,
+	"nginx.org/ca":                            true,
+	"nginx.org/upstream-tls-secret":           true,
 	"nginx.org/grpc-services":                 true,
 	"nginx.org/websocket-services":            true,
 	"nginx.com/sticky-cookie-services":        true,
+	"nginx.org/session-affinity-mode":         true,
 	"nginx.com/health-checks":                 true,
 	"nginx.com/health-checks-mandatory":       true,
 	"nginx.com/health-checks-mandatory-queue": true,
@@ -45,6 +66,7 @@ var minionBlacklist = map[string]bool{
 	"nginx.org/listen-ports":                            true,
 	"nginx.org/listen-ports-ssl":                        true,
 	"nginx.org/server-snippets":                         true,
+	"nginx.org/error-pages":                             true,
 	"appprotect.f5.com/app_protect_enable":              true,
 	"appprotect.f5.com/app_protect_policy":              true,
 	"appprotect.f5.com/app_protect_security_log_enable": true,
@@ -68,21 +90,30 @@ var minionInheritanceList = map[string]bool{
 	"nginx.org/max-fails":                true,
 	"nginx.org/max-conns":                true,
 	"nginx.org/fail-timeout":             true,
+	"nginx.org/limit-req-rate":           true,
+	"nginx.org/limit-req-key":            true,
+	"nginx.org/limit-req-zone-size":      true,
+	"nginx.org/limit-req-burst":          true,
+	"nginx.org/limit-req-no-delay":       true,
+	"nginx.org/limit-req-reject-code":    true,
+	"nginx.org/allow-list":               true,
+	"nginx.org/deny-list":                true,
 }
 
 func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool, hasAppProtect bool, hasAppProtectDos bool, enableInternalRoutes bool) ConfigParams {
 	cfgParams := *baseCfgParams
+	l := nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name)
 
 	if lbMethod, exists := ingEx.Ingress.Annotations["nginx.org/lb-method"]; exists {
 		if isPlus {
 			if parsedMethod, err := ParseLBMethodForPlus(lbMethod); err != nil {
-				glog.Errorf("Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
+				nl.Errorf(l, "Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
 		} else {
 			if parsedMethod, err := ParseLBMethod(lbMethod); err != nil {
-				glog.Errorf("Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
+				nl.Errorf(l, "Ingress %s/%s: Invalid value for the nginx.org/lb-method: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), lbMethod, err)
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
@@ -91,19 +122,19 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if healthCheckEnabled, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.com/health-checks", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		}
 		if isPlus {
 			cfgParams.HealthCheckEnabled = healthCheckEnabled
 		} else {
-			glog.Warning("Annotation 'nginx.com/health-checks' requires NGINX Plus")
+			nl.Warn(l, "Annotation 'nginx.com/health-checks' requires NGINX Plus")
 		}
 	}
 
 	if cfgParams.HealthCheckEnabled {
 		if healthCheckMandatory, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.com/health-checks-mandatory", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			}
 			cfgParams.HealthCheckMandatory = healthCheckMandatory
 		}
@@ -112,7 +143,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if cfgParams.HealthCheckMandatory {
 		if healthCheckQueue, exists, err := GetMapKeyAsInt64(ingEx.Ingress.Annotations, "nginx.com/health-checks-mandatory-queue", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			}
 			cfgParams.HealthCheckMandatoryQueue = healthCheckQueue
 		}
@@ -120,12 +151,12 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if slowStart, exists := ingEx.Ingress.Annotations["nginx.com/slow-start"]; exists {
 		if parsedSlowStart, err := ParseTime(slowStart); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/slow-start: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), slowStart, err)
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/slow-start: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), slowStart, err)
 		} else {
 			if isPlus {
 				cfgParams.SlowStart = parsedSlowStart
 			} else {
-				glog.Warning("Annotation 'nginx.com/slow-start' requires NGINX Plus")
+				nl.Warn(l, "Annotation 'nginx.com/slow-start' requires NGINX Plus")
 			}
 		}
 	}
@@ -135,7 +166,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 			if isPlus {
 				cfgParams.ServerTokens = ingEx.Ingress.Annotations["nginx.org/server-tokens"]
 			} else {
-				glog.Error(err)
+				nl.Error(l, err)
 			}
 		} else {
 			cfgParams.ServerTokens = "off"
@@ -147,7 +178,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if serverSnippets, exists, err := GetMapKeyAsStringSlice(ingEx.Ingress.Annotations, "nginx.org/server-snippets", ingEx.Ingress, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ServerSnippets = serverSnippets
 		}
@@ -155,7 +186,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if locationSnippets, exists, err := GetMapKeyAsStringSlice(ingEx.Ingress.Annotations, "nginx.org/location-snippets", ingEx.Ingress, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.LocationSnippets = locationSnippets
 		}
@@ -163,7 +194,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyConnectTimeout, exists := ingEx.Ingress.Annotations["nginx.org/proxy-connect-timeout"]; exists {
 		if parsedProxyConnectTimeout, err := ParseTime(proxyConnectTimeout); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/proxy-connect-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxyConnectTimeout, err)
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/proxy-connect-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxyConnectTimeout, err)
 		} else {
 			cfgParams.ProxyConnectTimeout = parsedProxyConnectTimeout
 		}
@@ -171,7 +202,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyReadTimeout, exists := ingEx.Ingress.Annotations["nginx.org/proxy-read-timeout"]; exists {
 		if parsedProxyReadTimeout, err := ParseTime(proxyReadTimeout); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/proxy-read-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxyReadTimeout, err)
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/proxy-read-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxyReadTimeout, err)
 		} else {
 			cfgParams.ProxyReadTimeout = parsedProxyReadTimeout
 		}
@@ -179,7 +210,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxySendTimeout, exists := ingEx.Ingress.Annotations["nginx.org/proxy-send-timeout"]; exists {
 		if parsedProxySendTimeout, err := ParseTime(proxySendTimeout); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/proxy-send-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxySendTimeout, err)
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/proxy-send-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), proxySendTimeout, err)
 		} else {
 			cfgParams.ProxySendTimeout = parsedProxySendTimeout
 		}
@@ -187,7 +218,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyHideHeaders, exists, err := GetMapKeyAsStringSlice(ingEx.Ingress.Annotations, "nginx.org/proxy-hide-headers", ingEx.Ingress, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyHideHeaders = proxyHideHeaders
 		}
@@ -195,7 +226,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyPassHeaders, exists, err := GetMapKeyAsStringSlice(ingEx.Ingress.Annotations, "nginx.org/proxy-pass-headers", ingEx.Ingress, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyPassHeaders = proxyPassHeaders
 		}
@@ -207,7 +238,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if redirectToHTTPS, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/redirect-to-https", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.RedirectToHTTPS = redirectToHTTPS
 		}
@@ -215,7 +246,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if sslRedirect, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "ingress.kubernetes.io/ssl-redirect", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.SSLRedirect = sslRedirect
 		}
@@ -223,7 +254,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if proxyBuffering, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/proxy-buffering", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyBuffering = proxyBuffering
 		}
@@ -231,28 +262,28 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if hsts, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			parsingErrors := false
 
 			hstsMaxAge, existsMA, err := GetMapKeyAsInt64(ingEx.Ingress.Annotations, "nginx.org/hsts-max-age", ingEx.Ingress)
 			if existsMA && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 			hstsIncludeSubdomains, existsIS, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts-include-subdomains", ingEx.Ingress)
 			if existsIS && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 			hstsBehindProxy, existsBP, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/hsts-behind-proxy", ingEx.Ingress)
 			if existsBP && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 
 			if parsingErrors {
-				glog.Errorf("Ingress %s/%s: There are configuration issues with hsts annotations, skipping annotions for all hsts settings", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName())
+				nl.Errorf(l, "Ingress %s/%s: There are configuration issues with hsts annotations, skipping annotions for all hsts settings", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName())
 			} else {
 				cfgParams.HSTS = hsts
 				if existsMA {
@@ -302,7 +333,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if values, exists := ingEx.Ingress.Annotations["nginx.org/listen-ports"]; exists {
 		ports, err := ParsePortList(values)
 		if err != nil {
-			glog.Errorf("In %v nginx.org/listen-ports contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
+			nl.Errorf(l, "In %v nginx.org/listen-ports contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
 		}
 		if len(ports) > 0 {
 			cfgParams.Ports = ports
@@ -312,7 +343,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if values, exists := ingEx.Ingress.Annotations["nginx.org/listen-ports-ssl"]; exists {
 		sslPorts, err := ParsePortList(values)
 		if err != nil {
-			glog.Errorf("In %v nginx.org/listen-ports-ssl contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
+			nl.Errorf(l, "In %v nginx.org/listen-ports-ssl contains invalid declaration: %v, ignoring", ingEx.Ingress.Name, err)
 		}
 		if len(sslPorts) > 0 {
 			cfgParams.SSLPorts = sslPorts
@@ -321,7 +352,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if keepalive, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/keepalive", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.Keepalive = keepalive
 		}
@@ -329,7 +360,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if maxFails, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/max-fails", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MaxFails = maxFails
 		}
@@ -337,7 +368,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if maxConns, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/max-conns", ingEx.Ingress); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MaxConns = maxConns
 		}
@@ -345,16 +376,97 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 	if failTimeout, exists := ingEx.Ingress.Annotations["nginx.org/fail-timeout"]; exists {
 		if parsedFailTimeout, err := ParseTime(failTimeout); err != nil {
-			glog.Errorf("Ingress %s/%s: Invalid value nginx.org/fail-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), failTimeout, err)
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/fail-timeout: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), failTimeout, err)
 		} else {
 			cfgParams.FailTimeout = parsedFailTimeout
 		}
 	}
 
+	if canaryWeight, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/canary-weight", ingEx.Ingress); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			cfgParams.CanaryWeight = canaryWeight
+		}
+	}
+
+	if canaryByHeader, exists := ingEx.Ingress.Annotations["nginx.org/canary-by-header"]; exists {
+		cfgParams.CanaryByHeader = canaryByHeader
+	}
+
+	if canaryByCookie, exists := ingEx.Ingress.Annotations["nginx.org/canary-by-cookie"]; exists {
+		cfgParams.CanaryByCookie = canaryByCookie
+	}
+
+	if pathRegex, exists := ingEx.Ingress.Annotations["nginx.org/path-regex"]; exists {
+		cfgParams.PathRegex = pathRegex
+	}
+
+	if limitReqRate, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-rate"]; exists {
+		cfgParams.LimitReqRate = limitReqRate
+	}
+
+	if limitReqKey, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-key"]; exists {
+		cfgParams.LimitReqKey = limitReqKey
+	}
+
+	if limitReqZoneSize, exists := ingEx.Ingress.Annotations["nginx.org/limit-req-zone-size"]; exists {
+		cfgParams.LimitReqZoneSize = limitReqZoneSize
+	}
+
+	if limitReqBurst, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/limit-req-burst", ingEx.Ingress); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			cfgParams.LimitReqBurst = limitReqBurst
+		}
+	}
+
+	if limitReqNoDelay, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "nginx.org/limit-req-no-delay", ingEx.Ingress); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			cfgParams.LimitReqNoDelay = limitReqNoDelay
+		}
+	}
+
+	if limitReqRejectCode, exists, err := GetMapKeyAsInt(ingEx.Ingress.Annotations, "nginx.org/limit-req-reject-code", ingEx.Ingress); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			cfgParams.LimitReqRejectCode = limitReqRejectCode
+		}
+	}
+
+	if allowList, exists := ingEx.Ingress.Annotations["nginx.org/allow-list"]; exists {
+		cfgParams.AllowList = ParseIPList(allowList)
+	}
+
+	if denyList, exists := ingEx.Ingress.Annotations["nginx.org/deny-list"]; exists {
+		cfgParams.DenyList = ParseIPList(denyList)
+	}
+
+	if upstreamCASecret, exists := ingEx.Ingress.Annotations[UpstreamCASecretAnnotation]; exists {
+		cfgParams.UpstreamCASecret = upstreamCASecret
+	}
+
+	if upstreamTLSSecret, exists := ingEx.Ingress.Annotations[UpstreamTLSSecretAnnotation]; exists {
+		cfgParams.UpstreamTLSSecret = upstreamTLSSecret
+	}
+
+	if pathOverrides, exists := ingEx.Ingress.Annotations["nginx.org/path-overrides"]; exists {
+		overrides, err := ParsePathOverrides(pathOverrides)
+		if err != nil {
+			nl.Errorf(l, "Ingress %s/%s: Invalid value nginx.org/path-overrides: got %q: %v", ingEx.Ingress.GetNamespace(), ingEx.Ingress.GetName(), pathOverrides, err)
+		} else {
+			cfgParams.PathOverrides = overrides
+		}
+	}
+
 	if hasAppProtect {
 		if appProtectEnable, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "appprotect.f5.com/app-protect-enable", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			} else {
 				if appProtectEnable {
 					cfgParams.AppProtectEnable = "on"
@@ -366,7 +478,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 
 		if appProtectLogEnable, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, "appprotect.f5.com/app-protect-security-log-enable", ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			} else {
 				if appProtectLogEnable {
 					cfgParams.AppProtectLogEnable = "on"
@@ -385,7 +497,7 @@ func parseAnnotations(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool
 	if enableInternalRoutes {
 		if spiffeServerCerts, exists, err := GetMapKeyAsBool(ingEx.Ingress.Annotations, nginxMeshInternalRouteAnnotation, ingEx.Ingress); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			} else {
 				cfgParams.SpiffeServerCerts = spiffeServerCerts
 			}
@@ -405,7 +517,7 @@ func getRewrites(ingEx *IngressEx) map[string]string {
 	if value, exists := ingEx.Ingress.Annotations["nginx.org/rewrites"]; exists {
 		rewrites, err := ParseRewriteList(value)
 		if err != nil {
-			glog.Error(err)
+			nl.Error(nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name), err)
 		}
 		return rewrites
 	}
@@ -427,16 +539,49 @@ func getGrpcServices(ingEx *IngressEx) map[string]bool {
 }
 
 func getSessionPersistenceServices(ingEx *IngressEx) map[string]string {
+	if ingEx.Ingress.Annotations[SessionAffinityModeAnnotation] == "hash" {
+		return nil
+	}
 	if value, exists := ingEx.Ingress.Annotations["nginx.com/sticky-cookie-services"]; exists {
 		services, err := ParseStickyServiceList(value)
 		if err != nil {
-			glog.Error(err)
+			nl.Error(nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name), err)
 		}
 		return services
 	}
 	return nil
 }
 
+// getHashSessionServices returns the session persistence of the services of the nginx.com/sticky-cookie-services annotation
+// in the hash session affinity mode.
+func getHashSessionServices(ingEx *IngressEx) map[string]*conf_v1.SessionCookie {
+	if ingEx.Ingress.Annotations[SessionAffinityModeAnnotation] != "hash" {
+		return nil
+	}
+	value, exists := ingEx.Ingress.Annotations["nginx.com/sticky-cookie-services"]
+	if !exists {
+		return nil
+	}
+
+	l := nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name)
+	services, err := ParseStickyServiceList(value)
+	if err != nil {
+		nl.Error(l, err)
+		return nil
+	}
+
+	sessions := make(map[string]*conf_v1.SessionCookie)
+	for service, params := range services {
+		sc, err := ParseHashSessionCookie(params)
+		if err != nil {
+			nl.Errorf(l, "Ingress %s/%s: Invalid value for the nginx.com/sticky-cookie-services for service %s: %v", ingEx.Ingress.Namespace, ingEx.Ingress.Name, service, err)
+			continue
+		}
+		sessions[service] = sc
+	}
+	return sessions
+}
+
 func filterMasterAnnotations(annotations map[string]string) []string {
 	var removedAnnotations []string
 
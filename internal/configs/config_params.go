@@ -5,9 +5,14 @@ import conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configurat
 // ConfigParams holds NGINX configuration parameters that affect the main NGINX config
 // as well as configs for Ingress resources.
 type ConfigParams struct {
+	CanaryByCookie                         string
+	CanaryByHeader                         string
+	CanaryWeight                           int
 	ClientMaxBodySize                      string
+	AllowList                              []string
 	DefaultServerAccessLogOff              bool
 	DefaultServerReturn                    string
+	DenyList                               []string
 	FailTimeout                            string
 	HealthCheckEnabled                     bool
 	HealthCheckMandatory                   bool
@@ -19,6 +24,12 @@ type ConfigParams struct {
 	HTTP2                                  bool
 	Keepalive                              int
 	LBMethod                               string
+	LimitReqBurst                          int
+	LimitReqKey                            string
+	LimitReqNoDelay                        bool
+	LimitReqRate                           string
+	LimitReqRejectCode                     int
+	LimitReqZoneSize                       string
 	LocationSnippets                       []string
 	MainAccessLogOff                       bool
 	MainErrorLogLevel                      string
@@ -32,6 +43,12 @@ type ConfigParams struct {
 	MainOpenTracingLoadModule              bool
 	MainOpenTracingTracer                  string
 	MainOpenTracingTracerConfig            string
+	MainOtelExporterEndpoint               string
+	MainOtelLoadModule                     bool
+	MainOtelSamplerPercentage              float64
+	MainOtelServiceName                    string
+	MainOtelTraceContext                   string
+	MainOtelTraceEnabled                   bool
 	MainServerNamesHashBucketSize          string
 	MainServerNamesHashMaxSize             string
 	MainStreamLogFormat                    []string
@@ -56,6 +73,8 @@ type ConfigParams struct {
 	AppProtectDosResource                  string
 	MainAppProtectDosLogFormat             []string
 	MainAppProtectDosLogFormatEscaping     string
+	PathOverrides                          map[string]map[string]string
+	PathRegex                              string
 	ProxyBuffering                         bool
 	ProxyBuffers                           string
 	ProxyBufferSize                        string
@@ -67,6 +86,8 @@ type ConfigParams struct {
 	ProxyReadTimeout                       string
 	ProxySendTimeout                       string
 	RedirectToHTTPS                        bool
+	RequestID                              bool
+	RequestIDHeader                        string
 	ResolverAddresses                      []string
 	ResolverIPV6                           bool
 	ResolverTimeout                        string
@@ -75,6 +96,8 @@ type ConfigParams struct {
 	ServerTokens                           string
 	SlowStart                              string
 	SSLRedirect                            bool
+	UpstreamCASecret                       string
+	UpstreamTLSSecret                      string
 	UpstreamZoneSize                       string
 	VariablesHashBucketSize                uint64
 	VariablesHashMaxSize                   uint64
@@ -122,6 +145,9 @@ type StaticConfigParams struct {
 	EnableLatencyMetrics           bool
 	EnablePreviewPolicies          bool
 	SSLRejectHandshake             bool
+	DynamicSSLReload               bool
+	StaticSSLPath                  string
+	ACMEAccountThumbprint          string
 }
 
 // GlobalConfigParams holds global configuration parameters. For now, it only holds listeners.
@@ -146,6 +172,7 @@ func NewDefaultConfigParams(isPlus bool) *ConfigParams {
 	return &ConfigParams{
 		DefaultServerReturn:           "404",
 		ServerTokens:                  "on",
+		MainOtelSamplerPercentage:     100,
 		ProxyConnectTimeout:           "60s",
 		ProxyReadTimeout:              "60s",
 		ProxySendTimeout:              "60s",
@@ -157,6 +184,7 @@ func NewDefaultConfigParams(isPlus bool) *ConfigParams {
 		MainWorkerProcesses:           "auto",
 		MainWorkerConnections:         "1024",
 		HSTSMaxAge:                    2592000,
+		RequestIDHeader:               "X-Request-ID",
 		Ports:                         []int{80},
 		SSLPorts:                      []int{443},
 		MaxFails:                      1,
@@ -164,6 +192,8 @@ func NewDefaultConfigParams(isPlus bool) *ConfigParams {
 		UpstreamZoneSize:              upstreamZoneSize,
 		FailTimeout:                   "10s",
 		LBMethod:                      "random two least_conn",
+		LimitReqKey:                   "${binary_remote_addr}",
+		LimitReqZoneSize:              "10m",
 		MainErrorLogLevel:             "notice",
 		ResolverIPV6:                  true,
 		MainKeepaliveTimeout:          "65s",
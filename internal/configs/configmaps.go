@@ -1,24 +1,28 @@
 package configs
 
 import (
+	"log/slog"
+	"strconv"
 	"strings"
 
-	"github.com/golang/glog"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 )
 
 // ParseConfigMap parses ConfigMap into ConfigParams.
 func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasAppProtectDos bool) *ConfigParams {
 	cfgParams := NewDefaultConfigParams(nginxPlus)
+	l := nl.WithResource(slog.Default(), "ConfigMap", cfgm.Namespace, cfgm.Name)
 
 	if serverTokens, exists, err := GetMapKeyAsBool(cfgm.Data, "server-tokens", cfgm); exists {
 		if err != nil {
 			if nginxPlus {
 				cfgParams.ServerTokens = cfgm.Data["server-tokens"]
 			} else {
-				glog.Error(err)
+				nl.Error(l, err)
 			}
 		} else {
 			cfgParams.ServerTokens = "off"
@@ -31,13 +35,13 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 	if lbMethod, exists := cfgm.Data["lb-method"]; exists {
 		if nginxPlus {
 			if parsedMethod, err := ParseLBMethodForPlus(lbMethod); err != nil {
-				glog.Errorf("Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
+				nl.Errorf(l, "Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
 		} else {
 			if parsedMethod, err := ParseLBMethod(lbMethod); err != nil {
-				glog.Errorf("Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
+				nl.Errorf(l, "Configmap %s/%s: Invalid value for the lb-method key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), lbMethod, err)
 			} else {
 				cfgParams.LBMethod = parsedMethod
 			}
@@ -58,7 +62,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if proxyHideHeaders, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "proxy-hide-headers", cfgm, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyHideHeaders = proxyHideHeaders
 		}
@@ -66,7 +70,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if proxyPassHeaders, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "proxy-pass-headers", cfgm, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyPassHeaders = proxyPassHeaders
 		}
@@ -86,7 +90,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if HTTP2, exists, err := GetMapKeyAsBool(cfgm.Data, "http2", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.HTTP2 = HTTP2
 		}
@@ -94,7 +98,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if redirectToHTTPS, exists, err := GetMapKeyAsBool(cfgm.Data, "redirect-to-https", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.RedirectToHTTPS = redirectToHTTPS
 		}
@@ -102,7 +106,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if sslRedirect, exists, err := GetMapKeyAsBool(cfgm.Data, "ssl-redirect", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.SSLRedirect = sslRedirect
 		}
@@ -110,28 +114,28 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if hsts, exists, err := GetMapKeyAsBool(cfgm.Data, "hsts", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			parsingErrors := false
 
 			hstsMaxAge, existsMA, err := GetMapKeyAsInt64(cfgm.Data, "hsts-max-age", cfgm)
 			if existsMA && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 			hstsIncludeSubdomains, existsIS, err := GetMapKeyAsBool(cfgm.Data, "hsts-include-subdomains", cfgm)
 			if existsIS && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 			hstsBehindProxy, existsBP, err := GetMapKeyAsBool(cfgm.Data, "hsts-behind-proxy", cfgm)
 			if existsBP && err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 				parsingErrors = true
 			}
 
 			if parsingErrors {
-				glog.Errorf("Configmap %s/%s: There are configuration issues with hsts annotations, skipping options for all hsts settings", cfgm.GetNamespace(), cfgm.GetName())
+				nl.Errorf(l, "Configmap %s/%s: There are configuration issues with hsts annotations, skipping options for all hsts settings", cfgm.GetNamespace(), cfgm.GetName())
 			} else {
 				cfgParams.HSTS = hsts
 				if existsMA {
@@ -149,19 +153,35 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if proxyProtocol, exists, err := GetMapKeyAsBool(cfgm.Data, "proxy-protocol", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyProtocol = proxyProtocol
 		}
 	}
 
+	if requestID, exists, err := GetMapKeyAsBool(cfgm.Data, "request-id", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			cfgParams.RequestID = requestID
+		}
+	}
+
+	if requestIDHeader, exists := cfgm.Data["request-id-header"]; exists {
+		if errs := validation.IsHTTPHeaderName(requestIDHeader); len(errs) > 0 {
+			nl.Errorf(l, "Configmap %s/%s: Invalid value for the request-id-header key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), requestIDHeader, strings.Join(errs, ", "))
+		} else {
+			cfgParams.RequestIDHeader = requestIDHeader
+		}
+	}
+
 	if realIPHeader, exists := cfgm.Data["real-ip-header"]; exists {
 		cfgParams.RealIPHeader = realIPHeader
 	}
 
 	if setRealIPFrom, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "set-real-ip-from", cfgm, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.SetRealIPFrom = setRealIPFrom
 		}
@@ -169,7 +189,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if realIPRecursive, exists, err := GetMapKeyAsBool(cfgm.Data, "real-ip-recursive", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.RealIPRecursive = realIPRecursive
 		}
@@ -181,7 +201,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if sslPreferServerCiphers, exists, err := GetMapKeyAsBool(cfgm.Data, "ssl-prefer-server-ciphers", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainServerSSLPreferServerCiphers = sslPreferServerCiphers
 		}
@@ -202,7 +222,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if accessLogOff, exists, err := GetMapKeyAsBool(cfgm.Data, "access-log-off", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainAccessLogOff = accessLogOff
 		}
@@ -210,7 +230,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if logFormat, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "log-format", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainLogFormat = logFormat
 		}
@@ -225,7 +245,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if streamLogFormat, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "stream-log-format", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainStreamLogFormat = streamLogFormat
 		}
@@ -240,7 +260,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if defaultServerAccessLogOff, exists, err := GetMapKeyAsBool(cfgm.Data, "default-server-access-log-off", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.DefaultServerAccessLogOff = defaultServerAccessLogOff
 		}
@@ -252,7 +272,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if proxyBuffering, exists, err := GetMapKeyAsBool(cfgm.Data, "proxy-buffering", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ProxyBuffering = proxyBuffering
 		}
@@ -272,7 +292,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if mainMainSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "main-snippets", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainMainSnippets = mainMainSnippets
 		}
@@ -280,7 +300,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if mainHTTPSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "http-snippets", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainHTTPSnippets = mainHTTPSnippets
 		}
@@ -288,7 +308,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if locationSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "location-snippets", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.LocationSnippets = locationSnippets
 		}
@@ -296,7 +316,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if serverSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "server-snippets", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.ServerSnippets = serverSnippets
 		}
@@ -304,7 +324,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if _, exists, err := GetMapKeyAsInt(cfgm.Data, "worker-processes", cfgm); exists {
 		if err != nil && cfgm.Data["worker-processes"] != "auto" {
-			glog.Errorf("Configmap %s/%s: Invalid value for worker-processes key: must be an integer or the string 'auto', got %q", cfgm.GetNamespace(), cfgm.GetName(), cfgm.Data["worker-processes"])
+			nl.Errorf(l, "Configmap %s/%s: Invalid value for worker-processes key: must be an integer or the string 'auto', got %q", cfgm.GetNamespace(), cfgm.GetName(), cfgm.Data["worker-processes"])
 		} else {
 			cfgParams.MainWorkerProcesses = cfgm.Data["worker-processes"]
 		}
@@ -328,7 +348,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if keepalive, exists, err := GetMapKeyAsInt(cfgm.Data, "keepalive", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.Keepalive = keepalive
 		}
@@ -336,7 +356,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if maxFails, exists, err := GetMapKeyAsInt(cfgm.Data, "max-fails", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MaxFails = maxFails
 		}
@@ -364,7 +384,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if mainStreamSnippets, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "stream-snippets", cfgm, "\n"); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainStreamSnippets = mainStreamSnippets
 		}
@@ -372,42 +392,26 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if resolverAddresses, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "resolver-addresses", cfgm, ","); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
-			if nginxPlus {
-				cfgParams.ResolverAddresses = resolverAddresses
-			} else {
-				glog.Warning("ConfigMap key 'resolver-addresses' requires NGINX Plus")
-			}
+			cfgParams.ResolverAddresses = resolverAddresses
 		}
 	}
 
 	if resolverIpv6, exists, err := GetMapKeyAsBool(cfgm.Data, "resolver-ipv6", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
-			if nginxPlus {
-				cfgParams.ResolverIPV6 = resolverIpv6
-			} else {
-				glog.Warning("ConfigMap key 'resolver-ipv6' requires NGINX Plus")
-			}
+			cfgParams.ResolverIPV6 = resolverIpv6
 		}
 	}
 
 	if resolverValid, exists := cfgm.Data["resolver-valid"]; exists {
-		if nginxPlus {
-			cfgParams.ResolverValid = resolverValid
-		} else {
-			glog.Warning("ConfigMap key 'resolver-valid' requires NGINX Plus")
-		}
+		cfgParams.ResolverValid = resolverValid
 	}
 
 	if resolverTimeout, exists := cfgm.Data["resolver-timeout"]; exists {
-		if nginxPlus {
-			cfgParams.ResolverTimeout = resolverTimeout
-		} else {
-			glog.Warning("ConfigMap key 'resolver-timeout' requires NGINX Plus")
-		}
+		cfgParams.ResolverTimeout = resolverTimeout
 	}
 
 	if keepaliveTimeout, exists := cfgm.Data["keepalive-timeout"]; exists {
@@ -416,7 +420,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if keepaliveRequests, exists, err := GetMapKeyAsInt64(cfgm.Data, "keepalive-requests", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.MainKeepaliveRequests = keepaliveRequests
 		}
@@ -424,7 +428,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if varHashBucketSize, exists, err := GetMapKeyAsUint64(cfgm.Data, "variables-hash-bucket-size", cfgm, true); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.VariablesHashBucketSize = varHashBucketSize
 		}
@@ -432,7 +436,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if varHashMaxSize, exists, err := GetMapKeyAsUint64(cfgm.Data, "variables-hash-max-size", cfgm, false); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			cfgParams.VariablesHashMaxSize = varHashMaxSize
 		}
@@ -452,12 +456,51 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 
 	if openTracing, exists, err := GetMapKeyAsBool(cfgm.Data, "opentracing", cfgm); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(l, err)
 		} else {
 			if cfgParams.MainOpenTracingLoadModule {
 				cfgParams.MainOpenTracingEnabled = openTracing
 			} else {
-				glog.Error("ConfigMap Key 'opentracing' requires both 'opentracing-tracer' and 'opentracing-tracer-config' Keys configured, Opentracing will be disabled")
+				nl.Error(l, "ConfigMap Key 'opentracing' requires both 'opentracing-tracer' and 'opentracing-tracer-config' Keys configured, Opentracing will be disabled")
+			}
+		}
+	}
+
+	if otelExporterEndpoint, exists := cfgm.Data["otel-exporter-endpoint"]; exists {
+		cfgParams.MainOtelExporterEndpoint = strings.TrimSpace(otelExporterEndpoint)
+		cfgParams.MainOtelLoadModule = cfgParams.MainOtelExporterEndpoint != ""
+	}
+
+	if otelServiceName, exists := cfgm.Data["otel-service-name"]; exists {
+		cfgParams.MainOtelServiceName = strings.TrimSpace(otelServiceName)
+	}
+
+	if otelSamplerRatio, exists := cfgm.Data["otel-sampler-ratio"]; exists {
+		otelSamplerPercentage, err := ParseOtelSamplerRatio(otelSamplerRatio)
+		if err != nil {
+			nl.Errorf(l, "Configmap %s/%s: Invalid value for the otel-sampler-ratio key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), otelSamplerRatio, err)
+		} else {
+			cfgParams.MainOtelSamplerPercentage = otelSamplerPercentage
+		}
+	}
+
+	if otelTraceContext, exists := cfgm.Data["otel-trace-context"]; exists {
+		parsedTraceContext, err := ParseOtelTraceContext(otelTraceContext)
+		if err != nil {
+			nl.Errorf(l, "Configmap %s/%s: Invalid value for the otel-trace-context key: got %q: %v", cfgm.GetNamespace(), cfgm.GetName(), otelTraceContext, err)
+		} else {
+			cfgParams.MainOtelTraceContext = parsedTraceContext
+		}
+	}
+
+	if otelTrace, exists, err := GetMapKeyAsBool(cfgm.Data, "otel-trace", cfgm); exists {
+		if err != nil {
+			nl.Error(l, err)
+		} else {
+			if cfgParams.MainOtelLoadModule {
+				cfgParams.MainOtelTraceEnabled = otelTrace
+			} else {
+				nl.Error(l, "ConfigMap Key 'otel-trace' requires the 'otel-exporter-endpoint' Key configured, OpenTelemetry tracing will be disabled")
 			}
 		}
 	}
@@ -467,7 +510,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 			if appProtectFailureModeAction == "pass" || appProtectFailureModeAction == "drop" {
 				cfgParams.MainAppProtectFailureModeAction = appProtectFailureModeAction
 			} else {
-				glog.Error("ConfigMap Key 'app-protect-failure-mode-action' must have value 'pass' or 'drop'. Ignoring.")
+				nl.Error(l, "ConfigMap Key 'app-protect-failure-mode-action' must have value 'pass' or 'drop'. Ignoring.")
 			}
 		}
 
@@ -475,7 +518,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 			if appProtectCompressedRequestsAction == "pass" || appProtectCompressedRequestsAction == "drop" {
 				cfgParams.MainAppProtectCompressedRequestsAction = appProtectCompressedRequestsAction
 			} else {
-				glog.Error("ConfigMap Key 'app-protect-compressed-requests-action' must have value 'pass' or 'drop'. Ignoring.")
+				nl.Error(l, "ConfigMap Key 'app-protect-compressed-requests-action' must have value 'pass' or 'drop'. Ignoring.")
 			}
 		}
 
@@ -487,7 +530,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 			if VerifyAppProtectThresholds(appProtectCPUThresholds) {
 				cfgParams.MainAppProtectCPUThresholds = appProtectCPUThresholds
 			} else {
-				glog.Error("ConfigMap Key 'app-protect-cpu-thresholds' must follow pattern: 'high=<0 - 100> low=<0 - 100>'. Ignoring.")
+				nl.Error(l, "ConfigMap Key 'app-protect-cpu-thresholds' must follow pattern: 'high=<0 - 100> low=<0 - 100>'. Ignoring.")
 			}
 		}
 
@@ -496,7 +539,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 			if VerifyAppProtectThresholds(appProtectPhysicalMemoryThresholds) {
 				cfgParams.MainAppProtectPhysicalMemoryThresholds = appProtectPhysicalMemoryThresholds
 			} else {
-				glog.Error("ConfigMap Key 'app-protect-physical-memory-thresholds' must follow pattern: 'high=<0 - 100> low=<0 - 100>'. Ignoring.")
+				nl.Error(l, "ConfigMap Key 'app-protect-physical-memory-thresholds' must follow pattern: 'high=<0 - 100> low=<0 - 100>'. Ignoring.")
 			}
 		}
 	}
@@ -504,7 +547,7 @@ func ParseConfigMap(cfgm *v1.ConfigMap, nginxPlus bool, hasAppProtect bool, hasA
 	if hasAppProtectDos {
 		if appProtectDosLogFormat, exists, err := GetMapKeyAsStringSlice(cfgm.Data, "app-protect-dos-log-format", cfgm, "\n"); exists {
 			if err != nil {
-				glog.Error(err)
+				nl.Error(l, err)
 			} else {
 				cfgParams.MainAppProtectDosLogFormat = appProtectDosLogFormat
 			}
@@ -527,6 +570,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		AccessLogOff:                       config.MainAccessLogOff,
 		DefaultServerAccessLogOff:          config.DefaultServerAccessLogOff,
 		DefaultServerReturn:                config.DefaultServerReturn,
+		DynamicSSLReload:                   staticCfgParams.DynamicSSLReload,
 		ErrorLogLevel:                      config.MainErrorLogLevel,
 		HealthStatus:                       staticCfgParams.HealthStatus,
 		HealthStatusURI:                    staticCfgParams.HealthStatusURI,
@@ -544,11 +588,18 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		OpenTracingLoadModule:              config.MainOpenTracingLoadModule,
 		OpenTracingTracer:                  config.MainOpenTracingTracer,
 		OpenTracingTracerConfig:            config.MainOpenTracingTracerConfig,
+		OtelExporterEndpoint:               config.MainOtelExporterEndpoint,
+		OtelLoadModule:                     config.MainOtelLoadModule,
+		OtelServiceName:                    config.MainOtelServiceName,
+		OtelTraceContext:                   config.MainOtelTraceContext,
 		ProxyProtocol:                      config.ProxyProtocol,
 		ResolverAddresses:                  config.ResolverAddresses,
 		ResolverIPV6:                       config.ResolverIPV6,
 		ResolverTimeout:                    config.ResolverTimeout,
 		ResolverValid:                      config.ResolverValid,
+		RequestID:                          config.RequestID,
+		RequestIDHeader:                    config.RequestIDHeader,
+		RequestIDHeaderVariable:            generateHeaderVariable(config.RequestIDHeader),
 		RealIPHeader:                       config.RealIPHeader,
 		RealIPRecursive:                    config.RealIPRecursive,
 		SetRealIPFrom:                      config.SetRealIPFrom,
@@ -560,6 +611,7 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		SSLPreferServerCiphers:             config.MainServerSSLPreferServerCiphers,
 		SSLProtocols:                       config.MainServerSSLProtocols,
 		SSLRejectHandshake:                 staticCfgParams.SSLRejectHandshake,
+		StaticSSLPath:                      staticCfgParams.StaticSSLPath,
 		TLSPassthrough:                     staticCfgParams.TLSPassthrough,
 		StreamLogFormat:                    config.MainStreamLogFormat,
 		StreamLogFormatEscaping:            config.MainStreamLogFormatEscaping,
@@ -586,5 +638,48 @@ func GenerateNginxMainConfig(staticCfgParams *StaticConfigParams, config *Config
 		LatencyMetrics:                     staticCfgParams.EnableLatencyMetrics,
 		PreviewPolicies:                    staticCfgParams.EnablePreviewPolicies,
 	}
+
+	if config.MainOtelTraceEnabled {
+		nginxCfg.OtelTrace = generateOtelTrace(config.MainOtelSamplerPercentage, otelRatioSamplerVariable)
+		if nginxCfg.OtelTrace == otelRatioSamplerVariable {
+			nginxCfg.OtelSamplerPercentage = formatPercentage(config.MainOtelSamplerPercentage)
+		}
+	}
+
 	return nginxCfg
 }
+
+const otelRatioSamplerVariable = "$otel_ratio_sampler"
+
+// generateOtelTrace generates the value of the otel_trace directive for the given percentage of sampled requests.
+// If only a part of the requests is sampled, the value is the variable of a split_clients that samples them.
+func generateOtelTrace(percentage float64, samplerVariable string) string {
+	if percentage <= 0 {
+		return "off"
+	}
+	if percentage >= 100 {
+		return "on"
+	}
+	return samplerVariable
+}
+
+func formatPercentage(percentage float64) string {
+	return strconv.FormatFloat(percentage, 'f', -1, 64) + "%"
+}
+
+// requestIDVariable is the variable of the main config that holds the request ID of a request.
+// The variable takes the value of the request ID header of the request or of the generated $request_id.
+const requestIDVariable = "$correlation_id"
+
+// generateHeaderVariable generates the NGINX variable of a request header, for example $http_x_request_id for X-Request-ID.
+func generateHeaderVariable(header string) string {
+	return "$http_" + strings.ReplaceAll(strings.ToLower(header), "-", "_")
+}
+
+// generateRequestIDHeader returns the name of the request ID header if the request ID is enabled.
+func generateRequestIDHeader(enable bool, cfgParams *ConfigParams) string {
+	if !enable {
+		return ""
+	}
+	return cfgParams.RequestIDHeader
+}
@@ -48,3 +48,179 @@ func TestParseConfigMapWithAppProtectCompressedRequestsAction(t *testing.T) {
 		}
 	}
 }
+
+func TestParseConfigMapWithOtel(t *testing.T) {
+	tests := []struct {
+		data                  map[string]string
+		expectedLoadModule    bool
+		expectedTraceEnabled  bool
+		expectedPercentage    float64
+		expectedTraceContext  string
+		expectedServiceName   string
+		expectedEndpointValue string
+		msg                   string
+	}{
+		{
+			data: map[string]string{
+				"otel-exporter-endpoint": "otel-collector:4317",
+				"otel-service-name":      "nginx-ingress",
+				"otel-trace":             "true",
+				"otel-sampler-ratio":     "0.25",
+				"otel-trace-context":     "propagate",
+			},
+			expectedLoadModule:    true,
+			expectedTraceEnabled:  true,
+			expectedPercentage:    25,
+			expectedTraceContext:  "propagate",
+			expectedServiceName:   "nginx-ingress",
+			expectedEndpointValue: "otel-collector:4317",
+			msg:                   "all keys",
+		},
+		{
+			data: map[string]string{
+				"otel-trace": "true",
+			},
+			expectedLoadModule:   false,
+			expectedTraceEnabled: false,
+			expectedPercentage:   100,
+			msg:                  "tracing without exporter endpoint",
+		},
+		{
+			data: map[string]string{
+				"otel-exporter-endpoint": "otel-collector:4317",
+				"otel-sampler-ratio":     "2",
+				"otel-trace-context":     "invalid",
+			},
+			expectedLoadModule:    true,
+			expectedPercentage:    100,
+			expectedEndpointValue: "otel-collector:4317",
+			msg:                   "invalid sampler ratio and trace context",
+		},
+	}
+
+	for _, test := range tests {
+		cm := &v1.ConfigMap{
+			Data: test.data,
+		}
+		result := ParseConfigMap(cm, false, false, false)
+		if result.MainOtelLoadModule != test.expectedLoadModule {
+			t.Errorf("ParseConfigMap() returned MainOtelLoadModule %v but expected %v for the case %s", result.MainOtelLoadModule, test.expectedLoadModule, test.msg)
+		}
+		if result.MainOtelTraceEnabled != test.expectedTraceEnabled {
+			t.Errorf("ParseConfigMap() returned MainOtelTraceEnabled %v but expected %v for the case %s", result.MainOtelTraceEnabled, test.expectedTraceEnabled, test.msg)
+		}
+		if result.MainOtelSamplerPercentage != test.expectedPercentage {
+			t.Errorf("ParseConfigMap() returned MainOtelSamplerPercentage %v but expected %v for the case %s", result.MainOtelSamplerPercentage, test.expectedPercentage, test.msg)
+		}
+		if result.MainOtelTraceContext != test.expectedTraceContext {
+			t.Errorf("ParseConfigMap() returned MainOtelTraceContext %q but expected %q for the case %s", result.MainOtelTraceContext, test.expectedTraceContext, test.msg)
+		}
+		if result.MainOtelServiceName != test.expectedServiceName {
+			t.Errorf("ParseConfigMap() returned MainOtelServiceName %q but expected %q for the case %s", result.MainOtelServiceName, test.expectedServiceName, test.msg)
+		}
+		if result.MainOtelExporterEndpoint != test.expectedEndpointValue {
+			t.Errorf("ParseConfigMap() returned MainOtelExporterEndpoint %q but expected %q for the case %s", result.MainOtelExporterEndpoint, test.expectedEndpointValue, test.msg)
+		}
+	}
+}
+
+func TestGenerateNginxMainConfigWithOtelTrace(t *testing.T) {
+	tests := []struct {
+		percentage         float64
+		expectedTrace      string
+		expectedPercentage string
+	}{
+		{
+			percentage:    100,
+			expectedTrace: "on",
+		},
+		{
+			percentage:    0,
+			expectedTrace: "off",
+		},
+		{
+			percentage:         12.5,
+			expectedTrace:      "$otel_ratio_sampler",
+			expectedPercentage: "12.5%",
+		},
+	}
+
+	for _, test := range tests {
+		cfgParams := NewDefaultConfigParams(false)
+		cfgParams.MainOtelLoadModule = true
+		cfgParams.MainOtelTraceEnabled = true
+		cfgParams.MainOtelSamplerPercentage = test.percentage
+
+		result := GenerateNginxMainConfig(&StaticConfigParams{}, cfgParams)
+		if result.OtelTrace != test.expectedTrace {
+			t.Errorf("GenerateNginxMainConfig() returned OtelTrace %q but expected %q for the percentage %v", result.OtelTrace, test.expectedTrace, test.percentage)
+		}
+		if result.OtelSamplerPercentage != test.expectedPercentage {
+			t.Errorf("GenerateNginxMainConfig() returned OtelSamplerPercentage %q but expected %q for the percentage %v", result.OtelSamplerPercentage, test.expectedPercentage, test.percentage)
+		}
+	}
+}
+
+func TestParseConfigMapWithRequestID(t *testing.T) {
+	tests := []struct {
+		data                    map[string]string
+		expectedRequestID       bool
+		expectedRequestIDHeader string
+		msg                     string
+	}{
+		{
+			data:                    map[string]string{},
+			expectedRequestID:       false,
+			expectedRequestIDHeader: "X-Request-ID",
+			msg:                     "default",
+		},
+		{
+			data: map[string]string{
+				"request-id":        "true",
+				"request-id-header": "X-Correlation-ID",
+			},
+			expectedRequestID:       true,
+			expectedRequestIDHeader: "X-Correlation-ID",
+			msg:                     "all keys",
+		},
+		{
+			data: map[string]string{
+				"request-id":        "yes",
+				"request-id-header": "X Correlation ID",
+			},
+			expectedRequestID:       false,
+			expectedRequestIDHeader: "X-Request-ID",
+			msg:                     "invalid values",
+		},
+	}
+
+	for _, test := range tests {
+		cm := &v1.ConfigMap{
+			Data: test.data,
+		}
+		result := ParseConfigMap(cm, false, false, false)
+		if result.RequestID != test.expectedRequestID {
+			t.Errorf("ParseConfigMap() returned RequestID %v but expected %v for the case %s", result.RequestID, test.expectedRequestID, test.msg)
+		}
+		if result.RequestIDHeader != test.expectedRequestIDHeader {
+			t.Errorf("ParseConfigMap() returned RequestIDHeader %q but expected %q for the case %s", result.RequestIDHeader, test.expectedRequestIDHeader, test.msg)
+		}
+	}
+}
+
+func TestGenerateNginxMainConfigWithRequestID(t *testing.T) {
+	cfgParams := NewDefaultConfigParams(false)
+	cfgParams.RequestID = true
+	cfgParams.RequestIDHeader = "X-Correlation-ID"
+
+	result := GenerateNginxMainConfig(&StaticConfigParams{}, cfgParams)
+	if !result.RequestID {
+		t.Errorf("GenerateNginxMainConfig() returned RequestID false but expected true")
+	}
+	if result.RequestIDHeader != "X-Correlation-ID" {
+		t.Errorf("GenerateNginxMainConfig() returned RequestIDHeader %q but expected %q", result.RequestIDHeader, "X-Correlation-ID")
+	}
+	if result.RequestIDHeaderVariable != "$http_x_correlation_id" {
+		t.Errorf("GenerateNginxMainConfig() returned RequestIDHeaderVariable %q but expected %q", result.RequestIDHeaderVariable, "$http_x_correlation_id")
+	}
+}
@@ -2,13 +2,16 @@ package configs
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/dos/v1beta1"
 
@@ -19,17 +22,18 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 
-	"github.com/golang/glog"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
+	"github.com/nginxinc/kubernetes-ingress/internal/tracing"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	latCollector "github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 )
 
@@ -52,6 +56,10 @@ const DefaultServerSecretName = "default"
 // WildcardSecretName is the filename of the Secret with a TLS cert and a key for the ingress resources with TLS termination enabled but not secret defined.
 const WildcardSecretName = "wildcard"
 
+// secretDirPathVariable is the NGINX variable that holds the path to the secrets directory.
+// It is only defined in the main config when dynamic SSL reload is enabled.
+const secretDirPathVariable = "$secret_dir_path" // #nosec G101
+
 // JWTKeyKey is the key of the data field of a Secret where the JWK must be stored.
 const JWTKeyKey = "jwk"
 
@@ -70,6 +78,16 @@ const (
 	spiffeKeyFileMode    = os.FileMode(0o600)
 )
 
+// generateSSLCertificatePath returns the path of a TLS cert and key file to use in ssl_certificate and ssl_certificate_key.
+// With dynamic SSL reload enabled, the secrets directory in the path is replaced with a variable. NGINX then loads
+// the cert and key during the TLS handshake, so that changes to the file are picked up without a reload.
+func generateSSLCertificatePath(path string, staticParams *StaticConfigParams) string {
+	if !staticParams.DynamicSSLReload || path == "" {
+		return path
+	}
+	return strings.Replace(path, staticParams.StaticSSLPath, secretDirPathVariable, 1)
+}
+
 // ExtendedResources holds all extended configuration resources, for which Configurator configures NGINX.
 type ExtendedResources struct {
 	IngressExes         []*IngressEx
@@ -110,6 +128,7 @@ type Configurator struct {
 	templateExecutorV2      *version2.TemplateExecutor
 	ingresses               map[string]*IngressEx
 	minions                 map[string]map[string]bool
+	ingressUpstreams        map[string]map[string]bool
 	virtualServers          map[string]*VirtualServerEx
 	tlsPassthroughPairs     map[string]tlsPassthroughPair
 	isWildcardEnabled       bool
@@ -120,12 +139,17 @@ type Configurator struct {
 	latencyCollector        latCollector.LatencyCollector
 	isLatencyMetricsEnabled bool
 	isReloadsEnabled        bool
+	metricsCollector        latCollector.ControllerCollector
+	syncCtx                 context.Context
+	syncKind                string
+	lowercasePaths          bool
 }
 
 // NewConfigurator creates a new Configurator.
 func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigParams, config *ConfigParams,
 	templateExecutor *version1.TemplateExecutor, templateExecutorV2 *version2.TemplateExecutor, isPlus bool, isWildcardEnabled bool,
-	labelUpdater collector.LabelUpdater, isPrometheusEnabled bool, latencyCollector latCollector.LatencyCollector, isLatencyMetricsEnabled bool) *Configurator {
+	labelUpdater collector.LabelUpdater, isPrometheusEnabled bool, latencyCollector latCollector.LatencyCollector, isLatencyMetricsEnabled bool,
+	metricsCollector latCollector.ControllerCollector) *Configurator {
 	metricLabelsIndex := &metricLabelsIndex{
 		ingressUpstreams:             make(map[string][]string),
 		virtualServerUpstreams:       make(map[string][]string),
@@ -147,6 +171,7 @@ func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigPa
 		templateExecutor:        templateExecutor,
 		templateExecutorV2:      templateExecutorV2,
 		minions:                 make(map[string]map[string]bool),
+		ingressUpstreams:        make(map[string]map[string]bool),
 		tlsPassthroughPairs:     make(map[string]tlsPassthroughPair),
 		isPlus:                  isPlus,
 		isWildcardEnabled:       isWildcardEnabled,
@@ -156,10 +181,26 @@ func NewConfigurator(nginxManager nginx.Manager, staticCfgParams *StaticConfigPa
 		latencyCollector:        latencyCollector,
 		isLatencyMetricsEnabled: isLatencyMetricsEnabled,
 		isReloadsEnabled:        false,
+		metricsCollector:        metricsCollector,
+		syncCtx:                 context.Background(),
 	}
 	return &cnf
 }
 
+// SetSyncContext sets the context and the resource kind of the sync operation that updates the configuration.
+// The spans of the configuration updates and NGINX reloads become the children of the span included in the context.
+// The NGINX reloads are attributed to the resource kind in the metrics. An empty kind means no sync operation.
+// The messages are logged with the logger included in the context.
+func (cnf *Configurator) SetSyncContext(ctx context.Context, kind string) {
+	cnf.syncCtx = ctx
+	cnf.syncKind = kind
+}
+
+// logger returns the logger of the sync operation, which includes the fields of the synced resource.
+func (cnf *Configurator) logger() *slog.Logger {
+	return nl.LoggerFromContext(cnf.syncCtx)
+}
+
 // AddOrUpdateDHParam creates a dhparam file with the content of the string.
 func (cnf *Configurator) AddOrUpdateDHParam(content string) (string, error) {
 	return cnf.nginxManager.CreateDHParam(content)
@@ -278,23 +319,49 @@ func (cnf *Configurator) addOrUpdateIngress(ingEx *IngressEx) (Warnings, error)
 		ingEx.SecretRefs[jwtKey].Path = cnf.nginxManager.GetFilenameForSecret(ingEx.Ingress.Namespace + "-" + jwtKey)
 	}
 
+	name := objectMetaToFileName(&ingEx.Ingress.ObjectMeta)
+	cnf.updateErrorPages(name, ingEx)
+
 	isMinion := false
 	nginxCfg, warnings := generateNginxCfg(ingEx, apResources, dosResource, isMinion, cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(),
 		cnf.staticCfgParams, cnf.isWildcardEnabled)
-	name := objectMetaToFileName(&ingEx.Ingress.ObjectMeta)
+	_, span := tracing.StartSpan(cnf.syncCtx, "template.execute", tracing.String("template", "ingress"), tracing.String("config.name", name))
+	start := time.Now()
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
+	cnf.observeTemplateExecution(span, "Ingress", start, content, err)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating Ingress Config %v: %w", name, err)
 	}
 	cnf.nginxManager.CreateConfig(name, content)
 
 	cnf.ingresses[name] = ingEx
+	cnf.ingressUpstreams[name] = getUpstreamNames(nginxCfg.Upstreams)
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.updateIngressMetricsLabels(ingEx, nginxCfg.Upstreams)
 	}
 	return warnings, nil
 }
 
+// updateErrorPages writes the files of the custom error pages of the Ingress and sets their path in the IngressEx.
+// The files are removed if the Ingress has no valid error pages.
+func (cnf *Configurator) updateErrorPages(name string, ingEx *IngressEx) {
+	files := make(map[string][]byte)
+	if ingEx.ErrorPages != nil {
+		errorPageFiles, _ := getErrorPageFiles(ingEx.ErrorPages)
+		for _, key := range errorPageFiles {
+			files[key] = []byte(ingEx.ErrorPages.Data[key])
+		}
+	}
+
+	if len(files) == 0 {
+		cnf.nginxManager.DeleteErrorPages(name)
+		ingEx.ErrorPagesPath = ""
+		return
+	}
+
+	ingEx.ErrorPagesPath = cnf.nginxManager.CreateErrorPages(name, files)
+}
+
 // AddOrUpdateMergeableIngress adds or updates NGINX configuration for the Ingress resources with Mergeable Types.
 func (cnf *Configurator) AddOrUpdateMergeableIngress(mergeableIngs *MergeableIngresses) (Warnings, error) {
 	warnings, err := cnf.addOrUpdateMergeableIngress(mergeableIngs)
@@ -326,17 +393,22 @@ func (cnf *Configurator) addOrUpdateMergeableIngress(mergeableIngs *MergeableIng
 		}
 	}
 
+	name := objectMetaToFileName(&mergeableIngs.Master.Ingress.ObjectMeta)
+	cnf.updateErrorPages(name, mergeableIngs.Master)
+
 	nginxCfg, warnings := generateNginxCfgForMergeableIngresses(mergeableIngs, apResources, dosResource, cnf.cfgParams, cnf.isPlus,
 		cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled)
-
-	name := objectMetaToFileName(&mergeableIngs.Master.Ingress.ObjectMeta)
+	_, span := tracing.StartSpan(cnf.syncCtx, "template.execute", tracing.String("template", "ingress"), tracing.String("config.name", name))
+	start := time.Now()
 	content, err := cnf.templateExecutor.ExecuteIngressConfigTemplate(&nginxCfg)
+	cnf.observeTemplateExecution(span, "Ingress", start, content, err)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating Ingress Config %v: %w", name, err)
 	}
 	cnf.nginxManager.CreateConfig(name, content)
 
 	cnf.ingresses[name] = mergeableIngs.Master
+	cnf.ingressUpstreams[name] = getUpstreamNames(nginxCfg.Upstreams)
 	cnf.minions[name] = make(map[string]bool)
 	for _, minion := range mergeableIngs.Minions {
 		minionName := objectMetaToFileName(&minion.Ingress.ObjectMeta)
@@ -447,7 +519,15 @@ func (cnf *Configurator) addOrUpdateOpenTracingTracerConfig(content string) erro
 	return err
 }
 
-func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServerEx) (Warnings, error) {
+func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServerEx) (warnings Warnings, err error) {
+	ctx, span := tracing.StartSpan(cnf.syncCtx, "Configurator.addOrUpdateVirtualServer",
+		tracing.String("resource.key", virtualServerEx.VirtualServer.Namespace+"/"+virtualServerEx.VirtualServer.Name))
+	defer func() {
+		span.SetAttributes(tracing.Int("warnings", len(warnings)))
+		span.RecordError(err)
+		span.End()
+	}()
+
 	apResources := cnf.updateApResourcesForVs(virtualServerEx)
 	dosResources := map[string]*appProtectDosResource{}
 	for k, v := range virtualServerEx.DosProtectedEx {
@@ -462,7 +542,10 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 
 	vsc := newVirtualServerConfigurator(cnf.cfgParams, cnf.isPlus, cnf.IsResolverConfigured(), cnf.staticCfgParams, cnf.isWildcardEnabled)
 	vsCfg, warnings := vsc.GenerateVirtualServerConfig(virtualServerEx, apResources, dosResources)
+	_, tmplSpan := tracing.StartSpan(ctx, "template.execute", tracing.String("template", "virtualserver"), tracing.String("config.name", name))
+	start := time.Now()
 	content, err := cnf.templateExecutorV2.ExecuteVirtualServerTemplate(&vsCfg)
+	cnf.observeTemplateExecution(tmplSpan, "VirtualServer", start, content, err)
 	if err != nil {
 		return warnings, fmt.Errorf("Error generating VirtualServer config: %v: %w", name, err)
 	}
@@ -470,12 +553,46 @@ func (cnf *Configurator) addOrUpdateVirtualServer(virtualServerEx *VirtualServer
 
 	cnf.virtualServers[name] = virtualServerEx
 
+	if err := cnf.updateLowercasePaths(); err != nil {
+		return warnings, err
+	}
+
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.updateVirtualServerMetricsLabels(virtualServerEx, vsCfg.Upstreams)
 	}
 	return warnings, nil
 }
 
+// generateNginxMainConfig generates MainConfig for the current VirtualServers.
+func (cnf *Configurator) generateNginxMainConfig(cfgParams *ConfigParams) *version1.MainConfig {
+	mainCfg := GenerateNginxMainConfig(cnf.staticCfgParams, cfgParams)
+	mainCfg.LowercasePaths = cnf.lowercasePaths
+	return mainCfg
+}
+
+// updateLowercasePaths rewrites the main config when the first VirtualServer starts
+// or the last VirtualServer stops using the lowercase path redirects, which need njs.
+func (cnf *Configurator) updateLowercasePaths() error {
+	lowercasePaths := false
+	for _, vsEx := range cnf.virtualServers {
+		if redirects := vsEx.VirtualServer.Spec.Redirects; redirects != nil && redirects.LowercasePaths {
+			lowercasePaths = true
+			break
+		}
+	}
+	if lowercasePaths == cnf.lowercasePaths {
+		return nil
+	}
+
+	cnf.lowercasePaths = lowercasePaths
+	mainCfgContent, err := cnf.templateExecutor.ExecuteMainConfigTemplate(cnf.generateNginxMainConfig(cnf.cfgParams))
+	if err != nil {
+		return fmt.Errorf("Error when writing main Config: %w", err)
+	}
+	cnf.nginxManager.CreateMainConfig(mainCfgContent)
+	return nil
+}
+
 // AddOrUpdateVirtualServers adds or updates NGINX configuration for multiple VirtualServer resources.
 func (cnf *Configurator) AddOrUpdateVirtualServers(virtualServerExes []*VirtualServerEx) (Warnings, error) {
 	allWarnings := newWarnings()
@@ -580,9 +697,12 @@ func (cnf *Configurator) AddOrUpdateTransportServer(transportServerEx *Transport
 func (cnf *Configurator) addOrUpdateTransportServer(transportServerEx *TransportServerEx) error {
 	name := getFileNameForTransportServer(transportServerEx.TransportServer)
 
-	tsCfg := generateTransportServerConfig(transportServerEx, transportServerEx.ListenerPort, cnf.isPlus)
+	tsCfg := generateTransportServerConfig(transportServerEx, transportServerEx.ListenerPort, cnf.isPlus, cnf.IsResolverConfigured())
 
+	_, span := tracing.StartSpan(cnf.syncCtx, "template.execute", tracing.String("template", "transportserver"), tracing.String("config.name", name))
+	start := time.Now()
 	content, err := cnf.templateExecutorV2.ExecuteTransportServerTemplate(tsCfg)
+	cnf.observeTemplateExecution(span, "TransportServer", start, content, err)
 	if err != nil {
 		return fmt.Errorf("Error generating TransportServer config %v: %w", name, err)
 	}
@@ -740,9 +860,11 @@ func GenerateCAFileContent(secret *api_v1.Secret) []byte {
 func (cnf *Configurator) DeleteIngress(key string) error {
 	name := keyToFileName(key)
 	cnf.nginxManager.DeleteConfig(name)
+	cnf.nginxManager.DeleteErrorPages(name)
 
 	delete(cnf.ingresses, name)
 	delete(cnf.minions, name)
+	delete(cnf.ingressUpstreams, name)
 
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteIngressMetricsLabels(key)
@@ -761,6 +883,9 @@ func (cnf *Configurator) DeleteVirtualServer(key string) error {
 	cnf.nginxManager.DeleteConfig(name)
 
 	delete(cnf.virtualServers, name)
+	if err := cnf.updateLowercasePaths(); err != nil {
+		return fmt.Errorf("Error when removing VirtualServer %v: %w", key, err)
+	}
 	if (cnf.isPlus && cnf.isPrometheusEnabled) || cnf.isLatencyMetricsEnabled {
 		cnf.deleteVirtualServerMetricsLabels(key)
 	}
@@ -817,16 +942,17 @@ func (cnf *Configurator) UpdateEndpoints(ingExes []*IngressEx) error {
 		}
 
 		if cnf.isPlus {
-			err := cnf.updatePlusEndpoints(ingEx)
+			name := objectMetaToFileName(&ingEx.Ingress.ObjectMeta)
+			err := cnf.updatePlusEndpoints(ingEx, cnf.ingressUpstreams[name])
 			if err != nil {
-				glog.Warningf("Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
+				nl.Warnf(cnf.logger(), "Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
 				reloadPlus = true
 			}
 		}
 	}
 
 	if cnf.isPlus && !reloadPlus {
-		glog.V(3).Info("No need to reload nginx")
+		nl.Trace(cnf.logger(), "No need to reload nginx")
 		return nil
 	}
 
@@ -849,10 +975,11 @@ func (cnf *Configurator) UpdateEndpointsMergeableIngress(mergeableIngresses []*M
 		}
 
 		if cnf.isPlus {
+			name := objectMetaToFileName(&mergeableIngresses[i].Master.Ingress.ObjectMeta)
 			for _, ing := range mergeableIngresses[i].Minions {
-				err = cnf.updatePlusEndpoints(ing)
+				err = cnf.updatePlusEndpoints(ing, cnf.ingressUpstreams[name])
 				if err != nil {
-					glog.Warningf("Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
+					nl.Warnf(cnf.logger(), "Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
 					reloadPlus = true
 				}
 			}
@@ -860,7 +987,7 @@ func (cnf *Configurator) UpdateEndpointsMergeableIngress(mergeableIngresses []*M
 	}
 
 	if cnf.isPlus && !reloadPlus {
-		glog.V(3).Info("No need to reload nginx")
+		nl.Trace(cnf.logger(), "No need to reload nginx")
 		return nil
 	}
 
@@ -885,14 +1012,14 @@ func (cnf *Configurator) UpdateEndpointsForVirtualServers(virtualServerExes []*V
 		if cnf.isPlus {
 			err := cnf.updatePlusEndpointsForVirtualServer(vs)
 			if err != nil {
-				glog.Warningf("Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
+				nl.Warnf(cnf.logger(), "Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
 				reloadPlus = true
 			}
 		}
 	}
 
 	if cnf.isPlus && !reloadPlus {
-		glog.V(3).Info("No need to reload nginx")
+		nl.Trace(cnf.logger(), "No need to reload nginx")
 		return nil
 	}
 
@@ -932,14 +1059,14 @@ func (cnf *Configurator) UpdateEndpointsForTransportServers(transportServerExes
 		if cnf.isPlus {
 			err := cnf.updatePlusEndpointsForTransportServer(tsEx)
 			if err != nil {
-				glog.Warningf("Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
+				nl.Warnf(cnf.logger(), "Couldn't update the endpoints via the API: %v; reloading configuration instead", err)
 				reloadPlus = true
 			}
 		}
 	}
 
 	if cnf.isPlus && !reloadPlus {
-		glog.V(3).Info("No need to reload nginx")
+		nl.Trace(cnf.logger(), "No need to reload nginx")
 		return nil
 	}
 
@@ -954,11 +1081,12 @@ func (cnf *Configurator) updatePlusEndpointsForTransportServer(transportServerEx
 	upstreamNamer := newUpstreamNamerForTransportServer(transportServerEx.TransportServer)
 
 	for _, u := range transportServerEx.TransportServer.Spec.Upstreams {
-		name := upstreamNamer.GetNameForUpstream(u.Name)
+		if len(u.Addresses) > 0 || transportServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(transportServerEx.TransportServer.Namespace, u.Service)] {
+			continue
+		}
 
-		// subselector is not supported yet in TransportServer upstreams. That's why we pass "nil" here
-		endpointsKey := GenerateEndpointsKey(transportServerEx.TransportServer.Namespace, u.Service, nil, uint16(u.Port))
-		endpoints := transportServerEx.Endpoints[endpointsKey]
+		name := upstreamNamer.GetNameForUpstream(u.Name)
+		endpoints := generateEndpointsForStreamUpstream(transportServerEx, u, cnf.IsResolverConfigured())
 
 		err := cnf.updateStreamServersInPlus(name, endpoints)
 		if err != nil {
@@ -969,7 +1097,19 @@ func (cnf *Configurator) updatePlusEndpointsForTransportServer(transportServerEx
 	return nil
 }
 
-func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
+// getUpstreamNames returns the names of the upstreams of an Ingress configuration.
+func getUpstreamNames(upstreams []version1.Upstream) map[string]bool {
+	names := make(map[string]bool)
+	for _, u := range upstreams {
+		names[u.Name] = true
+	}
+	return names
+}
+
+// updatePlusEndpoints updates the servers of the upstreams of the Ingress and its canaries via the NGINX Plus API.
+// Only the upstreams that were generated for the Ingress are updated: a canary of a path that is invalid,
+// rewritten or uses a resource backend has no upstream.
+func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx, upstreams map[string]bool) error {
 	ingCfg := parseAnnotations(ingEx, cnf.cfgParams, cnf.isPlus, cnf.staticCfgParams.MainAppProtectLoadModule, cnf.staticCfgParams.MainAppProtectDosLoadModule, cnf.staticCfgParams.EnableInternalRoutes)
 
 	cfg := nginx.ServerConfig{
@@ -979,14 +1119,13 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 		SlowStart:   ingCfg.SlowStart,
 	}
 
-	if ingEx.Ingress.Spec.DefaultBackend != nil {
+	if ingEx.Ingress.Spec.DefaultBackend != nil && ingEx.Ingress.Spec.DefaultBackend.Service != nil {
 		endps, exists := ingEx.Endpoints[ingEx.Ingress.Spec.DefaultBackend.Service.Name+GetBackendPortAsString(ingEx.Ingress.Spec.DefaultBackend.Service.Port)]
 		if exists {
 			if _, isExternalName := ingEx.ExternalNameSvcs[ingEx.Ingress.Spec.DefaultBackend.Service.Name]; isExternalName {
-				glog.V(3).Infof("Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", ingEx.Ingress.Spec.DefaultBackend.Service.Name)
-			} else {
-				name := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.DefaultBackend)
-				err := cnf.updateServersInPlus(name, endps, cfg)
+				nl.Tracef(cnf.logger(), "Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", ingEx.Ingress.Spec.DefaultBackend.Service.Name)
+			} else if name := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.DefaultBackend); upstreams[name] {
+				err := cnf.updateServersInPlus(name, nginx.NewServerKeys(endps), cfg)
 				if err != nil {
 					return fmt.Errorf("Couldn't update the endpoints for %v: %w", name, err)
 				}
@@ -1000,15 +1139,22 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 		}
 
 		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+
 			endps, exists := ingEx.Endpoints[path.Backend.Service.Name+GetBackendPortAsString(path.Backend.Service.Port)]
 			if exists {
 				if _, isExternalName := ingEx.ExternalNameSvcs[path.Backend.Service.Name]; isExternalName {
-					glog.V(3).Infof("Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", path.Backend.Service.Name)
+					nl.Tracef(cnf.logger(), "Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", path.Backend.Service.Name)
 					continue
 				}
 
 				name := getNameForUpstream(ingEx.Ingress, rule.Host, &path.Backend)
-				err := cnf.updateServersInPlus(name, endps, cfg)
+				if !upstreams[name] {
+					continue
+				}
+				err := cnf.updateServersInPlus(name, nginx.NewServerKeys(endps), cfg)
 				if err != nil {
 					return fmt.Errorf("Couldn't update the endpoints for %v: %w", name, err)
 				}
@@ -1016,6 +1162,12 @@ func (cnf *Configurator) updatePlusEndpoints(ingEx *IngressEx) error {
 		}
 	}
 
+	for _, canary := range ingEx.Canaries {
+		if err := cnf.updatePlusEndpoints(canary, upstreams); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1029,10 +1181,33 @@ func (cnf *Configurator) reload(isEndpointsUpdate bool) error {
 		return nil
 	}
 
-	return cnf.nginxManager.Reload(isEndpointsUpdate)
+	err := cnf.nginxManager.Reload(cnf.syncCtx, isEndpointsUpdate)
+
+	if err == nil && cnf.isPrometheusEnabled {
+		kind := cnf.syncKind
+		if kind == "" {
+			kind = "other"
+		}
+		cnf.metricsCollector.IncNginxReloadsByResourceKind(kind)
+	}
+
+	return err
+}
+
+// observeTemplateExecution ends the span of a template execution and records the duration and the size of the config in the metrics.
+func (cnf *Configurator) observeTemplateExecution(span *tracing.Span, kind string, start time.Time, content []byte, err error) {
+	duration := time.Since(start)
+
+	span.SetAttributes(tracing.Int("config.bytes", len(content)))
+	span.RecordError(err)
+	span.End()
+
+	if err == nil && cnf.isPrometheusEnabled {
+		cnf.metricsCollector.ObserveConfigGeneration(kind, duration, len(content))
+	}
 }
 
-func (cnf *Configurator) updateServersInPlus(upstream string, servers []string, config nginx.ServerConfig) error {
+func (cnf *Configurator) updateServersInPlus(upstream string, servers []nginx.ServerKey, config nginx.ServerConfig) error {
 	if !cnf.isReloadsEnabled {
 		return nil
 	}
@@ -1049,6 +1224,7 @@ func (cnf *Configurator) updateStreamServersInPlus(upstream string, servers []st
 }
 
 // UpdateConfig updates NGINX configuration parameters.
+//
 //gocyclo:ignore
 func (cnf *Configurator) UpdateConfig(cfgParams *ConfigParams, resources ExtendedResources) (Warnings, error) {
 	cnf.cfgParams = cfgParams
@@ -1083,7 +1259,7 @@ func (cnf *Configurator) UpdateConfig(cfgParams *ConfigParams, resources Extende
 		}
 	}
 
-	mainCfg := GenerateNginxMainConfig(cnf.staticCfgParams, cfgParams)
+	mainCfg := cnf.generateNginxMainConfig(cfgParams)
 	mainCfgContent, err := cnf.templateExecutor.ExecuteMainConfigTemplate(mainCfg)
 	if err != nil {
 		return allWarnings, fmt.Errorf("Error when writing main Config")
@@ -1184,6 +1360,22 @@ func getFileNameForTransportServerFromKey(key string) string {
 	return fmt.Sprintf("ts_%s", replaced)
 }
 
+// GetIngressConfig returns the NGINX config of the Ingress resource with the key namespace/name.
+// For mergeable Ingress resources, the config is generated for the master.
+func (cnf *Configurator) GetIngressConfig(key string) ([]byte, error) {
+	return cnf.nginxManager.GetConfig(keyToFileName(key))
+}
+
+// GetVirtualServerConfig returns the NGINX config of the VirtualServer resource with the key namespace/name.
+func (cnf *Configurator) GetVirtualServerConfig(key string) ([]byte, error) {
+	return cnf.nginxManager.GetConfig(getFileNameForVirtualServerFromKey(key))
+}
+
+// GetTransportServerConfig returns the NGINX config of the TransportServer resource with the key namespace/name.
+func (cnf *Configurator) GetTransportServerConfig(key string) ([]byte, error) {
+	return cnf.nginxManager.GetStreamConfig(getFileNameForTransportServerFromKey(key))
+}
+
 // HasIngress checks if the Ingress resource is present in NGINX configuration.
 func (cnf *Configurator) HasIngress(ing *networking.Ingress) bool {
 	name := objectMetaToFileName(&ing.ObjectMeta)
@@ -1489,7 +1681,7 @@ func (cnf *Configurator) DeleteAppProtectDosLogConf(resource *unstructured.Unstr
 func (cnf *Configurator) AddInternalRouteConfig() error {
 	cnf.staticCfgParams.EnableInternalRoutes = true
 	cnf.staticCfgParams.PodName = os.Getenv("POD_NAME")
-	mainCfg := GenerateNginxMainConfig(cnf.staticCfgParams, cnf.cfgParams)
+	mainCfg := cnf.generateNginxMainConfig(cnf.cfgParams)
 	mainCfgContent, err := cnf.templateExecutor.ExecuteMainConfigTemplate(mainCfg)
 	if err != nil {
 		return fmt.Errorf("Error when writing main Config: %w", err)
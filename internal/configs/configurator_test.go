@@ -42,7 +42,7 @@ func createTestConfigurator() (*Configurator, error) {
 
 	manager := nginx.NewFakeManager("/etc/nginx")
 
-	cnf, err := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), templateExecutor, templateExecutorV2, false, false, nil, false, nil, false), nil
+	cnf, err := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), templateExecutor, templateExecutorV2, false, false, nil, false, nil, false, nil), nil
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func createTestConfiguratorInvalidIngressTemplate() (*Configurator, error) {
 
 	manager := nginx.NewFakeManager("/etc/nginx")
 
-	cnf, err := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), templateExecutor, &version2.TemplateExecutor{}, false, false, nil, false, nil, false), nil
+	cnf, err := NewConfigurator(manager, createTestStaticConfigParams(), NewDefaultConfigParams(false), templateExecutor, &version2.TemplateExecutor{}, false, false, nil, false, nil, false, nil), nil
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +97,49 @@ func TestAddOrUpdateIngress(t *testing.T) {
 	}
 }
 
+func TestAddOrUpdateIngressRecordsCanaryUpstreams(t *testing.T) {
+	tests := []struct {
+		rewrites string
+		expected bool
+		msg      string
+	}{
+		{
+			rewrites: "",
+			expected: true,
+			msg:      "canary of a path",
+		},
+		{
+			rewrites: "serviceName=coffee-svc rewrite=/beans/",
+			expected: false,
+			msg:      "canary of a rewritten path",
+		},
+	}
+
+	for _, test := range tests {
+		cnf, err := createTestConfigurator()
+		if err != nil {
+			t.Fatalf("Failed to create a test configurator: %v", err)
+		}
+
+		ingress := createCafeIngressEx()
+		if test.rewrites != "" {
+			ingress.Ingress.Annotations["nginx.org/rewrites"] = test.rewrites
+		}
+		canary := createCafeCanaryIngressEx()
+		ingress.Canaries = []*IngressEx{canary}
+
+		if _, err := cnf.addOrUpdateIngress(&ingress); err != nil {
+			t.Fatalf("addOrUpdateIngress() returned unexpected error for the case of %s: %v", test.msg, err)
+		}
+
+		canaryUpstream := getNameForUpstream(canary.Ingress, "cafe.example.com", &canary.Ingress.Spec.Rules[0].HTTP.Paths[0].Backend)
+		result := cnf.ingressUpstreams["default-cafe-ingress"][canaryUpstream]
+		if result != test.expected {
+			t.Errorf("addOrUpdateIngress() recorded the canary upstream %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
 func TestAddOrUpdateMergeableIngress(t *testing.T) {
 	cnf, err := createTestConfigurator()
 	if err != nil {
@@ -528,6 +571,36 @@ func (u *mockLatencyCollector) RecordLatency(string) {}
 // Register implements a fake Register method
 func (u *mockLatencyCollector) Register(*prometheus.Registry) error { return nil }
 
+func TestGenerateSSLCertificatePath(t *testing.T) {
+	tests := []struct {
+		path         string
+		staticParams *StaticConfigParams
+		expected     string
+	}{
+		{
+			path:         "/etc/nginx/secrets/default-cafe-secret",
+			staticParams: &StaticConfigParams{},
+			expected:     "/etc/nginx/secrets/default-cafe-secret",
+		},
+		{
+			path:         "/etc/nginx/secrets/default-cafe-secret",
+			staticParams: &StaticConfigParams{DynamicSSLReload: true, StaticSSLPath: "/etc/nginx/secrets"},
+			expected:     "$secret_dir_path/default-cafe-secret",
+		},
+		{
+			path:         "",
+			staticParams: &StaticConfigParams{DynamicSSLReload: true, StaticSSLPath: "/etc/nginx/secrets"},
+			expected:     "",
+		},
+	}
+	for _, test := range tests {
+		result := generateSSLCertificatePath(test.path, test.staticParams)
+		if result != test.expected {
+			t.Errorf("generateSSLCertificatePath(%q, %+v) returned %q but expected %q", test.path, test.staticParams, result, test.expected)
+		}
+	}
+}
+
 func TestUpdateIngressMetricsLabels(t *testing.T) {
 	cnf, err := createTestConfigurator()
 	if err != nil {
@@ -701,6 +774,54 @@ func TestUpdateIngressMetricsLabels(t *testing.T) {
 	}
 }
 
+func TestUpdateLowercasePaths(t *testing.T) {
+	cnf, err := createTestConfigurator()
+	if err != nil {
+		t.Fatalf("Failed to create a test configurator: %v", err)
+	}
+
+	createVirtualServerEx := func(name string, lowercasePaths bool) *VirtualServerEx {
+		return &VirtualServerEx{
+			VirtualServer: &conf_v1.VirtualServer{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Host: name + ".example.com",
+					Redirects: &conf_v1.Redirects{
+						LowercasePaths: lowercasePaths,
+					},
+				},
+			},
+		}
+	}
+
+	if _, err := cnf.addOrUpdateVirtualServer(createVirtualServerEx("plain", false)); err != nil {
+		t.Fatalf("addOrUpdateVirtualServer() returned unexpected error: %v", err)
+	}
+	if cnf.lowercasePaths {
+		t.Errorf("addOrUpdateVirtualServer() enabled lowercase paths for a VirtualServer without them")
+	}
+
+	if _, err := cnf.addOrUpdateVirtualServer(createVirtualServerEx("lowercase", true)); err != nil {
+		t.Fatalf("addOrUpdateVirtualServer() returned unexpected error: %v", err)
+	}
+	if !cnf.lowercasePaths {
+		t.Errorf("addOrUpdateVirtualServer() did not enable lowercase paths for a VirtualServer with them")
+	}
+	if !cnf.generateNginxMainConfig(cnf.cfgParams).LowercasePaths {
+		t.Errorf("generateNginxMainConfig() returned LowercasePaths false but expected true")
+	}
+
+	if err := cnf.DeleteVirtualServer("default/lowercase"); err != nil {
+		t.Fatalf("DeleteVirtualServer() returned unexpected error: %v", err)
+	}
+	if cnf.lowercasePaths {
+		t.Errorf("DeleteVirtualServer() did not disable lowercase paths after the last VirtualServer with them was deleted")
+	}
+}
+
 func TestUpdateVirtualServerMetricsLabels(t *testing.T) {
 	cnf, err := createTestConfigurator()
 	if err != nil {
@@ -1,14 +1,16 @@
 package configs
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/dos/v1beta1"
 
-	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -17,9 +19,19 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 )
 
-const emptyHost = ""
+const (
+	emptyHost = ""
+
+	// errorPagesLocationPath is the path of the internal location that serves the custom error pages.
+	errorPagesLocationPath = "/_ingress_error_pages/"
+)
+
+var errorPageKeyRegexp = regexp.MustCompile(`^([0-9]{3})\.(html|json|txt|xml)$`)
 
 // AppProtectResources holds namespace names of App Protect resources relevant to an Ingress
 type AppProtectResources struct {
@@ -42,10 +54,14 @@ type IngressEx struct {
 	PodsByIP         map[string]PodInfo
 	ValidHosts       map[string]bool
 	ValidMinionPaths map[string]bool
+	Canaries         []*IngressEx
 	AppProtectPolicy *unstructured.Unstructured
 	AppProtectLogs   []AppProtectLog
 	DosEx            *DosEx
 	SecretRefs       map[string]*secrets.SecretReference
+	ErrorPages       *api_v1.ConfigMap
+	ErrorPagesPath   string
+	StaticResponses  map[string]*conf_v1alpha1.StaticResponse
 }
 
 // DosEx holds a DosProtectedResource and the dos policy and log confs it references.
@@ -84,6 +100,7 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 
 	wsServices := getWebsocketServices(ingEx)
 	spServices := getSessionPersistenceServices(ingEx)
+	hashSessionServices := getHashSessionServices(ingEx)
 	rewrites := getRewrites(ingEx)
 	sslServices := getSSLServices(ingEx)
 	grpcServices := getGrpcServices(ingEx)
@@ -91,16 +108,26 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 	upstreams := make(map[string]version1.Upstream)
 	healthChecks := make(map[string]version1.HealthCheck)
 
+	// sessionVariables maps an upstream with the hash session persistence to its session variable
+	sessionVariables := make(map[string]string)
+	var sessionMaps []version1.Map
+
 	// HTTP2 is required for gRPC to function
 	if len(grpcServices) > 0 && !cfgParams.HTTP2 {
-		glog.Errorf("Ingress %s/%s: annotation nginx.org/grpc-services requires HTTP2, ignoring", ingEx.Ingress.Namespace, ingEx.Ingress.Name)
+		nl.Errorf(nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name), "Ingress %s/%s: annotation nginx.org/grpc-services requires HTTP2, ignoring", ingEx.Ingress.Namespace, ingEx.Ingress.Name)
 		grpcServices = make(map[string]bool)
 	}
 
-	if ingEx.Ingress.Spec.DefaultBackend != nil {
+	if ingEx.Ingress.Spec.DefaultBackend != nil && ingEx.Ingress.Spec.DefaultBackend.Service != nil {
 		name := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.DefaultBackend)
 		upstream := createUpstream(ingEx, name, ingEx.Ingress.Spec.DefaultBackend, spServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], &cfgParams,
 			isPlus, isResolverConfigured, staticParams.EnableLatencyMetrics)
+		if sc, exists := hashSessionServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name]; exists {
+			sessionVariable := getNameForSessionVariable(ingEx.Ingress, len(sessionVariables))
+			upstream.LBMethod = generateHashSessionLBMethod(sessionVariable, sc)
+			sessionVariables[name] = sessionVariable
+			sessionMaps = append(sessionMaps, generateIngressHashSessionCookieMaps(sessionVariable, sc)...)
+		}
 		upstreams[name] = upstream
 
 		if cfgParams.HealthCheckEnabled {
@@ -112,7 +139,40 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 
 	allWarnings := newWarnings()
 
+	if _, exists := ingEx.Ingress.Annotations["nginx.org/lb-method"]; exists && len(hashSessionServices) > 0 {
+		allWarnings.AddWarningf(ingEx.Ingress, "the nginx.org/lb-method annotation is ignored for the services of the %s annotation: "+
+			"the hash session affinity mode sets the load balancing method", "nginx.com/sticky-cookie-services")
+	}
+	allWarnings.Add(generatePathOverridesWarnings(ingEx.Ingress, cfgParams.PathOverrides))
+
 	var servers []version1.Server
+	var splitClients []version1.SplitClient
+	var maps []version1.Map
+
+	limitReqZone, limitReq := generateIngressLimitReq(ingEx.Ingress, &cfgParams)
+	if len(cfgParams.AllowList) > 0 && len(cfgParams.DenyList) > 0 {
+		allWarnings.AddWarning(ingEx.Ingress, "the nginx.org/deny-list annotation is overridden by the nginx.org/allow-list annotation")
+	}
+
+	upstreamTLS, upstreamTLSWarnings := generateUpstreamTLS(ingEx.Ingress, ingEx.SecretRefs, &cfgParams)
+	allWarnings.Add(upstreamTLSWarnings)
+	if upstreamTLS != nil && staticParams.NginxServiceMesh && !cfgParams.SpiffeServerCerts {
+		allWarnings.AddWarningf(ingEx.Ingress, "the %s and %s annotations are ignored: the connections to the backends use the NGINX Service Mesh certificates",
+			UpstreamCASecretAnnotation, UpstreamTLSSecretAnnotation)
+		upstreamTLS = nil
+	}
+
+	var errorPages []version1.ErrorPage
+	var errorPagesLocation *version1.ErrorPagesLocation
+	if !isMinion {
+		var warnings Warnings
+		errorPages, errorPagesLocation, warnings = generateIngressErrorPages(ingEx)
+		allWarnings.Add(warnings)
+	}
+
+	canaries := getCanaryBackends(ingEx, baseCfgParams, isPlus, hasAppProtect, hasAppProtectDos, staticParams.EnableInternalRoutes)
+	canaryIndex := 0
+	returnLocationIndex := 0
 
 	for _, rule := range ingEx.Ingress.Spec.Rules {
 		// skipping invalid hosts
@@ -148,6 +208,7 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 			RealIPRecursive:       cfgParams.RealIPRecursive,
 			ProxyHideHeaders:      cfgParams.ProxyHideHeaders,
 			ProxyPassHeaders:      cfgParams.ProxyPassHeaders,
+			RequestIDHeader:       generateRequestIDHeader(cfgParams.RequestID, &cfgParams),
 			ServerSnippets:        cfgParams.ServerSnippets,
 			Ports:                 cfgParams.Ports,
 			SSLPorts:              cfgParams.SSLPorts,
@@ -160,6 +221,9 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 		warnings := addSSLConfig(&server, ingEx.Ingress, rule.Host, ingEx.Ingress.Spec.TLS, ingEx.SecretRefs, isWildcardEnabled)
 		allWarnings.Add(warnings)
 
+		server.SSLCertificate = generateSSLCertificatePath(server.SSLCertificate, staticParams)
+		server.SSLCertificateKey = generateSSLCertificatePath(server.SSLCertificateKey, staticParams)
+
 		if hasAppProtect {
 			server.AppProtectPolicy = apResources.AppProtectPolicy
 			server.AppProtectLogConfs = apResources.AppProtectLogconfs
@@ -194,6 +258,10 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 		grpcOnly := true
 		if len(grpcServices) > 0 {
 			for _, path := range httpIngressRuleValue.Paths {
+				if path.Backend.Service == nil {
+					grpcOnly = false
+					break
+				}
 				if _, exists := grpcServices[path.Backend.Service.Name]; !exists {
 					grpcOnly = false
 					break
@@ -209,6 +277,38 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 				continue
 			}
 
+			if path.Backend.Resource != nil {
+				loc, returnLoc, warnings := generateStaticResponseLocation(ingEx, path.Path, path.PathType, path.Backend.Resource, &cfgParams,
+					getNameForReturnLocation(ingEx.Ingress, returnLocationIndex))
+				allWarnings.Add(warnings)
+				if returnLoc != nil {
+					server.ReturnLocations = append(server.ReturnLocations, *returnLoc)
+					returnLocationIndex++
+				}
+
+				if canary, exists := canaries[canaryKey{host: rule.Host, path: path.Path}]; exists {
+					allWarnings.AddWarningf(canary.ingEx.Ingress, "canary for path %s is ignored: the path uses a resource backend", path.Path)
+				}
+
+				addIngressPoliciesToLocation(&loc, limitReq, &cfgParams)
+
+				if isMinion && cfgParams.JWTKey != "" {
+					jwtAuth, redirectLoc, warnings := generateJWTConfig(ingEx.Ingress, ingEx.SecretRefs, &cfgParams, getNameForRedirectLocation(ingEx.Ingress))
+					loc.JWTAuth = jwtAuth
+					if redirectLoc != nil {
+						server.JWTRedirectLocations = append(server.JWTRedirectLocations, *redirectLoc)
+					}
+					allWarnings.Add(warnings)
+				}
+
+				locations = append(locations, loc)
+
+				if loc.Path == "/" {
+					rootLocation = true
+				}
+				continue
+			}
+
 			upsName := getNameForUpstream(ingEx.Ingress, rule.Host, &path.Backend)
 
 			if cfgParams.HealthCheckEnabled {
@@ -219,6 +319,12 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 
 			if _, exists := upstreams[upsName]; !exists {
 				upstream := createUpstream(ingEx, upsName, &path.Backend, spServices[path.Backend.Service.Name], &cfgParams, isPlus, isResolverConfigured, staticParams.EnableLatencyMetrics)
+				if sc, exists := hashSessionServices[path.Backend.Service.Name]; exists {
+					sessionVariable := getNameForSessionVariable(ingEx.Ingress, len(sessionVariables))
+					upstream.LBMethod = generateHashSessionLBMethod(sessionVariable, sc)
+					sessionVariables[upsName] = sessionVariable
+					sessionMaps = append(sessionMaps, generateIngressHashSessionCookieMaps(sessionVariable, sc)...)
+				}
 				upstreams[upsName] = upstream
 			}
 
@@ -226,6 +332,42 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 			proxySSLName := generateProxySSLName(path.Backend.Service.Name, ingEx.Ingress.Namespace)
 			loc := createLocation(pathOrDefault(path.Path), upstreams[upsName], &cfgParams, wsServices[path.Backend.Service.Name], rewrites[path.Backend.Service.Name],
 				ssl, grpcServices[path.Backend.Service.Name], proxySSLName, path.PathType, path.Backend.Service.Name)
+			if header := generateHashSessionCookieAddHeader(sessionVariables[upsName], hashSessionServices[path.Backend.Service.Name]); header != nil {
+				loc.SessionCookieVariable = header.Value
+			}
+
+			if loc.Rewrite != "" && isRegexIngressPath(loc.Path) {
+				allWarnings.AddWarningf(ingEx.Ingress, "the rewrite of the service %s is ignored for path %s: rewrites are not supported for regular expression paths", path.Backend.Service.Name, path.Path)
+				loc.Rewrite = ""
+			}
+
+			if canary, exists := canaries[canaryKey{host: rule.Host, path: path.Path}]; exists {
+				if loc.Rewrite != "" {
+					allWarnings.AddWarningf(canary.ingEx.Ingress, "canary for path %s is ignored: the path uses the nginx.org/rewrites annotation", path.Path)
+				} else {
+					canaryUpsName := getNameForUpstream(canary.ingEx.Ingress, rule.Host, canary.backend)
+					if _, exists := upstreams[canaryUpsName]; !exists {
+						upstreams[canaryUpsName] = createUpstream(canary.ingEx, canaryUpsName, canary.backend, "", &canary.cfgParams, isPlus,
+							isResolverConfigured, staticParams.EnableLatencyMetrics)
+					}
+
+					variable := getNameForCanaryVariable(ingEx.Ingress, canaryIndex)
+					canaryIndex++
+
+					canarySplitClients, canaryMaps, upstreamVariable := generateCanaryRouting(variable, upsName, canaryUpsName, &canary.cfgParams)
+					splitClients = append(splitClients, canarySplitClients...)
+					maps = append(maps, canaryMaps...)
+
+					if upstreamVariable != upsName {
+						loc.UpstreamVariable = upstreamVariable
+					}
+				}
+			}
+
+			addIngressPoliciesToLocation(&loc, limitReq, &cfgParams)
+			if loc.SSL {
+				loc.UpstreamTLS = upstreamTLS
+			}
 
 			if isMinion && cfgParams.JWTKey != "" {
 				jwtAuth, redirectLoc, warnings := generateJWTConfig(ingEx.Ingress, ingEx.SecretRefs, &cfgParams, getNameForRedirectLocation(ingEx.Ingress))
@@ -243,14 +385,33 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 			}
 		}
 
-		if !rootLocation && ingEx.Ingress.Spec.DefaultBackend != nil {
+		if !rootLocation && ingEx.Ingress.Spec.DefaultBackend != nil && ingEx.Ingress.Spec.DefaultBackend.Resource != nil {
+			loc, returnLoc, warnings := generateStaticResponseLocation(ingEx, "/", nil, ingEx.Ingress.Spec.DefaultBackend.Resource, &cfgParams,
+				getNameForReturnLocation(ingEx.Ingress, returnLocationIndex))
+			allWarnings.Add(warnings)
+			if returnLoc != nil {
+				server.ReturnLocations = append(server.ReturnLocations, *returnLoc)
+				returnLocationIndex++
+			}
+			addIngressPoliciesToLocation(&loc, limitReq, &cfgParams)
+			locations = append(locations, loc)
+
+			grpcOnly = false
+		} else if !rootLocation && ingEx.Ingress.Spec.DefaultBackend != nil {
 			upsName := getNameForUpstream(ingEx.Ingress, emptyHost, ingEx.Ingress.Spec.DefaultBackend)
 			ssl := isSSLEnabled(sslServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], cfgParams, staticParams)
 			proxySSLName := generateProxySSLName(ingEx.Ingress.Spec.DefaultBackend.Service.Name, ingEx.Ingress.Namespace)
-			pathtype := networking.PathTypePrefix
 
+			// the default backend always gets the root prefix location, regardless of the nginx.org/path-regex annotation
 			loc := createLocation(pathOrDefault("/"), upstreams[upsName], &cfgParams, wsServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], rewrites[ingEx.Ingress.Spec.DefaultBackend.Service.Name],
-				ssl, grpcServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], proxySSLName, &pathtype, ingEx.Ingress.Spec.DefaultBackend.Service.Name)
+				ssl, grpcServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name], proxySSLName, nil, ingEx.Ingress.Spec.DefaultBackend.Service.Name)
+			if header := generateHashSessionCookieAddHeader(sessionVariables[upsName], hashSessionServices[ingEx.Ingress.Spec.DefaultBackend.Service.Name]); header != nil {
+				loc.SessionCookieVariable = header.Value
+			}
+			addIngressPoliciesToLocation(&loc, limitReq, &cfgParams)
+			if loc.SSL {
+				loc.UpstreamTLS = upstreamTLS
+			}
 			locations = append(locations, loc)
 
 			if cfgParams.HealthCheckEnabled {
@@ -264,10 +425,21 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 			}
 		}
 
+		sortRegexLocations(locations)
 		server.Locations = locations
 		server.HealthChecks = healthChecks
 		server.GRPCOnly = grpcOnly
 
+		if len(errorPages) > 0 {
+			if grpcOnly {
+				allWarnings.AddWarningf(ingEx.Ingress, "the %s annotation is ignored for host %s: all paths of the host use gRPC services", ErrorPagesAnnotation, rule.Host)
+			} else {
+				server.ErrorPages = filterJWTRedirectErrorPages(errorPages, server.JWTAuth)
+				server.ErrorPagesLocation = errorPagesLocation
+				addErrorPagesToLocations(server.ErrorPages, server.Locations)
+			}
+		}
+
 		servers = append(servers, server)
 	}
 
@@ -276,10 +448,20 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 		keepalive = fmt.Sprint(cfgParams.Keepalive)
 	}
 
+	var limitReqZones []version1.LimitReqZone
+	if limitReqZone != nil && hasLocations(servers) {
+		limitReqZones = append(limitReqZones, *limitReqZone)
+	}
+
+	maps = append(maps, sessionMaps...)
+
 	return version1.IngressNginxConfig{
-		Upstreams: upstreamMapToSlice(upstreams),
-		Servers:   servers,
-		Keepalive: keepalive,
+		Upstreams:     upstreamMapToSlice(upstreams),
+		SplitClients:  splitClients,
+		Maps:          maps,
+		LimitReqZones: limitReqZones,
+		Servers:       servers,
+		Keepalive:     keepalive,
 		Ingress: version1.Ingress{
 			Name:        ingEx.Ingress.Name,
 			Namespace:   ingEx.Ingress.Namespace,
@@ -289,6 +471,288 @@ func generateNginxCfg(ingEx *IngressEx, apResources *AppProtectResources, dosRes
 	}, allWarnings
 }
 
+// generateIngressLimitReq generates the rate limit configured by the nginx.org/limit-req-* annotations.
+// It reuses the rate limit generation of the RateLimit policy with the zone named after the Ingress.
+func generateIngressLimitReq(ing *networking.Ingress, cfgParams *ConfigParams) (*version1.LimitReqZone, *version1.LimitReq) {
+	if cfgParams.LimitReqRate == "" {
+		return nil, nil
+	}
+
+	rateLimit := &conf_v1.RateLimit{
+		Rate:     cfgParams.LimitReqRate,
+		Key:      cfgParams.LimitReqKey,
+		ZoneSize: cfgParams.LimitReqZoneSize,
+		NoDelay:  &cfgParams.LimitReqNoDelay,
+	}
+	if cfgParams.LimitReqBurst > 0 {
+		rateLimit.Burst = &cfgParams.LimitReqBurst
+	}
+	if cfgParams.LimitReqRejectCode > 0 {
+		rateLimit.RejectCode = &cfgParams.LimitReqRejectCode
+	}
+
+	zoneName := fmt.Sprintf("ing_rl_%v_%v", ing.Namespace, ing.Name)
+	zone := generateLimitReqZone(zoneName, rateLimit)
+	limitReq := generateLimitReq(zoneName, rateLimit)
+	options := generateLimitReqOptions(rateLimit)
+
+	ingLimitReqZone := &version1.LimitReqZone{
+		Name: zone.ZoneName,
+		Key:  zone.Key,
+		Size: zone.ZoneSize,
+		Rate: zone.Rate,
+	}
+	ingLimitReq := &version1.LimitReq{
+		Zone:       limitReq.ZoneName,
+		Burst:      limitReq.Burst,
+		NoDelay:    limitReq.NoDelay,
+		RejectCode: options.RejectCode,
+	}
+
+	return ingLimitReqZone, ingLimitReq
+}
+
+// addIngressPoliciesToLocation adds the rate limit and the access control of the Ingress to the location.
+func addIngressPoliciesToLocation(loc *version1.Location, limitReq *version1.LimitReq, cfgParams *ConfigParams) {
+	loc.LimitReq = limitReq
+	loc.Allow = cfgParams.AllowList
+	loc.Deny = cfgParams.DenyList
+}
+
+// addErrorPagesToLocations adds the custom error pages of a server to the locations that set their own error_page
+// directives: the locations of static responses, the gRPC locations and the locations with JWT redirects.
+// NGINX doesn't inherit the error_page directives of the server in such locations.
+func addErrorPagesToLocations(errorPages []version1.ErrorPage, locations []version1.Location) {
+	if len(errorPages) == 0 {
+		return
+	}
+
+	for i := range locations {
+		loc := &locations[i]
+		hasJWTRedirect := loc.JWTAuth != nil && loc.JWTAuth.RedirectLocationName != ""
+		if loc.StaticResponse != nil || loc.GRPC || hasJWTRedirect {
+			loc.ErrorPages = filterJWTRedirectErrorPages(errorPages, loc.JWTAuth)
+		}
+	}
+}
+
+// filterJWTRedirectErrorPages removes the 401 error page when JWT authentication redirects clients to the login URL,
+// because the error_page directive of the redirect must handle 401 responses.
+func filterJWTRedirectErrorPages(errorPages []version1.ErrorPage, jwtAuth *version1.JWTAuth) []version1.ErrorPage {
+	if jwtAuth == nil || jwtAuth.RedirectLocationName == "" {
+		return errorPages
+	}
+
+	var result []version1.ErrorPage
+	for _, p := range errorPages {
+		if p.Code != 401 {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// generateIngressErrorPages generates the custom error pages from the ConfigMap referenced in the nginx.org/error-pages annotation.
+func generateIngressErrorPages(ingEx *IngressEx) ([]version1.ErrorPage, *version1.ErrorPagesLocation, Warnings) {
+	warnings := newWarnings()
+
+	name, exists := ingEx.Ingress.Annotations[ErrorPagesAnnotation]
+	if !exists {
+		return nil, nil, warnings
+	}
+
+	if ingEx.ErrorPages == nil {
+		warnings.AddWarningf(ingEx.Ingress, "ConfigMap %s/%s referenced in the %s annotation does not exist", ingEx.Ingress.Namespace, name, ErrorPagesAnnotation)
+		return nil, nil, warnings
+	}
+
+	files, problems := getErrorPageFiles(ingEx.ErrorPages)
+	for _, problem := range problems {
+		warnings.AddWarningf(ingEx.Ingress, "ConfigMap %s/%s referenced in the %s annotation: %s", ingEx.Ingress.Namespace, name, ErrorPagesAnnotation, problem)
+	}
+
+	if len(files) == 0 || ingEx.ErrorPagesPath == "" {
+		return nil, nil, warnings
+	}
+
+	codes := make([]int, 0, len(files))
+	for code := range files {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	var errorPages []version1.ErrorPage
+	for _, code := range codes {
+		errorPages = append(errorPages, version1.ErrorPage{
+			Code: code,
+			URI:  errorPagesLocationPath + files[code],
+		})
+	}
+
+	errorPagesLocation := &version1.ErrorPagesLocation{
+		Path:  errorPagesLocationPath,
+		Alias: ingEx.ErrorPagesPath + "/",
+	}
+
+	return errorPages, errorPagesLocation, warnings
+}
+
+// getErrorPageFiles returns the keys of the ConfigMap with custom error pages by status code,
+// along with the problems of the invalid keys. A valid key is <code>.<extension>, for example 404.html.
+func getErrorPageFiles(cm *api_v1.ConfigMap) (map[int]string, []string) {
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	files := make(map[int]string)
+	var problems []string
+
+	for _, key := range keys {
+		match := errorPageKeyRegexp.FindStringSubmatch(key)
+		if match == nil {
+			problems = append(problems, fmt.Sprintf("the key %s is ignored: must be <code>.<extension>, where the extension is one of html, json, txt or xml", key))
+			continue
+		}
+
+		code, _ := strconv.Atoi(match[1])
+		if code < 300 || code > 599 {
+			problems = append(problems, fmt.Sprintf("the key %s is ignored: the code must be in the range 300-599", key))
+			continue
+		}
+
+		if existing, exists := files[code]; exists {
+			problems = append(problems, fmt.Sprintf("the key %s is ignored: the error page for the code %d is already defined by the key %s", key, code, existing))
+			continue
+		}
+
+		files[code] = key
+	}
+
+	return files, problems
+}
+
+func hasLocations(servers []version1.Server) bool {
+	for _, server := range servers {
+		if len(server.Locations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// generateStaticResponseLocation generates a location for a path with a resource backend that references a StaticResponse.
+// For a StaticResponse with a return, it also generates the named location that returns the response.
+func generateStaticResponseLocation(ingEx *IngressEx, path string, pathType *networking.PathType, resource *api_v1.TypedLocalObjectReference,
+	cfgParams *ConfigParams, returnLocationName string) (version1.Location, *version1.ReturnLocation, Warnings) {
+	warnings := newWarnings()
+
+	loc := createLocation(pathOrDefault(path), version1.Upstream{}, cfgParams, false, "", false, false, "", pathType, "")
+
+	staticResponse, exists := ingEx.StaticResponses[resource.Name]
+	if !exists {
+		warnings.AddWarningf(ingEx.Ingress, "StaticResponse %s/%s referenced by the path %s doesn't exist or is invalid", ingEx.Ingress.Namespace, resource.Name, pathOrDefault(path))
+		loc.StaticResponse = &version1.StaticResponse{
+			Invalid: true,
+		}
+		return loc, nil, warnings
+	}
+
+	if redirect := staticResponse.Spec.Redirect; redirect != nil {
+		code := redirect.Code
+		if code == 0 {
+			code = 301
+		}
+
+		loc.StaticResponse = &version1.StaticResponse{
+			ProxyPass: fmt.Sprintf("http://%s", nginx418Server),
+			Code:      code,
+			Page:      redirect.URL,
+		}
+		return loc, nil, warnings
+	}
+
+	ret := staticResponse.Spec.Return
+
+	code := ret.Code
+	if code == 0 {
+		code = 200
+	}
+	defaultType := ret.Type
+	if defaultType == "" {
+		defaultType = "text/plain"
+	}
+
+	loc.StaticResponse = &version1.StaticResponse{
+		ProxyPass: fmt.Sprintf("http://%s", nginx418Server),
+		Code:      code,
+		Page:      returnLocationName,
+	}
+	returnLoc := &version1.ReturnLocation{
+		Name:        returnLocationName,
+		DefaultType: defaultType,
+		Text:        ret.Body,
+	}
+
+	return loc, returnLoc, warnings
+}
+
+// generateUpstreamTLS generates the TLS configuration for the backends from the nginx.org/ca
+// and nginx.org/upstream-tls-secret annotations. If a referenced secret is invalid, the configuration is marked
+// as invalid, so that the requests are rejected rather than sent to the backends without the verification.
+func generateUpstreamTLS(owner runtime.Object, secretRefs map[string]*secrets.SecretReference, cfgParams *ConfigParams) (*version1.UpstreamTLS, Warnings) {
+	warnings := newWarnings()
+
+	if cfgParams.UpstreamCASecret == "" && cfgParams.UpstreamTLSSecret == "" {
+		return nil, warnings
+	}
+
+	upstreamTLS := &version1.UpstreamTLS{}
+
+	if cfgParams.UpstreamCASecret != "" {
+		path, err := getSecretPath(secretRefs[cfgParams.UpstreamCASecret], secrets.SecretTypeCA)
+		if err != nil {
+			warnings.AddWarningf(owner, "CA secret %s %v", cfgParams.UpstreamCASecret, err)
+			upstreamTLS.Invalid = true
+		}
+		upstreamTLS.TrustedCertificate = path
+	}
+
+	if cfgParams.UpstreamTLSSecret != "" {
+		path, err := getSecretPath(secretRefs[cfgParams.UpstreamTLSSecret], api_v1.SecretTypeTLS)
+		if err != nil {
+			warnings.AddWarningf(owner, "TLS secret %s %v", cfgParams.UpstreamTLSSecret, err)
+			upstreamTLS.Invalid = true
+		}
+		upstreamTLS.Certificate = path
+		upstreamTLS.CertificateKey = path
+	}
+
+	return upstreamTLS, warnings
+}
+
+// getSecretPath returns the path of the file of the referenced secret of the expected type.
+func getSecretPath(secretRef *secrets.SecretReference, expectedType api_v1.SecretType) (string, error) {
+	if secretRef == nil {
+		return "", errors.New("doesn't exist")
+	}
+
+	var secretType api_v1.SecretType
+	if secretRef.Secret != nil {
+		secretType = secretRef.Secret.Type
+	}
+	if secretType != "" && secretType != expectedType {
+		return "", fmt.Errorf("is of a wrong type '%s', must be '%s'", secretType, expectedType)
+	}
+	if secretRef.Error != nil {
+		return "", fmt.Errorf("is invalid: %w", secretRef.Error)
+	}
+
+	return secretRef.Path, nil
+}
+
 func generateJWTConfig(owner runtime.Object, secretRefs map[string]*secrets.SecretReference, cfgParams *ConfigParams,
 	redirectLocationName string) (*version1.JWTAuth, *version1.JWTRedirectLocation, Warnings) {
 	warnings := newWarnings()
@@ -379,20 +843,62 @@ func addSSLConfig(server *version1.Server, owner runtime.Object, host string, in
 	return warnings
 }
 
-func generateIngressPath(path string, pathType *networking.PathType) string {
+// generateIngressPath generates the location path. The nginx.org/path-regex annotation takes precedence over the pathType,
+// while ImplementationSpecific paths are treated as case-sensitive regular expressions.
+func generateIngressPath(path string, pathType *networking.PathType, pathRegex string) string {
 	if pathType == nil {
 		return path
 	}
-	if *pathType == networking.PathTypeExact {
+
+	switch pathRegex {
+	case "case_sensitive":
+		return fmt.Sprintf("~ \"^%s\"", path)
+	case "case_insensitive":
+		return fmt.Sprintf("~* \"^%s\"", path)
+	case "exact":
+		return fmt.Sprintf("= \"%s\"", path)
+	}
+
+	switch *pathType {
+	case networking.PathTypeExact:
 		path = "= " + path
+	case networking.PathTypeImplementationSpecific:
+		path = fmt.Sprintf("~ \"^%s\"", path)
 	}
 
 	return path
 }
 
+// isRegexIngressPath checks if the location path generated by generateIngressPath is a regular expression.
+func isRegexIngressPath(path string) bool {
+	return strings.HasPrefix(path, "~")
+}
+
+// sortRegexLocations sorts the locations with regular expression paths by the length of the path, longest first.
+// NGINX checks such locations in the order of the config, so a shorter path would shadow the longer paths that start with it.
+// The other locations keep their positions.
+func sortRegexLocations(locations []version1.Location) {
+	var indexes []int
+	var regexLocations []version1.Location
+	for i, loc := range locations {
+		if isRegexIngressPath(loc.Path) {
+			indexes = append(indexes, i)
+			regexLocations = append(regexLocations, loc)
+		}
+	}
+
+	sort.SliceStable(regexLocations, func(i, j int) bool {
+		return len(regexLocations[i].Path) > len(regexLocations[j].Path)
+	})
+
+	for i, index := range indexes {
+		locations[index] = regexLocations[i]
+	}
+}
+
 func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams, websocket bool, rewrite string, ssl bool, grpc bool, proxySSLName string, pathType *networking.PathType, serviceName string) version1.Location {
 	loc := version1.Location{
-		Path:                 generateIngressPath(path, pathType),
+		Path:                 generateIngressPath(path, pathType, cfg.PathRegex),
 		Upstream:             upstream,
 		ProxyConnectTimeout:  cfg.ProxyConnectTimeout,
 		ProxyReadTimeout:     cfg.ProxyReadTimeout,
@@ -411,9 +917,84 @@ func createLocation(path string, upstream version1.Upstream, cfg *ConfigParams,
 		ServiceName:          serviceName,
 	}
 
+	if override, exists := cfg.PathOverrides[path]; exists {
+		applyPathOverride(&loc, override)
+	}
+
 	return loc
 }
 
+// generatePathOverridesWarnings generates the warnings for the paths of the nginx.org/path-overrides annotation
+// that the Ingress doesn't have, because their settings are ignored.
+func generatePathOverridesWarnings(ing *networking.Ingress, overrides map[string]map[string]string) Warnings {
+	warnings := newWarnings()
+	if len(overrides) == 0 {
+		return warnings
+	}
+
+	paths := make(map[string]bool)
+	if ing.Spec.DefaultBackend != nil {
+		paths["/"] = true
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			paths[pathOrDefault(path.Path)] = true
+		}
+	}
+
+	var unknownPaths []string
+	for path := range overrides {
+		if !paths[path] {
+			unknownPaths = append(unknownPaths, path)
+		}
+	}
+	sort.Strings(unknownPaths)
+
+	for _, path := range unknownPaths {
+		warnings.AddWarningf(ing, "the path %s of the nginx.org/path-overrides annotation is ignored: the Ingress doesn't have this path", path)
+	}
+
+	return warnings
+}
+
+// applyPathOverride applies the settings of the nginx.org/path-overrides annotation for the path of the location.
+// The values are validated together with the annotations of the Ingress, so the invalid ones are ignored here.
+func applyPathOverride(loc *version1.Location, override map[string]string) {
+	for name, value := range override {
+		switch name {
+		case "proxy-connect-timeout":
+			if timeout, err := ParseTime(value); err == nil {
+				loc.ProxyConnectTimeout = timeout
+			}
+		case "proxy-read-timeout":
+			if timeout, err := ParseTime(value); err == nil {
+				loc.ProxyReadTimeout = timeout
+			}
+		case "proxy-send-timeout":
+			if timeout, err := ParseTime(value); err == nil {
+				loc.ProxySendTimeout = timeout
+			}
+		case "client-max-body-size":
+			loc.ClientMaxBodySize = value
+		case "proxy-buffering":
+			if buffering, err := ParseBool(value); err == nil {
+				loc.ProxyBuffering = buffering
+			}
+		case "proxy-buffers":
+			loc.ProxyBuffers = value
+		case "proxy-buffer-size":
+			loc.ProxyBufferSize = value
+		case "proxy-max-temp-file-size":
+			loc.ProxyMaxTempFileSize = value
+		case "rewrite":
+			loc.Rewrite = value
+		}
+	}
+}
+
 // upstreamRequiresQueue checks if the upstream requires a queue.
 // Mandatory Health Checks can cause nginx to return errors on reload, since all Upstreams start
 // Unhealthy. By adding a queue to the Upstream we can avoid returning errors, at the cost of a short delay.
@@ -451,7 +1032,7 @@ func createUpstream(ingEx *IngressEx, name string, backend *networking.IngressBa
 		// Always false for NGINX OSS
 		_, isExternalNameSvc := ingEx.ExternalNameSvcs[backend.Service.Name]
 		if isExternalNameSvc && !isResolverConfigured {
-			glog.Warningf("A resolver must be configured for Type ExternalName service %s, no upstream servers will be created", backend.Service.Name)
+			nl.Warnf(nl.WithResource(slog.Default(), "Ingress", ingEx.Ingress.Namespace, ingEx.Ingress.Name), "A resolver must be configured for Type ExternalName service %s, no upstream servers will be created", backend.Service.Name)
 			endps = []string{}
 		}
 
@@ -514,6 +1095,150 @@ func getNameForRedirectLocation(ing *networking.Ingress) string {
 	return fmt.Sprintf("@login_url_%v-%v", ing.Namespace, ing.Name)
 }
 
+func getNameForReturnLocation(ing *networking.Ingress, index int) string {
+	return fmt.Sprintf("@return_%v-%v_%d", ing.Namespace, ing.Name, index)
+}
+
+func getNameForCanaryVariable(ing *networking.Ingress, index int) string {
+	safeNsName := strings.NewReplacer("-", "_", ".", "_").Replace(fmt.Sprintf("%v_%v", ing.Namespace, ing.Name))
+	return fmt.Sprintf("$ing_%v_canary_%d", safeNsName, index)
+}
+
+func getNameForSessionVariable(ing *networking.Ingress, index int) string {
+	safeNsName := strings.NewReplacer("-", "_", ".", "_").Replace(fmt.Sprintf("%v_%v", ing.Namespace, ing.Name))
+	return fmt.Sprintf("$ing_%v_session_%d", safeNsName, index)
+}
+
+// generateIngressHashSessionCookieMaps generates the maps of the hash session persistence with a cookie for an upstream of an Ingress.
+func generateIngressHashSessionCookieMaps(sessionVariable string, sc *conf_v1.SessionCookie) []version1.Map {
+	var maps []version1.Map
+	for _, m := range generateHashSessionCookieMaps(sessionVariable, sc) {
+		ingMap := version1.Map{
+			Source:   m.Source,
+			Variable: m.Variable,
+		}
+		for _, p := range m.Parameters {
+			ingMap.Parameters = append(ingMap.Parameters, version1.Parameter{Value: p.Value, Result: p.Result})
+		}
+		maps = append(maps, ingMap)
+	}
+	return maps
+}
+
+// canaryKey identifies a path of a host.
+type canaryKey struct {
+	host string
+	path string
+}
+
+// canaryBackend is the backend of a canary Ingress for a path of the primary Ingress.
+type canaryBackend struct {
+	ingEx     *IngressEx
+	backend   *networking.IngressBackend
+	cfgParams ConfigParams
+}
+
+// getCanaryBackends returns the backends of the canary Ingresses of an Ingress by their host and path.
+// The annotations of a canary Ingress configure its upstreams and how the requests are routed to it.
+func getCanaryBackends(ingEx *IngressEx, baseCfgParams *ConfigParams, isPlus bool, hasAppProtect bool, hasAppProtectDos bool,
+	enableInternalRoutes bool,
+) map[canaryKey]canaryBackend {
+	backends := make(map[canaryKey]canaryBackend)
+
+	for _, canaryEx := range ingEx.Canaries {
+		cfgParams := parseAnnotations(canaryEx, baseCfgParams, isPlus, hasAppProtect, hasAppProtectDos, enableInternalRoutes)
+
+		for _, rule := range canaryEx.Ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			for i := range rule.HTTP.Paths {
+				path := rule.HTTP.Paths[i]
+				if !canaryEx.ValidMinionPaths[path.Path] {
+					continue
+				}
+
+				backends[canaryKey{host: rule.Host, path: path.Path}] = canaryBackend{
+					ingEx:     canaryEx,
+					backend:   &rule.HTTP.Paths[i].Backend,
+					cfgParams: cfgParams,
+				}
+			}
+		}
+	}
+
+	return backends
+}
+
+// generateCanaryRouting generates the split clients and the maps that choose between the primary and the canary upstreams
+// for a request. It returns the variable that holds the chosen upstream. The header takes precedence over the cookie,
+// which takes precedence over the weight.
+func generateCanaryRouting(variable string, primary string, canary string, cfgParams *ConfigParams) ([]version1.SplitClient, []version1.Map, string) {
+	var splitClients []version1.SplitClient
+	var maps []version1.Map
+
+	next := primary
+
+	if cfgParams.CanaryWeight >= 100 {
+		next = canary
+	} else if cfgParams.CanaryWeight > 0 {
+		weightVariable := variable + "_weight"
+		splitClients = append(splitClients, version1.SplitClient{
+			Source:   "$request_id",
+			Variable: weightVariable,
+			Distributions: []version1.Distribution{
+				{
+					Weight: fmt.Sprintf("%d%%", cfgParams.CanaryWeight),
+					Value:  canary,
+				},
+				{
+					Weight: "*",
+					Value:  primary,
+				},
+			},
+		})
+		next = weightVariable
+	}
+
+	if cfgParams.CanaryByCookie != "" {
+		cookieVariable := variable + "_cookie"
+		maps = append(maps, generateCanaryMap("$cookie_"+cfgParams.CanaryByCookie, cookieVariable, primary, canary, next))
+		next = cookieVariable
+	}
+
+	if cfgParams.CanaryByHeader != "" {
+		headerVariable := variable + "_header"
+		maps = append(maps, generateCanaryMap(generateHeaderVariable(cfgParams.CanaryByHeader), headerVariable, primary, canary, next))
+		next = headerVariable
+	}
+
+	return splitClients, maps, next
+}
+
+// generateCanaryMap generates a map that chooses the canary upstream for the value "always" of the source
+// and the primary upstream for the value "never". Otherwise, the map falls back to the default.
+func generateCanaryMap(source string, variable string, primary string, canary string, defaultValue string) version1.Map {
+	return version1.Map{
+		Source:   source,
+		Variable: variable,
+		Parameters: []version1.Parameter{
+			{
+				Value:  "always",
+				Result: canary,
+			},
+			{
+				Value:  "never",
+				Result: primary,
+			},
+			{
+				Value:  "default",
+				Result: defaultValue,
+			},
+		},
+	}
+}
+
 func upstreamMapToSlice(upstreams map[string]version1.Upstream) []version1.Upstream {
 	keys := make([]string, 0, len(upstreams))
 	for k := range upstreams {
@@ -541,6 +1266,8 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ap
 	var masterServer version1.Server
 	var locations []version1.Location
 	var upstreams []version1.Upstream
+	var splitClients []version1.SplitClient
+	var maps []version1.Map
 	healthChecks := make(map[string]version1.HealthCheck)
 	var keepalive string
 
@@ -550,7 +1277,8 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ap
 
 	removedAnnotations := filterMasterAnnotations(mergeableIngs.Master.Ingress.Annotations)
 	if len(removedAnnotations) != 0 {
-		glog.Errorf("Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'master' cannot contain the '%v' annotation(s). They will be ignored",
+		nl.Errorf(nl.WithResource(slog.Default(), "Ingress", mergeableIngs.Master.Ingress.Namespace, mergeableIngs.Master.Ingress.Name),
+			"Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'master' cannot contain the '%v' annotation(s). They will be ignored",
 			mergeableIngs.Master.Ingress.Namespace, mergeableIngs.Master.Ingress.Name, strings.Join(removedAnnotations, ","))
 	}
 	isMinion := false
@@ -568,6 +1296,7 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ap
 	masterServer.Locations = []version1.Location{}
 
 	upstreams = append(upstreams, masterNginxCfg.Upstreams...)
+	limitReqZones := masterNginxCfg.LimitReqZones
 
 	if masterNginxCfg.Keepalive != "" {
 		keepalive = masterNginxCfg.Keepalive
@@ -587,7 +1316,8 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ap
 
 		removedAnnotations = filterMinionAnnotations(minion.Ingress.Annotations)
 		if len(removedAnnotations) != 0 {
-			glog.Errorf("Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'minion' cannot contain the %v annotation(s). They will be ignored",
+			nl.Errorf(nl.WithResource(slog.Default(), "Ingress", minion.Ingress.Namespace, minion.Ingress.Name),
+				"Ingress Resource %v/%v with the annotation 'nginx.org/mergeable-ingress-type' set to 'minion' cannot contain the %v annotation(s). They will be ignored",
 				minion.Ingress.Namespace, minion.Ingress.Name, strings.Join(removedAnnotations, ","))
 		}
 
@@ -614,17 +1344,26 @@ func generateNginxCfgForMergeableIngresses(mergeableIngs *MergeableIngresses, ap
 				healthChecks[hcName] = healthCheck
 			}
 			masterServer.JWTRedirectLocations = append(masterServer.JWTRedirectLocations, server.JWTRedirectLocations...)
+			masterServer.ReturnLocations = append(masterServer.ReturnLocations, server.ReturnLocations...)
 		}
 
 		upstreams = append(upstreams, nginxCfg.Upstreams...)
+		splitClients = append(splitClients, nginxCfg.SplitClients...)
+		maps = append(maps, nginxCfg.Maps...)
+		limitReqZones = append(limitReqZones, nginxCfg.LimitReqZones...)
 	}
 
 	masterServer.HealthChecks = healthChecks
+	sortRegexLocations(locations)
+	addErrorPagesToLocations(masterServer.ErrorPages, locations)
 	masterServer.Locations = locations
 
 	return version1.IngressNginxConfig{
 		Servers:           []version1.Server{masterServer},
 		Upstreams:         upstreams,
+		SplitClients:      splitClients,
+		Maps:              maps,
+		LimitReqZones:     limitReqZones,
 		Keepalive:         keepalive,
 		Ingress:           masterNginxCfg.Ingress,
 		SpiffeClientCerts: staticParams.NginxServiceMesh && !baseCfgParams.SpiffeServerCerts,
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version1"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -137,9 +138,10 @@ func TestGenerateIngressPath(t *testing.T) {
 	prefix := networking.PathTypePrefix
 	impSpec := networking.PathTypeImplementationSpecific
 	tests := []struct {
-		pathType *networking.PathType
-		path     string
-		expected string
+		pathType  *networking.PathType
+		pathRegex string
+		path      string
+		expected  string
 	}{
 		{
 			pathType: &exact,
@@ -154,22 +156,68 @@ func TestGenerateIngressPath(t *testing.T) {
 		{
 			pathType: &impSpec,
 			path:     "/path/to/resource",
-			expected: "/path/to/resource",
+			expected: `~ "^/path/to/resource"`,
 		},
 		{
 			pathType: nil,
 			path:     "/path/to/resource",
 			expected: "/path/to/resource",
 		},
+		{
+			pathType:  &prefix,
+			pathRegex: "case_sensitive",
+			path:      "/path/[A-Z0-9]{3}",
+			expected:  `~ "^/path/[A-Z0-9]{3}"`,
+		},
+		{
+			pathType:  &impSpec,
+			pathRegex: "case_insensitive",
+			path:      "/path/[a-z]+",
+			expected:  `~* "^/path/[a-z]+"`,
+		},
+		{
+			pathType:  &prefix,
+			pathRegex: "exact",
+			path:      "/path/to/resource",
+			expected:  `= "/path/to/resource"`,
+		},
+		{
+			pathType:  nil,
+			pathRegex: "case_sensitive",
+			path:      "/",
+			expected:  "/",
+		},
 	}
 	for _, test := range tests {
-		result := generateIngressPath(test.path, test.pathType)
+		result := generateIngressPath(test.path, test.pathType, test.pathRegex)
 		if result != test.expected {
-			t.Errorf("generateIngressPath(%v, %v) returned %v, but expected %v", test.path, test.pathType, result, test.expected)
+			t.Errorf("generateIngressPath(%v, %v, %q) returned %v, but expected %v", test.path, test.pathType, test.pathRegex, result, test.expected)
 		}
 	}
 }
 
+func TestSortRegexLocations(t *testing.T) {
+	locations := []version1.Location{
+		{Path: `~ "^/tea"`},
+		{Path: "/"},
+		{Path: `~ "^/tea/green"`},
+		{Path: "= /coffee"},
+		{Path: `~* "^/tea/green/[a-z]+"`},
+	}
+	expected := []version1.Location{
+		{Path: `~* "^/tea/green/[a-z]+"`},
+		{Path: "/"},
+		{Path: `~ "^/tea/green"`},
+		{Path: "= /coffee"},
+		{Path: `~ "^/tea"`},
+	}
+
+	sortRegexLocations(locations)
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("sortRegexLocations() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func createExpectedConfigForCafeIngressEx(isPlus bool) version1.IngressNginxConfig {
 	upstreamZoneSize := "256k"
 	if isPlus {
@@ -864,6 +912,872 @@ func TestGenerateNginxCfgForSpiffe(t *testing.T) {
 	}
 }
 
+func TestGenerateNginxCfgForCanary(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Canaries = []*IngressEx{createCafeCanaryIngressEx()}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expected := createExpectedConfigForCafeIngressEx(isPlus)
+	expected.Upstreams = append(expected.Upstreams, version1.Upstream{
+		Name:             "default-cafe-ingress-canary-cafe.example.com-coffee-v2-svc-80",
+		LBMethod:         "random two least_conn",
+		UpstreamZoneSize: "256k",
+		UpstreamServers: []version1.UpstreamServer{
+			{
+				Address:     "10.0.0.3",
+				Port:        "80",
+				MaxFails:    1,
+				MaxConns:    0,
+				FailTimeout: "10s",
+			},
+		},
+	})
+	expected.SplitClients = []version1.SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$ing_default_cafe_ingress_canary_0_weight",
+			Distributions: []version1.Distribution{
+				{Weight: "20%", Value: "default-cafe-ingress-canary-cafe.example.com-coffee-v2-svc-80"},
+				{Weight: "*", Value: "default-cafe-ingress-cafe.example.com-coffee-svc-80"},
+			},
+		},
+	}
+	expected.Maps = []version1.Map{
+		{
+			Source:   "$http_x_canary",
+			Variable: "$ing_default_cafe_ingress_canary_0_header",
+			Parameters: []version1.Parameter{
+				{Value: "always", Result: "default-cafe-ingress-canary-cafe.example.com-coffee-v2-svc-80"},
+				{Value: "never", Result: "default-cafe-ingress-cafe.example.com-coffee-svc-80"},
+				{Value: "default", Result: "$ing_default_cafe_ingress_canary_0_weight"},
+			},
+		},
+	}
+	expected.Servers[0].Locations[0].UpstreamVariable = "$ing_default_cafe_ingress_canary_0_header"
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForCanaryWithRewrite(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/rewrites"] = "serviceName=coffee-svc rewrite=/beans/"
+	canaryEx := createCafeCanaryIngressEx()
+	cafeIngressEx.Canaries = []*IngressEx{canaryEx}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if result.Servers[0].Locations[0].UpstreamVariable != "" {
+		t.Errorf("generateNginxCfg() returned location with upstream variable %q but expected none", result.Servers[0].Locations[0].UpstreamVariable)
+	}
+	if len(warnings[canaryEx.Ingress]) != 1 {
+		t.Errorf("generateNginxCfg() returned warnings %v but expected one warning for the canary", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForPathRegexWithRewrite(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/path-regex"] = "case_insensitive"
+	cafeIngressEx.Ingress.Annotations["nginx.org/rewrites"] = "serviceName=coffee-svc rewrite=/beans/"
+	prefix := networking.PathTypePrefix
+	for i := range cafeIngressEx.Ingress.Spec.Rules[0].HTTP.Paths {
+		cafeIngressEx.Ingress.Spec.Rules[0].HTTP.Paths[i].PathType = &prefix
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	expectedPath := `~* "^/coffee"`
+	location := result.Servers[0].Locations[0]
+	if location.Path != expectedPath {
+		t.Errorf("generateNginxCfg() returned location with path %q but expected %q", location.Path, expectedPath)
+	}
+	if location.Rewrite != "" {
+		t.Errorf("generateNginxCfg() returned location with rewrite %q but expected none", location.Rewrite)
+	}
+	if len(warnings[cafeIngressEx.Ingress]) != 1 {
+		t.Errorf("generateNginxCfg() returned warnings %v but expected one warning for the Ingress", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForPathOverrides(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/proxy-read-timeout"] = "30s"
+	cafeIngressEx.Ingress.Annotations["nginx.org/path-overrides"] = `{"/coffee": {"proxy-read-timeout": "2m", "client-max-body-size": "10m", "proxy-buffering": "false", "rewrite": "/beans/"}}`
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	coffee := result.Servers[0].Locations[0]
+	if coffee.ProxyReadTimeout != "2m" || coffee.ClientMaxBodySize != "10m" || coffee.ProxyBuffering || coffee.Rewrite != "/beans/" {
+		t.Errorf("generateNginxCfg() returned location %q with read timeout %q, body size %q, buffering %v and rewrite %q, but expected the path overrides",
+			coffee.Path, coffee.ProxyReadTimeout, coffee.ClientMaxBodySize, coffee.ProxyBuffering, coffee.Rewrite)
+	}
+
+	tea := result.Servers[0].Locations[1]
+	if tea.ProxyReadTimeout != "30s" || tea.ClientMaxBodySize != configParams.ClientMaxBodySize || tea.ProxyBuffering != configParams.ProxyBuffering || tea.Rewrite != "" {
+		t.Errorf("generateNginxCfg() returned location %q with read timeout %q, body size %q, buffering %v and rewrite %q, but expected the Ingress-wide settings",
+			tea.Path, tea.ProxyReadTimeout, tea.ClientMaxBodySize, tea.ProxyBuffering, tea.Rewrite)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForPathOverridesOfUnknownPaths(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/path-overrides"] = `{"/coffee": {"proxy-read-timeout": "2m"}, "/tea/": {"proxy-read-timeout": "2m"}, "/mocha": {"rewrite": "/beans/"}}`
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedWarnings := Warnings{
+		cafeIngressEx.Ingress: {
+			"the path /mocha of the nginx.org/path-overrides annotation is ignored: the Ingress doesn't have this path",
+			"the path /tea/ of the nginx.org/path-overrides annotation is ignored: the Ingress doesn't have this path",
+		},
+	}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if coffee := result.Servers[0].Locations[0]; coffee.ProxyReadTimeout != "2m" {
+		t.Errorf("generateNginxCfg() returned location %q with read timeout %q but expected the path override", coffee.Path, coffee.ProxyReadTimeout)
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgForLimitReqAndAccessControl(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-rate"] = "10r/s"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-burst"] = "20"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-no-delay"] = "true"
+	cafeIngressEx.Ingress.Annotations["nginx.org/limit-req-reject-code"] = "429"
+	cafeIngressEx.Ingress.Annotations["nginx.org/allow-list"] = "10.0.0.0/8, 192.168.1.1"
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedZones := []version1.LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	}
+	expectedLimitReq := &version1.LimitReq{
+		Zone:       "ing_rl_default_cafe-ingress",
+		Burst:      20,
+		NoDelay:    true,
+		RejectCode: 429,
+	}
+	expectedAllow := []string{"10.0.0.0/8", "192.168.1.1"}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedZones, result.LimitReqZones); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected limit req zones (-want +got):\n%s", diff)
+	}
+	for _, loc := range result.Servers[0].Locations {
+		if diff := cmp.Diff(expectedLimitReq, loc.LimitReq); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected limit req for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+		if diff := cmp.Diff(expectedAllow, loc.Allow); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected allow list for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForMergeableIngressesWithLimitReq(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/limit-req-rate"] = "10r/s"
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/deny-list"] = "10.0.0.1"
+	mergeableIngresses.Minions[0].Ingress.Annotations["nginx.org/limit-req-rate"] = "5r/s"
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedZones := []version1.LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress-coffee-minion",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "5r/s",
+		},
+		{
+			Name: "ing_rl_default_cafe-ingress-tea-minion",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	}
+
+	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, nil, nil, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedZones, result.LimitReqZones); diff != "" {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected limit req zones (-want +got):\n%s", diff)
+	}
+	for _, loc := range result.Servers[0].Locations {
+		if loc.LimitReq == nil || !strings.HasSuffix(loc.LimitReq.Zone, loc.MinionIngress.Name) {
+			t.Errorf("generateNginxCfgForMergeableIngresses() returned limit req %v for location %s but expected the zone of the minion %s", loc.LimitReq, loc.Path, loc.MinionIngress.Name)
+		}
+		if diff := cmp.Diff([]string{"10.0.0.1"}, loc.Deny); diff != "" {
+			t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected deny list for location %s (-want +got):\n%s", loc.Path, diff)
+		}
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForErrorPages(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/error-pages"] = "cafe-error-pages"
+	cafeIngressEx.ErrorPages = &v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-error-pages",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"404.html": "<html>Not Found</html>",
+			"502.json": `{"message": "Bad Gateway"}`,
+		},
+	}
+	cafeIngressEx.ErrorPagesPath = "/etc/nginx/error-pages/default-cafe-ingress"
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedErrorPages := []version1.ErrorPage{
+		{
+			Code: 404,
+			URI:  "/_ingress_error_pages/404.html",
+		},
+		{
+			Code: 502,
+			URI:  "/_ingress_error_pages/502.json",
+		},
+	}
+	expectedErrorPagesLocation := &version1.ErrorPagesLocation{
+		Path:  "/_ingress_error_pages/",
+		Alias: "/etc/nginx/error-pages/default-cafe-ingress/",
+	}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	for _, server := range result.Servers {
+		if diff := cmp.Diff(expectedErrorPages, server.ErrorPages); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected error pages for server %s (-want +got):\n%s", server.Name, diff)
+		}
+		if diff := cmp.Diff(expectedErrorPagesLocation, server.ErrorPagesLocation); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected error pages location for server %s (-want +got):\n%s", server.Name, diff)
+		}
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForErrorPagesWithWarnings(t *testing.T) {
+	tests := []struct {
+		errorPages       *v1.ConfigMap
+		errorPagesPath   string
+		expectedWarnings []string
+		msg              string
+	}{
+		{
+			errorPages:     nil,
+			errorPagesPath: "",
+			expectedWarnings: []string{
+				"ConfigMap default/cafe-error-pages referenced in the nginx.org/error-pages annotation does not exist",
+			},
+			msg: "missing ConfigMap",
+		},
+		{
+			errorPages: &v1.ConfigMap{
+				Data: map[string]string{
+					"200.html":   "OK",
+					"404.html":   "Not Found",
+					"404.txt":    "Not Found",
+					"index.html": "Index",
+				},
+			},
+			errorPagesPath: "/etc/nginx/error-pages/default-cafe-ingress",
+			expectedWarnings: []string{
+				"ConfigMap default/cafe-error-pages referenced in the nginx.org/error-pages annotation: the key 200.html is ignored: the code must be in the range 300-599",
+				"ConfigMap default/cafe-error-pages referenced in the nginx.org/error-pages annotation: the key 404.txt is ignored: the error page for the code 404 is already defined by the key 404.html",
+				"ConfigMap default/cafe-error-pages referenced in the nginx.org/error-pages annotation: the key index.html is ignored: must be <code>.<extension>, where the extension is one of html, json, txt or xml",
+			},
+			msg: "invalid keys",
+		},
+	}
+
+	for _, test := range tests {
+		cafeIngressEx := createCafeIngressEx()
+		cafeIngressEx.Ingress.Annotations["nginx.org/error-pages"] = "cafe-error-pages"
+		cafeIngressEx.ErrorPages = test.errorPages
+		cafeIngressEx.ErrorPagesPath = test.errorPagesPath
+		isPlus := false
+		configParams := NewDefaultConfigParams(isPlus)
+
+		_, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+		if diff := cmp.Diff(test.expectedWarnings, warnings[cafeIngressEx.Ingress]); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected warnings for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateNginxCfgForMergeableIngressesWithErrorPages(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	mergeableIngresses.Master.Ingress.Annotations["nginx.org/error-pages"] = "cafe-error-pages"
+	mergeableIngresses.Master.ErrorPages = &v1.ConfigMap{
+		Data: map[string]string{
+			"503.html": "<html>Service Unavailable</html>",
+		},
+	}
+	mergeableIngresses.Master.ErrorPagesPath = "/etc/nginx/error-pages/default-cafe-ingress-master"
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedErrorPages := []version1.ErrorPage{
+		{
+			Code: 503,
+			URI:  "/_ingress_error_pages/503.html",
+		},
+	}
+
+	result, warnings := generateNginxCfgForMergeableIngresses(mergeableIngresses, nil, nil, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	if diff := cmp.Diff(expectedErrorPages, result.Servers[0].ErrorPages); diff != "" {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected error pages (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestAddErrorPagesToLocations(t *testing.T) {
+	errorPages := []version1.ErrorPage{
+		{Code: 401, URI: "/_ingress_error_pages/401.html"},
+		{Code: 502, URI: "/_ingress_error_pages/502.html"},
+	}
+	staticResponse := &version1.StaticResponse{Code: 503, Page: "@return_default-cafe-ingress_0"}
+
+	locations := []version1.Location{
+		{Path: "/tea"},
+		{Path: "/grpc", GRPC: true},
+		{Path: "/maintenance", StaticResponse: staticResponse},
+		{Path: "/coffee", JWTAuth: &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk"}},
+		{Path: "/mocha", JWTAuth: &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk", RedirectLocationName: "@login_url_default-cafe-ingress"}},
+	}
+	expected := []version1.Location{
+		{Path: "/tea"},
+		{Path: "/grpc", GRPC: true, ErrorPages: errorPages},
+		{Path: "/maintenance", StaticResponse: staticResponse, ErrorPages: errorPages},
+		{Path: "/coffee", JWTAuth: &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk"}},
+		{
+			Path:       "/mocha",
+			JWTAuth:    &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk", RedirectLocationName: "@login_url_default-cafe-ingress"},
+			ErrorPages: []version1.ErrorPage{{Code: 502, URI: "/_ingress_error_pages/502.html"}},
+		},
+	}
+
+	addErrorPagesToLocations(errorPages, locations)
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("addErrorPagesToLocations() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilterJWTRedirectErrorPages(t *testing.T) {
+	errorPages := []version1.ErrorPage{
+		{Code: 401, URI: "/_ingress_error_pages/401.html"},
+		{Code: 404, URI: "/_ingress_error_pages/404.html"},
+	}
+
+	tests := []struct {
+		jwtAuth  *version1.JWTAuth
+		expected []version1.ErrorPage
+		msg      string
+	}{
+		{
+			jwtAuth:  nil,
+			expected: errorPages,
+			msg:      "no JWT",
+		},
+		{
+			jwtAuth:  &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk"},
+			expected: errorPages,
+			msg:      "JWT without a redirect",
+		},
+		{
+			jwtAuth: &version1.JWTAuth{Key: "/etc/nginx/secrets/key.jwk", RedirectLocationName: "@login_url_default-cafe-ingress"},
+			expected: []version1.ErrorPage{
+				{Code: 404, URI: "/_ingress_error_pages/404.html"},
+			},
+			msg: "JWT with a redirect",
+		},
+	}
+
+	for _, test := range tests {
+		result := filterJWTRedirectErrorPages(errorPages, test.jwtAuth)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("filterJWTRedirectErrorPages() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateNginxCfgForUpstreamTLS(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/ssl-services"] = "tea-svc"
+	cafeIngressEx.Ingress.Annotations["nginx.org/ca"] = "cafe-ca"
+	cafeIngressEx.Ingress.Annotations["nginx.org/upstream-tls-secret"] = "cafe-client-secret"
+	cafeIngressEx.SecretRefs["cafe-ca"] = &secrets.SecretReference{
+		Secret: &v1.Secret{
+			Type: secrets.SecretTypeCA,
+		},
+		Path: "/etc/nginx/secrets/default-cafe-ca",
+	}
+	cafeIngressEx.SecretRefs["cafe-client-secret"] = &secrets.SecretReference{
+		Secret: &v1.Secret{
+			Type: v1.SecretTypeTLS,
+		},
+		Path: "/etc/nginx/secrets/default-cafe-client-secret",
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedUpstreamTLS := map[string]*version1.UpstreamTLS{
+		"/coffee": nil,
+		"/tea": {
+			TrustedCertificate: "/etc/nginx/secrets/default-cafe-ca",
+			Certificate:        "/etc/nginx/secrets/default-cafe-client-secret",
+			CertificateKey:     "/etc/nginx/secrets/default-cafe-client-secret",
+		},
+	}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	upstreamTLS := make(map[string]*version1.UpstreamTLS)
+	for _, loc := range result.Servers[0].Locations {
+		upstreamTLS[loc.Path] = loc.UpstreamTLS
+	}
+	if diff := cmp.Diff(expectedUpstreamTLS, upstreamTLS); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected upstream TLS (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("generateNginxCfg() returned unexpected warnings: %v", warnings)
+	}
+}
+
+func TestGenerateNginxCfgForUpstreamTLSWithNginxServiceMesh(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/ssl-services"] = "tea-svc"
+	cafeIngressEx.Ingress.Annotations["nginx.org/ca"] = "cafe-ca"
+	cafeIngressEx.SecretRefs["cafe-ca"] = &secrets.SecretReference{
+		Secret: &v1.Secret{
+			Type: secrets.SecretTypeCA,
+		},
+		Path: "/etc/nginx/secrets/default-cafe-ca",
+	}
+	isPlus := true
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedWarnings := []string{
+		"the nginx.org/ca and nginx.org/upstream-tls-secret annotations are ignored: the connections to the backends use the NGINX Service Mesh certificates",
+	}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{NginxServiceMesh: true}, false)
+
+	for _, loc := range result.Servers[0].Locations {
+		if loc.UpstreamTLS != nil {
+			t.Errorf("generateNginxCfg() returned upstream TLS %+v for location %s but expected nil", loc.UpstreamTLS, loc.Path)
+		}
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings[cafeIngressEx.Ingress]); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgForStaticResponses(t *testing.T) {
+	apiGroup := "k8s.nginx.org"
+	createResourceBackend := func(name string) networking.IngressBackend {
+		return networking.IngressBackend{
+			Resource: &v1.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "StaticResponse",
+				Name:     name,
+			},
+		}
+	}
+
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Spec.DefaultBackend = &networking.IngressBackend{
+		Resource: createResourceBackend("not-found").Resource,
+	}
+	cafeIngressEx.Ingress.Spec.Rules[0].HTTP.Paths = append(cafeIngressEx.Ingress.Spec.Rules[0].HTTP.Paths,
+		networking.HTTPIngressPath{
+			Path:    "/maintenance",
+			Backend: createResourceBackend("maintenance"),
+		},
+		networking.HTTPIngressPath{
+			Path:    "/old-coffee",
+			Backend: createResourceBackend("old-coffee"),
+		},
+		networking.HTTPIngressPath{
+			Path:    "/missing",
+			Backend: createResourceBackend("missing"),
+		},
+	)
+	cafeIngressEx.StaticResponses = map[string]*conf_v1alpha1.StaticResponse{
+		"not-found": {
+			Spec: conf_v1alpha1.StaticResponseSpec{
+				Return: &conf_v1alpha1.StaticResponseReturn{
+					Code: 404,
+					Body: "Not found",
+				},
+			},
+		},
+		"maintenance": {
+			Spec: conf_v1alpha1.StaticResponseSpec{
+				Return: &conf_v1alpha1.StaticResponseReturn{
+					Code: 503,
+					Type: "application/json",
+					Body: `{\"message\": \"Down for maintenance\"}`,
+				},
+			},
+		},
+		"old-coffee": {
+			Spec: conf_v1alpha1.StaticResponseSpec{
+				Redirect: &conf_v1alpha1.StaticResponseRedirect{
+					URL: "https://${host}/coffee",
+				},
+			},
+		},
+	}
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	expectedStaticResponses := map[string]*version1.StaticResponse{
+		"/coffee": nil,
+		"/tea":    nil,
+		"/maintenance": {
+			ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			Code:      503,
+			Page:      "@return_default-cafe-ingress_0",
+		},
+		"/old-coffee": {
+			ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			Code:      301,
+			Page:      "https://${host}/coffee",
+		},
+		"/missing": {
+			Invalid: true,
+		},
+		"/": {
+			ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			Code:      404,
+			Page:      "@return_default-cafe-ingress_1",
+		},
+	}
+	expectedReturnLocations := []version1.ReturnLocation{
+		{
+			Name:        "@return_default-cafe-ingress_0",
+			DefaultType: "application/json",
+			Text:        `{\"message\": \"Down for maintenance\"}`,
+		},
+		{
+			Name:        "@return_default-cafe-ingress_1",
+			DefaultType: "text/plain",
+			Text:        "Not found",
+		},
+	}
+	expectedWarnings := []string{
+		"StaticResponse default/missing referenced by the path /missing doesn't exist or is invalid",
+	}
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	staticResponses := make(map[string]*version1.StaticResponse)
+	for _, loc := range result.Servers[0].Locations {
+		staticResponses[loc.Path] = loc.StaticResponse
+	}
+	if diff := cmp.Diff(expectedStaticResponses, staticResponses); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected static responses (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedReturnLocations, result.Servers[0].ReturnLocations); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected return locations (-want +got):\n%s", diff)
+	}
+	// only the coffee and the tea services need upstreams
+	if len(result.Upstreams) != 2 {
+		t.Errorf("generateNginxCfg() returned %d upstreams but expected 2", len(result.Upstreams))
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings[cafeIngressEx.Ingress]); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgForHashSessionAffinityWithLBMethod(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/session-affinity-mode"] = "hash"
+	cafeIngressEx.Ingress.Annotations["nginx.com/sticky-cookie-services"] = "serviceName=coffee-svc srv_id"
+	cafeIngressEx.Ingress.Annotations["nginx.org/lb-method"] = "least_conn"
+	isPlus := false
+	configParams := NewDefaultConfigParams(isPlus)
+
+	result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+	expectedLBMethods := map[string]string{
+		"default-cafe-ingress-cafe.example.com-coffee-svc-80": "hash $ing_default_cafe_ingress_session_0 consistent",
+		"default-cafe-ingress-cafe.example.com-tea-svc-80":    "least_conn",
+	}
+	lbMethods := make(map[string]string)
+	for _, ups := range result.Upstreams {
+		lbMethods[ups.Name] = ups.LBMethod
+	}
+	if diff := cmp.Diff(expectedLBMethods, lbMethods); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected load balancing methods (-want +got):\n%s", diff)
+	}
+
+	expectedWarnings := []string{
+		"the nginx.org/lb-method annotation is ignored for the services of the nginx.com/sticky-cookie-services annotation: " +
+			"the hash session affinity mode sets the load balancing method",
+	}
+	if diff := cmp.Diff(expectedWarnings, warnings[cafeIngressEx.Ingress]); diff != "" {
+		t.Errorf("generateNginxCfg() returned unexpected warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateNginxCfgForHashSessionAffinity(t *testing.T) {
+	cafeIngressEx := createCafeIngressEx()
+	cafeIngressEx.Ingress.Annotations["nginx.org/session-affinity-mode"] = "hash"
+	cafeIngressEx.Ingress.Annotations["nginx.com/sticky-cookie-services"] = "serviceName=coffee-svc srv_id expires=1h httponly;serviceName=tea-svc client-ip"
+
+	for _, isPlus := range []bool{false, true} {
+		configParams := NewDefaultConfigParams(isPlus)
+
+		expectedLBMethods := map[string]string{
+			"default-cafe-ingress-cafe.example.com-coffee-svc-80": "hash $ing_default_cafe_ingress_session_0 consistent",
+			"default-cafe-ingress-cafe.example.com-tea-svc-80":    "hash $remote_addr consistent",
+		}
+		expectedSessionCookieVariables := map[string]string{
+			"/coffee": "$ing_default_cafe_ingress_session_0_cookie",
+			"/tea":    "",
+		}
+		expectedMaps := []version1.Map{
+			{
+				Source:   "$cookie_srv_id",
+				Variable: "$ing_default_cafe_ingress_session_0",
+				Parameters: []version1.Parameter{
+					{Value: `""`, Result: "$request_id"},
+					{Value: "default", Result: "$cookie_srv_id"},
+				},
+			},
+			{
+				Source:   "$cookie_srv_id",
+				Variable: "$ing_default_cafe_ingress_session_0_cookie",
+				Parameters: []version1.Parameter{
+					{Value: `""`, Result: `"srv_id=$ing_default_cafe_ingress_session_0; Path=/; Max-Age=3600; HttpOnly"`},
+					{Value: "default", Result: `""`},
+				},
+			},
+		}
+
+		result, warnings := generateNginxCfg(&cafeIngressEx, nil, nil, false, configParams, isPlus, false, &StaticConfigParams{}, false)
+
+		lbMethods := make(map[string]string)
+		for _, ups := range result.Upstreams {
+			lbMethods[ups.Name] = ups.LBMethod
+			if ups.StickyCookie != "" {
+				t.Errorf("generateNginxCfg() returned the sticky cookie %q for the upstream %s (isPlus %v)", ups.StickyCookie, ups.Name, isPlus)
+			}
+		}
+		if diff := cmp.Diff(expectedLBMethods, lbMethods); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected load balancing methods (isPlus %v) (-want +got):\n%s", isPlus, diff)
+		}
+
+		sessionCookieVariables := make(map[string]string)
+		for _, loc := range result.Servers[0].Locations {
+			sessionCookieVariables[loc.Path] = loc.SessionCookieVariable
+		}
+		if diff := cmp.Diff(expectedSessionCookieVariables, sessionCookieVariables); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected session cookie variables (isPlus %v) (-want +got):\n%s", isPlus, diff)
+		}
+		if diff := cmp.Diff(expectedMaps, result.Maps); diff != "" {
+			t.Errorf("generateNginxCfg() returned unexpected maps (isPlus %v) (-want +got):\n%s", isPlus, diff)
+		}
+		if len(warnings) != 0 {
+			t.Errorf("generateNginxCfg() returned warnings: %v", warnings)
+		}
+	}
+}
+
+func TestGenerateNginxCfgForMergeableIngressesWithHashSessionAffinity(t *testing.T) {
+	mergeableIngresses := createMergeableCafeIngress()
+	for _, minion := range mergeableIngresses.Minions {
+		minion.Ingress.Annotations["nginx.org/session-affinity-mode"] = "hash"
+		minion.Ingress.Annotations["nginx.com/sticky-cookie-services"] = "serviceName=coffee-svc srv_id;serviceName=tea-svc srv_id"
+	}
+
+	configParams := NewDefaultConfigParams(false)
+
+	result, _ := generateNginxCfgForMergeableIngresses(mergeableIngresses, nil, nil, configParams, false, false, &StaticConfigParams{}, false)
+
+	// each minion has an upstream with the session cookie, which requires two maps
+	if len(result.Maps) != 4 {
+		t.Errorf("generateNginxCfgForMergeableIngresses() returned %d maps but expected 4", len(result.Maps))
+	}
+	for _, loc := range result.Servers[0].Locations {
+		if loc.SessionCookieVariable == "" {
+			t.Errorf("generateNginxCfgForMergeableIngresses() returned no session cookie variable for the location %s", loc.Path)
+		}
+	}
+}
+
+func createCafeCanaryIngressEx() *IngressEx {
+	canaryIngress := networking.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-ingress-canary",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"kubernetes.io/ingress.class": "nginx",
+				"nginx.org/canary":            "true",
+				"nginx.org/canary-weight":     "20",
+				"nginx.org/canary-by-header":  "X-Canary",
+			},
+		},
+		Spec: networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "cafe.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path: "/coffee",
+									Backend: networking.IngressBackend{
+										Service: &networking.IngressServiceBackend{
+											Name: "coffee-v2-svc",
+											Port: networking.ServiceBackendPort{
+												Number: 80,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &IngressEx{
+		Ingress: &canaryIngress,
+		Endpoints: map[string][]string{
+			"coffee-v2-svc80": {"10.0.0.3:80"},
+		},
+		ExternalNameSvcs: map[string]bool{},
+		ValidHosts: map[string]bool{
+			"cafe.example.com": true,
+		},
+		ValidMinionPaths: map[string]bool{
+			"/coffee": true,
+		},
+	}
+}
+
+func TestGenerateCanaryRouting(t *testing.T) {
+	primary := "default-cafe-coffee-80"
+	canary := "default-cafe-canary-coffee-v2-80"
+	variable := "$ing_default_cafe_canary_0"
+
+	tests := []struct {
+		cfgParams            *ConfigParams
+		expectedSplitClients []version1.SplitClient
+		expectedMaps         []version1.Map
+		expectedVariable     string
+		msg                  string
+	}{
+		{
+			cfgParams:        &ConfigParams{},
+			expectedVariable: primary,
+			msg:              "no routing to the canary",
+		},
+		{
+			cfgParams:        &ConfigParams{CanaryWeight: 100},
+			expectedVariable: canary,
+			msg:              "all requests to the canary",
+		},
+		{
+			cfgParams: &ConfigParams{CanaryWeight: 10},
+			expectedSplitClients: []version1.SplitClient{
+				{
+					Source:   "$request_id",
+					Variable: "$ing_default_cafe_canary_0_weight",
+					Distributions: []version1.Distribution{
+						{Weight: "10%", Value: canary},
+						{Weight: "*", Value: primary},
+					},
+				},
+			},
+			expectedVariable: "$ing_default_cafe_canary_0_weight",
+			msg:              "weight",
+		},
+		{
+			cfgParams: &ConfigParams{CanaryByCookie: "canary", CanaryByHeader: "X-Canary"},
+			expectedMaps: []version1.Map{
+				{
+					Source:   "$cookie_canary",
+					Variable: "$ing_default_cafe_canary_0_cookie",
+					Parameters: []version1.Parameter{
+						{Value: "always", Result: canary},
+						{Value: "never", Result: primary},
+						{Value: "default", Result: primary},
+					},
+				},
+				{
+					Source:   "$http_x_canary",
+					Variable: "$ing_default_cafe_canary_0_header",
+					Parameters: []version1.Parameter{
+						{Value: "always", Result: canary},
+						{Value: "never", Result: primary},
+						{Value: "default", Result: "$ing_default_cafe_canary_0_cookie"},
+					},
+				},
+			},
+			expectedVariable: "$ing_default_cafe_canary_0_header",
+			msg:              "header and cookie",
+		},
+	}
+
+	for _, test := range tests {
+		splitClients, maps, result := generateCanaryRouting(variable, primary, canary, test.cfgParams)
+		if diff := cmp.Diff(test.expectedSplitClients, splitClients); diff != "" {
+			t.Errorf("generateCanaryRouting() returned unexpected split clients for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedMaps, maps); diff != "" {
+			t.Errorf("generateCanaryRouting() returned unexpected maps for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if result != test.expectedVariable {
+			t.Errorf("generateCanaryRouting() returned %q but expected %q for the case of %s", result, test.expectedVariable, test.msg)
+		}
+	}
+}
+
 func TestGenerateNginxCfgForInternalRoute(t *testing.T) {
 	internalRouteAnnotation := "nsm.nginx.com/internal-route"
 	cafeIngressEx := createCafeIngressEx()
@@ -1312,6 +2226,139 @@ func TestGenerateJWTConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateUpstreamTLS(t *testing.T) {
+	tests := []struct {
+		secretRefs          map[string]*secrets.SecretReference
+		cfgParams           *ConfigParams
+		expectedUpstreamTLS *version1.UpstreamTLS
+		expectedWarnings    Warnings
+		msg                 string
+	}{
+		{
+			secretRefs:          map[string]*secrets.SecretReference{},
+			cfgParams:           &ConfigParams{},
+			expectedUpstreamTLS: nil,
+			expectedWarnings:    Warnings{},
+			msg:                 "no secrets",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{
+				"cafe-ca": {
+					Secret: &v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-cafe-ca",
+				},
+			},
+			cfgParams: &ConfigParams{
+				UpstreamCASecret: "cafe-ca",
+			},
+			expectedUpstreamTLS: &version1.UpstreamTLS{
+				TrustedCertificate: "/etc/nginx/secrets/default-cafe-ca",
+			},
+			expectedWarnings: Warnings{},
+			msg:              "CA secret",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{
+				"cafe-ca": {
+					Secret: &v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-cafe-ca",
+				},
+				"cafe-client-secret": {
+					Secret: &v1.Secret{
+						Type: v1.SecretTypeTLS,
+					},
+					Path: "/etc/nginx/secrets/default-cafe-client-secret",
+				},
+			},
+			cfgParams: &ConfigParams{
+				UpstreamCASecret:  "cafe-ca",
+				UpstreamTLSSecret: "cafe-client-secret",
+			},
+			expectedUpstreamTLS: &version1.UpstreamTLS{
+				TrustedCertificate: "/etc/nginx/secrets/default-cafe-ca",
+				Certificate:        "/etc/nginx/secrets/default-cafe-client-secret",
+				CertificateKey:     "/etc/nginx/secrets/default-cafe-client-secret",
+			},
+			expectedWarnings: Warnings{},
+			msg:              "CA and TLS secrets",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{
+				"cafe-ca": {
+					Secret: &v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Error: errors.New("CA secret must have the data field ca.crt"),
+				},
+			},
+			cfgParams: &ConfigParams{
+				UpstreamCASecret: "cafe-ca",
+			},
+			expectedUpstreamTLS: &version1.UpstreamTLS{
+				Invalid: true,
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"CA secret cafe-ca is invalid: CA secret must have the data field ca.crt",
+				},
+			},
+			msg: "invalid CA secret",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{
+				"cafe-client-secret": {
+					Secret: &v1.Secret{
+						Type: secrets.SecretTypeCA,
+					},
+					Path: "/etc/nginx/secrets/default-cafe-client-secret",
+				},
+			},
+			cfgParams: &ConfigParams{
+				UpstreamTLSSecret: "cafe-client-secret",
+			},
+			expectedUpstreamTLS: &version1.UpstreamTLS{
+				Invalid: true,
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"TLS secret cafe-client-secret is of a wrong type 'nginx.org/ca', must be 'kubernetes.io/tls'",
+				},
+			},
+			msg: "TLS secret of wrong type",
+		},
+		{
+			secretRefs: map[string]*secrets.SecretReference{},
+			cfgParams: &ConfigParams{
+				UpstreamCASecret: "cafe-ca",
+			},
+			expectedUpstreamTLS: &version1.UpstreamTLS{
+				Invalid: true,
+			},
+			expectedWarnings: Warnings{
+				nil: {
+					"CA secret cafe-ca doesn't exist",
+				},
+			},
+			msg: "missing secret reference",
+		},
+	}
+
+	for _, test := range tests {
+		upstreamTLS, warnings := generateUpstreamTLS(nil, test.secretRefs, test.cfgParams)
+
+		if diff := cmp.Diff(test.expectedUpstreamTLS, upstreamTLS); diff != "" {
+			t.Errorf("generateUpstreamTLS() '%s' mismatch (-want +got):\n%s", test.msg, diff)
+		}
+		if !reflect.DeepEqual(test.expectedWarnings, warnings) {
+			t.Errorf("generateUpstreamTLS() returned %v but expected %v for the case of %s", warnings, test.expectedWarnings, test.msg)
+		}
+	}
+}
+
 func TestGenerateNginxCfgForAppProtect(t *testing.T) {
 	cafeIngressEx := createCafeIngressEx()
 	cafeIngressEx.Ingress.Annotations["appprotect.f5.com/app-protect-enable"] = "True"
@@ -0,0 +1,18 @@
+/*
+ * Functions that normalize the URIs of requests.
+ * They are used by the redirects of VirtualServers.
+ */
+
+// lowercaseURI returns the path of the request URI in lowercase, without the query string.
+function lowercaseURI(r) {
+    var uri = r.variables.request_uri;
+    var i = uri.indexOf('?');
+
+    if (i >= 0) {
+        uri = uri.substring(0, i);
+    }
+
+    return uri.toLowerCase();
+}
+
+export default {lowercaseURI};
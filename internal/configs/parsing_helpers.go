@@ -1,12 +1,15 @@
 package configs
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -205,6 +208,32 @@ func ParseTime(s string) (string, error) {
 	return fmt.Sprintf("%s%s%s%s%s%s%s%s", years, months, weeks, days, hours, mins, secs, millis), nil
 }
 
+// timeUnitSeconds is the number of seconds in each unit of timeRegexp, except milliseconds.
+var timeUnitSeconds = []int64{365 * 24 * 3600, 30 * 24 * 3600, 7 * 24 * 3600, 24 * 3600, 3600, 60, 1}
+
+// ParseTimeToSeconds converts a valid time string into seconds. Milliseconds are ignored.
+func ParseTimeToSeconds(s string) (int64, error) {
+	if s == "" || strings.TrimSpace(s) == "" || !timeRegexp.MatchString(s) {
+		return 0, errors.New("invalid time string")
+	}
+	units := timeRegexp.FindStringSubmatch(s)
+
+	var seconds int64
+	for i, unitSeconds := range timeUnitSeconds {
+		value := strings.TrimRight(units[i+1], "yMwdhms")
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		seconds += n * unitSeconds
+	}
+
+	return seconds, nil
+}
+
 // OffsetFmt http://nginx.org/en/docs/syntax.html
 const OffsetFmt = `\d+[kKmMgG]?`
 
@@ -296,6 +325,27 @@ func ParseRewriteList(s string) (map[string]string, error) {
 	return rewrites, nil
 }
 
+// ParsePathOverrides parses a JSON object that maps paths of an Ingress to the settings that override
+// the Ingress-wide annotations for those paths, for example {"/coffee": {"proxy-read-timeout": "120s"}}.
+func ParsePathOverrides(s string) (map[string]map[string]string, error) {
+	var overrides map[string]map[string]string
+	if err := json.Unmarshal([]byte(s), &overrides); err != nil {
+		return nil, fmt.Errorf("invalid path overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// ParseIPList parses a comma-separated list of IP addresses and CIDRs, ignoring the whitespace around the items.
+func ParseIPList(s string) []string {
+	var ips []string
+	for _, part := range strings.Split(s, ",") {
+		if ip := strings.TrimSpace(part); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
 // ParseStickyServiceList ensures that the string is a semicolon-separated list of sticky services
 func ParseStickyServiceList(s string) (map[string]string, error) {
 	services := make(map[string]string)
@@ -324,6 +374,80 @@ func parseStickyService(service string) (serviceName string, stickyCookie string
 	return svcNameParts[1], parts[1], nil
 }
 
+var (
+	sessionCookieNameRegexp   = regexp.MustCompile(`^[_A-Za-z0-9]+$`)
+	sessionHeaderNameRegexp   = regexp.MustCompile(`^[-A-Za-z0-9]+$`)
+	sessionCookieDomainRegexp = regexp.MustCompile(`^\.?[-A-Za-z0-9]+(\.[-A-Za-z0-9]+)*$`)
+	sessionCookiePathRegexp   = regexp.MustCompile(`^/[^\s"\\;]*$`)
+)
+
+// ParseHashSessionCookie parses the session persistence parameters of a service of the sticky-cookie-services annotation
+// in the hash session affinity mode. The parameters are either "client-ip", "header=<name>" or a cookie name
+// followed by the optional expires, domain, path, httponly and secure parameters of the sticky cookie of NGINX Plus.
+func ParseHashSessionCookie(s string) (*conf_v1.SessionCookie, error) {
+	params := strings.Fields(s)
+	if len(params) == 0 {
+		return nil, errors.New("invalid session persistence: must not be empty")
+	}
+
+	sc := &conf_v1.SessionCookie{Enable: true, Mode: "hash"}
+
+	if params[0] == "client-ip" || strings.HasPrefix(params[0], "header=") {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("invalid session persistence %q: %s doesn't support parameters", s, params[0])
+		}
+		if params[0] == "client-ip" {
+			sc.ClientIP = true
+			return sc, nil
+		}
+		sc.Header = strings.TrimPrefix(params[0], "header=")
+		if !sessionHeaderNameRegexp.MatchString(sc.Header) {
+			return nil, fmt.Errorf("invalid session persistence header %q", sc.Header)
+		}
+		return sc, nil
+	}
+
+	sc.Name = params[0]
+	if !sessionCookieNameRegexp.MatchString(sc.Name) {
+		return nil, fmt.Errorf("invalid session persistence cookie name %q", sc.Name)
+	}
+
+	for _, param := range params[1:] {
+		name, value, _ := strings.Cut(param, "=")
+		switch name {
+		case "expires":
+			if value != "max" {
+				if _, err := ParseTime(value); err != nil {
+					return nil, fmt.Errorf("invalid session persistence cookie expires %q", value)
+				}
+				// the expires becomes the Max-Age of the cookie in seconds, and Max-Age=0 deletes the cookie
+				if seconds, err := ParseTimeToSeconds(value); err != nil || seconds < 1 {
+					return nil, fmt.Errorf("invalid session persistence cookie expires %q: must be at least 1s", value)
+				}
+			}
+			sc.Expires = value
+		case "domain":
+			if !sessionCookieDomainRegexp.MatchString(value) {
+				return nil, fmt.Errorf("invalid session persistence cookie domain %q", value)
+			}
+			sc.Domain = value
+		case "path":
+			if !sessionCookiePathRegexp.MatchString(value) {
+				return nil, fmt.Errorf("invalid session persistence cookie path %q", value)
+			}
+			sc.Path = value
+		case "httponly":
+			sc.HTTPOnly = true
+		case "secure":
+			sc.Secure = true
+		default:
+			return nil, fmt.Errorf("invalid session persistence cookie parameter %q", param)
+		}
+	}
+
+	return sc, nil
+}
+
 func parseRewrites(service string) (serviceName string, rewrite string, err error) {
 	parts := strings.SplitN(strings.TrimSpace(service), " ", 2)
 
@@ -353,3 +477,30 @@ var (
 func VerifyAppProtectThresholds(value string) bool {
 	return threshEx.MatchString(value) || threshExR.MatchString(value)
 }
+
+// ParseOtelSamplerRatio ensures that the string value is a valid ratio of sampled requests between 0 and 1.
+// It returns the ratio as a percentage rounded to the precision supported by the split_clients directive.
+func ParseOtelSamplerRatio(s string) (float64, error) {
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 0, errors.New("invalid sampler ratio: must be a number between 0 and 1")
+	}
+
+	return math.Round(ratio*10000) / 100, nil
+}
+
+var otelTraceContexts = map[string]bool{
+	"extract":   true,
+	"inject":    true,
+	"propagate": true,
+	"ignore":    true,
+}
+
+// ParseOtelTraceContext ensures that the string value is a valid method of propagating the OpenTelemetry trace context.
+func ParseOtelTraceContext(s string) (string, error) {
+	if !otelTraceContexts[s] {
+		return "", fmt.Errorf("invalid trace context: %q, must be one of extract, inject, propagate or ignore", s)
+	}
+
+	return s, nil
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -402,6 +403,108 @@ func TestParseTime(t *testing.T) {
 	}
 }
 
+func TestParseTimeToSeconds(t *testing.T) {
+	testsWithValidInput := []struct {
+		input    string
+		expected int64
+	}{
+		{"1h30m 5 100ms", 5405},
+		{"10ms", 0},
+		{"1", 1},
+		{"5m 30s", 330},
+		{"2w", 1209600},
+		{"1M", 2592000},
+		{"1y 1d", 31622400},
+	}
+	invalidInput := []string{"5s 5s", "ss", "-5s", "", "1L", " "}
+
+	for _, test := range testsWithValidInput {
+		result, err := ParseTimeToSeconds(test.input)
+		if err != nil {
+			t.Errorf("ParseTimeToSeconds(%q) returned an error for valid input", test.input)
+		}
+
+		if result != test.expected {
+			t.Errorf("ParseTimeToSeconds(%q) returned %d expected %d", test.input, result, test.expected)
+		}
+	}
+
+	for _, test := range invalidInput {
+		result, err := ParseTimeToSeconds(test)
+		if err == nil {
+			t.Errorf("ParseTimeToSeconds(%q) didn't return error. Returned: %d", test, result)
+		}
+	}
+}
+
+func TestParseHashSessionCookie(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *conf_v1.SessionCookie
+	}{
+		{
+			input:    "srv_id",
+			expected: &conf_v1.SessionCookie{Enable: true, Mode: "hash", Name: "srv_id"},
+		},
+		{
+			input: "srv_id expires=1h domain=.example.com path=/tea httponly secure",
+			expected: &conf_v1.SessionCookie{
+				Enable:   true,
+				Mode:     "hash",
+				Name:     "srv_id",
+				Expires:  "1h",
+				Domain:   ".example.com",
+				Path:     "/tea",
+				HTTPOnly: true,
+				Secure:   true,
+			},
+		},
+		{
+			input:    "srv_id expires=max",
+			expected: &conf_v1.SessionCookie{Enable: true, Mode: "hash", Name: "srv_id", Expires: "max"},
+		},
+		{
+			input:    "header=X-Session-ID",
+			expected: &conf_v1.SessionCookie{Enable: true, Mode: "hash", Header: "X-Session-ID"},
+		},
+		{
+			input:    "client-ip",
+			expected: &conf_v1.SessionCookie{Enable: true, Mode: "hash", ClientIP: true},
+		},
+	}
+	invalidInput := []string{
+		"",
+		"srv-id",
+		"srv_id expires=1x",
+		"srv_id expires=500ms",
+		"srv_id expires=0",
+		"srv_id domain=example.com;",
+		`srv_id path=/tea"`,
+		"srv_id path=tea",
+		"srv_id samesite=lax",
+		"header=X_Session",
+		"header=X-Session-ID secure",
+		"client-ip srv_id",
+	}
+
+	for _, test := range tests {
+		result, err := ParseHashSessionCookie(test.input)
+		if err != nil {
+			t.Errorf("ParseHashSessionCookie(%q) returned an error for valid input: %v", test.input, err)
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ParseHashSessionCookie(%q) returned %v expected %v", test.input, result, test.expected)
+		}
+	}
+
+	for _, input := range invalidInput {
+		result, err := ParseHashSessionCookie(input)
+		if err == nil {
+			t.Errorf("ParseHashSessionCookie(%q) didn't return error. Returned: %v", input, result)
+		}
+	}
+}
+
 func TestParseOffset(t *testing.T) {
 	testsWithValidInput := []string{"1", "2k", "2K", "3m", "3M", "4g", "4G"}
 	invalidInput := []string{"-1", "", "blah"}
@@ -644,3 +747,78 @@ func TestParseUint64(t *testing.T) {
 		}
 	}
 }
+
+func TestParseOtelSamplerRatio(t *testing.T) {
+	testsWithValidInput := map[string]float64{
+		"0":       0,
+		"0.1":     10,
+		"0.12345": 12.35,
+		"1":       100,
+		" 0.5 ":   50,
+	}
+	invalidInput := []string{"", "-0.1", "1.1", "blah", "10%"}
+	for input, expected := range testsWithValidInput {
+		result, err := ParseOtelSamplerRatio(input)
+		if err != nil {
+			t.Errorf("ParseOtelSamplerRatio(%q) returned an error for valid input", input)
+		}
+		if result != expected {
+			t.Errorf("ParseOtelSamplerRatio(%q) returned %v expected %v", input, result, expected)
+		}
+	}
+	for _, input := range invalidInput {
+		result, err := ParseOtelSamplerRatio(input)
+		if err == nil {
+			t.Errorf("ParseOtelSamplerRatio(%q) didn't return error. Returned: %v", input, result)
+		}
+	}
+}
+
+func TestParseOtelTraceContext(t *testing.T) {
+	testsWithValidInput := []string{"extract", "inject", "propagate", "ignore"}
+	invalidInput := []string{"", "on", "Propagate"}
+	for _, test := range testsWithValidInput {
+		result, err := ParseOtelTraceContext(test)
+		if err != nil {
+			t.Errorf("ParseOtelTraceContext(%q) returned an error for valid input", test)
+		}
+		if test != result {
+			t.Errorf("ParseOtelTraceContext(%q) returned %q expected %q", test, result, test)
+		}
+	}
+	for _, test := range invalidInput {
+		result, err := ParseOtelTraceContext(test)
+		if err == nil {
+			t.Errorf("ParseOtelTraceContext(%q) didn't return error. Returned: %q", test, result)
+		}
+	}
+}
+
+func TestParsePathOverrides(t *testing.T) {
+	input := `{"/coffee": {"proxy-read-timeout": "120s", "rewrite": "/beans/"}, "/tea": {"proxy-buffering": "false"}}`
+	expected := map[string]map[string]string{
+		"/coffee": {
+			"proxy-read-timeout": "120s",
+			"rewrite":            "/beans/",
+		},
+		"/tea": {
+			"proxy-buffering": "false",
+		},
+	}
+
+	result, err := ParsePathOverrides(input)
+	if err != nil {
+		t.Errorf("ParsePathOverrides(%q) returned an error for valid input: %v", input, err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParsePathOverrides(%q) returned %v expected %v", input, result, expected)
+	}
+
+	invalidInput := []string{"", "/coffee", `{"/coffee": "120s"}`, `{"/coffee": {"proxy-buffering": false}}`}
+	for _, input := range invalidInput {
+		result, err := ParsePathOverrides(input)
+		if err == nil {
+			t.Errorf("ParsePathOverrides(%q) didn't return error. Returned: %v", input, result)
+		}
+	}
+}
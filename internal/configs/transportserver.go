@@ -2,9 +2,11 @@ package configs
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 )
 
@@ -12,10 +14,12 @@ const nginxNonExistingUnixSocket = "unix:/var/lib/nginx/non-existing-unix-socket
 
 // TransportServerEx holds a TransportServer along with the resources referenced by it.
 type TransportServerEx struct {
-	ListenerPort    int
-	TransportServer *conf_v1alpha1.TransportServer
-	Endpoints       map[string][]string
-	PodsByIP        map[string]string
+	ListenerPort     int
+	TransportServer  *conf_v1alpha1.TransportServer
+	Endpoints        map[string][]string
+	ExternalNameSvcs map[string]bool
+	LocalEndpoints   map[string]bool
+	PodsByIP         map[string]string
 }
 
 func (tsEx *TransportServerEx) String() string {
@@ -31,10 +35,28 @@ func (tsEx *TransportServerEx) String() string {
 }
 
 // generateTransportServerConfig generates a full configuration for a TransportServer.
-func generateTransportServerConfig(transportServerEx *TransportServerEx, listenerPort int, isPlus bool) *version2.TransportServerConfig {
+func generateTransportServerConfig(transportServerEx *TransportServerEx, listenerPort int, isPlus bool, isResolverConfigured bool) *version2.TransportServerConfig {
 	upstreamNamer := newUpstreamNamerForTransportServer(transportServerEx.TransportServer)
 
-	upstreams := generateStreamUpstreams(transportServerEx, upstreamNamer, isPlus)
+	upstreams, runtimeResolvedAddresses := generateStreamUpstreams(transportServerEx, upstreamNamer, isPlus, isResolverConfigured)
+
+	// NGINX resolves the address of the upstream at runtime when proxy_pass uses a variable
+	proxyPass := upstreamNamer.GetNameForUpstream(transportServerEx.TransportServer.Spec.Action.Pass)
+	var maps []version2.Map
+	if address, ok := runtimeResolvedAddresses[proxyPass]; ok {
+		variable := generateUpstreamAddressVariable(proxyPass)
+		maps = append(maps, version2.Map{
+			Source:   "$remote_addr",
+			Variable: variable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  "default",
+					Result: fmt.Sprintf("%q", address),
+				},
+			},
+		})
+		proxyPass = variable
+	}
 
 	healthCheck, match := generateTransportServerHealthCheck(transportServerEx.TransportServer.Spec.Action.Pass,
 		upstreamNamer.GetNameForUpstream(transportServerEx.TransportServer.Spec.Action.Pass),
@@ -80,7 +102,7 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 			StatusZone:               statusZone,
 			ProxyRequests:            proxyRequests,
 			ProxyResponses:           proxyResponses,
-			ProxyPass:                upstreamNamer.GetNameForUpstream(transportServerEx.TransportServer.Spec.Action.Pass),
+			ProxyPass:                proxyPass,
 			Name:                     transportServerEx.TransportServer.Name,
 			Namespace:                transportServerEx.TransportServer.Namespace,
 			ProxyConnectTimeout:      generateTimeWithDefault(connectTimeout, "60s"),
@@ -93,6 +115,7 @@ func generateTransportServerConfig(transportServerEx *TransportServerEx, listene
 		},
 		Match:          match,
 		Upstreams:      upstreams,
+		Maps:           maps,
 		StreamSnippets: streamSnippets,
 	}
 
@@ -107,17 +130,31 @@ func generateUnixSocket(transportServerEx *TransportServerEx) string {
 	return ""
 }
 
-func generateStreamUpstreams(transportServerEx *TransportServerEx, upstreamNamer *upstreamNamer, isPlus bool) []version2.StreamUpstream {
+// generateStreamUpstreams generates the upstreams of a TransportServer along with the addresses
+// that NGINX resolves at runtime instead of using the servers of the upstreams.
+func generateStreamUpstreams(
+	transportServerEx *TransportServerEx,
+	upstreamNamer *upstreamNamer,
+	isPlus bool,
+	isResolverConfigured bool,
+) ([]version2.StreamUpstream, map[string]string) {
 	var upstreams []version2.StreamUpstream
+	runtimeResolvedAddresses := make(map[string]string)
 
 	for _, u := range transportServerEx.TransportServer.Spec.Upstreams {
-
-		// subselector is not supported yet in TransportServer upstreams. That's why we pass "nil" here
-		endpointsKey := GenerateEndpointsKey(transportServerEx.TransportServer.Namespace, u.Service, nil, uint16(u.Port))
-		endpoints := transportServerEx.Endpoints[endpointsKey]
+		endpoints := generateEndpointsForStreamUpstream(transportServerEx, u, isResolverConfigured)
 
 		ups := generateStreamUpstream(u, upstreamNamer, endpoints, isPlus)
 
+		isExternalNameSvc := transportServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(transportServerEx.TransportServer.Namespace, u.Service)]
+		ups.Resolve = isExternalNameSvc || hasDNSNameAddress(u.Addresses)
+
+		// NGINX can't resolve the servers of an upstream
+		if !isPlus && ups.Resolve && len(endpoints) == 1 {
+			runtimeResolvedAddresses[ups.Name] = endpoints[0]
+			ups.Servers = generateStreamUpstream(u, upstreamNamer, nil, isPlus).Servers
+		}
+
 		ups.UpstreamLabels.Service = u.Service
 		ups.UpstreamLabels.ResourceType = "transportserver"
 		ups.UpstreamLabels.ResourceName = transportServerEx.TransportServer.Name
@@ -126,7 +163,48 @@ func generateStreamUpstreams(transportServerEx *TransportServerEx, upstreamNamer
 		upstreams = append(upstreams, ups)
 	}
 
-	return upstreams
+	return upstreams, runtimeResolvedAddresses
+}
+
+// generateEndpointsForStreamUpstream generates the endpoints of an upstream.
+// DNS names are ignored when no resolver is configured.
+func generateEndpointsForStreamUpstream(transportServerEx *TransportServerEx, upstream conf_v1alpha1.Upstream, isResolverConfigured bool) []string {
+	l := nl.WithResource(slog.Default(), "TransportServer", transportServerEx.TransportServer.Namespace, transportServerEx.TransportServer.Name)
+
+	if len(upstream.Addresses) > 0 {
+		if isResolverConfigured {
+			return upstream.Addresses
+		}
+
+		var endpoints []string
+		for _, address := range upstream.Addresses {
+			if isDNSNameAddress(address) {
+				nl.Warnf(l, "Address %v in upstream %v will be ignored. To use DNS names, a resolver must be configured in the ConfigMap", address, upstream.Name)
+				continue
+			}
+			endpoints = append(endpoints, address)
+		}
+		return endpoints
+	}
+
+	if transportServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(transportServerEx.TransportServer.Namespace, upstream.Service)] && !isResolverConfigured {
+		nl.Warnf(l, "Type ExternalName service %v in upstream %v will be ignored. To use ExternalName services, a resolver must be configured in the ConfigMap", upstream.Service, upstream.Name)
+		return nil
+	}
+
+	// subselector is not supported yet in TransportServer upstreams. That's why we pass "nil" here
+	endpointsKey := GenerateEndpointsKey(transportServerEx.TransportServer.Namespace, upstream.Service, nil, uint16(upstream.Port))
+	endpoints := transportServerEx.Endpoints[endpointsKey]
+
+	if upstream.TopologyAware != nil && upstream.TopologyAware.Enable {
+		if transportServerEx.LocalEndpoints == nil {
+			nl.Warnf(l, "Topology aware routing for upstream %v will be ignored. It requires the -enable-topology-aware-routing command-line argument", upstream.Name)
+		} else {
+			endpoints = filterLocalEndpoints(endpoints, transportServerEx.LocalEndpoints, upstream.TopologyAware.MinEndpoints)
+		}
+	}
+
+	return endpoints
 }
 
 func generateTransportServerHealthCheck(upstreamName string, generatedUpstreamName string, upstreams []conf_v1alpha1.Upstream) (*version2.StreamHealthCheck, *version2.Match) {
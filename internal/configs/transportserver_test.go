@@ -134,7 +134,7 @@ func TestGenerateTransportServerConfigForTCPSnippets(t *testing.T) {
 		StreamSnippets: []string{"limit_conn_zone $binary_remote_addr zone=addr:10m;"},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, true, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
@@ -220,7 +220,7 @@ func TestGenerateTransportServerConfigForTCP(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, true, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
@@ -308,7 +308,7 @@ func TestGenerateTransportServerConfigForTCPMaxConnections(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, true, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
@@ -394,7 +394,7 @@ func TestGenerateTransportServerConfigForTLSPasstrhough(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, true, false)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
@@ -485,7 +485,181 @@ func TestGenerateTransportServerConfigForUDP(t *testing.T) {
 		StreamSnippets: []string{},
 	}
 
-	result := generateTransportServerConfig(&transportServerEx, listenerPort, true)
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, true, false)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateTransportServerConfigForExternalNameService(t *testing.T) {
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1alpha1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.TransportServerSpec{
+				Listener: conf_v1alpha1.TransportServerListener{
+					Name:     "tcp-listener",
+					Protocol: "TCP",
+				},
+				Upstreams: []conf_v1alpha1.Upstream{
+					{
+						Name:    "tcp-app",
+						Service: "tcp-app-svc",
+						Port:    5001,
+					},
+				},
+				Action: &conf_v1alpha1.Action{
+					Pass: "tcp-app",
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tcp-app-svc:5001": {
+				"tcp-app.example.com:5001",
+			},
+		},
+		ExternalNameSvcs: map[string]bool{
+			"default/tcp-app-svc": true,
+		},
+	}
+
+	listenerPort := 2020
+
+	expected := &version2.TransportServerConfig{
+		Upstreams: []version2.StreamUpstream{
+			{
+				Name: "ts_default_tcp-server_tcp-app",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:     nginxNonExistingUnixSocket,
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				UpstreamLabels: version2.UpstreamLabels{
+					ResourceName:      "tcp-server",
+					ResourceType:      "transportserver",
+					ResourceNamespace: "default",
+					Service:           "tcp-app-svc",
+				},
+				LoadBalancingMethod: "random two least_conn",
+				Resolve:             true,
+			},
+		},
+		Maps: []version2.Map{
+			{
+				Source:   "$remote_addr",
+				Variable: "$ts_default_tcp_server_tcp_app_address",
+				Parameters: []version2.Parameter{
+					{
+						Value:  "default",
+						Result: `"tcp-app.example.com:5001"`,
+					},
+				},
+			},
+		},
+		Server: version2.StreamServer{
+			Port:                     listenerPort,
+			UDP:                      false,
+			StatusZone:               "tcp-listener",
+			ProxyPass:                "$ts_default_tcp_server_tcp_app_address",
+			Name:                     "tcp-server",
+			Namespace:                "default",
+			ProxyConnectTimeout:      "60s",
+			ProxyNextUpstream:        false,
+			ProxyNextUpstreamTries:   0,
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyTimeout:             "10m",
+			HealthCheck:              nil,
+			ServerSnippets:           []string{},
+		},
+		StreamSnippets: []string{},
+	}
+
+	isPlus := false
+	isResolverConfigured := true
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, isPlus, isResolverConfigured)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateTransportServerConfigForAddresses(t *testing.T) {
+	transportServerEx := TransportServerEx{
+		TransportServer: &conf_v1alpha1.TransportServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "tcp-server",
+				Namespace: "default",
+			},
+			Spec: conf_v1alpha1.TransportServerSpec{
+				Listener: conf_v1alpha1.TransportServerListener{
+					Name:     "tcp-listener",
+					Protocol: "TCP",
+				},
+				Upstreams: []conf_v1alpha1.Upstream{
+					{
+						Name:      "tcp-app",
+						Addresses: []string{"tcp-app.example.com:5001", "10.0.0.20:5001"},
+					},
+				},
+				Action: &conf_v1alpha1.Action{
+					Pass: "tcp-app",
+				},
+			},
+		},
+		Endpoints: map[string][]string{},
+	}
+
+	listenerPort := 2020
+
+	expected := &version2.TransportServerConfig{
+		Upstreams: []version2.StreamUpstream{
+			{
+				Name: "ts_default_tcp-server_tcp-app",
+				Servers: []version2.StreamUpstreamServer{
+					{
+						Address:     "tcp-app.example.com:5001",
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+					{
+						Address:     "10.0.0.20:5001",
+						MaxFails:    1,
+						FailTimeout: "10s",
+					},
+				},
+				UpstreamLabels: version2.UpstreamLabels{
+					ResourceName:      "tcp-server",
+					ResourceType:      "transportserver",
+					ResourceNamespace: "default",
+				},
+				LoadBalancingMethod: "random two least_conn",
+				Resolve:             true,
+			},
+		},
+		Server: version2.StreamServer{
+			Port:                     listenerPort,
+			UDP:                      false,
+			StatusZone:               "tcp-listener",
+			ProxyPass:                "ts_default_tcp-server_tcp-app",
+			Name:                     "tcp-server",
+			Namespace:                "default",
+			ProxyConnectTimeout:      "60s",
+			ProxyNextUpstream:        false,
+			ProxyNextUpstreamTries:   0,
+			ProxyNextUpstreamTimeout: "0s",
+			ProxyTimeout:             "10m",
+			HealthCheck:              nil,
+			ServerSnippets:           []string{},
+		},
+		StreamSnippets: []string{},
+	}
+
+	isPlus := true
+	isResolverConfigured := true
+	result := generateTransportServerConfig(&transportServerEx, listenerPort, isPlus, isResolverConfigured)
 	if diff := cmp.Diff(expected, result); diff != "" {
 		t.Errorf("generateTransportServerConfig() mismatch (-want +got):\n%s", diff)
 	}
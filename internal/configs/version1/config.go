@@ -11,12 +11,57 @@ type UpstreamLabels struct {
 // IngressNginxConfig describes an NGINX configuration.
 type IngressNginxConfig struct {
 	Upstreams         []Upstream
+	SplitClients      []SplitClient
+	Maps              []Map
+	LimitReqZones     []LimitReqZone
 	Servers           []Server
 	Keepalive         string
 	Ingress           Ingress
 	SpiffeClientCerts bool
 }
 
+// SplitClient describes a split_clients block that splits the requests between the upstreams.
+type SplitClient struct {
+	Source        string
+	Variable      string
+	Distributions []Distribution
+}
+
+// Distribution describes a distribution in a split_clients block.
+type Distribution struct {
+	Weight string
+	Value  string
+}
+
+// Map describes a map block.
+type Map struct {
+	Source     string
+	Variable   string
+	Parameters []Parameter
+}
+
+// Parameter describes a parameter in a map block.
+type Parameter struct {
+	Value  string
+	Result string
+}
+
+// LimitReqZone describes a shared memory zone for request rate limiting.
+type LimitReqZone struct {
+	Name string
+	Key  string
+	Size string
+	Rate string
+}
+
+// LimitReq describes a request rate limit of a location.
+type LimitReq struct {
+	Zone       string
+	Burst      int
+	NoDelay    bool
+	RejectCode int
+}
+
 // Ingress holds information about an Ingress resource.
 type Ingress struct {
 	Name        string
@@ -83,6 +128,7 @@ type Server struct {
 	HSTSBehindProxy       bool
 	ProxyHideHeaders      []string
 	ProxyPassHeaders      []string
+	RequestIDHeader       string
 
 	HealthChecks map[string]HealthCheck
 
@@ -93,6 +139,11 @@ type Server struct {
 	JWTAuth              *JWTAuth
 	JWTRedirectLocations []JWTRedirectLocation
 
+	ErrorPages         []ErrorPage
+	ErrorPagesLocation *ErrorPagesLocation
+
+	ReturnLocations []ReturnLocation
+
 	Ports                        []int
 	SSLPorts                     []int
 	AppProtectEnable             string
@@ -118,6 +169,37 @@ type JWTRedirectLocation struct {
 	LoginURL string
 }
 
+// ErrorPage describes a custom error page for a status code.
+type ErrorPage struct {
+	Code int
+	URI  string
+}
+
+// ErrorPagesLocation describes an internal location that serves the files of the custom error pages.
+type ErrorPagesLocation struct {
+	Path  string
+	Alias string
+}
+
+// StaticResponse describes the static response of a location.
+// The location passes the requests to the internal 418 server and replaces the 418 response with the static response,
+// so that the access control, the rate limiting and the authentication of the location are applied first.
+type StaticResponse struct {
+	ProxyPass string
+	Code      int
+	// Page is the URL of a redirect or the name of the ReturnLocation that returns the response.
+	Page string
+	// Invalid means that the referenced StaticResponse doesn't exist or is invalid, so requests to the location are rejected.
+	Invalid bool
+}
+
+// ReturnLocation describes a named location that returns a fixed response.
+type ReturnLocation struct {
+	Name        string
+	DefaultType string
+	Text        string
+}
+
 // JWTAuth holds JWT authentication configuration.
 type JWTAuth struct {
 	Key                  string
@@ -126,6 +208,15 @@ type JWTAuth struct {
 	RedirectLocationName string
 }
 
+// UpstreamTLS holds the TLS configuration for the connections to the backends of a location.
+type UpstreamTLS struct {
+	TrustedCertificate string
+	Certificate        string
+	CertificateKey     string
+	// Invalid means that a referenced Secret is invalid, so requests to the location are rejected.
+	Invalid bool
+}
+
 // Location describes an NGINX location.
 type Location struct {
 	LocationSnippets     []string
@@ -144,8 +235,21 @@ type Location struct {
 	ProxyBufferSize      string
 	ProxyMaxTempFileSize string
 	ProxySSLName         string
+	UpstreamTLS          *UpstreamTLS
+	StaticResponse       *StaticResponse
 	JWTAuth              *JWTAuth
 	ServiceName          string
+	LimitReq             *LimitReq
+	Allow                []string
+	Deny                 []string
+	// UpstreamVariable holds the name of the upstream chosen for the request. It is set when the location has a canary.
+	UpstreamVariable string
+	// SessionCookieVariable holds the Set-Cookie header of the hash session persistence, which is empty
+	// when the request already has the session cookie.
+	SessionCookieVariable string
+	// ErrorPages holds the custom error pages of the server for a location that sets its own error_page directives
+	// and therefore doesn't inherit them from the server.
+	ErrorPages []ErrorPage
 
 	MinionIngress *Ingress
 }
@@ -155,6 +259,7 @@ type MainConfig struct {
 	AccessLogOff                       bool
 	DefaultServerAccessLogOff          bool
 	DefaultServerReturn                string
+	DynamicSSLReload                   bool
 	ErrorLogLevel                      string
 	HealthStatus                       bool
 	HealthStatusURI                    string
@@ -172,6 +277,12 @@ type MainConfig struct {
 	OpenTracingLoadModule              bool
 	OpenTracingTracer                  string
 	OpenTracingTracerConfig            string
+	OtelExporterEndpoint               string
+	OtelLoadModule                     bool
+	OtelSamplerPercentage              string
+	OtelServiceName                    string
+	OtelTrace                          string
+	OtelTraceContext                   string
 	ProxyProtocol                      bool
 	ResolverAddresses                  []string
 	ResolverIPV6                       bool
@@ -179,6 +290,9 @@ type MainConfig struct {
 	ResolverValid                      string
 	RealIPHeader                       string
 	RealIPRecursive                    bool
+	RequestID                          bool
+	RequestIDHeader                    string
+	RequestIDHeaderVariable            string
 	SetRealIPFrom                      []string
 	ServerNamesHashBucketSize          string
 	ServerNamesHashMaxSize             string
@@ -188,6 +302,7 @@ type MainConfig struct {
 	SSLDHParam                         string
 	SSLPreferServerCiphers             bool
 	SSLProtocols                       string
+	StaticSSLPath                      string
 	StreamLogFormat                    []string
 	StreamLogFormatEscaping            string
 	StreamSnippets                     []string
@@ -213,6 +328,7 @@ type MainConfig struct {
 	InternalRouteServerName            string
 	LatencyMetrics                     bool
 	PreviewPolicies                    bool
+	LowercasePaths                     bool
 }
 
 // NewUpstreamWithDefaultServer creates an upstream with the default server.
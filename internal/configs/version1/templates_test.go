@@ -2,6 +2,8 @@ package version1
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 	"text/template"
 )
@@ -57,14 +59,16 @@ var ingCfg = IngressNginxConfig{
 			SSLCertificateKey: "secret.pem",
 			SSLPorts:          []int{443},
 			SSLRedirect:       true,
+			RequestIDHeader:   "X-Request-ID",
 			Locations: []Location{
 				{
-					Path:                "/tea",
-					Upstream:            testUps,
-					ProxyConnectTimeout: "10s",
-					ProxyReadTimeout:    "10s",
-					ProxySendTimeout:    "10s",
-					ClientMaxBodySize:   "2m",
+					Path:                  "/tea",
+					Upstream:              testUps,
+					SessionCookieVariable: "$ing_default_cafe_ingress_session_0_cookie",
+					ProxyConnectTimeout:   "10s",
+					ProxyReadTimeout:      "10s",
+					ProxySendTimeout:      "10s",
+					ClientMaxBodySize:     "2m",
 					JWTAuth: &JWTAuth{
 						Key:   "/etc/nginx/secrets/location-key.jwk",
 						Realm: "closed site",
@@ -74,9 +78,65 @@ var ingCfg = IngressNginxConfig{
 						Name:      "tea-minion",
 						Namespace: "default",
 					},
+					SSL:          true,
+					ProxySSLName: "tea-svc.default.svc",
+					UpstreamTLS: &UpstreamTLS{
+						TrustedCertificate: "/etc/nginx/secrets/default-tea-ca",
+						Certificate:        "/etc/nginx/secrets/default-tea-client",
+						CertificateKey:     "/etc/nginx/secrets/default-tea-client",
+					},
+				},
+				{
+					Path:                "/coffee",
+					Upstream:            testUps,
+					UpstreamVariable:    "$ing_default_cafe_ingress_canary_0",
+					ProxyConnectTimeout: "10s",
+					ProxyReadTimeout:    "10s",
+					ProxySendTimeout:    "10s",
+					ClientMaxBodySize:   "2m",
+					Allow:               []string{"10.0.0.0/8", "192.168.1.1"},
+					LimitReq: &LimitReq{
+						Zone:       "ing_rl_default_cafe-ingress",
+						Burst:      20,
+						NoDelay:    true,
+						RejectCode: 429,
+					},
+				},
+				{
+					Path:              "/maintenance",
+					ClientMaxBodySize: "1m",
+					StaticResponse: &StaticResponse{
+						ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+						Code:      503,
+						Page:      "@return_default-cafe-ingress_0",
+					},
+				},
+				{
+					Path:              "/old-coffee",
+					ClientMaxBodySize: "1m",
+					StaticResponse: &StaticResponse{
+						ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+						Code:      301,
+						Page:      "https://${host}/coffee",
+					},
+				},
+			},
+			ReturnLocations: []ReturnLocation{
+				{
+					Name:        "@return_default-cafe-ingress_0",
+					DefaultType: "text/plain",
+					Text:        "Down for maintenance",
 				},
 			},
 			HealthChecks: map[string]HealthCheck{"test": healthCheck},
+			ErrorPages: []ErrorPage{
+				{Code: 404, URI: "/_ingress_error_pages/404.html"},
+				{Code: 502, URI: "/_ingress_error_pages/502.json"},
+			},
+			ErrorPagesLocation: &ErrorPagesLocation{
+				Path:  "/_ingress_error_pages/",
+				Alias: "/etc/nginx/error-pages/default-cafe-ingress/",
+			},
 			JWTRedirectLocations: []JWTRedirectLocation{
 				{
 					Name:     "@login_url-default-cafe-ingress",
@@ -86,6 +146,43 @@ var ingCfg = IngressNginxConfig{
 		},
 	},
 	Upstreams: []Upstream{testUps},
+	SplitClients: []SplitClient{
+		{
+			Source:   "$request_id",
+			Variable: "$ing_default_cafe_ingress_canary_0_weight",
+			Distributions: []Distribution{
+				{Weight: "20%", Value: "default-cafe-ingress-canary-cafe.example.com-coffee-v2-svc-80"},
+				{Weight: "*", Value: "default-cafe-ingress-cafe.example.com-coffee-svc-80"},
+			},
+		},
+	},
+	Maps: []Map{
+		{
+			Source:   "$http_x_canary",
+			Variable: "$ing_default_cafe_ingress_canary_0",
+			Parameters: []Parameter{
+				{Value: "always", Result: "default-cafe-ingress-canary-cafe.example.com-coffee-v2-svc-80"},
+				{Value: "never", Result: "default-cafe-ingress-cafe.example.com-coffee-svc-80"},
+				{Value: "default", Result: "$ing_default_cafe_ingress_canary_0_weight"},
+			},
+		},
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$ing_default_cafe_ingress_session_0_cookie",
+			Parameters: []Parameter{
+				{Value: `""`, Result: `"srv_id=$ing_default_cafe_ingress_session_0; Path=/"`},
+				{Value: "default", Result: `""`},
+			},
+		},
+	},
+	LimitReqZones: []LimitReqZone{
+		{
+			Name: "ing_rl_default_cafe-ingress",
+			Key:  "${binary_remote_addr}",
+			Size: "10m",
+			Rate: "10r/s",
+		},
+	},
 	Keepalive: "16",
 	Ingress: Ingress{
 		Name:      "cafe-ingress",
@@ -115,6 +212,15 @@ var mainCfg = MainConfig{
 	VariablesHashBucketSize: 256,
 	VariablesHashMaxSize:    1024,
 	TLSPassthrough:          true,
+	OtelLoadModule:          true,
+	OtelExporterEndpoint:    "otel-collector:4317",
+	OtelServiceName:         "nginx-ingress",
+	OtelSamplerPercentage:   "10%",
+	OtelTrace:               "$otel_ratio_sampler",
+	OtelTraceContext:        "propagate",
+	RequestID:               true,
+	RequestIDHeader:         "X-Request-ID",
+	RequestIDHeaderVariable: "$http_x_request_id",
 }
 
 func TestIngressForNGINXPlus(t *testing.T) {
@@ -147,6 +253,101 @@ func TestIngressForNGINX(t *testing.T) {
 	}
 }
 
+func TestIngressErrorPagesForMixedGRPCServer(t *testing.T) {
+	errorPages := []ErrorPage{
+		{Code: 404, URI: "/_ingress_error_pages/404.html"},
+		{Code: 502, URI: "/_ingress_error_pages/502.json"},
+	}
+	cfg := IngressNginxConfig{
+		Servers: []Server{
+			{
+				Name:       "cafe.example.com",
+				StatusZone: "cafe.example.com",
+				HTTP2:      true,
+				Locations: []Location{
+					{
+						Path:     "/tea",
+						Upstream: testUps,
+					},
+					{
+						Path:       "/grpc",
+						Upstream:   testUps,
+						GRPC:       true,
+						ErrorPages: errorPages,
+					},
+					{
+						Path: "/maintenance",
+						StaticResponse: &StaticResponse{
+							ProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+							Code:      503,
+							Page:      "@return_default-cafe-ingress_0",
+						},
+						ErrorPages: errorPages,
+					},
+				},
+				ErrorPages: errorPages,
+				ErrorPagesLocation: &ErrorPagesLocation{
+					Path:  "/_ingress_error_pages/",
+					Alias: "/etc/nginx/error-pages/default-cafe-ingress/",
+				},
+			},
+		},
+		Upstreams: []Upstream{testUps},
+	}
+
+	for _, file := range []string{nginxIngressTmpl, nginxPlusIngressTmpl} {
+		tmpl, err := template.New(file).Funcs(helperFunctions).ParseFiles(file)
+		if err != nil {
+			t.Fatalf("Failed to parse template file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, cfg); err != nil {
+			t.Fatalf("Failed to write template %v", err)
+		}
+
+		tea := getLocationBlock(t, buf.String(), "/tea")
+		if strings.Contains(tea, "error_page") {
+			t.Errorf("Template %s generated error_page directives for a location that inherits them from the server:\n%s", file, tea)
+		}
+
+		grpc := getLocationBlock(t, buf.String(), "/grpc")
+		customPage := strings.Index(grpc, "error_page 502 /_ingress_error_pages/502.json;")
+		grpcPage := strings.Index(grpc, "error_page 502 @grpcerror502;")
+		if customPage == -1 || grpcPage == -1 || customPage > grpcPage {
+			t.Errorf("Template %s didn't generate the custom error page before the gRPC error page for the gRPC location:\n%s", file, grpc)
+		}
+
+		maintenance := getLocationBlock(t, buf.String(), "/maintenance")
+		for _, directive := range []string{
+			`error_page 418 =503 "@return_default-cafe-ingress_0";`,
+			"error_page 404 /_ingress_error_pages/404.html;",
+			"error_page 502 /_ingress_error_pages/502.json;",
+		} {
+			if !strings.Contains(maintenance, directive) {
+				t.Errorf("Template %s didn't generate %q for the static response location:\n%s", file, directive, maintenance)
+			}
+		}
+	}
+}
+
+// getLocationBlock returns the block of the location with the path from the generated config.
+func getLocationBlock(t *testing.T, config string, path string) string {
+	t.Helper()
+
+	start := strings.Index(config, fmt.Sprintf("location %s {", path))
+	if start == -1 {
+		t.Fatalf("Template didn't generate the location %s", path)
+	}
+
+	end := strings.Index(config[start:], "\n\t}")
+	if end == -1 {
+		t.Fatalf("Template didn't close the location %s", path)
+	}
+
+	return config[start : start+end]
+}
+
 func TestMainForNGINXPlus(t *testing.T) {
 	tmpl, err := template.New(nginxPlusMainTmpl).ParseFiles(nginxPlusMainTmpl)
 	if err != nil {
@@ -162,6 +363,59 @@ func TestMainForNGINXPlus(t *testing.T) {
 	}
 }
 
+func TestMainForNGINXPlusLoadsNJSOnlyWhenUsed(t *testing.T) {
+	tmpl, err := template.New(nginxPlusMainTmpl).ParseFiles(nginxPlusMainTmpl)
+	if err != nil {
+		t.Fatalf("Failed to parse template file: %v", err)
+	}
+
+	tests := []struct {
+		previewPolicies      bool
+		lowercasePaths       bool
+		expectedJSModule     bool
+		expectedLowercaseURI bool
+	}{
+		{
+			previewPolicies:      false,
+			lowercasePaths:       false,
+			expectedJSModule:     false,
+			expectedLowercaseURI: false,
+		},
+		{
+			previewPolicies:      true,
+			lowercasePaths:       false,
+			expectedJSModule:     true,
+			expectedLowercaseURI: false,
+		},
+		{
+			previewPolicies:      false,
+			lowercasePaths:       true,
+			expectedJSModule:     true,
+			expectedLowercaseURI: true,
+		},
+	}
+
+	for _, test := range tests {
+		cfg := mainCfg
+		cfg.PreviewPolicies = test.previewPolicies
+		cfg.LowercasePaths = test.lowercasePaths
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, cfg); err != nil {
+			t.Fatalf("Failed to write template %v", err)
+		}
+
+		jsModule := strings.Contains(buf.String(), "load_module modules/ngx_http_js_module.so;")
+		if jsModule != test.expectedJSModule {
+			t.Errorf("Template loaded the njs module %v but expected %v for PreviewPolicies %v and LowercasePaths %v", jsModule, test.expectedJSModule, test.previewPolicies, test.lowercasePaths)
+		}
+		lowercaseURI := strings.Contains(buf.String(), "js_set $lowercase_uri uri.lowercaseURI;")
+		if lowercaseURI != test.expectedLowercaseURI {
+			t.Errorf("Template set $lowercase_uri %v but expected %v for PreviewPolicies %v and LowercasePaths %v", lowercaseURI, test.expectedLowercaseURI, test.previewPolicies, test.lowercasePaths)
+		}
+	}
+}
+
 func TestMainForNGINX(t *testing.T) {
 	tmpl, err := template.New(nginxMainTmpl).ParseFiles(nginxMainTmpl)
 	if err != nil {
@@ -43,6 +43,10 @@ type Upstream struct {
 // UpstreamServer defines an upstream server.
 type UpstreamServer struct {
 	Address string
+	Weight  int
+	Backup  bool
+	// Service is the weighted or backup Service of the server. It is empty for the main Service of the upstream.
+	Service string
 }
 
 // Server defines a server.
@@ -62,6 +66,8 @@ type Server struct {
 	ReturnLocations           []ReturnLocation
 	HealthChecks              []HealthCheck
 	TLSRedirect               *TLSRedirect
+	Redirects                 []ServerRedirect
+	ACMEChallenge             *ACMEChallenge
 	TLSPassthrough            bool
 	Allow                     []string
 	Deny                      []string
@@ -73,6 +79,7 @@ type Server struct {
 	OIDC                      *OIDC
 	WAF                       *WAF
 	Dos                       *Dos
+	OtelTrace                 *OtelTrace
 	PoliciesErrorReturn       *Return
 	VSNamespace               string
 	VSName                    string
@@ -86,6 +93,27 @@ type SSL struct {
 	RejectHandshake bool
 }
 
+// ServerRedirect defines a redirect of a server. The redirect applies when the Variable is not empty.
+type ServerRedirect struct {
+	Variable string
+	Code     int
+	URL      string
+}
+
+// ACMEChallenge defines the response to the HTTP-01 challenges of an ACME server.
+// The key authorization of a challenge is the token followed by the thumbprint of the ACME account key,
+// so NGINX can respond to any challenge without knowing the tokens in advance.
+type ACMEChallenge struct {
+	Thumbprint string
+}
+
+// OtelTrace defines the OpenTelemetry tracing configuration of a server or a location.
+type OtelTrace struct {
+	// Trace is on, off or a variable that enables tracing for a sample of the requests.
+	Trace   string
+	Context string
+}
+
 // IngressMTLS defines TLS configuration for a server. This is a subset of TLS specifically for clients auth.
 type IngressMTLS struct {
 	ClientCert   string
@@ -178,6 +206,7 @@ type Location struct {
 	OIDC                     bool
 	WAF                      *WAF
 	Dos                      *Dos
+	OtelTrace                *OtelTrace
 	PoliciesErrorReturn      *Return
 	ServiceName              string
 	IsVSR                    bool
@@ -191,6 +220,7 @@ type ReturnLocation struct {
 	Name        string
 	DefaultType string
 	Return      Return
+	Headers     []Header
 }
 
 // SplitClient defines a split_clients.
@@ -289,6 +319,8 @@ type Map struct {
 	Source     string
 	Variable   string
 	Parameters []Parameter
+	// Geo makes the Map a geo block, which matches the Source address against IP addresses and CIDR ranges.
+	Geo bool
 }
 
 // Parameter defines a Parameter in a Map.
@@ -4,6 +4,7 @@ package version2
 type TransportServerConfig struct {
 	Server         StreamServer
 	Upstreams      []StreamUpstream
+	Maps           []Map
 	StreamSnippets []string
 	Match          *Match
 }
@@ -14,6 +15,7 @@ type StreamUpstream struct {
 	Servers             []StreamUpstreamServer
 	UpstreamLabels      UpstreamLabels
 	LoadBalancingMethod string
+	Resolve             bool
 }
 
 // StreamUpstreamServer defines a stream upstream server.
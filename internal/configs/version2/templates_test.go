@@ -41,6 +41,11 @@ var virtualServerCfg = VirtualServerConfig{
 			Servers: []UpstreamServer{
 				{
 					Address: "10.0.0.31:8001",
+					Weight:  2,
+				},
+				{
+					Address: "10.0.0.41:8001",
+					Backup:  true,
 				},
 			},
 			MaxFails:         8,
@@ -106,6 +111,21 @@ var virtualServerCfg = VirtualServerConfig{
 				},
 			},
 		},
+		{
+			Source:   "$remote_addr",
+			Variable: "$match_1_0",
+			Parameters: []Parameter{
+				{
+					Value:  "10.0.0.0/8",
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+			Geo: true,
+		},
 	},
 	HTTPSnippets: []string{"# HTTP snippet"},
 	Server: Server{
@@ -121,6 +141,20 @@ var virtualServerCfg = VirtualServerConfig{
 			BasedOn: "$scheme",
 			Code:    301,
 		},
+		Redirects: []ServerRedirect{
+			{
+				Variable: "$vs_default_cafe_redirect_host",
+				Code:     301,
+				URL:      "$scheme://$vs_default_cafe_redirect_host$request_uri",
+			},
+		},
+		ACMEChallenge: &ACMEChallenge{
+			Thumbprint: "ThTrGfiHBzQhgZCoY2xH9XdnSbTdJXuADHI7oLfxOCM",
+		},
+		OtelTrace: &OtelTrace{
+			Trace:   "on",
+			Context: "propagate",
+		},
 		ServerTokens:    "off",
 		SetRealIPFrom:   []string{"0.0.0.0/0"},
 		RealIPHeader:    "X-Real-IP",
@@ -193,8 +227,11 @@ var virtualServerCfg = VirtualServerConfig{
 			{
 				Path:     "/",
 				Snippets: []string{"# location snippet"},
-				Allow:    []string{"127.0.0.1"},
-				Deny:     []string{"127.0.0.1"},
+				OtelTrace: &OtelTrace{
+					Trace: "$vs_default_cafe_otel_sampler_0",
+				},
+				Allow: []string{"127.0.0.1"},
+				Deny:  []string{"127.0.0.1"},
 				LimitReqs: []LimitReq{
 					{
 						ZoneName: "loc_pol_rl_test_test_test",
@@ -312,6 +349,12 @@ var virtualServerCfg = VirtualServerConfig{
 					},
 				},
 				InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+				AddHeaders: []AddHeader{
+					{
+						Header: Header{Name: "Strict-Transport-Security", Value: "max-age=31536000"},
+						Always: true,
+					},
+				},
 			},
 		},
 		ErrorPageLocations: []ErrorPageLocation{
@@ -351,6 +394,9 @@ var virtualServerCfg = VirtualServerConfig{
 					Code: 200,
 					Text: "Hello!",
 				},
+				Headers: []Header{
+					{Name: "Strict-Transport-Security", Value: "max-age=31536000"},
+				},
 			},
 		},
 	},
@@ -366,6 +412,27 @@ var transportServerCfg = TransportServerConfig{
 				},
 			},
 		},
+		{
+			Name: "dns-upstream",
+			Servers: []StreamUpstreamServer{
+				{
+					Address: "dns.example.com:53",
+				},
+			},
+			Resolve: true,
+		},
+	},
+	Maps: []Map{
+		{
+			Source:   "$remote_addr",
+			Variable: "$dns_upstream_address",
+			Parameters: []Parameter{
+				{
+					Value:  "default",
+					Result: `"dns.example.com:53"`,
+				},
+			},
+		},
 	},
 	Match: &Match{
 		Name:                "match_udp-upstream",
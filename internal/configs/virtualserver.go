@@ -2,10 +2,12 @@ package configs
 
 import (
 	"fmt"
+	"log/slog"
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
@@ -15,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs/version2"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 )
 
@@ -76,10 +79,14 @@ type PodInfo struct {
 
 // VirtualServerEx holds a VirtualServer along with the resources that are referenced in this VirtualServer.
 type VirtualServerEx struct {
-	VirtualServer       *conf_v1.VirtualServer
+	VirtualServer *conf_v1.VirtualServer
+	// ValidAliases marks the aliases of the VirtualServer as valid (true) or invalid (false).
+	// Only the valid aliases are added to the server names.
+	ValidAliases        map[string]bool
 	Endpoints           map[string][]string
 	VirtualServerRoutes []*conf_v1.VirtualServerRoute
 	ExternalNameSvcs    map[string]bool
+	LocalEndpoints      map[string]bool
 	Policies            map[string]*conf_v1.Policy
 	PodsByIP            map[string]PodInfo
 	SecretRefs          map[string]*secrets.SecretReference
@@ -101,6 +108,19 @@ func (vsx *VirtualServerEx) String() string {
 	return fmt.Sprintf("%s/%s", vsx.VirtualServer.Namespace, vsx.VirtualServer.Name)
 }
 
+// generateServerName generates the server names of a VirtualServer: its host followed by its valid aliases.
+func generateServerName(vsEx *VirtualServerEx) string {
+	names := []string{vsEx.VirtualServer.Spec.Host}
+
+	for _, alias := range vsEx.VirtualServer.Spec.Aliases {
+		if vsEx.ValidAliases[alias] {
+			names = append(names, alias)
+		}
+	}
+
+	return strings.Join(names, " ")
+}
+
 // appProtectResourcesForVS holds file names of APPolicy and APLogConf resources used in a VirtualServer.
 type appProtectResourcesForVS struct {
 	Policies map[string]string
@@ -191,6 +211,18 @@ func (namer *variableNamer) GetNameForSplitClientVariable(index int) string {
 	return fmt.Sprintf("$vs_%s_splits_%d", namer.safeNsName, index)
 }
 
+func (namer *variableNamer) GetNameForSplitClientKeyVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_splits_%d_key", namer.safeNsName, index)
+}
+
+func (namer *variableNamer) GetNameForOtelSamplerVariable(index int) string {
+	return fmt.Sprintf("$vs_%s_otel_sampler_%d", namer.safeNsName, index)
+}
+
+func (namer *variableNamer) GetNameForRedirectVariable(name string) string {
+	return fmt.Sprintf("$vs_%s_redirect_%s", namer.safeNsName, name)
+}
+
 func (namer *variableNamer) GetNameForVariableForMatchesRouteMap(
 	matchesIndex int,
 	matchIndex int,
@@ -236,6 +268,7 @@ type virtualServerConfigurator struct {
 	warnings             Warnings
 	spiffeCerts          bool
 	oidcPolCfg           *oidcPolicyCfg
+	staticParams         *StaticConfigParams
 }
 
 type oidcPolicyCfg struct {
@@ -275,6 +308,7 @@ func newVirtualServerConfigurator(
 		warnings:             make(map[runtime.Object][]string),
 		spiffeCerts:          staticParams.NginxServiceMesh,
 		oidcPolCfg:           &oidcPolicyCfg{},
+		staticParams:         staticParams,
 	}
 }
 
@@ -284,23 +318,107 @@ func (vsc *virtualServerConfigurator) generateEndpointsForUpstream(
 	upstream conf_v1.Upstream,
 	virtualServerEx *VirtualServerEx,
 ) []string {
-	endpointsKey := GenerateEndpointsKey(namespace, upstream.Service, upstream.Subselector, upstream.Port)
-	externalNameSvcKey := GenerateExternalNameSvcKey(namespace, upstream.Service)
-	endpoints := virtualServerEx.Endpoints[endpointsKey]
+	var endpoints []string
+	if len(upstream.Addresses) > 0 {
+		endpoints = vsc.generateEndpointsForAddresses(owner, upstream)
+	} else {
+		endpointsKey := GenerateEndpointsKey(namespace, upstream.Service, upstream.Subselector, upstream.Port)
+		externalNameSvcKey := GenerateExternalNameSvcKey(namespace, upstream.Service)
+		endpoints = virtualServerEx.Endpoints[endpointsKey]
+
+		_, isExternalNameSvc := virtualServerEx.ExternalNameSvcs[externalNameSvcKey]
+		if isExternalNameSvc && !vsc.isResolverConfigured {
+			msgFmt := "Type ExternalName service %v in upstream %v will be ignored. To use ExternaName services, a resolver must be configured in the ConfigMap"
+			vsc.addWarningf(owner, msgFmt, upstream.Service, upstream.Name)
+			endpoints = []string{}
+		}
+
+		if upstream.TopologyAware != nil && upstream.TopologyAware.Enable && !isExternalNameSvc {
+			if virtualServerEx.LocalEndpoints == nil {
+				msgFmt := "Topology aware routing for upstream %v will be ignored. It requires the -enable-topology-aware-routing command-line argument"
+				vsc.addWarningf(owner, msgFmt, upstream.Name)
+			} else {
+				endpoints = filterLocalEndpoints(endpoints, virtualServerEx.LocalEndpoints, upstream.TopologyAware.MinEndpoints)
+			}
+		}
+	}
+
 	if !vsc.isPlus && len(endpoints) == 0 {
 		return []string{nginx502Server}
 	}
 
-	_, isExternalNameSvc := virtualServerEx.ExternalNameSvcs[externalNameSvcKey]
-	if isExternalNameSvc && !vsc.isResolverConfigured {
-		msgFmt := "Type ExternalName service %v in upstream %v will be ignored. To use ExternaName services, a resolver must be configured in the ConfigMap"
-		vsc.addWarningf(owner, msgFmt, upstream.Service, upstream.Name)
-		endpoints = []string{}
+	return endpoints
+}
+
+// filterLocalEndpoints returns the endpoints in the zone of the Ingress Controller when there are at least minEndpoints of them.
+// Otherwise, it returns all endpoints, so that the traffic falls back to the other zones.
+func filterLocalEndpoints(endpoints []string, localEndpoints map[string]bool, minEndpoints int) []string {
+	if minEndpoints < 1 {
+		minEndpoints = 1
+	}
+
+	var local []string
+	for _, e := range endpoints {
+		if localEndpoints[e] {
+			local = append(local, e)
+		}
+	}
+
+	if len(local) < minEndpoints {
+		return endpoints
+	}
+
+	return local
+}
+
+// generateEndpointsForAddresses generates the endpoints for the addresses of an upstream.
+// Addresses with DNS names are ignored when no resolver is configured.
+func (vsc *virtualServerConfigurator) generateEndpointsForAddresses(owner runtime.Object, upstream conf_v1.Upstream) []string {
+	if vsc.isResolverConfigured || !hasDNSNameAddress(upstream.Addresses) {
+		return upstream.Addresses
+	}
+
+	var endpoints []string
+	for _, address := range upstream.Addresses {
+		if isDNSNameAddress(address) {
+			msgFmt := "Address %v in upstream %v will be ignored. To use DNS names, a resolver must be configured in the ConfigMap"
+			vsc.addWarningf(owner, msgFmt, address, upstream.Name)
+			continue
+		}
+		endpoints = append(endpoints, address)
 	}
 
 	return endpoints
 }
 
+// generateRuntimeResolvedAddress returns the address that NGINX resolves at runtime instead of using the servers of the upstream.
+// NGINX can't resolve the servers of an upstream, so the requests are proxied to the address through a variable,
+// which makes NGINX resolve it using the configured resolver. NGINX Plus resolves the servers of an upstream instead.
+// Only an upstream with a single address can be resolved at runtime. NGINX resolves the addresses of other upstreams
+// once when it loads the configuration, which is reported as a warning.
+func (vsc *virtualServerConfigurator) generateRuntimeResolvedAddress(
+	owner runtime.Object,
+	upstream conf_v1.Upstream,
+	resolve bool,
+	endpoints []string,
+) string {
+	if vsc.isPlus || !resolve {
+		return ""
+	}
+
+	if len(endpoints) == 1 && endpoints[0] == nginx502Server {
+		return ""
+	}
+
+	if len(endpoints) != 1 || len(upstream.Services) > 0 || upstream.Backup != "" {
+		vsc.addWarningf(owner, "NGINX resolves the addresses of upstream %v only when it loads the configuration. "+
+			"Only an upstream with a single address and without additional services or a backup is resolved at runtime", upstream.Name)
+		return ""
+	}
+
+	return endpoints[0]
+}
+
 // GenerateVirtualServerConfig generates a full configuration for a VirtualServer
 func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	vsEx *VirtualServerEx,
@@ -311,6 +429,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 	sslConfig := vsc.generateSSLConfig(vsEx.VirtualServer, vsEx.VirtualServer.Spec.TLS, vsEx.VirtualServer.Namespace, vsEx.SecretRefs, vsc.cfgParams)
 	tlsRedirectConfig := generateTLSRedirectConfig(vsEx.VirtualServer.Spec.TLS)
+	acmeChallengeConfig := vsc.generateACMEChallengeConfig(vsEx.VirtualServer, vsEx.VirtualServer.Spec.TLS)
 
 	policyOpts := policyOptions{
 		tls:         sslConfig != nil,
@@ -332,11 +451,15 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	// necessary for generateLocation to know what Upstream each Location references
 	crUpstreams := make(map[string]conf_v1.Upstream)
 
+	// runtimeResolvedAddresses maps an UpstreamName to the address that NGINX resolves at runtime
+	runtimeResolvedAddresses := make(map[string]string)
+
 	virtualServerUpstreamNamer := newUpstreamNamerForVirtualServer(vsEx.VirtualServer)
 	var upstreams []version2.Upstream
 	var statusMatches []version2.StatusMatch
 	var healthChecks []version2.HealthCheck
 	var limitReqZones []version2.LimitReqZone
+	var sessionMaps []version2.Map
 
 	limitReqZones = append(limitReqZones, policiesCfg.LimitReqZones...)
 
@@ -351,10 +474,16 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		upstreamNamespace := vsEx.VirtualServer.Namespace
 		endpoints := vsc.generateEndpointsForUpstream(vsEx.VirtualServer, upstreamNamespace, u, vsEx)
 
-		// isExternalNameSvc is always false for OSS
 		_, isExternalNameSvc := vsEx.ExternalNameSvcs[GenerateExternalNameSvcKey(upstreamNamespace, u.Service)]
-		ups := vsc.generateUpstream(vsEx.VirtualServer, upstreamName, u, isExternalNameSvc, endpoints)
+		resolve := isExternalNameSvc || hasDNSNameAddress(u.Addresses)
+		ups := vsc.generateUpstream(vsEx.VirtualServer, upstreamName, u, resolve, endpoints)
+		vsc.addServersForAdditionalServices(vsEx.VirtualServer, &ups, upstreamNamespace, u, vsEx)
+		if address := vsc.generateRuntimeResolvedAddress(vsEx.VirtualServer, u, resolve, endpoints); address != "" {
+			runtimeResolvedAddresses[upstreamName] = address
+			ups.Servers = []version2.UpstreamServer{{Address: nginx502Server}}
+		}
 		upstreams = append(upstreams, ups)
+		sessionMaps = append(sessionMaps, generateHashSessionCookieMaps(generateSessionVariable(upstreamName), u.SessionCookie)...)
 
 		u.TLS.Enable = isTLSEnabled(u, vsc.spiffeCerts)
 		crUpstreams[upstreamName] = u
@@ -381,10 +510,16 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			upstreamNamespace := vsr.Namespace
 			endpoints := vsc.generateEndpointsForUpstream(vsr, upstreamNamespace, u, vsEx)
 
-			// isExternalNameSvc is always false for OSS
 			_, isExternalNameSvc := vsEx.ExternalNameSvcs[GenerateExternalNameSvcKey(upstreamNamespace, u.Service)]
-			ups := vsc.generateUpstream(vsr, upstreamName, u, isExternalNameSvc, endpoints)
+			resolve := isExternalNameSvc || hasDNSNameAddress(u.Addresses)
+			ups := vsc.generateUpstream(vsr, upstreamName, u, resolve, endpoints)
+			vsc.addServersForAdditionalServices(vsr, &ups, upstreamNamespace, u, vsEx)
+			if address := vsc.generateRuntimeResolvedAddress(vsr, u, resolve, endpoints); address != "" {
+				runtimeResolvedAddresses[upstreamName] = address
+				ups.Servers = []version2.UpstreamServer{{Address: nginx502Server}}
+			}
 			upstreams = append(upstreams, ups)
+			sessionMaps = append(sessionMaps, generateHashSessionCookieMaps(generateSessionVariable(upstreamName), u.SessionCookie)...)
 			u.TLS.Enable = isTLSEnabled(u, vsc.spiffeCerts)
 			crUpstreams[upstreamName] = u
 
@@ -410,19 +545,34 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 	vsrErrorPagesRouteIndex := make(map[string]int)
 	vsrLocationSnippetsFromVs := make(map[string]string)
 	vsrPoliciesFromVs := make(map[string][]conf_v1.PolicyReference)
+	vsrTracingFromVs := make(map[string]*conf_v1.Tracing)
 	isVSR := false
 	matchesRoutes := 0
 
 	variableNamer := newVariableNamer(vsEx.VirtualServer)
 
+	otelTraceCfg, otelSampler := vsc.generateOtelTrace(vsEx.VirtualServer, vsEx.VirtualServer.Spec.Tracing,
+		variableNamer.GetNameForOtelSamplerVariable(len(splitClients)))
+	if otelSampler != nil {
+		splitClients = append(splitClients, *otelSampler)
+	}
+
+	requestIDHeader := generateRequestIDHeader(generateRequestIDEnable(vsEx.VirtualServer.Spec.RequestID, vsc.cfgParams), vsc.cfgParams)
+	vsHeaders := mergeActionProxyHeaders(generateRequestIDProxy(requestIDHeader),
+		generateHeadersProxy(vsEx.VirtualServer.Spec.Headers, vsc.cfgParams, sslConfig != nil))
+	vsSecurityHeaders := generateSecurityHeadersForResource(vsEx.VirtualServer.Spec.Headers, vsc.cfgParams, sslConfig != nil)
+
 	// generates config for VirtualServer routes
 	for _, r := range vsEx.VirtualServer.Spec.Routes {
+		r = applyHeadersToRoute(vsHeaders, r)
 		errorPages := errorPageDetails{
 			pages: r.ErrorPages,
 			index: len(errorPageLocations),
 			owner: vsEx.VirtualServer,
 		}
-		errorPageLocations = append(errorPageLocations, generateErrorPageLocations(errorPages.index, errorPages.pages)...)
+		routeErrorPageLocations := generateErrorPageLocations(errorPages.index, errorPages.pages)
+		addSecurityHeadersToErrorPageLocations(vsSecurityHeaders, routeErrorPageLocations)
+		errorPageLocations = append(errorPageLocations, routeErrorPageLocations...)
 
 		// ignore routes that reference VirtualServerRoute
 		if r.Route != "" {
@@ -447,9 +597,17 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				vsrPoliciesFromVs[name] = r.Policies
 			}
 
+			// store route tracing for the referenced VirtualServerRoute in case they don't define their own
+			if r.Tracing != nil {
+				vsrTracingFromVs[name] = r.Tracing
+			}
+
 			continue
 		}
 
+		routeLocationsIndex := len(locations)
+		routeReturnLocationsIndex := len(returnLocations)
+
 		vsLocSnippets := r.LocationSnippets
 		ownerDetails := policyOwnerDetails{
 			owner:          vsEx.VirtualServer,
@@ -465,6 +623,12 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 		dosRouteCfg := generateDosCfg(dosResources[r.Path])
 
+		otelRouteCfg, otelRouteSampler := vsc.generateOtelTrace(vsEx.VirtualServer, r.Tracing,
+			variableNamer.GetNameForOtelSamplerVariable(len(splitClients)))
+		if otelRouteSampler != nil {
+			splitClients = append(splitClients, *otelRouteSampler)
+		}
+
 		if len(r.Matches) > 0 {
 			cfg := generateMatchesConfig(
 				r,
@@ -484,6 +648,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+			addOtelTraceToLocations(otelRouteCfg, cfg.Locations)
+			addRouteProxyToLocations(r.Proxy, cfg.Locations)
 
 			maps = append(maps, cfg.Maps...)
 			locations = append(locations, cfg.Locations...)
@@ -496,6 +662,9 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				vsc.cfgParams, errorPages, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings)
 			addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 			addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+			addOtelTraceToLocations(otelRouteCfg, cfg.Locations)
+			addRouteProxyToLocations(r.Proxy, cfg.Locations)
+			maps = append(maps, cfg.Maps...)
 			splitClients = append(splitClients, cfg.SplitClients...)
 			locations = append(locations, cfg.Locations...)
 			internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
@@ -504,31 +673,44 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			upstreamName := virtualServerUpstreamNamer.GetNameForUpstreamFromAction(r.Action)
 			upstream := crUpstreams[upstreamName]
 
-			proxySSLName := generateProxySSLName(upstream.Service, vsEx.VirtualServer.Namespace)
+			proxySSLName := generateProxySSLNameForUpstream(upstream, vsEx.VirtualServer.Namespace)
 
 			loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
 				proxySSLName, r.Path, vsLocSnippets, vsc.enableSnippets, len(returnLocations), isVSR, "", "", vsc.warnings)
 			addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 			loc.Dos = dosRouteCfg
+			loc.OtelTrace = otelRouteCfg
+			addRouteProxyToLocation(r.Proxy, &loc)
 
 			locations = append(locations, loc)
 			if returnLoc != nil {
 				returnLocations = append(returnLocations, *returnLoc)
 			}
 		}
+
+		addSecurityHeadersToLocations(vsSecurityHeaders, locations[routeLocationsIndex:], returnLocations[routeReturnLocationsIndex:])
 	}
 
 	// generate config for subroutes of each VirtualServerRoute
 	for _, vsr := range vsEx.VirtualServerRoutes {
 		isVSR := true
 		upstreamNamer := newUpstreamNamerForVirtualServerRoute(vsEx.VirtualServer, vsr)
+		// the headers of the VirtualServerRoute take precedence over the headers of the VirtualServer
+		vsrHeaders := mergeActionProxyHeaders(vsHeaders, generateHeadersProxy(vsr.Spec.Headers, vsc.cfgParams, sslConfig != nil))
+		vsrSecurityHeaders := mergeAddHeaderLists(vsSecurityHeaders,
+			generateSecurityHeadersForResource(vsr.Spec.Headers, vsc.cfgParams, sslConfig != nil))
 		for _, r := range vsr.Spec.Subroutes {
+			r = applyHeadersToRoute(vsrHeaders, r)
 			errorPages := errorPageDetails{
 				pages: r.ErrorPages,
 				index: len(errorPageLocations),
 				owner: vsr,
 			}
-			errorPageLocations = append(errorPageLocations, generateErrorPageLocations(errorPages.index, errorPages.pages)...)
+			routeErrorPageLocations := generateErrorPageLocations(errorPages.index, errorPages.pages)
+			addSecurityHeadersToErrorPageLocations(vsrSecurityHeaders, routeErrorPageLocations)
+			errorPageLocations = append(errorPageLocations, routeErrorPageLocations...)
+			routeLocationsIndex := len(locations)
+			routeReturnLocationsIndex := len(returnLocations)
 			vsrNamespaceName := fmt.Sprintf("%v/%v", vsr.Namespace, vsr.Name)
 			// use the VirtualServer error pages if the route does not define any
 			if r.ErrorPages == nil {
@@ -575,6 +757,19 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 
 			dosRouteCfg := generateDosCfg(dosResources[r.Path])
 
+			var tracingOwner runtime.Object = vsr
+			tracing := r.Tracing
+			// use the VirtualServer route tracing if the route does not define any
+			if tracing == nil {
+				tracingOwner = vsEx.VirtualServer
+				tracing = vsrTracingFromVs[vsrNamespaceName]
+			}
+			otelRouteCfg, otelRouteSampler := vsc.generateOtelTrace(tracingOwner, tracing,
+				variableNamer.GetNameForOtelSamplerVariable(len(splitClients)))
+			if otelRouteSampler != nil {
+				splitClients = append(splitClients, *otelRouteSampler)
+			}
+
 			if len(r.Matches) > 0 {
 				cfg := generateMatchesConfig(
 					r,
@@ -595,6 +790,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 				)
 				addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 				addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+				addOtelTraceToLocations(otelRouteCfg, cfg.Locations)
+				addRouteProxyToLocations(r.Proxy, cfg.Locations)
 
 				maps = append(maps, cfg.Maps...)
 				locations = append(locations, cfg.Locations...)
@@ -607,7 +804,10 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 					errorPages, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
 				addPoliciesCfgToLocations(routePoliciesCfg, cfg.Locations)
 				addDosConfigToLocations(dosRouteCfg, cfg.Locations)
+				addOtelTraceToLocations(otelRouteCfg, cfg.Locations)
+				addRouteProxyToLocations(r.Proxy, cfg.Locations)
 
+				maps = append(maps, cfg.Maps...)
 				splitClients = append(splitClients, cfg.SplitClients...)
 				locations = append(locations, cfg.Locations...)
 				internalRedirectLocations = append(internalRedirectLocations, cfg.InternalRedirectLocation)
@@ -615,18 +815,42 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			} else {
 				upstreamName := upstreamNamer.GetNameForUpstreamFromAction(r.Action)
 				upstream := crUpstreams[upstreamName]
-				proxySSLName := generateProxySSLName(upstream.Service, vsr.Namespace)
+				proxySSLName := generateProxySSLNameForUpstream(upstream, vsr.Namespace)
 
 				loc, returnLoc := generateLocation(r.Path, upstreamName, upstream, r.Action, vsc.cfgParams, errorPages, false,
 					proxySSLName, r.Path, locSnippets, vsc.enableSnippets, len(returnLocations), isVSR, vsr.Name, vsr.Namespace, vsc.warnings)
 				addPoliciesCfgToLocation(routePoliciesCfg, &loc)
 				loc.Dos = dosRouteCfg
+				loc.OtelTrace = otelRouteCfg
+				addRouteProxyToLocation(r.Proxy, &loc)
 
 				locations = append(locations, loc)
 				if returnLoc != nil {
 					returnLocations = append(returnLocations, *returnLoc)
 				}
 			}
+
+			addSecurityHeadersToLocations(vsrSecurityHeaders, locations[routeLocationsIndex:], returnLocations[routeReturnLocationsIndex:])
+		}
+	}
+
+	addRequestIDToErrorPageLocations(requestIDHeader, errorPageLocations)
+
+	// the redirects exclude the locations of the OIDC policy, so they are generated after the policies
+	redirectMaps, redirects := generateServerRedirects(vsEx.VirtualServer.Spec.Redirects, vsEx.VirtualServer.Spec.Host,
+		tlsRedirectConfig, vsc.oidcPolCfg.oidc, newVariableNamer(vsEx.VirtualServer))
+	maps = append(maps, redirectMaps...)
+	maps = append(maps, sessionMaps...)
+
+	for _, ups := range upstreams {
+		address, ok := runtimeResolvedAddresses[ups.Name]
+		if !ok {
+			continue
+		}
+		variable := generateUpstreamAddressVariable(ups.Name)
+		maps = append(maps, generateUpstreamAddressMap(variable, address))
+		for i := range locations {
+			setRuntimeResolvedProxyPass(&locations[i], ups.Name, variable)
 		}
 	}
 
@@ -645,7 +869,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 		LimitReqZones: removeDuplicateLimitReqZones(limitReqZones),
 		HTTPSnippets:  httpSnippets,
 		Server: version2.Server{
-			ServerName:                vsEx.VirtualServer.Spec.Host,
+			ServerName:                generateServerName(vsEx),
 			StatusZone:                vsEx.VirtualServer.Spec.Host,
 			ProxyProtocol:             vsc.cfgParams.ProxyProtocol,
 			SSL:                       sslConfig,
@@ -659,6 +883,8 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			ReturnLocations:           returnLocations,
 			HealthChecks:              healthChecks,
 			TLSRedirect:               tlsRedirectConfig,
+			Redirects:                 redirects,
+			ACMEChallenge:             acmeChallengeConfig,
 			ErrorPageLocations:        errorPageLocations,
 			TLSPassthrough:            vsc.isTLSPassthrough,
 			Allow:                     policiesCfg.Allow,
@@ -671,6 +897,7 @@ func (vsc *virtualServerConfigurator) GenerateVirtualServerConfig(
 			OIDC:                      vsc.oidcPolCfg.oidc,
 			WAF:                       policiesCfg.WAF,
 			Dos:                       dosCfg,
+			OtelTrace:                 otelTraceCfg,
 			PoliciesErrorReturn:       policiesCfg.ErrorReturn,
 			VSNamespace:               vsEx.VirtualServer.Namespace,
 			VSName:                    vsEx.VirtualServer.Name,
@@ -1205,6 +1432,34 @@ func addDosConfigToLocations(dosCfg *version2.Dos, locations []version2.Location
 	}
 }
 
+func addOtelTraceToLocations(otelTrace *version2.OtelTrace, locations []version2.Location) {
+	for i := range locations {
+		locations[i].OtelTrace = otelTrace
+	}
+}
+
+func addRouteProxyToLocations(proxy *conf_v1.RouteProxy, locations []version2.Location) {
+	for i := range locations {
+		addRouteProxyToLocation(proxy, &locations[i])
+	}
+}
+
+// addRouteProxyToLocation overrides the proxy settings that the location inherited from its upstream
+// with the ones of the route. Locations that don't proxy requests (redirects and returns) are left intact.
+func addRouteProxyToLocation(proxy *conf_v1.RouteProxy, location *version2.Location) {
+	if proxy == nil || (location.ProxyPass == "" && location.GRPCPass == "") {
+		return
+	}
+
+	location.ProxyConnectTimeout = generateTimeWithDefault(proxy.ProxyConnectTimeout, location.ProxyConnectTimeout)
+	location.ProxyReadTimeout = generateTimeWithDefault(proxy.ProxyReadTimeout, location.ProxyReadTimeout)
+	location.ProxySendTimeout = generateTimeWithDefault(proxy.ProxySendTimeout, location.ProxySendTimeout)
+	location.ClientMaxBodySize = generateString(proxy.ClientMaxBodySize, location.ClientMaxBodySize)
+	location.ProxyBuffering = generateBool(proxy.ProxyBuffering, location.ProxyBuffering)
+	location.ProxyBuffers = generateBuffers(proxy.ProxyBuffers, location.ProxyBuffers)
+	location.ProxyBufferSize = generateString(proxy.ProxyBufferSize, location.ProxyBufferSize)
+}
+
 func getUpstreamResourceLabels(owner runtime.Object) version2.UpstreamLabels {
 	var resourceType, resourceName, resourceNamespace string
 
@@ -1230,18 +1485,22 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 	owner runtime.Object,
 	upstreamName string,
 	upstream conf_v1.Upstream,
-	isExternalNameSvc bool,
+	resolve bool,
 	endpoints []string,
 ) version2.Upstream {
 	var upsServers []version2.UpstreamServer
 	for _, e := range endpoints {
 		s := version2.UpstreamServer{
 			Address: e,
+			Weight:  upstream.Weight,
 		}
 		upsServers = append(upsServers, s)
 	}
 
 	lbMethod := generateLBMethod(upstream.LBMethod, vsc.cfgParams.LBMethod)
+	if isHashSessionCookie(upstream.SessionCookie) {
+		lbMethod = generateHashSessionLBMethod(generateSessionVariable(upstreamName), upstream.SessionCookie)
+	}
 
 	upstreamLabels := getUpstreamResourceLabels(owner)
 	upstreamLabels.Service = upstream.Service
@@ -1250,7 +1509,7 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 		Name:             upstreamName,
 		UpstreamLabels:   upstreamLabels,
 		Servers:          upsServers,
-		Resolve:          isExternalNameSvc,
+		Resolve:          resolve,
 		LBMethod:         lbMethod,
 		Keepalive:        generateIntFromPointer(upstream.Keepalive, vsc.cfgParams.Keepalive),
 		MaxFails:         generateIntFromPointer(upstream.MaxFails, vsc.cfgParams.MaxFails),
@@ -1269,6 +1528,67 @@ func (vsc *virtualServerConfigurator) generateUpstream(
 	return ups
 }
 
+// IsLBMethodCompatibleWithBackup checks if backup servers can be used with the load balancing method.
+func IsLBMethodCompatibleWithBackup(lbMethod string) bool {
+	lbMethod = strings.TrimSpace(lbMethod)
+	return !strings.HasPrefix(lbMethod, "hash") && !strings.HasPrefix(lbMethod, "ip_hash") && !strings.HasPrefix(lbMethod, "random")
+}
+
+// addServersForAdditionalServices adds the servers of the weighted Services and of the backup Service of the upstream.
+// NGINX doesn't allow an upstream with backup servers only, so the backup servers become the primary servers
+// when no other server is available. The backup servers are not used if the load balancing method of the upstream,
+// which can come from the ConfigMap, doesn't support them.
+func (vsc *virtualServerConfigurator) addServersForAdditionalServices(
+	owner runtime.Object,
+	ups *version2.Upstream,
+	namespace string,
+	upstream conf_v1.Upstream,
+	virtualServerEx *VirtualServerEx,
+) {
+	if len(upstream.Services) == 0 && upstream.Backup == "" {
+		return
+	}
+
+	var servers []version2.UpstreamServer
+	for _, s := range ups.Servers {
+		if s.Address != nginx502Server {
+			servers = append(servers, s)
+		}
+	}
+
+	for _, svc := range upstream.Services {
+		for _, e := range virtualServerEx.Endpoints[GenerateEndpointsKey(namespace, svc.Name, nil, svc.Port)] {
+			servers = append(servers, version2.UpstreamServer{
+				Address: e,
+				Weight:  svc.Weight,
+				Service: svc.Name,
+			})
+		}
+	}
+
+	if upstream.Backup != "" {
+		isBackup := len(servers) > 0
+		if isBackup && !IsLBMethodCompatibleWithBackup(ups.LBMethod) {
+			vsc.addWarningf(owner, "Backup service %s of upstream %s is not used because lb method '%s' is incompatible with backup servers",
+				upstream.Backup, upstream.Name, ups.LBMethod)
+		} else {
+			for _, e := range virtualServerEx.Endpoints[GenerateEndpointsKey(namespace, upstream.Backup, nil, upstream.BackupPort)] {
+				servers = append(servers, version2.UpstreamServer{
+					Address: e,
+					Backup:  isBackup,
+					Service: upstream.Backup,
+				})
+			}
+		}
+	}
+
+	if !vsc.isPlus && len(servers) == 0 {
+		servers = []version2.UpstreamServer{{Address: nginx502Server}}
+	}
+
+	ups.Servers = servers
+}
+
 func (vsc *virtualServerConfigurator) generateSlowStartForPlus(
 	owner runtime.Object,
 	upstream conf_v1.Upstream,
@@ -1358,7 +1678,7 @@ func generateHealthCheck(
 }
 
 func generateSessionCookie(sc *conf_v1.SessionCookie) *version2.SessionCookie {
-	if sc == nil || !sc.Enable {
+	if sc == nil || !sc.Enable || isHashSessionCookie(sc) {
 		return nil
 	}
 
@@ -1373,6 +1693,120 @@ func generateSessionCookie(sc *conf_v1.SessionCookie) *version2.SessionCookie {
 	}
 }
 
+// isHashSessionCookie checks if the session persistence uses the hash mode, which is also supported by NGINX.
+func isHashSessionCookie(sc *conf_v1.SessionCookie) bool {
+	return sc != nil && sc.Enable && sc.Mode == "hash"
+}
+
+// maxSessionCookieAge is the Max-Age of the session cookie with the max expiration time, which is 10 years.
+const maxSessionCookieAge = 315360000
+
+func generateSessionVariable(upstreamName string) string {
+	return fmt.Sprintf("$%s_session", strings.ReplaceAll(upstreamName, "-", "_"))
+}
+
+// generateHashSessionLBMethod generates the consistent hash load balancing method of the hash mode of the session persistence.
+// The key is a header, the client IP or the session variable, which holds the session cookie. Without the cookie,
+// the session variable holds the request ID, which becomes the value of the new cookie.
+func generateHashSessionLBMethod(sessionVariable string, sc *conf_v1.SessionCookie) string {
+	key := sessionVariable
+	if sc.Header != "" {
+		key = fmt.Sprintf("$http_%s", strings.ReplaceAll(sc.Header, "-", "_"))
+	} else if sc.ClientIP {
+		key = "$remote_addr"
+	}
+
+	return fmt.Sprintf("hash %s consistent", key)
+}
+
+// generateHashSessionCookieMaps generates the maps of the hash mode of the session persistence with a cookie:
+// the first map generates the session key, the second one the Set-Cookie header that sets the cookie
+// when the request doesn't have it.
+func generateHashSessionCookieMaps(sessionVariable string, sc *conf_v1.SessionCookie) []version2.Map {
+	if !isHashSessionCookie(sc) || sc.Header != "" || sc.ClientIP {
+		return nil
+	}
+
+	cookieVariable := fmt.Sprintf("$cookie_%s", sc.Name)
+
+	return []version2.Map{
+		{
+			Source:   cookieVariable,
+			Variable: sessionVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "$request_id",
+				},
+				{
+					Value:  "default",
+					Result: cookieVariable,
+				},
+			},
+		},
+		{
+			Source:   cookieVariable,
+			Variable: sessionVariable + "_cookie",
+			Parameters: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: fmt.Sprintf("%q", generateHashSessionCookie(sc, sessionVariable)),
+				},
+				{
+					Value:  "default",
+					Result: `""`,
+				},
+			},
+		},
+	}
+}
+
+func generateHashSessionCookie(sc *conf_v1.SessionCookie, sessionVariable string) string {
+	path := sc.Path
+	if path == "" {
+		path = "/"
+	}
+
+	cookie := fmt.Sprintf("%s=%s; Path=%s", sc.Name, sessionVariable, path)
+
+	if sc.Expires == "max" {
+		cookie += fmt.Sprintf("; Max-Age=%d", maxSessionCookieAge)
+	} else if maxAge, err := ParseTimeToSeconds(sc.Expires); err == nil {
+		cookie += fmt.Sprintf("; Max-Age=%d", maxAge)
+	}
+
+	if sc.Domain != "" {
+		cookie += fmt.Sprintf("; Domain=%s", sc.Domain)
+	}
+
+	if sc.HTTPOnly {
+		cookie += "; HttpOnly"
+	}
+
+	if sc.Secure {
+		cookie += "; Secure"
+	}
+
+	return cookie
+}
+
+// generateHashSessionCookieAddHeader generates the header that sets the session cookie of the hash mode
+// of the session persistence. NGINX doesn't add the header when the request already has the cookie,
+// because the value of the header is empty.
+func generateHashSessionCookieAddHeader(sessionVariable string, sc *conf_v1.SessionCookie) *version2.AddHeader {
+	if !isHashSessionCookie(sc) || sc.Header != "" || sc.ClientIP {
+		return nil
+	}
+
+	return &version2.AddHeader{
+		Header: version2.Header{
+			Name:  "Set-Cookie",
+			Value: sessionVariable + "_cookie",
+		},
+		Always: true,
+	}
+}
+
 func generateStatusMatchName(upstreamName string) string {
 	return fmt.Sprintf("%s_match", upstreamName)
 }
@@ -1384,6 +1818,71 @@ func generateUpstreamStatusMatch(upstreamName string, status string) version2.St
 	}
 }
 
+// isDNSNameAddress checks if the host of a host:port address is a DNS name rather than an IP address.
+func isDNSNameAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return net.ParseIP(host) == nil
+}
+
+func hasDNSNameAddress(addresses []string) bool {
+	for _, address := range addresses {
+		if isDNSNameAddress(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateUpstreamAddressVariable(upstreamName string) string {
+	return fmt.Sprintf("$%s_address", strings.ReplaceAll(upstreamName, "-", "_"))
+}
+
+// generateUpstreamAddressMap generates a map that sets the variable to the address regardless of the request.
+func generateUpstreamAddressMap(variable string, address string) version2.Map {
+	return version2.Map{
+		Source:   "$host",
+		Variable: variable,
+		Parameters: []version2.Parameter{
+			{
+				Value:  "default",
+				Result: fmt.Sprintf("%q", address),
+			},
+		},
+	}
+}
+
+// setRuntimeResolvedProxyPass makes the location proxy the requests of the upstream to the address stored in the variable.
+// proxy_pass with a variable passes a URI as is instead of replacing the path of the location with it,
+// so the rewrite of the path is done with a rewrite directive instead.
+func setRuntimeResolvedProxyPass(loc *version2.Location, upstreamName string, variable string) {
+	proxyPass := replaceUpstreamInPass(loc.ProxyPass, upstreamName, variable)
+	if proxyPass != loc.ProxyPass && loc.ProxyPassRewrite != "" {
+		path := strings.TrimSpace(strings.TrimPrefix(loc.Path, "="))
+		loc.Rewrites = append(loc.Rewrites, fmt.Sprintf(`"^%v(.*)$" "%v$1" break`, path, loc.ProxyPassRewrite))
+		loc.ProxyPassRewrite = ""
+	}
+
+	loc.ProxyPass = proxyPass
+	loc.GRPCPass = replaceUpstreamInPass(loc.GRPCPass, upstreamName, variable)
+}
+
+func replaceUpstreamInPass(pass string, upstreamName string, variable string) string {
+	i := strings.Index(pass, "://")
+	if i == -1 {
+		return pass
+	}
+
+	rest := strings.TrimPrefix(pass[i+3:], upstreamName)
+	if rest == pass[i+3:] || (rest != "" && !strings.HasPrefix(rest, "$")) {
+		return pass
+	}
+
+	return pass[:i+3] + variable + rest
+}
+
 // GenerateExternalNameSvcKey returns the key to identify an ExternalName service.
 func GenerateExternalNameSvcKey(namespace string, service string) string {
 	return fmt.Sprintf("%v/%v", namespace, service)
@@ -1675,6 +2174,11 @@ func generateProxyAddHeaders(proxy *conf_v1.ActionProxy) []version2.AddHeader {
 func generateLocationForProxying(path string, upstreamName string, upstream conf_v1.Upstream,
 	cfgParams *ConfigParams, errorPages []conf_v1.ErrorPage, internal bool, errPageIndex int,
 	proxySSLName string, proxy *conf_v1.ActionProxy, originalPath string, locationSnippets []string, isVSR bool, vsrName string, vsrNamespace string) version2.Location {
+	addHeaders := generateProxyAddHeaders(proxy)
+	if sessionHeader := generateHashSessionCookieAddHeader(generateSessionVariable(upstreamName), upstream.SessionCookie); sessionHeader != nil {
+		addHeaders = append(addHeaders, *sessionHeader)
+	}
+
 	return version2.Location{
 		Path:                     generatePath(path),
 		Internal:                 internal,
@@ -1697,7 +2201,7 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 		ProxyHideHeaders:         generateProxyHideHeaders(proxy),
 		ProxyPassHeaders:         generateProxyPassHeaders(proxy),
 		ProxyIgnoreHeaders:       generateProxyIgnoreHeaders(proxy),
-		AddHeaders:               generateProxyAddHeaders(proxy),
+		AddHeaders:               addHeaders,
 		ProxyPassRewrite:         generateProxyPassRewrite(path, proxy, internal),
 		Rewrites:                 generateRewrites(path, proxy, internal, originalPath, isGRPC(upstream.Type)),
 		HasKeepalive:             upstreamHasKeepalive(upstream, cfgParams),
@@ -1711,6 +2215,315 @@ func generateLocationForProxying(path string, upstreamName string, upstream conf
 	}
 }
 
+func generateRequestIDEnable(requestID *conf_v1.RequestID, cfgParams *ConfigParams) bool {
+	if requestID == nil {
+		return cfgParams.RequestID
+	}
+	return requestID.Enable
+}
+
+// generateRequestIDProxy generates an ActionProxy that passes the request ID to the upstream
+// and returns it to the client in the request ID header.
+func generateRequestIDProxy(header string) *conf_v1.ActionProxy {
+	if header == "" {
+		return nil
+	}
+
+	return &conf_v1.ActionProxy{
+		RequestHeaders: &conf_v1.ProxyRequestHeaders{
+			Set: []conf_v1.Header{{Name: header, Value: requestIDVariable}},
+		},
+		ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+			Hide: []string{header},
+			Add: []conf_v1.AddHeader{
+				{
+					Header: conf_v1.Header{Name: header, Value: requestIDVariable},
+					Always: true,
+				},
+			},
+		},
+	}
+}
+
+func addRequestIDToErrorPageLocations(header string, errorPageLocations []version2.ErrorPageLocation) {
+	if header == "" {
+		return
+	}
+
+	for i := range errorPageLocations {
+		if hasHeader(errorPageLocations[i].Headers, header) {
+			// the header of the error page takes precedence
+			continue
+		}
+		errorPageLocations[i].Headers = append(errorPageLocations[i].Headers, version2.Header{Name: header, Value: requestIDVariable})
+	}
+}
+
+func hasHeader(headers []version2.Header, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateHeadersProxy converts the headers of a VirtualServer or a VirtualServerRoute into an ActionProxy
+// that can be merged into the actions of their routes.
+func generateHeadersProxy(headers *conf_v1.Headers, cfgParams *ConfigParams, tls bool) *conf_v1.ActionProxy {
+	if headers == nil {
+		return nil
+	}
+
+	var securityHeaders *conf_v1.ProxyResponseHeaders
+	if addHeaders := generateSecurityHeaders(headers.Security, cfgParams, tls); len(addHeaders) > 0 {
+		securityHeaders = &conf_v1.ProxyResponseHeaders{Add: addHeaders}
+		// the headers of the upstream are hidden to avoid sending duplicate headers to clients
+		for _, h := range addHeaders {
+			securityHeaders.Hide = append(securityHeaders.Hide, h.Name)
+		}
+	}
+
+	return &conf_v1.ActionProxy{
+		RequestHeaders:  headers.RequestHeaders,
+		ResponseHeaders: mergeResponseHeaders(securityHeaders, headers.ResponseHeaders),
+	}
+}
+
+// generateSecurityHeadersForResource generates the security headers of a VirtualServer or a VirtualServerRoute.
+func generateSecurityHeadersForResource(headers *conf_v1.Headers, cfgParams *ConfigParams, tls bool) []conf_v1.AddHeader {
+	if headers == nil {
+		return nil
+	}
+
+	return generateSecurityHeaders(headers.Security, cfgParams, tls)
+}
+
+// addSecurityHeadersToLocations adds the security headers to the locations of the redirect and return actions,
+// which respond to clients without passing requests to an upstream. The other locations get the security headers
+// in their proxy response headers.
+func addSecurityHeadersToLocations(headers []conf_v1.AddHeader, locations []version2.Location, returnLocations []version2.ReturnLocation) {
+	for _, h := range headers {
+		for i := range locations {
+			if locations[i].InternalProxyPass == "" {
+				continue
+			}
+			locations[i].AddHeaders = append(locations[i].AddHeaders, version2.AddHeader{
+				Header: version2.Header{Name: h.Name, Value: h.Value},
+				Always: h.Always,
+			})
+		}
+
+		for i := range returnLocations {
+			returnLocations[i].Headers = append(returnLocations[i].Headers, version2.Header{Name: h.Name, Value: h.Value})
+		}
+	}
+}
+
+// addSecurityHeadersToErrorPageLocations adds the security headers to the locations of the error pages.
+// The headers of the error pages take precedence.
+func addSecurityHeadersToErrorPageLocations(headers []conf_v1.AddHeader, errorPageLocations []version2.ErrorPageLocation) {
+	for i := range errorPageLocations {
+		pageHeaders := errorPageLocations[i].Headers
+		for _, h := range headers {
+			if !hasHeader(pageHeaders, h.Name) {
+				errorPageLocations[i].Headers = append(errorPageLocations[i].Headers, version2.Header{Name: h.Name, Value: h.Value})
+			}
+		}
+	}
+}
+
+// generateSecurityHeaders generates the security headers. Like for the nginx.org/hsts annotation of Ingress resources,
+// the HSTS header requires TLS termination or the hsts-behind-proxy ConfigMap key, because browsers ignore
+// the header in the responses over plain HTTP.
+func generateSecurityHeaders(security *conf_v1.SecurityHeaders, cfgParams *ConfigParams, tls bool) []conf_v1.AddHeader {
+	if security == nil {
+		return nil
+	}
+
+	var headers []conf_v1.AddHeader
+
+	if security.HSTS != nil && security.HSTS.Enable && (tls || cfgParams.HSTSBehindProxy) {
+		maxAge := security.HSTS.MaxAge
+		if maxAge == 0 {
+			maxAge = cfgParams.HSTSMaxAge
+		}
+
+		value := fmt.Sprintf("max-age=%d", maxAge)
+		if security.HSTS.IncludeSubdomains {
+			value += "; includeSubDomains"
+		}
+		if security.HSTS.Preload {
+			value += "; preload"
+		}
+
+		headers = append(headers, generateSecurityHeader("Strict-Transport-Security", value))
+	}
+
+	if security.FrameOptions != "" {
+		headers = append(headers, generateSecurityHeader("X-Frame-Options", security.FrameOptions))
+	}
+
+	if security.ContentSecurityPolicy != "" {
+		headers = append(headers, generateSecurityHeader("Content-Security-Policy", security.ContentSecurityPolicy))
+	}
+
+	if security.ReferrerPolicy != "" {
+		headers = append(headers, generateSecurityHeader("Referrer-Policy", security.ReferrerPolicy))
+	}
+
+	return headers
+}
+
+func generateSecurityHeader(name string, value string) conf_v1.AddHeader {
+	return conf_v1.AddHeader{
+		Header: conf_v1.Header{
+			Name:  name,
+			Value: value,
+		},
+		Always: true,
+	}
+}
+
+// applyHeadersToRoute returns a copy of the route where the headers are merged into the actions
+// that pass requests to an upstream. The headers of the actions take precedence.
+func applyHeadersToRoute(headers *conf_v1.ActionProxy, route conf_v1.Route) conf_v1.Route {
+	if headers == nil {
+		return route
+	}
+
+	r := route.DeepCopy()
+
+	applyHeadersToAction(headers, r.Action)
+	for i := range r.Splits {
+		applyHeadersToAction(headers, r.Splits[i].Action)
+	}
+	for i := range r.Matches {
+		applyHeadersToAction(headers, r.Matches[i].Action)
+		for j := range r.Matches[i].Splits {
+			applyHeadersToAction(headers, r.Matches[i].Splits[j].Action)
+		}
+	}
+
+	return *r
+}
+
+func applyHeadersToAction(headers *conf_v1.ActionProxy, action *conf_v1.Action) {
+	if action == nil || action.Redirect != nil || action.Return != nil {
+		return
+	}
+
+	if action.Pass != "" {
+		action.Proxy = &conf_v1.ActionProxy{Upstream: action.Pass}
+		action.Pass = ""
+	}
+
+	action.Proxy = mergeActionProxyHeaders(headers, action.Proxy)
+}
+
+// mergeActionProxyHeaders returns a copy of the proxy with the headers of the parent added.
+// The headers of the proxy take precedence over the headers of the parent.
+func mergeActionProxyHeaders(parent *conf_v1.ActionProxy, proxy *conf_v1.ActionProxy) *conf_v1.ActionProxy {
+	if parent == nil {
+		return proxy
+	}
+
+	merged := &conf_v1.ActionProxy{}
+	if proxy != nil {
+		merged = proxy.DeepCopy()
+	}
+
+	merged.RequestHeaders = mergeRequestHeaders(parent.RequestHeaders, merged.RequestHeaders)
+	merged.ResponseHeaders = mergeResponseHeaders(parent.ResponseHeaders, merged.ResponseHeaders)
+
+	return merged
+}
+
+func mergeRequestHeaders(parent *conf_v1.ProxyRequestHeaders, headers *conf_v1.ProxyRequestHeaders) *conf_v1.ProxyRequestHeaders {
+	if parent == nil {
+		return headers
+	}
+	if headers == nil {
+		return parent.DeepCopy()
+	}
+
+	merged := &conf_v1.ProxyRequestHeaders{
+		Pass: headers.Pass,
+		Set:  mergeHeaderLists(parent.Set, headers.Set),
+	}
+	if merged.Pass == nil {
+		merged.Pass = parent.Pass
+	}
+
+	return merged
+}
+
+func mergeResponseHeaders(parent *conf_v1.ProxyResponseHeaders, headers *conf_v1.ProxyResponseHeaders) *conf_v1.ProxyResponseHeaders {
+	if parent == nil {
+		return headers
+	}
+	if headers == nil {
+		return parent.DeepCopy()
+	}
+
+	return &conf_v1.ProxyResponseHeaders{
+		Hide:   mergeHeaderNames(parent.Hide, headers.Hide),
+		Pass:   mergeHeaderNames(parent.Pass, headers.Pass),
+		Ignore: mergeHeaderNames(parent.Ignore, headers.Ignore),
+		Add:    mergeAddHeaderLists(parent.Add, headers.Add),
+	}
+}
+
+// mergeHeaderLists returns the headers of the parent that are not overridden by the headers, followed by the headers.
+func mergeHeaderLists(parent []conf_v1.Header, headers []conf_v1.Header) []conf_v1.Header {
+	names := make(map[string]bool)
+	for _, h := range headers {
+		names[strings.ToLower(h.Name)] = true
+	}
+
+	var merged []conf_v1.Header
+	for _, h := range parent {
+		if !names[strings.ToLower(h.Name)] {
+			merged = append(merged, h)
+		}
+	}
+
+	return append(merged, headers...)
+}
+
+func mergeAddHeaderLists(parent []conf_v1.AddHeader, headers []conf_v1.AddHeader) []conf_v1.AddHeader {
+	names := make(map[string]bool)
+	for _, h := range headers {
+		names[strings.ToLower(h.Name)] = true
+	}
+
+	var merged []conf_v1.AddHeader
+	for _, h := range parent {
+		if !names[strings.ToLower(h.Name)] {
+			merged = append(merged, h)
+		}
+	}
+
+	return append(merged, headers...)
+}
+
+func mergeHeaderNames(parent []string, names []string) []string {
+	existing := make(map[string]bool)
+	for _, n := range names {
+		existing[strings.ToLower(n)] = true
+	}
+
+	var merged []string
+	for _, n := range parent {
+		if !existing[strings.ToLower(n)] {
+			merged = append(merged, n)
+			existing[strings.ToLower(n)] = true
+		}
+	}
+
+	return append(merged, names...)
+}
+
 func generateProxyInterceptErrors(errorPages []conf_v1.ErrorPage) bool {
 	return len(errorPages) > 0
 }
@@ -1785,6 +2598,8 @@ type routingCfg struct {
 
 func generateSplits(
 	splits []conf_v1.Split,
+	splitKey *conf_v1.SplitKey,
+	stickySplits *conf_v1.StickySplits,
 	upstreamNamer *upstreamNamer,
 	crUpstreams map[string]conf_v1.Upstream,
 	variableNamer *variableNamer,
@@ -1799,7 +2614,7 @@ func generateSplits(
 	vsrName string,
 	vsrNamespace string,
 	vscWarnings Warnings,
-) (version2.SplitClient, []version2.Location, []version2.ReturnLocation) {
+) (version2.SplitClient, []version2.Map, []version2.Location, []version2.ReturnLocation) {
 	var distributions []version2.Distribution
 
 	for i, s := range splits {
@@ -1810,8 +2625,44 @@ func generateSplits(
 		distributions = append(distributions, d)
 	}
 
+	source := generateSplitKeySource(splitKey)
+
+	var maps []version2.Map
+	var stickyHeader *version2.AddHeader
+
+	// With sticky splits, the split key is stored in a cookie, and split_clients chooses the split from the key on every
+	// request. The client keeps its split as long as the weights of the splits don't change.
+	if stickySplits != nil {
+		cookieVariable := fmt.Sprintf("$cookie_%s", stickySplits.Cookie)
+		keyVariable := variableNamer.GetNameForSplitClientKeyVariable(scIndex)
+
+		maps = append(maps, version2.Map{
+			Source:   cookieVariable,
+			Variable: keyVariable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: source,
+				},
+				{
+					Value:  "default",
+					Result: cookieVariable,
+				},
+			},
+		})
+
+		source = keyVariable
+		stickyHeader = &version2.AddHeader{
+			Header: version2.Header{
+				Name:  "Set-Cookie",
+				Value: generateStickySplitsCookie(stickySplits, keyVariable),
+			},
+			Always: true,
+		}
+	}
+
 	splitClient := version2.SplitClient{
-		Source:        "$request_id",
+		Source:        source,
 		Variable:      variableNamer.GetNameForSplitClientVariable(scIndex),
 		Distributions: distributions,
 	}
@@ -1823,17 +2674,59 @@ func generateSplits(
 		path := fmt.Sprintf("/%vsplits_%d_split_%d", internalLocationPrefix, scIndex, i)
 		upstreamName := upstreamNamer.GetNameForUpstreamFromAction(s.Action)
 		upstream := crUpstreams[upstreamName]
-		proxySSLName := generateProxySSLName(upstream.Service, upstreamNamer.namespace)
+		proxySSLName := generateProxySSLNameForUpstream(upstream, upstreamNamer.namespace)
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, s.Action, cfgParams, errorPages, true,
 			proxySSLName, originalPath, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+		// the cookie is only set in the responses of the splits that pass requests to an upstream
+		if stickyHeader != nil && loc.InternalProxyPass == "" {
+			loc.AddHeaders = append(loc.AddHeaders, *stickyHeader)
+		}
 		locations = append(locations, loc)
 		if returnLoc != nil {
 			returnLocations = append(returnLocations, *returnLoc)
 		}
 	}
 
-	return splitClient, locations, returnLocations
+	return splitClient, maps, locations, returnLocations
+}
+
+func generateSplitKeySource(splitKey *conf_v1.SplitKey) string {
+	if splitKey == nil {
+		return "$request_id"
+	}
+
+	if splitKey.Cookie != "" {
+		return fmt.Sprintf("$cookie_%s", splitKey.Cookie)
+	}
+
+	if splitKey.Header != "" {
+		return fmt.Sprintf("$http_%s", strings.ReplaceAll(splitKey.Header, "-", "_"))
+	}
+
+	if splitKey.ClientIP {
+		return "$remote_addr"
+	}
+
+	if splitKey.JWTSubject {
+		return "$jwt_claim_sub"
+	}
+
+	return "$request_id"
+}
+
+func generateStickySplitsCookie(stickySplits *conf_v1.StickySplits, keyVariable string) string {
+	path := stickySplits.Path
+	if path == "" {
+		path = "/"
+	}
+
+	cookie := fmt.Sprintf("%s=%s; Path=%s", stickySplits.Cookie, keyVariable, path)
+	if stickySplits.MaxAge > 0 {
+		cookie += fmt.Sprintf("; Max-Age=%d", stickySplits.MaxAge)
+	}
+
+	return cookie
 }
 
 func generateDefaultSplitsConfig(
@@ -1853,8 +2746,9 @@ func generateDefaultSplitsConfig(
 	vsrNamespace string,
 	vscWarnings Warnings,
 ) routingCfg {
-	sc, locs, returnLocs := generateSplits(route.Splits, upstreamNamer, crUpstreams, variableNamer, scIndex, cfgParams,
-		errorPages, originalPath, locSnippets, enableSnippets, retLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
+	sc, maps, locs, returnLocs := generateSplits(route.Splits, route.SplitKey, route.StickySplits, upstreamNamer, crUpstreams,
+		variableNamer, scIndex, cfgParams, errorPages, originalPath, locSnippets, enableSnippets, retLocIndex, isVSR, vsrName,
+		vsrNamespace, vscWarnings)
 
 	splitClientVarName := variableNamer.GetNameForSplitClientVariable(scIndex)
 
@@ -1864,6 +2758,7 @@ func generateDefaultSplitsConfig(
 	}
 
 	return routingCfg{
+		Maps:                     maps,
 		SplitClients:             []version2.SplitClient{sc},
 		Locations:                locs,
 		InternalRedirectLocation: irl,
@@ -1886,12 +2781,13 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 				successfulResult = variableNamer.GetNameForVariableForMatchesRouteMap(index, i, j+1)
 			}
 
-			params := generateParametersForMatchesRouteMap(c.Value, successfulResult)
+			params := generateParametersForMatchCondition(c, successfulResult)
 
 			matchMap := version2.Map{
 				Source:     source,
 				Variable:   variable,
 				Parameters: params,
+				Geo:        c.ClientIP,
 			}
 			maps = append(maps, matchMap)
 		}
@@ -1948,8 +2844,10 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 	for i, m := range route.Matches {
 		if len(m.Splits) > 0 {
 			newRetLocIndex := retLocIndex + len(returnLocations)
-			sc, locs, returnLocs := generateSplits(
+			sc, scMaps, locs, returnLocs := generateSplits(
 				m.Splits,
+				route.SplitKey,
+				route.StickySplits,
 				upstreamNamer,
 				crUpstreams,
 				variableNamer,
@@ -1967,13 +2865,14 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 			)
 			scLocalIndex++
 			splitClients = append(splitClients, sc)
+			maps = append(maps, scMaps...)
 			locations = append(locations, locs...)
 			returnLocations = append(returnLocations, returnLocs...)
 		} else {
 			path := fmt.Sprintf("/%vmatches_%d_match_%d", internalLocationPrefix, index, i)
 			upstreamName := upstreamNamer.GetNameForUpstreamFromAction(m.Action)
 			upstream := crUpstreams[upstreamName]
-			proxySSLName := generateProxySSLName(upstream.Service, upstreamNamer.namespace)
+			proxySSLName := generateProxySSLNameForUpstream(upstream, upstreamNamer.namespace)
 			newRetLocIndex := retLocIndex + len(returnLocations)
 			loc, returnLoc := generateLocation(path, upstreamName, upstream, m.Action, cfgParams, errorPages, true,
 				proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
@@ -1987,8 +2886,10 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 	// Generate default splits or default action
 	if len(route.Splits) > 0 {
 		newRetLocIndex := retLocIndex + len(returnLocations)
-		sc, locs, returnLocs := generateSplits(
+		sc, scMaps, locs, returnLocs := generateSplits(
 			route.Splits,
+			route.SplitKey,
+			route.StickySplits,
 			upstreamNamer,
 			crUpstreams,
 			variableNamer,
@@ -2005,13 +2906,14 @@ func generateMatchesConfig(route conf_v1.Route, upstreamNamer *upstreamNamer, cr
 			vscWarnings,
 		)
 		splitClients = append(splitClients, sc)
+		maps = append(maps, scMaps...)
 		locations = append(locations, locs...)
 		returnLocations = append(returnLocations, returnLocs...)
 	} else {
 		path := fmt.Sprintf("/%vmatches_%d_default", internalLocationPrefix, index)
 		upstreamName := upstreamNamer.GetNameForUpstreamFromAction(route.Action)
 		upstream := crUpstreams[upstreamName]
-		proxySSLName := generateProxySSLName(upstream.Service, upstreamNamer.namespace)
+		proxySSLName := generateProxySSLNameForUpstream(upstream, upstreamNamer.namespace)
 		newRetLocIndex := retLocIndex + len(returnLocations)
 		loc, returnLoc := generateLocation(path, upstreamName, upstream, route.Action, cfgParams, errorPages, true,
 			proxySSLName, route.Path, locSnippets, enableSnippets, newRetLocIndex, isVSR, vsrName, vsrNamespace, vscWarnings)
@@ -2060,8 +2962,33 @@ func generateValueForMatchesRouteMap(matchedValue string) (value string, isNegat
 	return fmt.Sprintf(`"%s"`, matchedValue), isNegative
 }
 
-func generateParametersForMatchesRouteMap(matchedValue string, successfulResult string) []version2.Parameter {
-	value, isNegative := generateValueForMatchesRouteMap(matchedValue)
+// generateParametersForMatchCondition generates the map parameters for a condition according to its operator.
+// The negation of a condition swaps the results of the value and the default parameters.
+func generateParametersForMatchCondition(condition conf_v1.Condition, successfulResult string) []version2.Parameter {
+	var value string
+	isNegative := false
+
+	switch condition.Operator {
+	case conf_v1.ConditionOperatorRegex:
+		value = fmt.Sprintf(`"~%s"`, condition.Value)
+	case conf_v1.ConditionOperatorPrefix:
+		value = fmt.Sprintf(`"~^%s"`, regexp.QuoteMeta(condition.Value))
+	case conf_v1.ConditionOperatorPresent:
+		value = `""`
+		isNegative = true
+	case conf_v1.ConditionOperatorAbsent:
+		value = `""`
+	default:
+		if condition.ClientIP {
+			value = condition.Value
+		} else {
+			value, isNegative = generateValueForMatchesRouteMap(condition.Value)
+		}
+	}
+
+	if condition.Negate {
+		isNegative = !isNegative
+	}
 
 	valueResult := successfulResult
 	defaultResult := "0"
@@ -2070,7 +2997,7 @@ func generateParametersForMatchesRouteMap(matchedValue string, successfulResult
 		defaultResult = successfulResult
 	}
 
-	params := []version2.Parameter{
+	return []version2.Parameter{
 		{
 			Value:  value,
 			Result: valueResult,
@@ -2080,11 +3007,21 @@ func generateParametersForMatchesRouteMap(matchedValue string, successfulResult
 			Result: defaultResult,
 		},
 	}
-
-	return params
 }
 
 func getNameForSourceForMatchesRouteMapFromCondition(condition conf_v1.Condition) string {
+	if condition.Method {
+		return "$request_method"
+	}
+
+	if condition.ClientIP {
+		return "$remote_addr"
+	}
+
+	if condition.JWTClaim != "" {
+		return fmt.Sprintf("$jwt_claim_%s", condition.JWTClaim)
+	}
+
 	if condition.Header != "" {
 		return fmt.Sprintf("$http_%s", strings.ReplaceAll(condition.Header, "-", "_"))
 	}
@@ -2110,8 +3047,8 @@ func (vsc *virtualServerConfigurator) generateSSLConfig(owner runtime.Object, tl
 		if vsc.isWildcardEnabled {
 			ssl := version2.SSL{
 				HTTP2:           cfgParams.HTTP2,
-				Certificate:     pemFileNameForWildcardTLSSecret,
-				CertificateKey:  pemFileNameForWildcardTLSSecret,
+				Certificate:     generateSSLCertificatePath(pemFileNameForWildcardTLSSecret, vsc.staticParams),
+				CertificateKey:  generateSSLCertificatePath(pemFileNameForWildcardTLSSecret, vsc.staticParams),
 				RejectHandshake: false,
 			}
 			return &ssl
@@ -2136,6 +3073,8 @@ func (vsc *virtualServerConfigurator) generateSSLConfig(owner runtime.Object, tl
 		name = secretRef.Path
 	}
 
+	name = generateSSLCertificatePath(name, vsc.staticParams)
+
 	ssl := version2.SSL{
 		HTTP2:           cfgParams.HTTP2,
 		Certificate:     name,
@@ -2159,6 +3098,271 @@ func generateTLSRedirectConfig(tls *conf_v1.TLS) *version2.TLSRedirect {
 	return redirect
 }
 
+const defaultRedirectCode = 301
+
+// generateServerRedirects generates the maps and the redirects of a server for the redirects of a VirtualServer.
+// The redirects are checked in the following order: the canonical host, the paths, the lowercase paths
+// and the trailing slash.
+func generateServerRedirects(
+	redirects *conf_v1.Redirects,
+	host string,
+	tlsRedirect *version2.TLSRedirect,
+	oidc *version2.OIDC,
+	namer *variableNamer,
+) ([]version2.Map, []version2.ServerRedirect) {
+	if redirects == nil {
+		return nil, nil
+	}
+
+	var maps []version2.Map
+	var serverRedirects []version2.ServerRedirect
+
+	code := redirects.Code
+	if code == 0 {
+		code = defaultRedirectCode
+	}
+
+	if redirects.CanonicalHost {
+		// a redirect to http would be followed by the TLS redirect, so we redirect to https directly
+		scheme := "$scheme"
+		if tlsRedirect != nil {
+			scheme = "https"
+		}
+
+		variable := namer.GetNameForRedirectVariable("host")
+		maps = append(maps, version2.Map{
+			Source:   "$host",
+			Variable: variable,
+			Parameters: []version2.Parameter{
+				{
+					Value:  fmt.Sprintf("%q", host),
+					Result: `""`,
+				},
+				{
+					Value:  "default",
+					Result: fmt.Sprintf("%q", host),
+				},
+			},
+		})
+		serverRedirects = append(serverRedirects, version2.ServerRedirect{
+			Variable: variable,
+			Code:     code,
+			URL:      fmt.Sprintf("%s://%s$request_uri", scheme, variable),
+		})
+	}
+
+	// the paths are grouped by code, because the code of a return directive can't be a variable
+	var pathCodes []int
+	pathParameters := make(map[int][]version2.Parameter)
+
+	for _, p := range redirects.Paths {
+		pathCode := p.Code
+		if pathCode == 0 {
+			pathCode = code
+		}
+
+		if _, exists := pathParameters[pathCode]; !exists {
+			pathCodes = append(pathCodes, pathCode)
+		}
+
+		pathParameters[pathCode] = append(pathParameters[pathCode], version2.Parameter{
+			Value:  fmt.Sprintf(`"%s"`, generateRedirectFrom(p.From)),
+			Result: fmt.Sprintf(`"%s"`, p.To),
+		})
+	}
+
+	for _, pathCode := range pathCodes {
+		variable := namer.GetNameForRedirectVariable(fmt.Sprintf("paths_%d", pathCode))
+		maps = append(maps, version2.Map{
+			Source:     "$uri",
+			Variable:   variable,
+			Parameters: append(pathParameters[pathCode], version2.Parameter{Value: "default", Result: `""`}),
+		})
+		serverRedirects = append(serverRedirects, version2.ServerRedirect{
+			Variable: variable,
+			Code:     pathCode,
+			URL:      fmt.Sprintf("%s$is_args$args", variable),
+		})
+	}
+
+	if redirects.LowercasePaths {
+		// $lowercase_uri is set by njs in the http context
+		variable := namer.GetNameForRedirectVariable("lowercase")
+		maps = append(maps, version2.Map{
+			Source:   "$uri",
+			Variable: variable,
+			Parameters: append(generateInternalPathParameters(oidc),
+				version2.Parameter{
+					Value:  `"~[A-Z]"`,
+					Result: "$lowercase_uri",
+				},
+				version2.Parameter{
+					Value:  "default",
+					Result: `""`,
+				},
+			),
+		})
+		serverRedirects = append(serverRedirects, version2.ServerRedirect{
+			Variable: variable,
+			Code:     code,
+			URL:      fmt.Sprintf("%s$is_args$args", variable),
+		})
+	}
+
+	if redirects.TrailingSlash != "" {
+		// "remove" doesn't apply to the root path, while "add" doesn't apply to paths that end with a file name,
+		// such as /styles/main.css
+		parameter := version2.Parameter{
+			Value:  `"~^(.+)/$"`,
+			Result: "$1",
+		}
+		if redirects.TrailingSlash == "add" {
+			parameter = version2.Parameter{
+				Value:  `"~^((.*/)?[^/.]+)$"`,
+				Result: `"$1/"`,
+			}
+		}
+
+		variable := namer.GetNameForRedirectVariable("trailing_slash")
+		maps = append(maps, version2.Map{
+			Source:   "$uri",
+			Variable: variable,
+			Parameters: append(generateInternalPathParameters(oidc),
+				parameter,
+				version2.Parameter{
+					Value:  "default",
+					Result: `""`,
+				},
+			),
+		})
+		serverRedirects = append(serverRedirects, version2.ServerRedirect{
+			Variable: variable,
+			Code:     code,
+			URL:      fmt.Sprintf("%s$is_args$args", variable),
+		})
+	}
+
+	return maps, serverRedirects
+}
+
+// generateInternalPathParameters generates the map parameters that exclude the locations generated by the Ingress
+// Controller from the lowercase and trailing slash redirects. Subrequests and internal redirects to those locations,
+// such as the OIDC code exchange or the JWKS request, run the server redirects again, so a redirect would break them.
+// The parameters must precede the regular expressions of a map, because NGINX checks the regular expressions in order.
+func generateInternalPathParameters(oidc *version2.OIDC) []version2.Parameter {
+	parameters := []version2.Parameter{
+		{
+			Value:  `"~^/_"`,
+			Result: `""`,
+		},
+	}
+
+	if oidc == nil {
+		return parameters
+	}
+
+	parameters = append(parameters, version2.Parameter{
+		Value:  `"/logout"`,
+		Result: `""`,
+	})
+	if strings.HasPrefix(oidc.RedirectURI, "/") && !strings.HasPrefix(oidc.RedirectURI, "/_") {
+		parameters = append(parameters, version2.Parameter{
+			Value:  fmt.Sprintf("%q", oidc.RedirectURI),
+			Result: `""`,
+		})
+	}
+
+	return parameters
+}
+
+// generateRedirectFrom generates the value of a map parameter for the path of a redirect.
+// Unlike in locations, a regular expression in a map can't have a space after the modifier.
+func generateRedirectFrom(from string) string {
+	for _, modifier := range []string{"~*", "~"} {
+		if strings.HasPrefix(from, modifier) {
+			return modifier + strings.TrimSpace(strings.TrimPrefix(from, modifier))
+		}
+	}
+
+	return from
+}
+
+func (vsc *virtualServerConfigurator) generateACMEChallengeConfig(owner runtime.Object, tls *conf_v1.TLS) *version2.ACMEChallenge {
+	if tls == nil || tls.ACME == nil {
+		return nil
+	}
+
+	if vsc.staticParams.ACMEAccountThumbprint == "" {
+		vsc.addWarningf(owner, "ACME certificate issuance is not enabled, the certificate will not be issued. Use the -enable-acme command-line argument to enable it")
+		return nil
+	}
+
+	return &version2.ACMEChallenge{
+		Thumbprint: vsc.staticParams.ACMEAccountThumbprint,
+	}
+}
+
+func (vsc *virtualServerConfigurator) generateOtelTrace(
+	owner runtime.Object,
+	tracing *conf_v1.Tracing,
+	samplerVariable string,
+) (*version2.OtelTrace, *version2.SplitClient) {
+	if tracing == nil {
+		return nil, nil
+	}
+
+	if !vsc.cfgParams.MainOtelLoadModule {
+		vsc.addWarningf(owner, "OpenTelemetry tracing is not configured, the tracing configuration will be ignored. Set the otel-exporter-endpoint ConfigMap key to configure it")
+		return nil, nil
+	}
+
+	otelTrace := &version2.OtelTrace{
+		Context: tracing.Context,
+	}
+
+	var sampler *version2.SplitClient
+
+	if tracing.Enable != nil {
+		otelTrace.Trace = "off"
+	}
+
+	if tracing.Enable != nil && *tracing.Enable {
+		percentage := float64(100)
+		if tracing.SamplerRatio != "" {
+			var err error
+			percentage, err = ParseOtelSamplerRatio(tracing.SamplerRatio)
+			if err != nil {
+				vsc.addWarningf(owner, "Invalid tracing sampler ratio %q, all requests will be traced: %v", tracing.SamplerRatio, err)
+				percentage = 100
+			}
+		}
+
+		otelTrace.Trace = generateOtelTrace(percentage, samplerVariable)
+		if otelTrace.Trace == samplerVariable {
+			sampler = &version2.SplitClient{
+				Source:   "$otel_trace_id",
+				Variable: samplerVariable,
+				Distributions: []version2.Distribution{
+					{
+						Weight: formatPercentage(percentage),
+						Value:  "on",
+					},
+					{
+						Weight: "*",
+						Value:  "off",
+					},
+				},
+			}
+		}
+	}
+
+	if otelTrace.Trace == "" && otelTrace.Context == "" {
+		return nil, nil
+	}
+
+	return otelTrace, sampler
+}
+
 func generateTLSRedirectBasedOn(basedOn string) string {
 	if basedOn == "x-forwarded-proto" {
 		return "$http_x_forwarded_proto"
@@ -2166,11 +3370,11 @@ func generateTLSRedirectBasedOn(basedOn string) string {
 	return "$scheme"
 }
 
-func createEndpointsFromUpstream(upstream version2.Upstream) []string {
-	var endpoints []string
+func createEndpointsFromUpstream(upstream version2.Upstream) []nginx.ServerKey {
+	var endpoints []nginx.ServerKey
 
 	for _, server := range upstream.Servers {
-		endpoints = append(endpoints, server.Address)
+		endpoints = append(endpoints, nginx.ServerKey{Service: server.Service, Address: server.Address})
 	}
 
 	return endpoints
@@ -2186,32 +3390,42 @@ func createUpstreamsForPlus(
 	isPlus := true
 	upstreamNamer := newUpstreamNamerForVirtualServer(virtualServerEx.VirtualServer)
 	vsc := newVirtualServerConfigurator(baseCfgParams, isPlus, false, staticParams, false)
+	l := nl.WithResource(slog.Default(), "VirtualServer", virtualServerEx.VirtualServer.Namespace, virtualServerEx.VirtualServer.Name).
+		With(nl.HostKey, virtualServerEx.VirtualServer.Spec.Host)
 
 	for _, u := range virtualServerEx.VirtualServer.Spec.Upstreams {
+		if len(u.Addresses) > 0 {
+			nl.Tracef(l, "Upstream %s has addresses, skipping NGINX Plus endpoints update via API", u.Name)
+			continue
+		}
+
 		isExternalNameSvc := virtualServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(virtualServerEx.VirtualServer.Namespace, u.Service)]
 		if isExternalNameSvc {
-			glog.V(3).Infof("Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", u.Service)
+			nl.Tracef(l, "Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API", u.Service)
 			continue
 		}
 
 		upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
 		upstreamNamespace := virtualServerEx.VirtualServer.Namespace
 
-		endpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Service, u.Subselector, u.Port)
-		endpoints := virtualServerEx.Endpoints[endpointsKey]
+		endpoints := vsc.generateEndpointsForUpstream(virtualServerEx.VirtualServer, upstreamNamespace, u, virtualServerEx)
 
 		ups := vsc.generateUpstream(virtualServerEx.VirtualServer, upstreamName, u, isExternalNameSvc, endpoints)
+		vsc.addServersForAdditionalServices(virtualServerEx.VirtualServer, &ups, upstreamNamespace, u, virtualServerEx)
 		upstreams = append(upstreams, ups)
 	}
 
 	for _, vsr := range virtualServerEx.VirtualServerRoutes {
 		upstreamNamer = newUpstreamNamerForVirtualServerRoute(virtualServerEx.VirtualServer, vsr)
 		for _, u := range vsr.Spec.Upstreams {
+			if len(u.Addresses) > 0 {
+				nl.Tracef(l, "Upstream %s has addresses, skipping NGINX Plus endpoints update via API", u.Name)
+				continue
+			}
+
 			isExternalNameSvc := virtualServerEx.ExternalNameSvcs[GenerateExternalNameSvcKey(vsr.Namespace, u.Service)]
 			if isExternalNameSvc {
-				glog.V(
-					3,
-				).Infof(
+				nl.Tracef(l,
 					"Service %s is Type ExternalName, skipping NGINX Plus endpoints update via API",
 					u.Service,
 				)
@@ -2221,10 +3435,10 @@ func createUpstreamsForPlus(
 			upstreamName := upstreamNamer.GetNameForUpstream(u.Name)
 			upstreamNamespace := vsr.Namespace
 
-			endpointsKey := GenerateEndpointsKey(upstreamNamespace, u.Service, u.Subselector, u.Port)
-			endpoints := virtualServerEx.Endpoints[endpointsKey]
+			endpoints := vsc.generateEndpointsForUpstream(vsr, upstreamNamespace, u, virtualServerEx)
 
 			ups := vsc.generateUpstream(vsr, upstreamName, u, isExternalNameSvc, endpoints)
+			vsc.addServersForAdditionalServices(vsr, &ups, upstreamNamespace, u, virtualServerEx)
 			upstreams = append(upstreams, ups)
 		}
 	}
@@ -2236,12 +3450,30 @@ func createUpstreamServersConfigForPlus(upstream version2.Upstream) nginx.Server
 	if len(upstream.Servers) == 0 {
 		return nginx.ServerConfig{}
 	}
-	return nginx.ServerConfig{
+	cfg := nginx.ServerConfig{
 		MaxFails:    upstream.MaxFails,
 		FailTimeout: upstream.FailTimeout,
 		MaxConns:    upstream.MaxConns,
 		SlowStart:   upstream.SlowStart,
 	}
+
+	for _, s := range upstream.Servers {
+		key := nginx.ServerKey{Service: s.Service, Address: s.Address}
+		if s.Weight > 0 {
+			if cfg.Weights == nil {
+				cfg.Weights = make(map[nginx.ServerKey]int)
+			}
+			cfg.Weights[key] = s.Weight
+		}
+		if s.Backup {
+			if cfg.BackupServers == nil {
+				cfg.BackupServers = make(map[nginx.ServerKey]bool)
+			}
+			cfg.BackupServers[key] = true
+		}
+	}
+
+	return cfg
 }
 
 func generateQueueForPlus(upstreamQueue *conf_v1.UpstreamQueue, defaultTimeout string) *version2.Queue {
@@ -2354,6 +3586,21 @@ func generateProxySSLName(svcName, ns string) string {
 	return fmt.Sprintf("%s.%s.svc", svcName, ns)
 }
 
+// generateProxySSLNameForUpstream returns the server name of the upstream for TLS.
+// For an upstream with addresses, it is the host of the first address.
+func generateProxySSLNameForUpstream(upstream conf_v1.Upstream, ns string) string {
+	if len(upstream.Addresses) == 0 {
+		return generateProxySSLName(upstream.Service, ns)
+	}
+
+	host, _, err := net.SplitHostPort(upstream.Addresses[0])
+	if err != nil {
+		return upstream.Addresses[0]
+	}
+
+	return host
+}
+
 func isTLSEnabled(u conf_v1.Upstream, spiffeCerts bool) bool {
 	return u.TLS.Enable || spiffeCerts
 }
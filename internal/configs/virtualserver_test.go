@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -189,6 +191,14 @@ func TestVariableNamer(t *testing.T) {
 	if result != expected {
 		t.Errorf("GetNameForVariableForMatchesRouteMainMap() returned %q but expected %q", result, expected)
 	}
+
+	// GetNameForRedirectVariable()
+	expected = "$vs_default_cafe_redirect_host"
+
+	result = variableNamer.GetNameForRedirectVariable("host")
+	if result != expected {
+		t.Errorf("GetNameForRedirectVariable() returned %q but expected %q", result, expected)
+	}
 }
 
 func TestGenerateVirtualServerConfig(t *testing.T) {
@@ -4960,6 +4970,200 @@ func TestGenerateSSLConfig(t *testing.T) {
 	}
 }
 
+func TestGenerateACMEChallengeConfig(t *testing.T) {
+	acmeTLS := &conf_v1.TLS{
+		Secret: "cafe-secret",
+		ACME: &conf_v1.ACME{
+			Issuer: "https://acme-v02.api.letsencrypt.org/directory",
+		},
+	}
+
+	tests := []struct {
+		inputTLS         *conf_v1.TLS
+		staticParams     *StaticConfigParams
+		expected         *version2.ACMEChallenge
+		expectedWarnings Warnings
+		msg              string
+	}{
+		{
+			inputTLS:         nil,
+			staticParams:     &StaticConfigParams{ACMEAccountThumbprint: "thumbprint"},
+			expected:         nil,
+			expectedWarnings: Warnings{},
+			msg:              "no TLS",
+		},
+		{
+			inputTLS:         &conf_v1.TLS{Secret: "cafe-secret"},
+			staticParams:     &StaticConfigParams{ACMEAccountThumbprint: "thumbprint"},
+			expected:         nil,
+			expectedWarnings: Warnings{},
+			msg:              "no ACME",
+		},
+		{
+			inputTLS:     acmeTLS,
+			staticParams: &StaticConfigParams{ACMEAccountThumbprint: "thumbprint"},
+			expected: &version2.ACMEChallenge{
+				Thumbprint: "thumbprint",
+			},
+			expectedWarnings: Warnings{},
+			msg:              "ACME enabled",
+		},
+		{
+			inputTLS:     acmeTLS,
+			staticParams: &StaticConfigParams{},
+			expected:     nil,
+			expectedWarnings: Warnings{
+				nil: {
+					"ACME certificate issuance is not enabled, the certificate will not be issued. Use the -enable-acme command-line argument to enable it",
+				},
+			},
+			msg: "ACME not enabled",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, test.staticParams, false)
+
+		// it is ok to use nil as the owner
+		result := vsc.generateACMEChallengeConfig(nil, test.inputTLS)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateACMEChallengeConfig() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+		if !reflect.DeepEqual(vsc.warnings, test.expectedWarnings) {
+			t.Errorf("generateACMEChallengeConfig() returned warnings of \n%v but expected \n%v for the case of %s", vsc.warnings, test.expectedWarnings, test.msg)
+		}
+	}
+}
+
+func TestGenerateOtelTrace(t *testing.T) {
+	samplerVariable := "$vs_default_cafe_otel_sampler_0"
+
+	tests := []struct {
+		tracing          *conf_v1.Tracing
+		cfgParams        *ConfigParams
+		expected         *version2.OtelTrace
+		expectedSampler  *version2.SplitClient
+		expectedWarnings Warnings
+		msg              string
+	}{
+		{
+			tracing:          nil,
+			cfgParams:        &ConfigParams{MainOtelLoadModule: true},
+			expected:         nil,
+			expectedSampler:  nil,
+			expectedWarnings: Warnings{},
+			msg:              "no tracing",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Enable: createPointerFromBool(true),
+			},
+			cfgParams: &ConfigParams{MainOtelLoadModule: true},
+			expected: &version2.OtelTrace{
+				Trace: "on",
+			},
+			expectedSampler:  nil,
+			expectedWarnings: Warnings{},
+			msg:              "tracing enabled",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Enable: createPointerFromBool(false),
+			},
+			cfgParams: &ConfigParams{MainOtelLoadModule: true},
+			expected: &version2.OtelTrace{
+				Trace: "off",
+			},
+			expectedSampler:  nil,
+			expectedWarnings: Warnings{},
+			msg:              "tracing disabled",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Context: "extract",
+			},
+			cfgParams: &ConfigParams{MainOtelLoadModule: true},
+			expected: &version2.OtelTrace{
+				Context: "extract",
+			},
+			expectedSampler:  nil,
+			expectedWarnings: Warnings{},
+			msg:              "trace context only",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Enable:       createPointerFromBool(true),
+				SamplerRatio: "0.05",
+				Context:      "propagate",
+			},
+			cfgParams: &ConfigParams{MainOtelLoadModule: true},
+			expected: &version2.OtelTrace{
+				Trace:   samplerVariable,
+				Context: "propagate",
+			},
+			expectedSampler: &version2.SplitClient{
+				Source:   "$otel_trace_id",
+				Variable: samplerVariable,
+				Distributions: []version2.Distribution{
+					{
+						Weight: "5%",
+						Value:  "on",
+					},
+					{
+						Weight: "*",
+						Value:  "off",
+					},
+				},
+			},
+			expectedWarnings: Warnings{},
+			msg:              "tracing with sampler ratio",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Enable:       createPointerFromBool(true),
+				SamplerRatio: "0",
+			},
+			cfgParams: &ConfigParams{MainOtelLoadModule: true},
+			expected: &version2.OtelTrace{
+				Trace: "off",
+			},
+			expectedSampler:  nil,
+			expectedWarnings: Warnings{},
+			msg:              "tracing with zero sampler ratio",
+		},
+		{
+			tracing: &conf_v1.Tracing{
+				Enable: createPointerFromBool(true),
+			},
+			cfgParams:       &ConfigParams{},
+			expected:        nil,
+			expectedSampler: nil,
+			expectedWarnings: Warnings{
+				nil: {
+					"OpenTelemetry tracing is not configured, the tracing configuration will be ignored. Set the otel-exporter-endpoint ConfigMap key to configure it",
+				},
+			},
+			msg: "OpenTelemetry not configured",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(test.cfgParams, false, false, &StaticConfigParams{}, false)
+
+		// it is ok to use nil as the owner
+		result, sampler := vsc.generateOtelTrace(nil, test.tracing, samplerVariable)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateOtelTrace() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+		if !reflect.DeepEqual(sampler, test.expectedSampler) {
+			t.Errorf("generateOtelTrace() returned sampler %v but expected %v for the case of %s", sampler, test.expectedSampler, test.msg)
+		}
+		if !reflect.DeepEqual(vsc.warnings, test.expectedWarnings) {
+			t.Errorf("generateOtelTrace() returned warnings of \n%v but expected \n%v for the case of %s", vsc.warnings, test.expectedWarnings, test.msg)
+		}
+	}
+}
+
 func TestGenerateRedirectConfig(t *testing.T) {
 	tests := []struct {
 		inputTLS *conf_v1.TLS
@@ -5264,6 +5468,212 @@ func TestCreateUpstreamServersConfigForPlus(t *testing.T) {
 	}
 }
 
+func TestCreateUpstreamServersConfigForPlusWithWeightsAndBackupServers(t *testing.T) {
+	upstream := version2.Upstream{
+		Servers: []version2.UpstreamServer{
+			{
+				Address: "10.0.0.20:80",
+				Weight:  3,
+			},
+			{
+				Address: "10.0.0.21:80",
+			},
+			{
+				Address: "10.0.0.20:80",
+				Weight:  2,
+				Service: "weighted",
+			},
+			{
+				Address: "10.0.0.30:80",
+				Backup:  true,
+				Service: "backup",
+			},
+			{
+				Address: "10.0.0.21:80",
+				Backup:  true,
+				Service: "backup",
+			},
+		},
+		MaxFails:    1,
+		FailTimeout: "10s",
+	}
+
+	expected := nginx.ServerConfig{
+		MaxFails:    1,
+		FailTimeout: "10s",
+		Weights: map[nginx.ServerKey]int{
+			{Address: "10.0.0.20:80"}:                      3,
+			{Service: "weighted", Address: "10.0.0.20:80"}: 2,
+		},
+		BackupServers: map[nginx.ServerKey]bool{
+			{Service: "backup", Address: "10.0.0.30:80"}: true,
+			{Service: "backup", Address: "10.0.0.21:80"}: true,
+		},
+	}
+
+	result := createUpstreamServersConfigForPlus(upstream)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("createUpstreamServersConfigForPlus() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddServersForAdditionalServices(t *testing.T) {
+	vsEx := &VirtualServerEx{
+		Endpoints: map[string][]string{
+			"default/primary:80":  {"10.0.0.1:8080"},
+			"default/weighted:80": {"10.0.0.2:8080"},
+			"default/backup:8080": {"10.0.0.3:8080"},
+			"default/empty:80":    {},
+		},
+	}
+
+	tests := []struct {
+		upstream         conf_v1.Upstream
+		servers          []version2.UpstreamServer
+		isPlus           bool
+		lbMethod         string
+		expectedServers  []version2.UpstreamServer
+		expectedWarnings int
+		msg              string
+	}{
+		{
+			upstream: conf_v1.Upstream{
+				Service: "primary",
+				Port:    80,
+			},
+			servers:         []version2.UpstreamServer{{Address: "10.0.0.1:8080"}},
+			lbMethod:        "random two least_conn",
+			expectedServers: []version2.UpstreamServer{{Address: "10.0.0.1:8080"}},
+			msg:             "no additional services",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service: "primary",
+				Port:    80,
+				Weight:  9,
+				Services: []conf_v1.UpstreamService{
+					{
+						Name:   "weighted",
+						Port:   80,
+						Weight: 1,
+					},
+				},
+				Backup:     "backup",
+				BackupPort: 8080,
+			},
+			servers:  []version2.UpstreamServer{{Address: "10.0.0.1:8080", Weight: 9}},
+			lbMethod: "least_conn",
+			expectedServers: []version2.UpstreamServer{
+				{Address: "10.0.0.1:8080", Weight: 9},
+				{Address: "10.0.0.2:8080", Weight: 1, Service: "weighted"},
+				{Address: "10.0.0.3:8080", Backup: true, Service: "backup"},
+			},
+			msg: "weighted and backup services",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Name:    "tea",
+				Service: "primary",
+				Port:    80,
+				Services: []conf_v1.UpstreamService{
+					{
+						Name:   "weighted",
+						Port:   80,
+						Weight: 1,
+					},
+				},
+				Backup:     "backup",
+				BackupPort: 8080,
+			},
+			servers:  []version2.UpstreamServer{{Address: "10.0.0.1:8080"}},
+			lbMethod: "random two least_conn",
+			expectedServers: []version2.UpstreamServer{
+				{Address: "10.0.0.1:8080"},
+				{Address: "10.0.0.2:8080", Weight: 1, Service: "weighted"},
+			},
+			expectedWarnings: 1,
+			msg:              "backup service with an incompatible lb method",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:    "empty",
+				Port:       80,
+				Backup:     "backup",
+				BackupPort: 8080,
+			},
+			servers:         []version2.UpstreamServer{{Address: nginx502Server}},
+			lbMethod:        "random two least_conn",
+			expectedServers: []version2.UpstreamServer{{Address: "10.0.0.3:8080", Service: "backup"}},
+			msg:             "backup servers replace the primary servers without endpoints",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:    "empty",
+				Port:       80,
+				Backup:     "empty",
+				BackupPort: 8080,
+			},
+			servers:         []version2.UpstreamServer{{Address: nginx502Server}},
+			expectedServers: []version2.UpstreamServer{{Address: nginx502Server}},
+			msg:             "no endpoints in NGINX",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:    "empty",
+				Port:       80,
+				Backup:     "empty",
+				BackupPort: 8080,
+			},
+			isPlus:          true,
+			expectedServers: nil,
+			msg:             "no endpoints in NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&ConfigParams{}, test.isPlus, false, &StaticConfigParams{}, false)
+		vs := &conf_v1.VirtualServer{}
+		ups := version2.Upstream{
+			Servers:  test.servers,
+			LBMethod: test.lbMethod,
+		}
+
+		vsc.addServersForAdditionalServices(vs, &ups, "default", test.upstream, vsEx)
+
+		if diff := cmp.Diff(test.expectedServers, ups.Servers); diff != "" {
+			t.Errorf("addServersForAdditionalServices() servers mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if ups.LBMethod != test.lbMethod {
+			t.Errorf("addServersForAdditionalServices() changed lb method to %q but expected %q for the case of %s", ups.LBMethod, test.lbMethod, test.msg)
+		}
+		if len(vsc.warnings[vs]) != test.expectedWarnings {
+			t.Errorf("addServersForAdditionalServices() returned warnings %v but expected %d for the case of %s", vsc.warnings[vs], test.expectedWarnings, test.msg)
+		}
+	}
+}
+
+func TestIsLBMethodCompatibleWithBackup(t *testing.T) {
+	tests := []struct {
+		lbMethod string
+		expected bool
+	}{
+		{lbMethod: "", expected: true},
+		{lbMethod: "round_robin", expected: true},
+		{lbMethod: "least_conn", expected: true},
+		{lbMethod: "least_time header", expected: true},
+		{lbMethod: "ip_hash", expected: false},
+		{lbMethod: "hash $request_id consistent", expected: false},
+		{lbMethod: "random two least_conn", expected: false},
+	}
+
+	for _, test := range tests {
+		result := IsLBMethodCompatibleWithBackup(test.lbMethod)
+		if result != test.expected {
+			t.Errorf("IsLBMethodCompatibleWithBackup(%q) returned %v but expected %v", test.lbMethod, result, test.expected)
+		}
+	}
+}
+
 func TestCreateUpstreamServersConfigForPlusNoUpstreams(t *testing.T) {
 	noUpstream := version2.Upstream{}
 	expected := nginx.ServerConfig{}
@@ -5465,8 +5875,10 @@ func TestGenerateSplits(t *testing.T) {
 
 	vsc := newVirtualServerConfigurator(&cfgParams, false, false, &StaticConfigParams{}, false)
 
-	resultSplitClient, resultLocations, resultReturnLocations := generateSplits(
+	resultSplitClient, resultMaps, resultLocations, resultReturnLocations := generateSplits(
 		splits,
+		nil,
+		nil,
 		upstreamNamer,
 		crUpstreams,
 		variableNamer,
@@ -5486,6 +5898,9 @@ func TestGenerateSplits(t *testing.T) {
 	if diff := cmp.Diff(expectedSplitClient, resultSplitClient); diff != "" {
 		t.Errorf("generateSplits() resultSplitClient mismatch (-want +got):\n%s", diff)
 	}
+	if len(resultMaps) > 0 {
+		t.Errorf("generateSplits() returned maps %v but expected none", resultMaps)
+	}
 	if diff := cmp.Diff(expectedLocations, resultLocations); diff != "" {
 		t.Errorf("generateSplits() resultLocations mismatch (-want +got):\n%s", diff)
 	}
@@ -5602,45 +6017,314 @@ func TestGenerateDefaultSplitsConfig(t *testing.T) {
 	}
 }
 
-func TestGenerateMatchesConfig(t *testing.T) {
+func TestGenerateDefaultSplitsConfigWithStickySplits(t *testing.T) {
 	route := conf_v1.Route{
 		Path: "/",
-		Matches: []conf_v1.Match{
+		SplitKey: &conf_v1.SplitKey{
+			Header: "x-user-id",
+		},
+		StickySplits: &conf_v1.StickySplits{
+			Cookie: "canary",
+			MaxAge: 3600,
+		},
+		Splits: []conf_v1.Split{
 			{
-				Conditions: []conf_v1.Condition{
-					{
-						Header: "x-version",
-						Value:  "v1",
-					},
-					{
-						Cookie: "user",
-						Value:  "john",
-					},
-					{
-						Argument: "answer",
-						Value:    "yes",
-					},
-					{
-						Variable: "$request_method",
-						Value:    "GET",
-					},
-				},
+				Weight: 90,
 				Action: &conf_v1.Action{
 					Pass: "coffee-v1",
 				},
 			},
 			{
-				Conditions: []conf_v1.Condition{
+				Weight: 10,
+				Action: &conf_v1.Action{
+					Pass: "coffee-v2",
+				},
+			},
+		},
+	}
+	virtualServer := conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+	upstreamNamer := newUpstreamNamerForVirtualServer(&virtualServer)
+	variableNamer := newVariableNamer(&virtualServer)
+	index := 1
+
+	stickyHeader := version2.AddHeader{
+		Header: version2.Header{
+			Name:  "Set-Cookie",
+			Value: "canary=$vs_default_cafe_splits_1_key; Path=/; Max-Age=3600",
+		},
+		Always: true,
+	}
+
+	expected := routingCfg{
+		Maps: []version2.Map{
+			{
+				Source:   "$cookie_canary",
+				Variable: "$vs_default_cafe_splits_1_key",
+				Parameters: []version2.Parameter{
 					{
-						Header: "x-version",
-						Value:  "v2",
+						Value:  `""`,
+						Result: "$http_x_user_id",
 					},
 					{
-						Cookie: "user",
-						Value:  "paul",
+						Value:  "default",
+						Result: "$cookie_canary",
 					},
-					{
-						Argument: "answer",
+				},
+			},
+		},
+		SplitClients: []version2.SplitClient{
+			{
+				Source:   "$vs_default_cafe_splits_1_key",
+				Variable: "$vs_default_cafe_splits_1",
+				Distributions: []version2.Distribution{
+					{
+						Weight: "90%",
+						Value:  "/internal_location_splits_1_split_0",
+					},
+					{
+						Weight: "10%",
+						Value:  "/internal_location_splits_1_split_1",
+					},
+				},
+			},
+		},
+		Locations: []version2.Location{
+			{
+				Path:                     "/internal_location_splits_1_split_0",
+				ProxyPass:                "http://vs_default_cafe_coffee-v1$request_uri",
+				ProxyNextUpstream:        "error timeout",
+				ProxyNextUpstreamTimeout: "0s",
+				ProxyNextUpstreamTries:   0,
+				Internal:                 true,
+				ProxySSLName:             "coffee-v1.default.svc",
+				ProxyPassRequestHeaders:  true,
+				ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+				AddHeaders:               []version2.AddHeader{stickyHeader},
+				ServiceName:              "coffee-v1",
+				IsVSR:                    true,
+				VSRName:                  "coffee",
+				VSRNamespace:             "default",
+			},
+			{
+				Path:                     "/internal_location_splits_1_split_1",
+				ProxyPass:                "http://vs_default_cafe_coffee-v2$request_uri",
+				ProxyNextUpstream:        "error timeout",
+				ProxyNextUpstreamTimeout: "0s",
+				ProxyNextUpstreamTries:   0,
+				Internal:                 true,
+				ProxySSLName:             "coffee-v2.default.svc",
+				ProxyPassRequestHeaders:  true,
+				ProxySetHeaders:          []version2.Header{{Name: "Host", Value: "$host"}},
+				AddHeaders:               []version2.AddHeader{stickyHeader},
+				ServiceName:              "coffee-v2",
+				IsVSR:                    true,
+				VSRName:                  "coffee",
+				VSRNamespace:             "default",
+			},
+		},
+		InternalRedirectLocation: version2.InternalRedirectLocation{
+			Path:        "/",
+			Destination: "$vs_default_cafe_splits_1",
+		},
+	}
+
+	cfgParams := ConfigParams{}
+	locSnippet := ""
+	enableSnippets := false
+	crUpstreams := map[string]conf_v1.Upstream{
+		"vs_default_cafe_coffee-v1": {
+			Service: "coffee-v1",
+		},
+		"vs_default_cafe_coffee-v2": {
+			Service: "coffee-v2",
+		},
+	}
+
+	errorPageDetails := errorPageDetails{
+		pages: route.ErrorPages,
+		index: 0,
+		owner: nil,
+	}
+
+	result := generateDefaultSplitsConfig(route, upstreamNamer, crUpstreams, variableNamer, index, &cfgParams,
+		errorPageDetails, "", locSnippet, enableSnippets, 0, true, "coffee", "default", Warnings{})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateDefaultSplitsConfig() returned \n%+v but expected \n%+v", result, expected)
+	}
+}
+
+func TestGenerateVirtualServerConfigWithStickySplits(t *testing.T) {
+	createStickySplitsRoute := func(path string, cookie string, upstreams ...string) conf_v1.Route {
+		route := conf_v1.Route{
+			Path:         path,
+			SplitKey:     &conf_v1.SplitKey{Header: "x-user-id"},
+			StickySplits: &conf_v1.StickySplits{Cookie: cookie},
+		}
+		for _, u := range upstreams {
+			route.Splits = append(route.Splits, conf_v1.Split{Weight: 50, Action: &conf_v1.Action{Pass: u}})
+		}
+		return route
+	}
+
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Upstreams: []conf_v1.Upstream{
+					{Name: "tea-v1", Service: "tea-v1-svc", Port: 80},
+					{Name: "tea-v2", Service: "tea-v2-svc", Port: 80},
+				},
+				Routes: []conf_v1.Route{
+					createStickySplitsRoute("/tea", "tea_split", "tea-v1", "tea-v2"),
+					{
+						Path:  "/coffee",
+						Route: "default/coffee",
+					},
+				},
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Upstreams: []conf_v1.Upstream{
+						{Name: "coffee-v1", Service: "coffee-v1-svc", Port: 80},
+						{Name: "coffee-v2", Service: "coffee-v2-svc", Port: 80},
+					},
+					Subroutes: []conf_v1.Route{
+						createStickySplitsRoute("/coffee", "coffee_split", "coffee-v1", "coffee-v2"),
+					},
+				},
+			},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected warnings: %v", warnings)
+	}
+
+	mapSources := make(map[string]string)
+	for _, m := range result.Maps {
+		mapSources[m.Variable] = m.Source
+	}
+
+	expectedMapSources := map[string]string{
+		"$vs_default_cafe_splits_0_key": "$cookie_tea_split",
+		"$vs_default_cafe_splits_1_key": "$cookie_coffee_split",
+	}
+	for variable, source := range expectedMapSources {
+		if mapSources[variable] != source {
+			t.Errorf("GenerateVirtualServerConfig() returned the map of %s with the source %q but expected %q", variable, mapSources[variable], source)
+		}
+	}
+
+	if len(result.SplitClients) != len(expectedMapSources) {
+		t.Fatalf("GenerateVirtualServerConfig() returned %d split clients but expected %d", len(result.SplitClients), len(expectedMapSources))
+	}
+	for _, sc := range result.SplitClients {
+		if _, exists := mapSources[sc.Source]; !exists {
+			t.Errorf("GenerateVirtualServerConfig() returned the split client of %s with the undefined source %s", sc.Variable, sc.Source)
+		}
+	}
+}
+
+func TestGenerateSplitKeySource(t *testing.T) {
+	tests := []struct {
+		input    *conf_v1.SplitKey
+		expected string
+	}{
+		{
+			input:    nil,
+			expected: "$request_id",
+		},
+		{
+			input: &conf_v1.SplitKey{
+				Cookie: "user",
+			},
+			expected: "$cookie_user",
+		},
+		{
+			input: &conf_v1.SplitKey{
+				Header: "x-user-id",
+			},
+			expected: "$http_x_user_id",
+		},
+		{
+			input: &conf_v1.SplitKey{
+				ClientIP: true,
+			},
+			expected: "$remote_addr",
+		},
+		{
+			input: &conf_v1.SplitKey{
+				JWTSubject: true,
+			},
+			expected: "$jwt_claim_sub",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateSplitKeySource(test.input)
+		if result != test.expected {
+			t.Errorf("generateSplitKeySource(%+v) returned %q but expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateMatchesConfig(t *testing.T) {
+	route := conf_v1.Route{
+		Path: "/",
+		Matches: []conf_v1.Match{
+			{
+				Conditions: []conf_v1.Condition{
+					{
+						Header: "x-version",
+						Value:  "v1",
+					},
+					{
+						Cookie: "user",
+						Value:  "john",
+					},
+					{
+						Argument: "answer",
+						Value:    "yes",
+					},
+					{
+						Variable: "$request_method",
+						Value:    "GET",
+					},
+				},
+				Action: &conf_v1.Action{
+					Pass: "coffee-v1",
+				},
+			},
+			{
+				Conditions: []conf_v1.Condition{
+					{
+						Header: "x-version",
+						Value:  "v2",
+					},
+					{
+						Cookie: "user",
+						Value:  "paul",
+					},
+					{
+						Argument: "answer",
 						Value:    "no",
 					},
 					{
@@ -6487,14 +7171,16 @@ func TestGenerateValueForMatchesRouteMap(t *testing.T) {
 	}
 }
 
-func TestGenerateParametersForMatchesRouteMap(t *testing.T) {
+func TestGenerateParametersForMatchCondition(t *testing.T) {
 	tests := []struct {
-		inputMatchedValue     string
+		inputCondition        conf_v1.Condition
 		inputSuccessfulResult string
 		expected              []version2.Parameter
 	}{
 		{
-			inputMatchedValue:     "abc",
+			inputCondition: conf_v1.Condition{
+				Value: "abc",
+			},
 			inputSuccessfulResult: "1",
 			expected: []version2.Parameter{
 				{
@@ -6508,7 +7194,9 @@ func TestGenerateParametersForMatchesRouteMap(t *testing.T) {
 			},
 		},
 		{
-			inputMatchedValue:     "!abc",
+			inputCondition: conf_v1.Condition{
+				Value: "!abc",
+			},
 			inputSuccessfulResult: "1",
 			expected: []version2.Parameter{
 				{
@@ -6521,49 +7209,225 @@ func TestGenerateParametersForMatchesRouteMap(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for _, test := range tests {
-		result := generateParametersForMatchesRouteMap(test.inputMatchedValue, test.inputSuccessfulResult)
-		if !reflect.DeepEqual(result, test.expected) {
-			t.Errorf("generateParametersForMatchesRouteMap(%q, %q) returned %v but expected %v", test.inputMatchedValue, test.inputSuccessfulResult, result, test.expected)
-		}
-	}
-}
-
-func TestGetNameForSourceForMatchesRouteMapFromCondition(t *testing.T) {
-	tests := []struct {
-		input    conf_v1.Condition
-		expected string
-	}{
 		{
-			input: conf_v1.Condition{
-				Header: "x-version",
+			inputCondition: conf_v1.Condition{
+				Value:  "abc",
+				Negate: true,
 			},
-			expected: "$http_x_version",
-		},
-		{
-			input: conf_v1.Condition{
-				Cookie: "mycookie",
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `"abc"`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "1",
+				},
 			},
-			expected: "$cookie_mycookie",
 		},
 		{
-			input: conf_v1.Condition{
-				Argument: "arg",
+			inputCondition: conf_v1.Condition{
+				Value:  "!abc",
+				Negate: true,
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `"abc"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
 			},
-			expected: "$arg_arg",
 		},
 		{
-			input: conf_v1.Condition{
-				Variable: "$request_method",
+			inputCondition: conf_v1.Condition{
+				Header:   "x-user-group",
+				Operator: conf_v1.ConditionOperatorRegex,
+				Value:    "^beta-",
 			},
-			expected: "$request_method",
-		},
-	}
-
-	for _, test := range tests {
-		result := getNameForSourceForMatchesRouteMapFromCondition(test.input)
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `"~^beta-"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				Header:   "x-user-group",
+				Operator: conf_v1.ConditionOperatorRegex,
+				Negate:   true,
+				Value:    "^beta-",
+			},
+			inputSuccessfulResult: "$vs_default_cafe_matches_0_match_0_cond_1",
+			expected: []version2.Parameter{
+				{
+					Value:  `"~^beta-"`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "$vs_default_cafe_matches_0_match_0_cond_1",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				Argument: "version",
+				Operator: conf_v1.ConditionOperatorPrefix,
+				Value:    "v1.2",
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `"~^v1\.2"`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				Header:   "x-debug",
+				Operator: conf_v1.ConditionOperatorPresent,
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "1",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				Header:   "x-debug",
+				Operator: conf_v1.ConditionOperatorAbsent,
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `""`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				ClientIP: true,
+				Value:    "10.0.0.0/8",
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `10.0.0.0/8`,
+					Result: "1",
+				},
+				{
+					Value:  "default",
+					Result: "0",
+				},
+			},
+		},
+		{
+			inputCondition: conf_v1.Condition{
+				ClientIP: true,
+				Negate:   true,
+				Value:    "10.0.0.0/8",
+			},
+			inputSuccessfulResult: "1",
+			expected: []version2.Parameter{
+				{
+					Value:  `10.0.0.0/8`,
+					Result: "0",
+				},
+				{
+					Value:  "default",
+					Result: "1",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		result := generateParametersForMatchCondition(test.inputCondition, test.inputSuccessfulResult)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateParametersForMatchCondition(%+v, %q) returned %v but expected %v", test.inputCondition, test.inputSuccessfulResult, result, test.expected)
+		}
+	}
+}
+
+func TestGetNameForSourceForMatchesRouteMapFromCondition(t *testing.T) {
+	tests := []struct {
+		input    conf_v1.Condition
+		expected string
+	}{
+		{
+			input: conf_v1.Condition{
+				Header: "x-version",
+			},
+			expected: "$http_x_version",
+		},
+		{
+			input: conf_v1.Condition{
+				Cookie: "mycookie",
+			},
+			expected: "$cookie_mycookie",
+		},
+		{
+			input: conf_v1.Condition{
+				Argument: "arg",
+			},
+			expected: "$arg_arg",
+		},
+		{
+			input: conf_v1.Condition{
+				Variable: "$request_method",
+			},
+			expected: "$request_method",
+		},
+		{
+			input: conf_v1.Condition{
+				Method: true,
+			},
+			expected: "$request_method",
+		},
+		{
+			input: conf_v1.Condition{
+				ClientIP: true,
+			},
+			expected: "$remote_addr",
+		},
+		{
+			input: conf_v1.Condition{
+				JWTClaim: "group",
+			},
+			expected: "$jwt_claim_group",
+		},
+	}
+
+	for _, test := range tests {
+		result := getNameForSourceForMatchesRouteMapFromCondition(test.input)
 		if result != test.expected {
 			t.Errorf("getNameForSourceForMatchesRouteMapFromCondition() returned %q but expected %q for input %v", result, test.expected, test.input)
 		}
@@ -7019,126 +7883,1598 @@ func TestGenerateEndpointsForUpstream(t *testing.T) {
 						Namespace: namespace,
 					},
 				},
-				Endpoints: map[string][]string{
-					"test-namespace/test:8080": {"192.168.10.10:8080"},
-				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:8080": {"192.168.10.10:8080"},
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			expected:             []string{"192.168.10.10:8080"},
+			msg:                  "Service with endpoints",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service: name,
+				Port:    8080,
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			expected:             []string{nginx502Server},
+			msg:                  "Service with no endpoints",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service: name,
+				Port:    8080,
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{},
+			},
+			isPlus:               true,
+			isResolverConfigured: false,
+			expected:             nil,
+			msg:                  "Service with no endpoints",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:     name,
+				Port:        8080,
+				Subselector: map[string]string{"version": "test"},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test_version=test:8080": {"192.168.10.10:8080"},
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			expected:             []string{"192.168.10.10:8080"},
+			msg:                  "Upstream with subselector, with a matching endpoint",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:     name,
+				Port:        8080,
+				Subselector: map[string]string{"version": "test"},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:8080": {"192.168.10.10:8080"},
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			expected:             []string{nginx502Server},
+			msg:                  "Upstream with subselector, without a matching endpoint",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service: name,
+				Port:    80,
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:80": {"example.com:80"},
+				},
+				ExternalNameSvcs: map[string]bool{
+					"test-namespace/test": true,
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: true,
+			expected:             []string{"example.com:80"},
+			msg:                  "ExternalName service for NGINX",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service: name,
+				Port:    80,
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:80": {"example.com:80"},
+				},
+				ExternalNameSvcs: map[string]bool{
+					"test-namespace/test": true,
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			warningsExpected:     true,
+			expected:             []string{nginx502Server},
+			msg:                  "ExternalName service without resolver configured for NGINX",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Addresses: []string{"example.com:80", "10.0.0.1:8080"},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{},
+			},
+			isPlus:               true,
+			isResolverConfigured: true,
+			expected:             []string{"example.com:80", "10.0.0.1:8080"},
+			msg:                  "Upstream with addresses",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Addresses: []string{"example.com:80", "10.0.0.1:8080"},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{},
+			},
+			isPlus:               true,
+			isResolverConfigured: false,
+			warningsExpected:     true,
+			expected:             []string{"10.0.0.1:8080"},
+			msg:                  "Upstream with addresses without resolver configured",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Addresses: []string{"example.com:80"},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			warningsExpected:     true,
+			expected:             []string{nginx502Server},
+			msg:                  "Upstream with a DNS name address without resolver configured for NGINX",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:       name,
+				Port:          8080,
+				TopologyAware: &conf_v1.TopologyAware{Enable: true},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:8080": {"10.0.0.1:8080", "10.0.0.2:8080", "10.0.1.1:8080"},
+				},
+				LocalEndpoints: map[string]bool{"10.0.0.1:8080": true, "10.0.0.2:8080": true},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			expected:             []string{"10.0.0.1:8080", "10.0.0.2:8080"},
+			msg:                  "Topology aware upstream",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:       name,
+				Port:          8080,
+				TopologyAware: &conf_v1.TopologyAware{Enable: true, MinEndpoints: 3},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:8080": {"10.0.0.1:8080", "10.0.0.2:8080", "10.0.1.1:8080"},
+				},
+				LocalEndpoints: map[string]bool{"10.0.0.1:8080": true, "10.0.0.2:8080": true},
+			},
+			isPlus:               true,
+			isResolverConfigured: false,
+			expected:             []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.1.1:8080"},
+			msg:                  "Topology aware upstream with too few local endpoints",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:       name,
+				Port:          8080,
+				TopologyAware: &conf_v1.TopologyAware{Enable: true},
+			},
+			vsEx: &VirtualServerEx{
+				VirtualServer: &conf_v1.VirtualServer{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+				},
+				Endpoints: map[string][]string{
+					"test-namespace/test:8080": {"10.0.0.1:8080", "10.0.0.2:8080", "10.0.1.1:8080"},
+				},
+			},
+			isPlus:               false,
+			isResolverConfigured: false,
+			warningsExpected:     true,
+			expected:             []string{"10.0.0.1:8080", "10.0.0.2:8080", "10.0.1.1:8080"},
+			msg:                  "Topology aware upstream without topology aware routing enabled",
+		},
+	}
+
+	for _, test := range tests {
+		isWildcardEnabled := false
+		vsc := newVirtualServerConfigurator(
+			&ConfigParams{},
+			test.isPlus,
+			test.isResolverConfigured,
+			&StaticConfigParams{},
+			isWildcardEnabled,
+		)
+		result := vsc.generateEndpointsForUpstream(test.vsEx.VirtualServer, namespace, test.upstream, test.vsEx)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) returned %v, but expected %v for case: %v",
+				test.isPlus, test.isResolverConfigured, result, test.expected, test.msg)
+		}
+
+		if len(vsc.warnings) == 0 && test.warningsExpected {
+			t.Errorf(
+				"generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) didn't return any warnings for %v but warnings expected",
+				test.isPlus,
+				test.isResolverConfigured,
+				test.upstream,
+			)
+		}
+
+		if len(vsc.warnings) != 0 && !test.warningsExpected {
+			t.Errorf("generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) returned warnings for %v",
+				test.isPlus, test.isResolverConfigured, test.upstream)
+		}
+	}
+}
+
+func TestFilterLocalEndpoints(t *testing.T) {
+	endpoints := []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.1.1:80"}
+
+	tests := []struct {
+		localEndpoints map[string]bool
+		minEndpoints   int
+		expected       []string
+		msg            string
+	}{
+		{
+			localEndpoints: map[string]bool{"10.0.0.1:80": true, "10.0.0.2:80": true},
+			minEndpoints:   0,
+			expected:       []string{"10.0.0.1:80", "10.0.0.2:80"},
+			msg:            "default min endpoints",
+		},
+		{
+			localEndpoints: map[string]bool{"10.0.0.1:80": true, "10.0.0.2:80": true},
+			minEndpoints:   2,
+			expected:       []string{"10.0.0.1:80", "10.0.0.2:80"},
+			msg:            "enough local endpoints",
+		},
+		{
+			localEndpoints: map[string]bool{"10.0.0.1:80": true, "10.0.0.2:80": true},
+			minEndpoints:   3,
+			expected:       endpoints,
+			msg:            "too few local endpoints",
+		},
+		{
+			localEndpoints: map[string]bool{},
+			minEndpoints:   0,
+			expected:       endpoints,
+			msg:            "no local endpoints",
+		},
+	}
+
+	for _, test := range tests {
+		result := filterLocalEndpoints(endpoints, test.localEndpoints, test.minEndpoints)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("filterLocalEndpoints() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateRuntimeResolvedAddress(t *testing.T) {
+	tests := []struct {
+		upstream  conf_v1.Upstream
+		resolve   bool
+		endpoints []string
+		isPlus    bool
+		expected  string
+		warning   bool
+		msg       string
+	}{
+		{
+			upstream:  conf_v1.Upstream{Service: "test", Port: 80},
+			resolve:   true,
+			endpoints: []string{"example.com:80"},
+			expected:  "example.com:80",
+			msg:       "ExternalName service",
+		},
+		{
+			upstream:  conf_v1.Upstream{Addresses: []string{"example.com:80"}},
+			resolve:   true,
+			endpoints: []string{"example.com:80"},
+			expected:  "example.com:80",
+			msg:       "address with a DNS name",
+		},
+		{
+			upstream:  conf_v1.Upstream{Addresses: []string{"example.com:80"}},
+			resolve:   true,
+			endpoints: []string{"example.com:80"},
+			isPlus:    true,
+			expected:  "",
+			msg:       "address with a DNS name for NGINX Plus",
+		},
+		{
+			upstream:  conf_v1.Upstream{Addresses: []string{"10.0.0.1:80"}},
+			resolve:   false,
+			endpoints: []string{"10.0.0.1:80"},
+			expected:  "",
+			msg:       "address with an IP address",
+		},
+		{
+			upstream:  conf_v1.Upstream{Service: "test", Port: 80},
+			resolve:   true,
+			endpoints: []string{nginx502Server},
+			expected:  "",
+			msg:       "ExternalName service without resolver configured",
+		},
+		{
+			upstream: conf_v1.Upstream{
+				Service:  "test",
+				Port:     80,
+				Services: []conf_v1.UpstreamService{{Name: "test-v2", Port: 80}},
+			},
+			resolve:   true,
+			endpoints: []string{"example.com:80"},
+			expected:  "",
+			warning:   true,
+			msg:       "ExternalName service with additional services",
+		},
+		{
+			upstream:  conf_v1.Upstream{Name: "tea", Addresses: []string{"tea.example.com:80", "tea.example.org:80"}},
+			resolve:   true,
+			endpoints: []string{"tea.example.com:80", "tea.example.org:80"},
+			expected:  "",
+			warning:   true,
+			msg:       "several addresses with DNS names",
+		},
+		{
+			upstream:  conf_v1.Upstream{Name: "tea", Addresses: []string{"tea.example.com:80", "tea.example.org:80"}},
+			resolve:   true,
+			endpoints: []string{"tea.example.com:80", "tea.example.org:80"},
+			isPlus:    true,
+			expected:  "",
+			msg:       "several addresses with DNS names for NGINX Plus",
+		},
+	}
+
+	owner := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+
+	for _, test := range tests {
+		vsc := newVirtualServerConfigurator(&ConfigParams{}, test.isPlus, true, &StaticConfigParams{}, false)
+		result := vsc.generateRuntimeResolvedAddress(owner, test.upstream, test.resolve, test.endpoints)
+		if result != test.expected {
+			t.Errorf("generateRuntimeResolvedAddress() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+		if warning := len(vsc.warnings[owner]) > 0; warning != test.warning {
+			t.Errorf("generateRuntimeResolvedAddress() returned the warnings %v for the case of %s", vsc.warnings[owner], test.msg)
+		}
+	}
+}
+
+func TestSetRuntimeResolvedProxyPass(t *testing.T) {
+	upstreamName := "vs_default_cafe_tea"
+	variable := "$vs_default_cafe_tea_address"
+
+	tests := []struct {
+		loc      version2.Location
+		expected version2.Location
+		msg      string
+	}{
+		{
+			loc:      version2.Location{ProxyPass: "http://vs_default_cafe_tea"},
+			expected: version2.Location{ProxyPass: "http://$vs_default_cafe_tea_address"},
+			msg:      "proxy_pass",
+		},
+		{
+			loc:      version2.Location{ProxyPass: "https://vs_default_cafe_tea$request_uri"},
+			expected: version2.Location{ProxyPass: "https://$vs_default_cafe_tea_address$request_uri"},
+			msg:      "internal proxy_pass with TLS",
+		},
+		{
+			loc: version2.Location{
+				ProxyPass: "http://vs_default_cafe_tea",
+				GRPCPass:  "grpc://vs_default_cafe_tea",
+			},
+			expected: version2.Location{
+				ProxyPass: "http://$vs_default_cafe_tea_address",
+				GRPCPass:  "grpc://$vs_default_cafe_tea_address",
+			},
+			msg: "grpc_pass",
+		},
+		{
+			loc: version2.Location{
+				Path:             "/coffee",
+				ProxyPass:        "http://vs_default_cafe_tea",
+				ProxyPassRewrite: "/beans",
+			},
+			expected: version2.Location{
+				Path:      "/coffee",
+				ProxyPass: "http://$vs_default_cafe_tea_address",
+				Rewrites:  []string{`"^/coffee(.*)$" "/beans$1" break`},
+			},
+			msg: "proxy_pass with a rewrite of the path",
+		},
+		{
+			loc: version2.Location{
+				Path:             "= /coffee",
+				ProxyPass:        "http://vs_default_cafe_tea",
+				ProxyPassRewrite: "/beans",
+			},
+			expected: version2.Location{
+				Path:      "= /coffee",
+				ProxyPass: "http://$vs_default_cafe_tea_address",
+				Rewrites:  []string{`"^/coffee(.*)$" "/beans$1" break`},
+			},
+			msg: "proxy_pass with a rewrite of an exact path",
+		},
+		{
+			loc: version2.Location{
+				Path:             "/coffee",
+				ProxyPass:        "http://vs_default_cafe_coffee",
+				ProxyPassRewrite: "/beans",
+			},
+			expected: version2.Location{
+				Path:             "/coffee",
+				ProxyPass:        "http://vs_default_cafe_coffee",
+				ProxyPassRewrite: "/beans",
+			},
+			msg: "another upstream with a rewrite of the path",
+		},
+		{
+			loc:      version2.Location{ProxyPass: "http://vs_default_cafe_tea_v2"},
+			expected: version2.Location{ProxyPass: "http://vs_default_cafe_tea_v2"},
+			msg:      "another upstream with the same prefix",
+		},
+		{
+			loc:      version2.Location{ProxyPass: "http://vs_default_cafe_coffee"},
+			expected: version2.Location{ProxyPass: "http://vs_default_cafe_coffee"},
+			msg:      "another upstream",
+		},
+	}
+
+	for _, test := range tests {
+		setRuntimeResolvedProxyPass(&test.loc, upstreamName, variable)
+		if diff := cmp.Diff(test.expected, test.loc); diff != "" {
+			t.Errorf("setRuntimeResolvedProxyPass() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestAddRouteProxyToLocation(t *testing.T) {
+	proxyLocation := version2.Location{
+		Path:                "/upload",
+		ProxyPass:           "http://vs_default_cafe_backend",
+		ProxyConnectTimeout: "60s",
+		ProxyReadTimeout:    "60s",
+		ProxySendTimeout:    "60s",
+		ClientMaxBodySize:   "1m",
+		ProxyBuffering:      true,
+		ProxyBuffers:        "8 4k",
+		ProxyBufferSize:     "4k",
+	}
+
+	tests := []struct {
+		proxy    *conf_v1.RouteProxy
+		loc      version2.Location
+		expected version2.Location
+		msg      string
+	}{
+		{
+			proxy:    nil,
+			loc:      proxyLocation,
+			expected: proxyLocation,
+			msg:      "no proxy settings",
+		},
+		{
+			proxy: &conf_v1.RouteProxy{
+				ProxyReadTimeout:  "5m",
+				ClientMaxBodySize: "1g",
+				ProxyBuffering:    createPointerFromBool(false),
+				ProxyBuffers: &conf_v1.UpstreamBuffers{
+					Number: 16,
+					Size:   "8k",
+				},
+			},
+			loc: proxyLocation,
+			expected: version2.Location{
+				Path:                "/upload",
+				ProxyPass:           "http://vs_default_cafe_backend",
+				ProxyConnectTimeout: "60s",
+				ProxyReadTimeout:    "5m",
+				ProxySendTimeout:    "60s",
+				ClientMaxBodySize:   "1g",
+				ProxyBuffering:      false,
+				ProxyBuffers:        "16 8k",
+				ProxyBufferSize:     "4k",
+			},
+			msg: "proxy settings override the upstream settings",
+		},
+		{
+			proxy: &conf_v1.RouteProxy{
+				ClientMaxBodySize: "1g",
+			},
+			loc: version2.Location{
+				Path:              "/upload",
+				ClientMaxBodySize: "1m",
+				InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			},
+			expected: version2.Location{
+				Path:              "/upload",
+				ClientMaxBodySize: "1m",
+				InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			},
+			msg: "location that doesn't proxy requests",
+		},
+	}
+
+	for _, test := range tests {
+		addRouteProxyToLocation(test.proxy, &test.loc)
+		if diff := cmp.Diff(test.expected, test.loc); diff != "" {
+			t.Errorf("addRouteProxyToLocation() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestGenerateHeadersProxy(t *testing.T) {
+	cfgParams := &ConfigParams{HSTSMaxAge: 2592000}
+
+	tests := []struct {
+		headers     *conf_v1.Headers
+		tls         bool
+		behindProxy bool
+		expected    *conf_v1.ActionProxy
+		msg         string
+	}{
+		{
+			headers:  nil,
+			expected: nil,
+			msg:      "no headers",
+		},
+		{
+			headers: &conf_v1.Headers{
+				RequestHeaders: &conf_v1.ProxyRequestHeaders{
+					Set: []conf_v1.Header{{Name: "X-Env", Value: "prod"}},
+				},
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"X-Powered-By"},
+				},
+			},
+			expected: &conf_v1.ActionProxy{
+				RequestHeaders: &conf_v1.ProxyRequestHeaders{
+					Set: []conf_v1.Header{{Name: "X-Env", Value: "prod"}},
+				},
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"X-Powered-By"},
+				},
+			},
+			msg: "request and response headers",
+		},
+		{
+			headers: &conf_v1.Headers{
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "ALLOW-FROM https://example.com"}},
+					},
+				},
+				Security: &conf_v1.SecurityHeaders{
+					HSTS:                  &conf_v1.HSTS{Enable: true, IncludeSubdomains: true, Preload: true},
+					FrameOptions:          "DENY",
+					ContentSecurityPolicy: "default-src 'self'",
+					ReferrerPolicy:        "no-referrer",
+				},
+			},
+			tls: true,
+			expected: &conf_v1.ActionProxy{
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"Strict-Transport-Security", "X-Frame-Options", "Content-Security-Policy", "Referrer-Policy"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "Strict-Transport-Security", Value: "max-age=2592000; includeSubDomains; preload"}, Always: true},
+						{Header: conf_v1.Header{Name: "Content-Security-Policy", Value: "default-src 'self'"}, Always: true},
+						{Header: conf_v1.Header{Name: "Referrer-Policy", Value: "no-referrer"}, Always: true},
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "ALLOW-FROM https://example.com"}},
+					},
+				},
+			},
+			msg: "security headers overridden by response headers",
+		},
+		{
+			headers: &conf_v1.Headers{
+				Security: &conf_v1.SecurityHeaders{
+					HSTS: &conf_v1.HSTS{Enable: true, MaxAge: 31536000},
+				},
+			},
+			tls: true,
+			expected: &conf_v1.ActionProxy{
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"Strict-Transport-Security"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "Strict-Transport-Security", Value: "max-age=31536000"}, Always: true},
+					},
+				},
+			},
+			msg: "hsts with max age",
+		},
+		{
+			headers: &conf_v1.Headers{
+				Security: &conf_v1.SecurityHeaders{
+					HSTS:         &conf_v1.HSTS{Enable: true},
+					FrameOptions: "DENY",
+				},
+			},
+			expected: &conf_v1.ActionProxy{
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"X-Frame-Options"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+					},
+				},
+			},
+			msg: "hsts without TLS",
+		},
+		{
+			headers: &conf_v1.Headers{
+				Security: &conf_v1.SecurityHeaders{
+					HSTS: &conf_v1.HSTS{Enable: true},
+				},
+			},
+			behindProxy: true,
+			expected: &conf_v1.ActionProxy{
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"Strict-Transport-Security"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "Strict-Transport-Security", Value: "max-age=2592000"}, Always: true},
+					},
+				},
+			},
+			msg: "hsts without TLS behind a proxy",
+		},
+	}
+
+	for _, test := range tests {
+		cfgParams.HSTSBehindProxy = test.behindProxy
+		result := generateHeadersProxy(test.headers, cfgParams, test.tls)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("generateHeadersProxy() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestMergeActionProxyHeaders(t *testing.T) {
+	parent := &conf_v1.ActionProxy{
+		RequestHeaders: &conf_v1.ProxyRequestHeaders{
+			Pass: createPointerFromBool(false),
+			Set: []conf_v1.Header{
+				{Name: "X-Env", Value: "prod"},
+				{Name: "X-Team", Value: "cafe"},
+			},
+		},
+		ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+			Hide: []string{"X-Powered-By", "Server"},
+			Add: []conf_v1.AddHeader{
+				{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		parent   *conf_v1.ActionProxy
+		proxy    *conf_v1.ActionProxy
+		expected *conf_v1.ActionProxy
+		msg      string
+	}{
+		{
+			parent:   nil,
+			proxy:    &conf_v1.ActionProxy{Upstream: "tea"},
+			expected: &conf_v1.ActionProxy{Upstream: "tea"},
+			msg:      "no parent headers",
+		},
+		{
+			parent:   parent,
+			proxy:    nil,
+			expected: parent,
+			msg:      "no proxy",
+		},
+		{
+			parent: parent,
+			proxy: &conf_v1.ActionProxy{
+				Upstream:    "tea",
+				RewritePath: "/",
+				RequestHeaders: &conf_v1.ProxyRequestHeaders{
+					Pass: createPointerFromBool(true),
+					Set:  []conf_v1.Header{{Name: "x-team", Value: "tea"}},
+				},
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"server"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}},
+					},
+				},
+			},
+			expected: &conf_v1.ActionProxy{
+				Upstream:    "tea",
+				RewritePath: "/",
+				RequestHeaders: &conf_v1.ProxyRequestHeaders{
+					Pass: createPointerFromBool(true),
+					Set: []conf_v1.Header{
+						{Name: "X-Env", Value: "prod"},
+						{Name: "x-team", Value: "tea"},
+					},
+				},
+				ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+					Hide: []string{"X-Powered-By", "server"},
+					Add: []conf_v1.AddHeader{
+						{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "SAMEORIGIN"}},
+					},
+				},
+			},
+			msg: "proxy headers override parent headers",
+		},
+	}
+
+	for _, test := range tests {
+		result := mergeActionProxyHeaders(test.parent, test.proxy)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("mergeActionProxyHeaders() mismatch for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+func TestApplyHeadersToRoute(t *testing.T) {
+	headers := &conf_v1.ActionProxy{
+		RequestHeaders: &conf_v1.ProxyRequestHeaders{
+			Set: []conf_v1.Header{{Name: "X-Env", Value: "prod"}},
+		},
+	}
+
+	route := conf_v1.Route{
+		Path: "/tea",
+		Action: &conf_v1.Action{
+			Pass: "tea",
+		},
+		Matches: []conf_v1.Match{
+			{
+				Conditions: []conf_v1.Condition{{Header: "x-version", Value: "v2"}},
+				Splits: []conf_v1.Split{
+					{
+						Weight: 90,
+						Action: &conf_v1.Action{Pass: "tea-v2"},
+					},
+					{
+						Weight: 10,
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{Body: "maintenance"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := conf_v1.Route{
+		Path: "/tea",
+		Action: &conf_v1.Action{
+			Proxy: &conf_v1.ActionProxy{
+				Upstream:       "tea",
+				RequestHeaders: headers.RequestHeaders,
+			},
+		},
+		Matches: []conf_v1.Match{
+			{
+				Conditions: []conf_v1.Condition{{Header: "x-version", Value: "v2"}},
+				Splits: []conf_v1.Split{
+					{
+						Weight: 90,
+						Action: &conf_v1.Action{
+							Proxy: &conf_v1.ActionProxy{
+								Upstream:       "tea-v2",
+								RequestHeaders: headers.RequestHeaders,
+							},
+						},
+					},
+					{
+						Weight: 10,
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{Body: "maintenance"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := applyHeadersToRoute(headers, route)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("applyHeadersToRoute() mismatch (-want +got):\n%s", diff)
+	}
+
+	if route.Action.Pass != "tea" || route.Action.Proxy != nil {
+		t.Errorf("applyHeadersToRoute() modified the original route")
+	}
+
+	result = applyHeadersToRoute(nil, route)
+	if diff := cmp.Diff(route, result); diff != "" {
+		t.Errorf("applyHeadersToRoute() mismatch for no headers (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddSecurityHeadersToLocations(t *testing.T) {
+	headers := []conf_v1.AddHeader{
+		{Header: conf_v1.Header{Name: "Strict-Transport-Security", Value: "max-age=100"}, Always: true},
+	}
+
+	locations := []version2.Location{
+		{Path: "/tea", ProxyPass: "http://tea"},
+		{Path: "/coffee", InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock"},
+	}
+	returnLocations := []version2.ReturnLocation{
+		{Name: "@return_0", DefaultType: "text/plain"},
+	}
+
+	expectedLocations := []version2.Location{
+		{Path: "/tea", ProxyPass: "http://tea"},
+		{
+			Path:              "/coffee",
+			InternalProxyPass: "http://unix:/var/lib/nginx/nginx-418-server.sock",
+			AddHeaders: []version2.AddHeader{
+				{Header: version2.Header{Name: "Strict-Transport-Security", Value: "max-age=100"}, Always: true},
+			},
+		},
+	}
+	expectedReturnLocations := []version2.ReturnLocation{
+		{
+			Name:        "@return_0",
+			DefaultType: "text/plain",
+			Headers:     []version2.Header{{Name: "Strict-Transport-Security", Value: "max-age=100"}},
+		},
+	}
+
+	addSecurityHeadersToLocations(headers, locations, returnLocations)
+
+	if diff := cmp.Diff(expectedLocations, locations); diff != "" {
+		t.Errorf("addSecurityHeadersToLocations() locations mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedReturnLocations, returnLocations); diff != "" {
+		t.Errorf("addSecurityHeadersToLocations() return locations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddSecurityHeadersToErrorPageLocations(t *testing.T) {
+	headers := []conf_v1.AddHeader{
+		{Header: conf_v1.Header{Name: "Strict-Transport-Security", Value: "max-age=100"}, Always: true},
+		{Header: conf_v1.Header{Name: "X-Frame-Options", Value: "DENY"}, Always: true},
+	}
+
+	errorPageLocations := []version2.ErrorPageLocation{
+		{
+			Name:    "@error_page_0_0",
+			Headers: []version2.Header{{Name: "x-frame-options", Value: "SAMEORIGIN"}},
+		},
+		{
+			Name: "@error_page_0_1",
+		},
+	}
+
+	expected := []version2.ErrorPageLocation{
+		{
+			Name: "@error_page_0_0",
+			Headers: []version2.Header{
+				{Name: "x-frame-options", Value: "SAMEORIGIN"},
+				{Name: "Strict-Transport-Security", Value: "max-age=100"},
+			},
+		},
+		{
+			Name: "@error_page_0_1",
+			Headers: []version2.Header{
+				{Name: "Strict-Transport-Security", Value: "max-age=100"},
+				{Name: "X-Frame-Options", Value: "DENY"},
+			},
+		},
+	}
+
+	addSecurityHeadersToErrorPageLocations(headers, errorPageLocations)
+
+	if diff := cmp.Diff(expected, errorPageLocations); diff != "" {
+		t.Errorf("addSecurityHeadersToErrorPageLocations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateVirtualServerConfigWithSecurityHeadersForResponsesWithoutUpstream(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Headers: &conf_v1.Headers{
+					Security: &conf_v1.SecurityHeaders{
+						HSTS:         &conf_v1.HSTS{Enable: true, MaxAge: 100},
+						FrameOptions: "DENY",
+					},
+				},
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+						ErrorPages: []conf_v1.ErrorPage{
+							{
+								Codes: []int{502},
+								Return: &conf_v1.ErrorPageReturn{
+									ActionReturn: conf_v1.ActionReturn{Body: "unavailable"},
+								},
+							},
+						},
+					},
+					{
+						Path: "/return",
+						Action: &conf_v1.Action{
+							Return: &conf_v1.ActionReturn{Body: "ok"},
+						},
+					},
+					{
+						Path: "/redirect",
+						Action: &conf_v1.Action{
+							Redirect: &conf_v1.ActionRedirect{URL: "https://example.com"},
+						},
+					},
+					{
+						Path:  "/coffee",
+						Route: "default/coffee",
+					},
+				},
+			},
+		},
+		VirtualServerRoutes: []*conf_v1.VirtualServerRoute{
+			{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "coffee",
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Host: "cafe.example.com",
+					Headers: &conf_v1.Headers{
+						Security: &conf_v1.SecurityHeaders{
+							FrameOptions: "SAMEORIGIN",
+						},
+					},
+					Subroutes: []conf_v1.Route{
+						{
+							Path: "/coffee",
+							Action: &conf_v1.Action{
+								Return: &conf_v1.ActionReturn{Body: "coffee"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {"10.0.0.20:80"},
+		},
+	}
+
+	vsHeaders := []version2.Header{
+		{Name: "Strict-Transport-Security", Value: "max-age=100"},
+		{Name: "X-Frame-Options", Value: "DENY"},
+	}
+	vsrHeaders := []version2.Header{
+		{Name: "Strict-Transport-Security", Value: "max-age=100"},
+		{Name: "X-Frame-Options", Value: "SAMEORIGIN"},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{HSTSBehindProxy: true}, false, false, &StaticConfigParams{}, false)
+	result, _ := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+
+	if diff := cmp.Diff(vsHeaders, result.Server.ErrorPageLocations[0].Headers); diff != "" {
+		t.Errorf("GenerateVirtualServerConfig() error page location headers mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedReturnHeaders := [][]version2.Header{vsHeaders, vsrHeaders}
+	if len(result.Server.ReturnLocations) != len(expectedReturnHeaders) {
+		t.Fatalf("GenerateVirtualServerConfig() generated %d return locations but expected %d", len(result.Server.ReturnLocations), len(expectedReturnHeaders))
+	}
+	for i, expected := range expectedReturnHeaders {
+		if diff := cmp.Diff(expected, result.Server.ReturnLocations[i].Headers); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() headers of return location %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	foundRedirect := false
+	for _, loc := range result.Server.Locations {
+		if loc.Path != "/redirect" {
+			continue
+		}
+		foundRedirect = true
+
+		var headers []version2.Header
+		for _, h := range loc.AddHeaders {
+			if !h.Always {
+				t.Errorf("GenerateVirtualServerConfig() added the header %s without always to the redirect location", h.Name)
+			}
+			headers = append(headers, h.Header)
+		}
+		if diff := cmp.Diff(vsHeaders, headers); diff != "" {
+			t.Errorf("GenerateVirtualServerConfig() redirect location headers mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if !foundRedirect {
+		t.Errorf("GenerateVirtualServerConfig() didn't generate the redirect location")
+	}
+}
+
+func TestGenerateServerRedirects(t *testing.T) {
+	namer := &variableNamer{safeNsName: "default_cafe"}
+
+	maps, redirects := generateServerRedirects(nil, "cafe.example.com", nil, nil, namer)
+	if maps != nil || redirects != nil {
+		t.Errorf("generateServerRedirects() returned %v and %v but expected nil for no redirects", maps, redirects)
+	}
+
+	tests := []struct {
+		redirects         *conf_v1.Redirects
+		tlsRedirect       *version2.TLSRedirect
+		oidc              *version2.OIDC
+		expectedMaps      []version2.Map
+		expectedRedirects []version2.ServerRedirect
+		msg               string
+	}{
+		{
+			redirects: &conf_v1.Redirects{
+				CanonicalHost: true,
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$host",
+					Variable: "$vs_default_cafe_redirect_host",
+					Parameters: []version2.Parameter{
+						{Value: `"cafe.example.com"`, Result: `""`},
+						{Value: "default", Result: `"cafe.example.com"`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_host",
+					Code:     301,
+					URL:      "$scheme://$vs_default_cafe_redirect_host$request_uri",
+				},
+			},
+			msg: "canonical host",
+		},
+		{
+			redirects: &conf_v1.Redirects{
+				CanonicalHost: true,
+				Code:          308,
+			},
+			tlsRedirect: &version2.TLSRedirect{Code: 301, BasedOn: "$scheme"},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$host",
+					Variable: "$vs_default_cafe_redirect_host",
+					Parameters: []version2.Parameter{
+						{Value: `"cafe.example.com"`, Result: `""`},
+						{Value: "default", Result: `"cafe.example.com"`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_host",
+					Code:     308,
+					URL:      "https://$vs_default_cafe_redirect_host$request_uri",
+				},
+			},
+			msg: "canonical host with a TLS redirect",
+		},
+		{
+			redirects: &conf_v1.Redirects{
+				Paths: []conf_v1.PathRedirect{
+					{From: "/old", To: "/new"},
+					{From: "~ ^/blog/(.*)$", To: "https://blog.example.com/$1", Code: 302},
+					{From: "~*^/docs/(.*)$", To: "/documentation/$1"},
+				},
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_paths_301",
+					Parameters: []version2.Parameter{
+						{Value: `"/old"`, Result: `"/new"`},
+						{Value: `"~*^/docs/(.*)$"`, Result: `"/documentation/$1"`},
+						{Value: "default", Result: `""`},
+					},
+				},
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_paths_302",
+					Parameters: []version2.Parameter{
+						{Value: `"~^/blog/(.*)$"`, Result: `"https://blog.example.com/$1"`},
+						{Value: "default", Result: `""`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_paths_301",
+					Code:     301,
+					URL:      "$vs_default_cafe_redirect_paths_301$is_args$args",
+				},
+				{
+					Variable: "$vs_default_cafe_redirect_paths_302",
+					Code:     302,
+					URL:      "$vs_default_cafe_redirect_paths_302$is_args$args",
+				},
+			},
+			msg: "paths with different codes",
+		},
+		{
+			redirects: &conf_v1.Redirects{
+				LowercasePaths: true,
+				TrailingSlash:  "add",
+				Code:           302,
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_lowercase",
+					Parameters: []version2.Parameter{
+						{Value: `"~^/_"`, Result: `""`},
+						{Value: `"~[A-Z]"`, Result: "$lowercase_uri"},
+						{Value: "default", Result: `""`},
+					},
+				},
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Parameters: []version2.Parameter{
+						{Value: `"~^/_"`, Result: `""`},
+						{Value: `"~^((.*/)?[^/.]+)$"`, Result: `"$1/"`},
+						{Value: "default", Result: `""`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_lowercase",
+					Code:     302,
+					URL:      "$vs_default_cafe_redirect_lowercase$is_args$args",
+				},
+				{
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Code:     302,
+					URL:      "$vs_default_cafe_redirect_trailing_slash$is_args$args",
+				},
+			},
+			msg: "lowercase paths and adding a trailing slash",
+		},
+		{
+			redirects: &conf_v1.Redirects{
+				TrailingSlash: "remove",
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Parameters: []version2.Parameter{
+						{Value: `"~^/_"`, Result: `""`},
+						{Value: `"~^(.+)/$"`, Result: "$1"},
+						{Value: "default", Result: `""`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Code:     301,
+					URL:      "$vs_default_cafe_redirect_trailing_slash$is_args$args",
+				},
+			},
+			msg: "removing a trailing slash",
+		},
+		{
+			redirects: &conf_v1.Redirects{
+				TrailingSlash: "add",
+			},
+			oidc: &version2.OIDC{
+				RedirectURI: "/callback",
+			},
+			expectedMaps: []version2.Map{
+				{
+					Source:   "$uri",
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Parameters: []version2.Parameter{
+						{Value: `"~^/_"`, Result: `""`},
+						{Value: `"/logout"`, Result: `""`},
+						{Value: `"/callback"`, Result: `""`},
+						{Value: `"~^((.*/)?[^/.]+)$"`, Result: `"$1/"`},
+						{Value: "default", Result: `""`},
+					},
+				},
+			},
+			expectedRedirects: []version2.ServerRedirect{
+				{
+					Variable: "$vs_default_cafe_redirect_trailing_slash",
+					Code:     301,
+					URL:      "$vs_default_cafe_redirect_trailing_slash$is_args$args",
+				},
+			},
+			msg: "adding a trailing slash with an OIDC policy",
+		},
+	}
+
+	for _, test := range tests {
+		maps, redirects := generateServerRedirects(test.redirects, "cafe.example.com", test.tlsRedirect, test.oidc, namer)
+		if diff := cmp.Diff(test.expectedMaps, maps); diff != "" {
+			t.Errorf("generateServerRedirects() returned unexpected maps for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+		if diff := cmp.Diff(test.expectedRedirects, redirects); diff != "" {
+			t.Errorf("generateServerRedirects() returned unexpected redirects for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
+
+// getMapResult returns the value of the variable of a map for a source value. Like NGINX, it checks the exact values
+// before the regular expressions and the regular expressions in the order of the parameters.
+func getMapResult(t *testing.T, m version2.Map, source string) string {
+	t.Helper()
+
+	result := ""
+	for _, p := range m.Parameters {
+		value := strings.Trim(p.Value, `"`)
+		if p.Value == "default" {
+			result = p.Result
+		} else if !strings.HasPrefix(value, "~") && value == source {
+			return strings.Trim(p.Result, `"`)
+		}
+	}
+
+	for _, p := range m.Parameters {
+		value := strings.Trim(p.Value, `"`)
+		if !strings.HasPrefix(value, "~") {
+			continue
+		}
+
+		expr := strings.TrimPrefix(value, "~")
+		if strings.HasPrefix(expr, "*") {
+			expr = "(?i)" + strings.TrimPrefix(expr, "*")
+		}
+		re := regexp.MustCompile(expr)
+		if match := re.FindStringSubmatchIndex(source); match != nil {
+			return string(re.ExpandString(nil, strings.Trim(p.Result, `"`), source, match))
+		}
+	}
+
+	return strings.Trim(result, `"`)
+}
+
+func TestGenerateServerRedirectsExcludesInternalPaths(t *testing.T) {
+	namer := &variableNamer{safeNsName: "default_cafe"}
+	oidc := &version2.OIDC{
+		RedirectURI: "/callback",
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+		msg      string
+	}{
+		{
+			path:     "/tea",
+			expected: "/tea/",
+			msg:      "route path",
+		},
+		{
+			path:     "/styles/main.css",
+			expected: "",
+			msg:      "file",
+		},
+		{
+			path:     "/_codexch",
+			expected: "",
+			msg:      "default OIDC redirect URI",
+		},
+		{
+			path:     "/callback",
+			expected: "",
+			msg:      "custom OIDC redirect URI",
+		},
+		{
+			path:     "/_jwks_uri",
+			expected: "",
+			msg:      "JWKS",
+		},
+		{
+			path:     "/_token",
+			expected: "",
+			msg:      "OIDC token subrequest",
+		},
+		{
+			path:     "/logout",
+			expected: "",
+			msg:      "OIDC logout",
+		},
+		{
+			path:     "/_logout",
+			expected: "",
+			msg:      "OIDC logout redirect",
+		},
+	}
+
+	maps, _ := generateServerRedirects(&conf_v1.Redirects{TrailingSlash: "add"}, "cafe.example.com", nil, oidc, namer)
+	if len(maps) != 1 {
+		t.Fatalf("generateServerRedirects() returned %d maps but expected 1", len(maps))
+	}
+
+	for _, test := range tests {
+		result := getMapResult(t, maps[0], test.path)
+		if result != test.expected {
+			t.Errorf("generateServerRedirects() redirected %s to %q but expected %q for the case of %s", test.path, result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestGenerateVirtualServerConfigErrorPagesSkipServerRedirects(t *testing.T) {
+	virtualServerEx := VirtualServerEx{
+		VirtualServer: &conf_v1.VirtualServer{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      "cafe",
+				Namespace: "default",
+			},
+			Spec: conf_v1.VirtualServerSpec{
+				Host: "cafe.example.com",
+				Redirects: &conf_v1.Redirects{
+					LowercasePaths: true,
+					TrailingSlash:  "add",
+				},
+				Upstreams: []conf_v1.Upstream{
+					{
+						Name:    "tea",
+						Service: "tea-svc",
+						Port:    80,
+					},
+				},
+				Routes: []conf_v1.Route{
+					{
+						Path: "/tea",
+						Action: &conf_v1.Action{
+							Pass: "tea",
+						},
+						ErrorPages: []conf_v1.ErrorPage{
+							{
+								Codes: []int{404},
+								Return: &conf_v1.ErrorPageReturn{
+									ActionReturn: conf_v1.ActionReturn{
+										Body: "Not Found",
+									},
+								},
+							},
+							{
+								Codes: []int{502},
+								Redirect: &conf_v1.ErrorPageRedirect{
+									ActionRedirect: conf_v1.ActionRedirect{
+										URL: "http://nginx.com",
+									},
+								},
+							},
+						},
+					},
+				},
 			},
-			isPlus:               false,
-			isResolverConfigured: false,
-			expected:             []string{"192.168.10.10:8080"},
-			msg:                  "Service with endpoints",
 		},
-		{
-			upstream: conf_v1.Upstream{
-				Service: name,
-				Port:    8080,
-			},
-			vsEx: &VirtualServerEx{
-				VirtualServer: &conf_v1.VirtualServer{
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      name,
-						Namespace: namespace,
-					},
-				},
-				Endpoints: map[string][]string{},
+		Endpoints: map[string][]string{
+			"default/tea-svc:80": {"10.0.0.20:80"},
+		},
+	}
+
+	vsc := newVirtualServerConfigurator(&ConfigParams{}, false, false, &StaticConfigParams{}, false)
+	result, warnings := vsc.GenerateVirtualServerConfig(&virtualServerEx, nil, nil)
+	if len(warnings) != 0 {
+		t.Errorf("GenerateVirtualServerConfig() returned unexpected warnings: %v", warnings)
+	}
+
+	// the server redirects run again for an internal redirect to a path, but not for a named location,
+	// and an error page with a URL redirects the client
+	for _, loc := range result.Server.Locations {
+		for _, errorPage := range loc.ErrorPages {
+			if !strings.HasPrefix(errorPage.Name, "@") && !strings.Contains(errorPage.Name, "://") {
+				t.Errorf("GenerateVirtualServerConfig() generated the error page %s of the location %s that the server redirects apply to", errorPage.Name, loc.Path)
+			}
+		}
+	}
+	for _, loc := range result.Server.ErrorPageLocations {
+		if !strings.HasPrefix(loc.Name, "@") {
+			t.Errorf("GenerateVirtualServerConfig() generated the error page location %s that the server redirects apply to", loc.Name)
+		}
+	}
+}
+
+func TestGenerateRequestIDProxy(t *testing.T) {
+	if result := generateRequestIDProxy(""); result != nil {
+		t.Errorf("generateRequestIDProxy() returned %v but expected nil for a disabled request ID", result)
+	}
+
+	expected := &conf_v1.ActionProxy{
+		RequestHeaders: &conf_v1.ProxyRequestHeaders{
+			Set: []conf_v1.Header{{Name: "X-Request-ID", Value: "$correlation_id"}},
+		},
+		ResponseHeaders: &conf_v1.ProxyResponseHeaders{
+			Hide: []string{"X-Request-ID"},
+			Add: []conf_v1.AddHeader{
+				{Header: conf_v1.Header{Name: "X-Request-ID", Value: "$correlation_id"}, Always: true},
 			},
-			isPlus:               false,
-			isResolverConfigured: false,
-			expected:             []string{nginx502Server},
-			msg:                  "Service with no endpoints",
 		},
+	}
+
+	result := generateRequestIDProxy("X-Request-ID")
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("generateRequestIDProxy() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateRequestIDEnable(t *testing.T) {
+	tests := []struct {
+		requestID *conf_v1.RequestID
+		enabled   bool
+		expected  bool
+		msg       string
+	}{
 		{
-			upstream: conf_v1.Upstream{
-				Service: name,
-				Port:    8080,
-			},
-			vsEx: &VirtualServerEx{
-				VirtualServer: &conf_v1.VirtualServer{
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      name,
-						Namespace: namespace,
-					},
-				},
-				Endpoints: map[string][]string{},
-			},
-			isPlus:               true,
-			isResolverConfigured: false,
-			expected:             nil,
-			msg:                  "Service with no endpoints",
+			requestID: nil,
+			enabled:   true,
+			expected:  true,
+			msg:       "enabled in the ConfigMap",
 		},
 		{
-			upstream: conf_v1.Upstream{
-				Service:     name,
-				Port:        8080,
-				Subselector: map[string]string{"version": "test"},
-			},
-			vsEx: &VirtualServerEx{
-				VirtualServer: &conf_v1.VirtualServer{
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      name,
-						Namespace: namespace,
-					},
-				},
-				Endpoints: map[string][]string{
-					"test-namespace/test_version=test:8080": {"192.168.10.10:8080"},
-				},
-			},
-			isPlus:               false,
-			isResolverConfigured: false,
-			expected:             []string{"192.168.10.10:8080"},
-			msg:                  "Upstream with subselector, with a matching endpoint",
+			requestID: &conf_v1.RequestID{Enable: false},
+			enabled:   true,
+			expected:  false,
+			msg:       "disabled in the VirtualServer",
 		},
 		{
-			upstream: conf_v1.Upstream{
-				Service:     name,
-				Port:        8080,
-				Subselector: map[string]string{"version": "test"},
-			},
-			vsEx: &VirtualServerEx{
-				VirtualServer: &conf_v1.VirtualServer{
-					ObjectMeta: meta_v1.ObjectMeta{
-						Name:      name,
-						Namespace: namespace,
-					},
-				},
-				Endpoints: map[string][]string{
-					"test-namespace/test:8080": {"192.168.10.10:8080"},
-				},
-			},
-			isPlus:               false,
-			isResolverConfigured: false,
-			expected:             []string{nginx502Server},
-			msg:                  "Upstream with subselector, without a matching endpoint",
+			requestID: &conf_v1.RequestID{Enable: true},
+			enabled:   false,
+			expected:  true,
+			msg:       "enabled in the VirtualServer",
 		},
 	}
 
 	for _, test := range tests {
-		isWildcardEnabled := false
-		vsc := newVirtualServerConfigurator(
-			&ConfigParams{},
-			test.isPlus,
-			test.isResolverConfigured,
-			&StaticConfigParams{},
-			isWildcardEnabled,
-		)
-		result := vsc.generateEndpointsForUpstream(test.vsEx.VirtualServer, namespace, test.upstream, test.vsEx)
-		if !reflect.DeepEqual(result, test.expected) {
-			t.Errorf("generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) returned %v, but expected %v for case: %v",
-				test.isPlus, test.isResolverConfigured, result, test.expected, test.msg)
+		result := generateRequestIDEnable(test.requestID, &ConfigParams{RequestID: test.enabled})
+		if result != test.expected {
+			t.Errorf("generateRequestIDEnable() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
 		}
+	}
+}
 
-		if len(vsc.warnings) == 0 && test.warningsExpected {
-			t.Errorf(
-				"generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) didn't return any warnings for %v but warnings expected",
-				test.isPlus,
-				test.isResolverConfigured,
-				test.upstream,
-			)
-		}
+func TestAddRequestIDToErrorPageLocations(t *testing.T) {
+	errorPageLocations := []version2.ErrorPageLocation{
+		{
+			Name:    "@error_page_0_0",
+			Headers: []version2.Header{{Name: "Set-Cookie", Value: "cookie1=test"}},
+		},
+		{
+			Name:    "@error_page_0_1",
+			Headers: []version2.Header{{Name: "x-request-id", Value: "static"}},
+		},
+	}
 
-		if len(vsc.warnings) != 0 && !test.warningsExpected {
-			t.Errorf("generateEndpointsForUpstream(isPlus=%v, isResolverConfigured=%v) returned warnings for %v",
-				test.isPlus, test.isResolverConfigured, test.upstream)
+	expected := []version2.ErrorPageLocation{
+		{
+			Name: "@error_page_0_0",
+			Headers: []version2.Header{
+				{Name: "Set-Cookie", Value: "cookie1=test"},
+				{Name: "X-Request-ID", Value: "$correlation_id"},
+			},
+		},
+		{
+			Name:    "@error_page_0_1",
+			Headers: []version2.Header{{Name: "x-request-id", Value: "static"}},
+		},
+	}
+
+	addRequestIDToErrorPageLocations("X-Request-ID", errorPageLocations)
+	if diff := cmp.Diff(expected, errorPageLocations); diff != "" {
+		t.Errorf("addRequestIDToErrorPageLocations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateProxySSLNameForUpstream(t *testing.T) {
+	tests := []struct {
+		upstream conf_v1.Upstream
+		expected string
+	}{
+		{
+			upstream: conf_v1.Upstream{Service: "tea-svc"},
+			expected: "tea-svc.default.svc",
+		},
+		{
+			upstream: conf_v1.Upstream{Addresses: []string{"tea.example.com:443", "10.0.0.1:443"}},
+			expected: "tea.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateProxySSLNameForUpstream(test.upstream, "default")
+		if result != test.expected {
+			t.Errorf("generateProxySSLNameForUpstream(%v) returned %q but expected %q", test.upstream, result, test.expected)
 		}
 	}
 }
@@ -7217,9 +9553,9 @@ func TestCreateEndpointsFromUpstream(t *testing.T) {
 		},
 	}
 
-	expected := []string{
-		"10.0.0.20:80",
-		"10.0.0.30:80",
+	expected := []nginx.ServerKey{
+		{Address: "10.0.0.20:80"},
+		{Address: "10.0.0.30:80"},
 	}
 
 	endpoints := createEndpointsFromUpstream(ups)
@@ -7352,6 +9688,11 @@ func TestGenerateSessionCookie(t *testing.T) {
 			expected: nil,
 			msg:      "session cookie not enabled",
 		},
+		{
+			sc:       &conf_v1.SessionCookie{Enable: true, Name: "test", Mode: "hash"},
+			expected: nil,
+			msg:      "session cookie with the hash mode",
+		},
 	}
 	for _, test := range tests {
 		result := generateSessionCookie(test.sc)
@@ -7361,6 +9702,143 @@ func TestGenerateSessionCookie(t *testing.T) {
 	}
 }
 
+func TestGenerateUpstreamWithHashSessionCookie(t *testing.T) {
+	name := "vs_default_cafe_tea"
+	endpoints := []string{
+		"192.168.10.10:8080",
+	}
+	cfgParams := ConfigParams{LBMethod: "random two least_conn"}
+
+	tests := []struct {
+		sc       *conf_v1.SessionCookie
+		expected string
+		msg      string
+	}{
+		{
+			sc:       &conf_v1.SessionCookie{Enable: true, Name: "srv_id", Mode: "hash"},
+			expected: "hash $vs_default_cafe_tea_session consistent",
+			msg:      "cookie",
+		},
+		{
+			sc:       &conf_v1.SessionCookie{Enable: true, Mode: "hash", Header: "X-Session-ID"},
+			expected: "hash $http_X_Session_ID consistent",
+			msg:      "header",
+		},
+		{
+			sc:       &conf_v1.SessionCookie{Enable: true, Mode: "hash", ClientIP: true},
+			expected: "hash $remote_addr consistent",
+			msg:      "client IP",
+		},
+		{
+			sc:       &conf_v1.SessionCookie{Enable: false, Name: "srv_id", Mode: "hash"},
+			expected: "random two least_conn",
+			msg:      "disabled",
+		},
+	}
+
+	for _, isPlus := range []bool{false, true} {
+		for _, test := range tests {
+			upstream := conf_v1.Upstream{Service: "tea", Port: 80, SessionCookie: test.sc}
+			vsc := newVirtualServerConfigurator(&cfgParams, isPlus, false, &StaticConfigParams{}, false)
+			result := vsc.generateUpstream(nil, name, upstream, false, endpoints)
+			if result.LBMethod != test.expected {
+				t.Errorf("generateUpstream() returned LBMethod %q but expected %q for the case of %s (isPlus %v)", result.LBMethod, test.expected, test.msg, isPlus)
+			}
+			if result.SessionCookie != nil {
+				t.Errorf("generateUpstream() returned SessionCookie %v but expected nil for the case of %s (isPlus %v)", result.SessionCookie, test.msg, isPlus)
+			}
+		}
+	}
+}
+
+func TestGenerateHashSessionCookieMaps(t *testing.T) {
+	sc := &conf_v1.SessionCookie{
+		Enable:   true,
+		Mode:     "hash",
+		Name:     "srv_id",
+		Path:     "/tea",
+		Expires:  "1h",
+		Domain:   ".example.com",
+		HTTPOnly: true,
+		Secure:   true,
+	}
+
+	expected := []version2.Map{
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_session",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: "$request_id"},
+				{Value: "default", Result: "$cookie_srv_id"},
+			},
+		},
+		{
+			Source:   "$cookie_srv_id",
+			Variable: "$vs_default_cafe_tea_session_cookie",
+			Parameters: []version2.Parameter{
+				{Value: `""`, Result: `"srv_id=$vs_default_cafe_tea_session; Path=/tea; Max-Age=3600; Domain=.example.com; HttpOnly; Secure"`},
+				{Value: "default", Result: `""`},
+			},
+		},
+	}
+
+	result := generateHashSessionCookieMaps("$vs_default_cafe_tea_session", sc)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("generateHashSessionCookieMaps() returned %v but expected %v", result, expected)
+	}
+
+	expectedHeader := &version2.AddHeader{
+		Header: version2.Header{Name: "Set-Cookie", Value: "$vs_default_cafe_tea_session_cookie"},
+		Always: true,
+	}
+
+	header := generateHashSessionCookieAddHeader("$vs_default_cafe_tea_session", sc)
+	if !reflect.DeepEqual(header, expectedHeader) {
+		t.Errorf("generateHashSessionCookieAddHeader() returned %v but expected %v", header, expectedHeader)
+	}
+
+	for _, sc := range []*conf_v1.SessionCookie{
+		nil,
+		{Enable: true, Name: "srv_id"},
+		{Enable: true, Mode: "hash", Header: "X-Session-ID"},
+		{Enable: true, Mode: "hash", ClientIP: true},
+	} {
+		if result := generateHashSessionCookieMaps("$vs_default_cafe_tea_session", sc); result != nil {
+			t.Errorf("generateHashSessionCookieMaps() returned %v but expected nil for %v", result, sc)
+		}
+		if header := generateHashSessionCookieAddHeader("$vs_default_cafe_tea_session", sc); header != nil {
+			t.Errorf("generateHashSessionCookieAddHeader() returned %v but expected nil for %v", header, sc)
+		}
+	}
+}
+
+func TestGenerateHashSessionCookie(t *testing.T) {
+	tests := []struct {
+		sc       *conf_v1.SessionCookie
+		expected string
+	}{
+		{
+			sc:       &conf_v1.SessionCookie{Name: "srv_id"},
+			expected: "srv_id=$session; Path=/",
+		},
+		{
+			sc:       &conf_v1.SessionCookie{Name: "srv_id", Expires: "max"},
+			expected: "srv_id=$session; Path=/; Max-Age=315360000",
+		},
+		{
+			sc:       &conf_v1.SessionCookie{Name: "srv_id", Path: "/coffee", Expires: "1d 1h"},
+			expected: "srv_id=$session; Path=/coffee; Max-Age=90000",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateHashSessionCookie(test.sc, "$session")
+		if result != test.expected {
+			t.Errorf("generateHashSessionCookie() returned %q but expected %q", result, test.expected)
+		}
+	}
+}
+
 func TestGeneratePath(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -8403,3 +10881,41 @@ func TestGenerateTimeWithDefault(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateServerName(t *testing.T) {
+	vs := &conf_v1.VirtualServer{
+		Spec: conf_v1.VirtualServerSpec{
+			Host:    "cafe.example.com",
+			Aliases: []string{"www.cafe.example.com", "*.cafe.example.com"},
+		},
+	}
+
+	tests := []struct {
+		validAliases map[string]bool
+		expected     string
+		msg          string
+	}{
+		{
+			validAliases: map[string]bool{"www.cafe.example.com": true, "*.cafe.example.com": true},
+			expected:     "cafe.example.com www.cafe.example.com *.cafe.example.com",
+			msg:          "valid aliases",
+		},
+		{
+			validAliases: map[string]bool{"www.cafe.example.com": false, "*.cafe.example.com": true},
+			expected:     "cafe.example.com *.cafe.example.com",
+			msg:          "alias taken by another resource",
+		},
+		{
+			validAliases: nil,
+			expected:     "cafe.example.com",
+			msg:          "no valid aliases",
+		},
+	}
+
+	for _, test := range tests {
+		result := generateServerName(&VirtualServerEx{VirtualServer: vs, ValidAliases: test.validAliases})
+		if result != test.expected {
+			t.Errorf("generateServerName() returned %q but expected %q for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"fmt"
+	"log/slog"
 	"reflect"
 	"sort"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,6 +24,7 @@ const (
 	virtualServerKind      = "VirtualServer"
 	virtualServerRouteKind = "VirtualServerRoute"
 	transportServerKind    = "TransportServer"
+	staticResponseKind     = "StaticResponse"
 )
 
 // Operation defines an operation to perform for a resource.
@@ -92,6 +95,8 @@ type IngressConfiguration struct {
 	IsMaster bool
 	// Minions contains minions if the Ingress is a master.
 	Minions []*MinionConfiguration
+	// Canaries contains the canaries of a regular Ingress.
+	Canaries []*CanaryConfiguration
 	// ValidHosts marks the hosts of the Ingress as valid (true) or invalid (false).
 	// Regular Ingress resources can have multiple hosts. It is possible that some of the hosts are taken by other
 	// resources. In that case, those hosts will be marked as invalid.
@@ -173,6 +178,20 @@ func (ic *IngressConfiguration) IsEqual(resource Resource) bool {
 		}
 	}
 
+	if len(ic.Canaries) != len(ingConfig.Canaries) {
+		return false
+	}
+
+	for i := range ic.Canaries {
+		if !compareObjectMetasWithAnnotations(&ic.Canaries[i].Ingress.ObjectMeta, &ingConfig.Canaries[i].Ingress.ObjectMeta) {
+			return false
+		}
+
+		if !reflect.DeepEqual(ic.Canaries[i].ValidPaths, ingConfig.Canaries[i].ValidPaths) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -194,11 +213,32 @@ func NewMinionConfiguration(ing *networking.Ingress) *MinionConfiguration {
 	}
 }
 
+// CanaryConfiguration holds a canary Ingress resource.
+type CanaryConfiguration struct {
+	// Ingress is the Ingress behind a canary.
+	Ingress *networking.Ingress
+	// ValidPaths marks the paths of the Ingress as valid (true) or invalid (false).
+	// A path of a canary is valid when the primary Ingress has the same path and no other canary takes it.
+	ValidPaths map[string]bool
+}
+
+// NewCanaryConfiguration creates a new CanaryConfiguration.
+func NewCanaryConfiguration(ing *networking.Ingress) *CanaryConfiguration {
+	return &CanaryConfiguration{
+		Ingress:    ing,
+		ValidPaths: make(map[string]bool),
+	}
+}
+
 // VirtualServerConfiguration holds a VirtualServer along with its VirtualServerRoutes.
 type VirtualServerConfiguration struct {
 	VirtualServer       *conf_v1.VirtualServer
 	VirtualServerRoutes []*conf_v1.VirtualServerRoute
-	Warnings            []string
+	// ValidAliases marks the aliases of the VirtualServer as valid (true) or invalid (false).
+	// It is possible that some of the aliases are taken by other resources. In that case, those aliases will be marked
+	// as invalid, while the VirtualServer keeps serving its host and the other aliases.
+	ValidAliases map[string]bool
+	Warnings     []string
 }
 
 // NewVirtualServerConfiguration creates a VirtualServerConfiguration.
@@ -243,6 +283,10 @@ func (vsc *VirtualServerConfiguration) IsEqual(resource Resource) bool {
 		return false
 	}
 
+	if !reflect.DeepEqual(vsc.ValidAliases, vsConfig.ValidAliases) {
+		return false
+	}
+
 	if len(vsc.VirtualServerRoutes) != len(vsConfig.VirtualServerRoutes) {
 		return false
 	}
@@ -344,13 +388,15 @@ type Configuration struct {
 	globalConfigurationValidator *validation.GlobalConfigurationValidator
 	transportServerValidator     *validation.TransportServerValidator
 
-	secretReferenceChecker     *secretReferenceChecker
-	serviceReferenceChecker    *serviceReferenceChecker
-	endpointReferenceChecker   *serviceReferenceChecker
-	policyReferenceChecker     *policyReferenceChecker
-	appPolicyReferenceChecker  *appProtectResourceReferenceChecker
-	appLogConfReferenceChecker *appProtectResourceReferenceChecker
-	appDosProtectedChecker     *dosResourceReferenceChecker
+	secretReferenceChecker         *secretReferenceChecker
+	serviceReferenceChecker        *serviceReferenceChecker
+	endpointReferenceChecker       *serviceReferenceChecker
+	policyReferenceChecker         *policyReferenceChecker
+	appPolicyReferenceChecker      *appProtectResourceReferenceChecker
+	appLogConfReferenceChecker     *appProtectResourceReferenceChecker
+	appDosProtectedChecker         *dosResourceReferenceChecker
+	configMapReferenceChecker      *configMapReferenceChecker
+	staticResponseReferenceChecker *staticResponseReferenceChecker
 
 	isPlus                  bool
 	appProtectEnabled       bool
@@ -358,6 +404,7 @@ type Configuration struct {
 	internalRoutesEnabled   bool
 	isTLSPassthroughEnabled bool
 	snippetsEnabled         bool
+	logger                  *slog.Logger
 
 	lock sync.RWMutex
 }
@@ -374,32 +421,36 @@ func NewConfiguration(
 	transportServerValidator *validation.TransportServerValidator,
 	isTLSPassthroughEnabled bool,
 	snippetsEnabled bool,
+	logger *slog.Logger,
 ) *Configuration {
 	return &Configuration{
-		hosts:                        make(map[string]Resource),
-		listeners:                    make(map[string]*TransportServerConfiguration),
-		ingresses:                    make(map[string]*networking.Ingress),
-		virtualServers:               make(map[string]*conf_v1.VirtualServer),
-		virtualServerRoutes:          make(map[string]*conf_v1.VirtualServerRoute),
-		transportServers:             make(map[string]*conf_v1alpha1.TransportServer),
-		hostProblems:                 make(map[string]ConfigurationProblem),
-		hasCorrectIngressClass:       hasCorrectIngressClass,
-		virtualServerValidator:       virtualServerValidator,
-		globalConfigurationValidator: globalConfigurationValidator,
-		transportServerValidator:     transportServerValidator,
-		secretReferenceChecker:       newSecretReferenceChecker(isPlus),
-		serviceReferenceChecker:      newServiceReferenceChecker(false),
-		endpointReferenceChecker:     newServiceReferenceChecker(true),
-		policyReferenceChecker:       newPolicyReferenceChecker(),
-		appPolicyReferenceChecker:    newAppProtectResourceReferenceChecker(configs.AppProtectPolicyAnnotation),
-		appLogConfReferenceChecker:   newAppProtectResourceReferenceChecker(configs.AppProtectLogConfAnnotation),
-		appDosProtectedChecker:       newDosResourceReferenceChecker(configs.AppProtectDosProtectedAnnotation),
-		isPlus:                       isPlus,
-		appProtectEnabled:            appProtectEnabled,
-		appProtectDosEnabled:         appProtectDosEnabled,
-		internalRoutesEnabled:        internalRoutesEnabled,
-		isTLSPassthroughEnabled:      isTLSPassthroughEnabled,
-		snippetsEnabled:              snippetsEnabled,
+		hosts:                          make(map[string]Resource),
+		listeners:                      make(map[string]*TransportServerConfiguration),
+		ingresses:                      make(map[string]*networking.Ingress),
+		virtualServers:                 make(map[string]*conf_v1.VirtualServer),
+		virtualServerRoutes:            make(map[string]*conf_v1.VirtualServerRoute),
+		transportServers:               make(map[string]*conf_v1alpha1.TransportServer),
+		hostProblems:                   make(map[string]ConfigurationProblem),
+		hasCorrectIngressClass:         hasCorrectIngressClass,
+		virtualServerValidator:         virtualServerValidator,
+		globalConfigurationValidator:   globalConfigurationValidator,
+		transportServerValidator:       transportServerValidator,
+		secretReferenceChecker:         newSecretReferenceChecker(isPlus),
+		serviceReferenceChecker:        newServiceReferenceChecker(false),
+		endpointReferenceChecker:       newServiceReferenceChecker(true),
+		policyReferenceChecker:         newPolicyReferenceChecker(),
+		appPolicyReferenceChecker:      newAppProtectResourceReferenceChecker(configs.AppProtectPolicyAnnotation),
+		appLogConfReferenceChecker:     newAppProtectResourceReferenceChecker(configs.AppProtectLogConfAnnotation),
+		appDosProtectedChecker:         newDosResourceReferenceChecker(configs.AppProtectDosProtectedAnnotation),
+		configMapReferenceChecker:      newConfigMapReferenceChecker(),
+		staticResponseReferenceChecker: newStaticResponseReferenceChecker(),
+		isPlus:                         isPlus,
+		appProtectEnabled:              appProtectEnabled,
+		appProtectDosEnabled:           appProtectDosEnabled,
+		internalRoutesEnabled:          internalRoutesEnabled,
+		isTLSPassthroughEnabled:        isTLSPassthroughEnabled,
+		snippetsEnabled:                snippetsEnabled,
+		logger:                         logger,
 	}
 }
 
@@ -874,6 +925,16 @@ func (c *Configuration) FindResourcesForAppProtectDosProtected(namespace string,
 	return c.findResourcesForResourceReference(namespace, name, c.appDosProtectedChecker)
 }
 
+// FindResourcesForConfigMap finds resources that reference the specified ConfigMap with custom error pages.
+func (c *Configuration) FindResourcesForConfigMap(cmNamespace string, cmName string) []Resource {
+	return c.findResourcesForResourceReference(cmNamespace, cmName, c.configMapReferenceChecker)
+}
+
+// FindResourcesForStaticResponse finds resources that reference the specified StaticResponse.
+func (c *Configuration) FindResourcesForStaticResponse(srNamespace string, srName string) []Resource {
+	return c.findResourcesForResourceReference(srNamespace, srName, c.staticResponseReferenceChecker)
+}
+
 func (c *Configuration) findResourcesForResourceReference(namespace string, name string, checker resourceReferenceChecker) []Resource {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -896,6 +957,14 @@ func (c *Configuration) findResourcesForResourceReference(namespace string, name
 					break
 				}
 			}
+
+			// only regular Ingress resources have canaries, so they don't have minions
+			for _, c := range impl.Canaries {
+				if checker.IsReferencedByMinion(namespace, name, c.Ingress) {
+					result = append(result, r)
+					break
+				}
+			}
 		case *VirtualServerConfiguration:
 			if checker.IsReferencedByVirtualServer(namespace, name, impl.VirtualServer) {
 				result = append(result, r)
@@ -937,6 +1006,7 @@ func (c *Configuration) rebuildHosts() ([]ResourceChange, []ConfigurationProblem
 	newHosts, newResources := c.buildHostsAndResources()
 
 	updateActiveHostsForIngresses(newHosts, newResources)
+	updateActiveAliasesForVirtualServers(newHosts, newResources)
 
 	removedHosts, updatedHosts, addedHosts := detectChangesInHosts(c.hosts, newHosts)
 	changes := createResourceChangesForHosts(removedHosts, updatedHosts, addedHosts, c.hosts, newHosts)
@@ -960,6 +1030,7 @@ func (c *Configuration) rebuildHosts() ([]ResourceChange, []ConfigurationProblem
 
 	c.addProblemsForResourcesWithoutActiveHost(newResources, newProblems)
 	c.addProblemsForOrphanMinions(newProblems)
+	c.addProblemsForOrphanCanaries(newProblems)
 	c.addProblemsForOrphanOrIgnoredVsrs(newProblems)
 
 	newOrUpdatedProblems := detectChangesInProblems(newProblems, c.hostProblems)
@@ -984,6 +1055,22 @@ func updateActiveHostsForIngresses(hosts map[string]Resource, resources map[stri
 	}
 }
 
+func updateActiveAliasesForVirtualServers(hosts map[string]Resource, resources map[string]Resource) {
+	for _, r := range resources {
+		vsConfig, ok := r.(*VirtualServerConfiguration)
+		if !ok || len(vsConfig.VirtualServer.Spec.Aliases) == 0 {
+			continue
+		}
+
+		vsConfig.ValidAliases = make(map[string]bool)
+
+		for _, alias := range vsConfig.VirtualServer.Spec.Aliases {
+			res, exists := hosts[alias]
+			vsConfig.ValidAliases[alias] = exists && res.GetKeyWithKind() == r.GetKeyWithKind()
+		}
+	}
+}
+
 func detectChangesInProblems(newProblems map[string]ConfigurationProblem, oldProblems map[string]ConfigurationProblem) []ConfigurationProblem {
 	var result []ConfigurationProblem
 
@@ -1054,6 +1141,9 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 			res := c.hosts[impl.VirtualServer.Spec.Host]
 
 			if res.GetKeyWithKind() != r.GetKeyWithKind() {
+				nl.Debugf(nl.WithResource(c.logger, virtualServerKind, impl.VirtualServer.Namespace, impl.VirtualServer.Name).With(nl.HostKey, impl.VirtualServer.Spec.Host),
+					"Host is taken by %s", res.GetKeyWithKind())
+
 				p := ConfigurationProblem{
 					Object:  impl.VirtualServer,
 					IsError: false,
@@ -1066,6 +1156,9 @@ func (c *Configuration) addProblemsForResourcesWithoutActiveHost(resources map[s
 			res := c.hosts[impl.TransportServer.Spec.Host]
 
 			if res.GetKeyWithKind() != r.GetKeyWithKind() {
+				nl.Debugf(nl.WithResource(c.logger, transportServerKind, impl.TransportServer.Namespace, impl.TransportServer.Name).With(nl.HostKey, impl.TransportServer.Spec.Host),
+					"Host is taken by %s", res.GetKeyWithKind())
+
 				p := ConfigurationProblem{
 					Object:  impl.TransportServer,
 					IsError: false,
@@ -1102,6 +1195,30 @@ func (c *Configuration) addProblemsForOrphanMinions(problems map[string]Configur
 	}
 }
 
+func (c *Configuration) addProblemsForOrphanCanaries(problems map[string]ConfigurationProblem) {
+	for _, key := range getSortedIngressKeys(c.ingresses) {
+		ing := c.ingresses[key]
+
+		if !isCanary(ing) {
+			continue
+		}
+
+		r, exists := c.hosts[ing.Spec.Rules[0].Host]
+		ingressConf, ok := r.(*IngressConfiguration)
+
+		if !exists || !ok || ingressConf.IsMaster {
+			p := ConfigurationProblem{
+				Object:  ing,
+				IsError: false,
+				Reason:  "NoPrimaryIngressFound",
+				Message: "Primary Ingress is invalid or doesn't exist",
+			}
+			k := getResourceKeyWithKind(ingressKind, &ing.ObjectMeta)
+			problems[k] = p
+		}
+	}
+}
+
 func (c *Configuration) addProblemsForOrphanOrIgnoredVsrs(problems map[string]ConfigurationProblem) {
 	for _, key := range getSortedVirtualServerRouteKeys(c.virtualServerRoutes) {
 		vsr := c.virtualServerRoutes[key]
@@ -1284,7 +1401,7 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 	for _, key := range getSortedIngressKeys(c.ingresses) {
 		ing := c.ingresses[key]
 
-		if isMinion(ing) {
+		if isMinion(ing) || isCanary(ing) {
 			continue
 		}
 
@@ -1318,6 +1435,9 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 	}
 
 	// Step 2 - Build hosts from VirtualServer resources
+	// A wildcard host, such as *.example.com, only collides with the same wildcard host. It doesn't collide with
+	// the exact hosts that it matches, such as cafe.example.com, because NGINX gives the exact hosts precedence over
+	// the wildcard hosts when choosing the server for a request.
 
 	for _, key := range getSortedVirtualServerKeys(c.virtualServers) {
 		vs := c.virtualServers[key]
@@ -1373,9 +1493,122 @@ func (c *Configuration) buildHostsAndResources() (newHosts map[string]Resource,
 		}
 	}
 
+	// Step 4 - Build hosts from the aliases of VirtualServer resources
+	// The aliases are claimed after all the other hosts, so that only a VirtualServer that holds its host claims
+	// its aliases. An alias never takes a host claimed in the previous steps, such as the host of an Ingress,
+	// a TransportServer or another VirtualServer. The conflicts between the aliases are resolved like the conflicts
+	// between the hosts.
+
+	primaryHosts := make(map[string]bool, len(newHosts))
+	for host := range newHosts {
+		primaryHosts[host] = true
+	}
+
+	for _, key := range getSortedVirtualServerKeys(c.virtualServers) {
+		vs := c.virtualServers[key]
+		resource := newResources[getResourceKeyWithKind(virtualServerKind, &vs.ObjectMeta)]
+
+		if newHosts[vs.Spec.Host] != resource {
+			continue
+		}
+
+		for _, alias := range vs.Spec.Aliases {
+			holder, exists := newHosts[alias]
+			if !exists {
+				newHosts[alias] = resource
+				continue
+			}
+
+			warning := fmt.Sprintf("host %s is taken by another resource", alias)
+
+			if !primaryHosts[alias] && !holder.Wins(resource) {
+				newHosts[alias] = resource
+				holder.AddWarning(warning)
+			} else {
+				resource.AddWarning(warning)
+			}
+		}
+	}
+
+	// Step 5 - Add canary Ingress resources to the regular Ingress resources that hold their hosts
+
+	c.addCanaryConfigs(newHosts)
+
 	return newHosts, newResources
 }
 
+func (c *Configuration) addCanaryConfigs(hosts map[string]Resource) {
+	// the paths taken by the canaries. The key is the host and the path.
+	paths := make(map[string]*CanaryConfiguration)
+
+	for _, key := range getSortedIngressKeys(c.ingresses) {
+		ingress := c.ingresses[key]
+
+		if !isCanary(ingress) {
+			continue
+		}
+
+		host := ingress.Spec.Rules[0].Host
+
+		primary, ok := hosts[host].(*IngressConfiguration)
+		if !ok || primary.IsMaster {
+			continue
+		}
+
+		primaryPaths := getIngressPathsForHost(primary.Ingress, host)
+		canaryConfig := NewCanaryConfiguration(ingress)
+		canaryKey := getResourceKey(&ingress.ObjectMeta)
+
+		for _, p := range ingress.Spec.Rules[0].HTTP.Paths {
+			if !primaryPaths[p.Path] {
+				warning := fmt.Sprintf("path %s doesn't exist in the primary Ingress %s", p.Path, getResourceKey(&primary.Ingress.ObjectMeta))
+				primary.ChildWarnings[canaryKey] = append(primary.ChildWarnings[canaryKey], warning)
+				continue
+			}
+
+			pathKey := host + p.Path
+
+			holder, exists := paths[pathKey]
+			if !exists {
+				paths[pathKey] = canaryConfig
+				canaryConfig.ValidPaths[p.Path] = true
+				continue
+			}
+
+			warning := fmt.Sprintf("path %s is taken by another canary", p.Path)
+
+			if !chooseObjectMetaWinner(&holder.Ingress.ObjectMeta, &ingress.ObjectMeta) {
+				paths[pathKey] = canaryConfig
+				canaryConfig.ValidPaths[p.Path] = true
+
+				holder.ValidPaths[p.Path] = false
+				key := getResourceKey(&holder.Ingress.ObjectMeta)
+				primary.ChildWarnings[key] = append(primary.ChildWarnings[key], warning)
+			} else {
+				primary.ChildWarnings[canaryKey] = append(primary.ChildWarnings[canaryKey], warning)
+			}
+		}
+
+		primary.Canaries = append(primary.Canaries, canaryConfig)
+	}
+}
+
+func getIngressPathsForHost(ing *networking.Ingress, host string) map[string]bool {
+	paths := make(map[string]bool)
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != host || rule.HTTP == nil {
+			continue
+		}
+
+		for _, p := range rule.HTTP.Paths {
+			paths[p.Path] = true
+		}
+	}
+
+	return paths
+}
+
 func (c *Configuration) buildMinionConfigs(masterHost string) ([]*MinionConfiguration, map[string][]string) {
 	var minionConfigs []*MinionConfiguration
 	childWarnings := make(map[string][]string)
@@ -37,6 +37,7 @@ func createTestConfiguration() *Configuration {
 		validation.NewTransportServerValidator(isTLSPassthroughEnabled, snippetsEnabled, isPlus),
 		isTLSPassthroughEnabled,
 		snippetsEnabled,
+		nil,
 	)
 }
 
@@ -867,6 +868,152 @@ func TestMinionPathCollisions(t *testing.T) {
 	}
 }
 
+func TestAddIngressForCanaryIngresses(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	// Add canary-1
+
+	canary1 := createTestIngressCanary("ingress-canary-1", "foo.example.com", "/")
+	var expectedChanges []ResourceChange
+	expectedProblems := []ConfigurationProblem{
+		{
+			Object:  canary1,
+			Reason:  "NoPrimaryIngressFound",
+			Message: "Primary Ingress is invalid or doesn't exist",
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateIngress(canary1)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add primary
+
+	primary := createTestIngressWithPath("ingress-primary", "foo.example.com", "/")
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: primary,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"/": true,
+						},
+					},
+				},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(primary)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add canary-2 with a path that doesn't exist in the primary
+
+	canary2 := createTestIngressCanary("ingress-canary-2", "foo.example.com", "/tea")
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: primary,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"/": true,
+						},
+					},
+					{
+						Ingress:    canary2,
+						ValidPaths: map[string]bool{},
+					},
+				},
+				ChildWarnings: map[string][]string{
+					"default/ingress-canary-2": {
+						"path /tea doesn't exist in the primary Ingress default/ingress-primary",
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(canary2)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add canary-3 for the path of canary-1
+
+	canary3 := createTestIngressCanary("ingress-canary-3", "foo.example.com", "/")
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress: primary,
+				ValidHosts: map[string]bool{
+					"foo.example.com": true,
+				},
+				Canaries: []*CanaryConfiguration{
+					{
+						Ingress: canary1,
+						ValidPaths: map[string]bool{
+							"/": true,
+						},
+					},
+					{
+						Ingress:    canary2,
+						ValidPaths: map[string]bool{},
+					},
+					{
+						Ingress:    canary3,
+						ValidPaths: map[string]bool{},
+					},
+				},
+				ChildWarnings: map[string][]string{
+					"default/ingress-canary-2": {
+						"path /tea doesn't exist in the primary Ingress default/ingress-primary",
+					},
+					"default/ingress-canary-3": {
+						"path / is taken by another canary",
+					},
+				},
+			},
+		},
+	}
+	expectedProblems = nil
+
+	changes, problems = configuration.AddOrUpdateIngress(canary3)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
 func TestAddIngressWithIncorrectClass(t *testing.T) {
 	configuration := createTestConfiguration()
 
@@ -1871,6 +2018,230 @@ func TestHostCollisions(t *testing.T) {
 	}
 }
 
+func TestVirtualServerAliasCollisions(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	var expectedProblems []ConfigurationProblem
+
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	vs.Spec.Aliases = []string{"www.cafe.example.com", "*.cafe.example.com"}
+	ing := createTestIngress("ingress", "tea.cafe.example.com")
+	vs2 := createTestVirtualServer("virtualserver-2", "www.cafe.example.com")
+
+	// Add VirtualServer with aliases
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				ValidAliases:  map[string]bool{"www.cafe.example.com": true, "*.cafe.example.com": true},
+			},
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateVirtualServer(vs)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add Ingress with an exact host matched by the wildcard alias
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress:       ing,
+				ValidHosts:    map[string]bool{"tea.cafe.example.com": true},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateIngress(ing)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Add VirtualServer with the host equal to the alias of the first VirtualServer
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				ValidAliases:  map[string]bool{"www.cafe.example.com": false, "*.cafe.example.com": true},
+				Warnings:      []string{"host www.cafe.example.com is taken by another resource"},
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs2,
+			},
+		},
+	}
+
+	changes, problems = configuration.AddOrUpdateVirtualServer(vs2)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete the second VirtualServer
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs2,
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				ValidAliases:  map[string]bool{"www.cafe.example.com": true, "*.cafe.example.com": true},
+			},
+		},
+	}
+
+	changes, problems = configuration.DeleteVirtualServer("default/virtualserver-2")
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestVirtualServerAliasDoesNotTakeIngressHost(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	var expectedProblems []ConfigurationProblem
+
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	vs.Spec.Aliases = []string{"www.cafe.example.com"}
+	ing := createTestIngress("ingress", "www.cafe.example.com")
+	ing.CreationTimestamp = metav1.NewTime(vs.CreationTimestamp.Add(time.Second))
+
+	configuration.AddOrUpdateVirtualServer(vs)
+
+	// Add a newer Ingress with the host equal to the alias of the VirtualServer
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				ValidAliases:  map[string]bool{"www.cafe.example.com": false},
+				Warnings:      []string{"host www.cafe.example.com is taken by another resource"},
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &IngressConfiguration{
+				Ingress:       ing,
+				ValidHosts:    map[string]bool{"www.cafe.example.com": true},
+				ChildWarnings: map[string][]string{},
+			},
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateIngress(ing)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateIngress() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestVirtualServerAliasConflicts(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	var expectedProblems []ConfigurationProblem
+
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	vs.Spec.Aliases = []string{"www.example.com"}
+	vs2 := createTestVirtualServer("virtualserver-2", "tea.example.com")
+	vs2.Spec.Aliases = []string{"www.example.com"}
+	vs2.CreationTimestamp = metav1.NewTime(vs.CreationTimestamp.Add(time.Second))
+
+	configuration.AddOrUpdateVirtualServer(vs)
+
+	// Add a newer VirtualServer with the same alias
+
+	expectedChanges := []ResourceChange{
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs2,
+				ValidAliases:  map[string]bool{"www.example.com": false},
+				Warnings:      []string{"host www.example.com is taken by another resource"},
+			},
+		},
+	}
+
+	changes, problems := configuration.AddOrUpdateVirtualServer(vs2)
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("AddOrUpdateVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+
+	// Delete the first VirtualServer, so that the second one claims the alias
+
+	expectedChanges = []ResourceChange{
+		{
+			Op: Delete,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs,
+				ValidAliases:  map[string]bool{"www.example.com": true},
+			},
+		},
+		{
+			Op: AddOrUpdate,
+			Resource: &VirtualServerConfiguration{
+				VirtualServer: vs2,
+				ValidAliases:  map[string]bool{"www.example.com": true},
+			},
+		},
+	}
+
+	changes, problems = configuration.DeleteVirtualServer("default/virtualserver")
+	if diff := cmp.Diff(expectedChanges, changes); diff != "" {
+		t.Errorf("DeleteVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expectedProblems, problems); diff != "" {
+		t.Errorf("DeleteVirtualServer() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestVirtualServerWithTakenHostDoesNotClaimAliases(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	ing := createTestIngress("ingress", "cafe.example.com")
+	vs := createTestVirtualServer("virtualserver", "cafe.example.com")
+	vs.Spec.Aliases = []string{"www.cafe.example.com"}
+
+	configuration.AddOrUpdateIngress(ing)
+	configuration.AddOrUpdateVirtualServer(vs)
+
+	if _, exists := configuration.hosts["www.cafe.example.com"]; exists {
+		t.Errorf("the alias of a VirtualServer with a taken host was claimed")
+	}
+}
+
 func TestAddTransportServer(t *testing.T) {
 	configuration := createTestConfiguration()
 
@@ -2714,6 +3085,27 @@ func createTestIngressMinion(name string, host string, path string) *networking.
 	return ing
 }
 
+func createTestIngressWithPath(name string, host string, path string) *networking.Ingress {
+	ing := createTestIngress(name, host)
+	ing.Spec.Rules[0].IngressRuleValue = networking.IngressRuleValue{
+		HTTP: &networking.HTTPIngressRuleValue{
+			Paths: []networking.HTTPIngressPath{
+				{
+					Path: path,
+				},
+			},
+		},
+	}
+
+	return ing
+}
+
+func createTestIngressCanary(name string, host string, path string) *networking.Ingress {
+	ing := createTestIngressWithPath(name, host, path)
+	ing.Annotations["nginx.org/canary"] = "true"
+	return ing
+}
+
 func createTestIngress(name string, hosts ...string) *networking.Ingress {
 	var rules []networking.IngressRule
 
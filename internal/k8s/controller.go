@@ -19,6 +19,7 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
@@ -30,7 +31,6 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectdos"
 	"k8s.io/client-go/informers"
 
-	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	"github.com/spiffe/go-spiffe/workload"
 
@@ -44,8 +44,10 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/nginxinc/kubernetes-ingress/internal/acme"
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+	"github.com/nginxinc/kubernetes-ingress/internal/tracing"
 
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -57,6 +59,7 @@ import (
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	k8s_nginx_informers "github.com/nginxinc/kubernetes-ingress/pkg/client/informers/externalversions"
 
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
@@ -83,8 +86,9 @@ var (
 )
 
 type podEndpoint struct {
-	Address string
-	PodName string
+	Address  string
+	PodName  string
+	NodeName string
 	// MeshPodOwner is used for NGINX Service Mesh metrics
 	configs.MeshPodOwner
 }
@@ -106,7 +110,9 @@ type LoadBalancerController struct {
 	svcLister                     cache.Store
 	endpointLister                storeToEndpointLister
 	configMapLister               storeToConfigMapLister
+	errorPagesConfigMapLister     cache.Store
 	podLister                     indexerToPodLister
+	nodeLister                    cache.Store
 	secretLister                  cache.Store
 	virtualServerLister           cache.Store
 	virtualServerRouteLister      cache.Store
@@ -119,12 +125,14 @@ type LoadBalancerController struct {
 	appProtectUserSigLister       cache.Store
 	transportServerLister         cache.Store
 	policyLister                  cache.Store
+	staticResponseLister          cache.Store
 	ingressLinkLister             cache.Store
 	syncQueue                     *taskQueue
 	ctx                           context.Context
 	cancel                        context.CancelFunc
 	configurator                  *configs.Configurator
 	watchNginxConfigMaps          bool
+	nginxConfigMapKey             string
 	watchGlobalConfiguration      bool
 	watchIngressLink              bool
 	isNginxPlus                   bool
@@ -153,6 +161,12 @@ type LoadBalancerController struct {
 	isNginxReady                  bool
 	isPrometheusEnabled           bool
 	isLatencyMetricsEnabled       bool
+	isDynamicSSLReloadEnabled     bool
+	acmeConfig                    *acme.Config
+	controllerZone                string
+	acmeManager                   *acme.Manager
+	tracer                        *tracing.Tracer
+	logger                        *slog.Logger
 	configuration                 *Configuration
 	secretStore                   secrets.SecretStore
 	appProtectConfiguration       appprotect.Configuration
@@ -196,6 +210,11 @@ type NewLoadBalancerControllerInput struct {
 	IsLatencyMetricsEnabled      bool
 	IsTLSPassthroughEnabled      bool
 	SnippetsEnabled              bool
+	IsDynamicSSLReloadEnabled    bool
+	ACMEConfig                   *acme.Config
+	ControllerZone               string
+	Tracer                       *tracing.Tracer
+	Logger                       *slog.Logger
 }
 
 // NewLoadBalancerController creates a controller
@@ -225,26 +244,33 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		internalRoutesEnabled:        input.InternalRoutesEnabled,
 		isPrometheusEnabled:          input.IsPrometheusEnabled,
 		isLatencyMetricsEnabled:      input.IsLatencyMetricsEnabled,
+		isDynamicSSLReloadEnabled:    input.IsDynamicSSLReloadEnabled,
+		acmeConfig:                   input.ACMEConfig,
+		controllerZone:               input.ControllerZone,
+		tracer:                       input.Tracer,
+		logger:                       input.Logger,
 	}
 
 	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(glog.Infof)
+	eventBroadcaster.StartLogging(func(format string, args ...interface{}) {
+		nl.Infof(lbc.logger, format, args...)
+	})
 	eventBroadcaster.StartRecordingToSink(&core_v1.EventSinkImpl{
 		Interface: core_v1.New(input.KubeClient.CoreV1().RESTClient()).Events(""),
 	})
 	lbc.recorder = eventBroadcaster.NewRecorder(scheme.Scheme,
 		api_v1.EventSource{Component: "nginx-ingress-controller"})
 
-	lbc.syncQueue = newTaskQueue(lbc.sync)
+	lbc.syncQueue = newTaskQueue(lbc.sync, lbc.metricsCollector, lbc.logger)
 	if input.SpireAgentAddress != "" {
 		var err error
 		lbc.spiffeController, err = NewSpiffeController(lbc.syncSVIDRotation, input.SpireAgentAddress)
 		if err != nil {
-			glog.Fatalf("failed to create Spiffe Controller: %v", err)
+			nl.Fatalf(lbc.logger, "failed to create Spiffe Controller: %v", err)
 		}
 	}
 
-	glog.V(3).Infof("Nginx Ingress Controller has class: %v", input.IngressClass)
+	nl.Tracef(lbc.logger, "Nginx Ingress Controller has class: %v", input.IngressClass)
 
 	lbc.sharedInformerFactory = informers.NewSharedInformerFactoryWithOptions(lbc.client, input.ResyncPeriod, informers.WithNamespace(lbc.namespace))
 
@@ -254,6 +280,11 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	lbc.addServiceHandler(createServiceHandlers(lbc))
 	lbc.addEndpointHandler(createEndpointHandlers(lbc))
 	lbc.addPodHandler()
+	lbc.addErrorPagesConfigMapHandler(createErrorPagesConfigMapHandlers(lbc))
+
+	if lbc.controllerZone != "" {
+		lbc.addNodeHandler(createNodeHandlers(lbc))
+	}
 
 	if lbc.areCustomResourcesEnabled {
 		lbc.confSharedInformerFactorry = k8s_nginx_informers.NewSharedInformerFactoryWithOptions(lbc.confClient, input.ResyncPeriod, k8s_nginx_informers.WithNamespace(lbc.namespace))
@@ -262,6 +293,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		lbc.addVirtualServerRouteHandler(createVirtualServerRouteHandlers(lbc))
 		lbc.addTransportServerHandler(createTransportServerHandlers(lbc))
 		lbc.addPolicyHandler(createPolicyHandlers(lbc))
+		lbc.addStaticResponseHandler(createStaticResponseHandlers(lbc))
 
 		if input.GlobalConfiguration != "" {
 			lbc.watchGlobalConfiguration = true
@@ -289,9 +321,10 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 	if input.ConfigMaps != "" {
 		nginxConfigMapsNS, nginxConfigMapsName, err := ParseNamespaceName(input.ConfigMaps)
 		if err != nil {
-			glog.Warning(err)
+			nl.Warn(lbc.logger, err)
 		} else {
 			lbc.watchNginxConfigMaps = true
+			lbc.nginxConfigMapKey = nginxConfigMapsNS + "/" + nginxConfigMapsName
 			lbc.addConfigMapHandler(createConfigMapHandlers(lbc, nginxConfigMapsName), nginxConfigMapsNS)
 		}
 	}
@@ -317,6 +350,7 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		keyFunc:                  keyFunc,
 		confClient:               input.ConfClient,
 		hasCorrectIngressClass:   lbc.HasCorrectIngressClass,
+		logger:                   lbc.logger,
 	}
 
 	lbc.configuration = NewConfiguration(
@@ -329,7 +363,8 @@ func NewLoadBalancerController(input NewLoadBalancerControllerInput) *LoadBalanc
 		input.GlobalConfigurationValidator,
 		input.TransportServerValidator,
 		input.IsTLSPassthroughEnabled,
-		input.SnippetsEnabled)
+		input.SnippetsEnabled,
+		lbc.logger)
 
 	lbc.appProtectConfiguration = appprotect.NewConfiguration()
 	lbc.dosConfiguration = appprotectdos.NewConfiguration(input.AppProtectDosEnabled)
@@ -344,7 +379,7 @@ func (lbc *LoadBalancerController) addLeaderHandler(leaderHandler leaderelection
 	var err error
 	lbc.leaderElector, err = newLeaderElector(lbc.client, leaderHandler, lbc.controllerNamespace, lbc.leaderElectionLockName)
 	if err != nil {
-		glog.V(3).Infof("Error starting LeaderElection: %v", err)
+		nl.Tracef(lbc.logger, "Error starting LeaderElection: %v", err)
 	}
 }
 
@@ -458,6 +493,15 @@ func (lbc *LoadBalancerController) addConfigMapHandler(handlers cache.ResourceEv
 	lbc.cacheSyncs = append(lbc.cacheSyncs, lbc.configMapController.HasSynced)
 }
 
+// addErrorPagesConfigMapHandler adds the handler for config maps with custom error pages to the controller
+func (lbc *LoadBalancerController) addErrorPagesConfigMapHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := lbc.sharedInformerFactory.Core().V1().ConfigMaps().Informer()
+	informer.AddEventHandler(handlers)
+	lbc.errorPagesConfigMapLister = informer.GetStore()
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) addPodHandler() {
 	informer := lbc.sharedInformerFactory.Core().V1().Pods().Informer()
 	lbc.podLister.Indexer = informer.GetIndexer()
@@ -465,6 +509,15 @@ func (lbc *LoadBalancerController) addPodHandler() {
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
+// addNodeHandler adds the handler for nodes to the controller to find the zones of the endpoints.
+func (lbc *LoadBalancerController) addNodeHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := lbc.sharedInformerFactory.Core().V1().Nodes().Informer()
+	informer.AddEventHandler(handlers)
+	lbc.nodeLister = informer.GetStore()
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) addVirtualServerHandler(handlers cache.ResourceEventHandlerFuncs) {
 	informer := lbc.confSharedInformerFactorry.K8s().V1().VirtualServers().Informer()
 	informer.AddEventHandler(handlers)
@@ -489,6 +542,14 @@ func (lbc *LoadBalancerController) addPolicyHandler(handlers cache.ResourceEvent
 	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
 }
 
+func (lbc *LoadBalancerController) addStaticResponseHandler(handlers cache.ResourceEventHandlerFuncs) {
+	informer := lbc.confSharedInformerFactorry.K8s().V1alpha1().StaticResponses().Informer()
+	informer.AddEventHandler(handlers)
+	lbc.staticResponseLister = informer.GetStore()
+
+	lbc.cacheSyncs = append(lbc.cacheSyncs, informer.HasSynced)
+}
+
 func (lbc *LoadBalancerController) addGlobalConfigurationHandler(handlers cache.ResourceEventHandlerFuncs, namespace string, name string) {
 	lbc.globalConfigurationLister, lbc.globalConfigurationController = cache.NewInformer(
 		cache.NewListWatchFromClient(
@@ -534,12 +595,20 @@ func (lbc *LoadBalancerController) Run() {
 	if lbc.spiffeController != nil {
 		err := lbc.spiffeController.Start(lbc.ctx.Done(), lbc.addInternalRouteServer)
 		if err != nil {
-			glog.Fatal(err)
+			nl.Fatal(lbc.logger, err)
 		}
 	}
 	if lbc.leaderElector != nil {
 		go lbc.leaderElector.Run(lbc.ctx)
 	}
+	if lbc.acmeConfig != nil {
+		// only the leader issues certificates, but every replica responds to the challenges
+		lbc.acmeManager = acme.NewManager(lbc.ctx, lbc.client, *lbc.acmeConfig, lbc.reportCustomResourceStatusEnabled, lbc.updateVirtualServerCondition)
+		go lbc.acmeManager.Run()
+	}
+	if lbc.tracer != nil {
+		go lbc.tracer.Run(lbc.ctx.Done())
+	}
 
 	go lbc.sharedInformerFactory.Start(lbc.ctx.Done())
 	if lbc.watchNginxConfigMaps {
@@ -558,7 +627,7 @@ func (lbc *LoadBalancerController) Run() {
 		go lbc.dynInformerFactory.Start(lbc.ctx.Done())
 	}
 
-	glog.V(3).Infof("Waiting for %d caches to sync", len(lbc.cacheSyncs))
+	nl.Tracef(lbc.logger, "Waiting for %d caches to sync", len(lbc.cacheSyncs))
 
 	if !cache.WaitForCacheSync(lbc.ctx.Done(), lbc.cacheSyncs...) {
 		return
@@ -566,7 +635,7 @@ func (lbc *LoadBalancerController) Run() {
 
 	lbc.preSyncSecrets()
 
-	glog.V(3).Infof("Starting the queue with %d initial elements", lbc.syncQueue.Len())
+	nl.Tracef(lbc.logger, "Starting the queue with %d initial elements", lbc.syncQueue.Len())
 
 	go lbc.syncQueue.Run(time.Second, lbc.ctx.Done())
 	<-lbc.ctx.Done()
@@ -579,9 +648,10 @@ func (lbc *LoadBalancerController) Stop() {
 	lbc.syncQueue.Shutdown()
 }
 
-func (lbc *LoadBalancerController) syncEndpoints(task task) {
+func (lbc *LoadBalancerController) syncEndpoints(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing endpoints %v", key)
+	nl.Tracef(l, "Syncing endpoints %v", key)
 
 	obj, endpExists, err := lbc.endpointLister.GetByKey(key)
 	if err != nil {
@@ -599,35 +669,35 @@ func (lbc *LoadBalancerController) syncEndpoints(task task) {
 	resourceExes := lbc.createExtendedResources(resources)
 
 	if len(resourceExes.IngressExes) > 0 {
-		glog.V(3).Infof("Updating Endpoints for %v", resourceExes.IngressExes)
+		nl.Tracef(l, "Updating Endpoints for %v", resourceExes.IngressExes)
 		err = lbc.configurator.UpdateEndpoints(resourceExes.IngressExes)
 		if err != nil {
-			glog.Errorf("Error updating endpoints for %v: %v", resourceExes.IngressExes, err)
+			nl.Errorf(l, "Error updating endpoints for %v: %v", resourceExes.IngressExes, err)
 		}
 	}
 
 	if len(resourceExes.MergeableIngresses) > 0 {
-		glog.V(3).Infof("Updating Endpoints for %v", resourceExes.MergeableIngresses)
+		nl.Tracef(l, "Updating Endpoints for %v", resourceExes.MergeableIngresses)
 		err = lbc.configurator.UpdateEndpointsMergeableIngress(resourceExes.MergeableIngresses)
 		if err != nil {
-			glog.Errorf("Error updating endpoints for %v: %v", resourceExes.MergeableIngresses, err)
+			nl.Errorf(l, "Error updating endpoints for %v: %v", resourceExes.MergeableIngresses, err)
 		}
 	}
 
 	if lbc.areCustomResourcesEnabled {
 		if len(resourceExes.VirtualServerExes) > 0 {
-			glog.V(3).Infof("Updating endpoints for %v", resourceExes.VirtualServerExes)
+			nl.Tracef(l, "Updating endpoints for %v", resourceExes.VirtualServerExes)
 			err := lbc.configurator.UpdateEndpointsForVirtualServers(resourceExes.VirtualServerExes)
 			if err != nil {
-				glog.Errorf("Error updating endpoints for %v: %v", resourceExes.VirtualServerExes, err)
+				nl.Errorf(l, "Error updating endpoints for %v: %v", resourceExes.VirtualServerExes, err)
 			}
 		}
 
 		if len(resourceExes.TransportServerExes) > 0 {
-			glog.V(3).Infof("Updating endpoints for %v", resourceExes.TransportServerExes)
+			nl.Tracef(l, "Updating endpoints for %v", resourceExes.TransportServerExes)
 			err := lbc.configurator.UpdateEndpointsForTransportServers(resourceExes.TransportServerExes)
 			if err != nil {
-				glog.Errorf("Error updating endpoints for %v: %v", resourceExes.TransportServerExes, err)
+				nl.Errorf(l, "Error updating endpoints for %v: %v", resourceExes.TransportServerExes, err)
 			}
 		}
 	}
@@ -640,7 +710,7 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 		switch impl := r.(type) {
 		case *VirtualServerConfiguration:
 			vs := impl.VirtualServer
-			vsEx := lbc.createVirtualServerEx(vs, impl.VirtualServerRoutes)
+			vsEx := lbc.createVirtualServerEx(vs, impl.VirtualServerRoutes, impl.ValidAliases)
 			result.VirtualServerExes = append(result.VirtualServerExes, vsEx)
 		case *IngressConfiguration:
 
@@ -648,7 +718,7 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 				mergeableIng := lbc.createMergeableIngresses(impl)
 				result.MergeableIngresses = append(result.MergeableIngresses, mergeableIng)
 			} else {
-				ingEx := lbc.createIngressEx(impl.Ingress, impl.ValidHosts, nil)
+				ingEx := lbc.createRegularIngressEx(impl)
 				result.IngressExes = append(result.IngressExes, ingEx)
 			}
 		case *TransportServerConfiguration:
@@ -660,9 +730,10 @@ func (lbc *LoadBalancerController) createExtendedResources(resources []Resource)
 	return result
 }
 
-func (lbc *LoadBalancerController) syncConfigMap(task task) {
+func (lbc *LoadBalancerController) syncConfigMap(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing configmap %v", key)
+	nl.Tracef(l, "Syncing configmap %v", key)
 
 	obj, configExists, err := lbc.configMapLister.GetByKey(key)
 	if err != nil {
@@ -677,7 +748,7 @@ func (lbc *LoadBalancerController) syncConfigMap(task task) {
 	}
 
 	if !lbc.isNginxReady {
-		glog.V(3).Infof("Skipping ConfigMap update because the pod is not ready yet")
+		nl.Tracef(l, "Skipping ConfigMap update because the pod is not ready yet")
 		return
 	}
 
@@ -693,7 +764,7 @@ func (lbc *LoadBalancerController) updateAllConfigs() {
 
 	resources := lbc.configuration.GetResources()
 
-	glog.V(3).Infof("Updating %v resources", len(resources))
+	nl.Tracef(lbc.logger, "Updating %v resources", len(resources))
 
 	resourceExes := lbc.createExtendedResources(resources)
 
@@ -735,69 +806,84 @@ func (lbc *LoadBalancerController) updateAllConfigs() {
 // it will report warnings. (See https://github.com/nginxinc/kubernetes-ingress/issues/1448 )
 func (lbc *LoadBalancerController) preSyncSecrets() {
 	objects := lbc.secretLister.List()
-	glog.V(3).Infof("PreSync %d Secrets", len(objects))
+	nl.Tracef(lbc.logger, "PreSync %d Secrets", len(objects))
 
 	for _, obj := range objects {
 		secret := obj.(*api_v1.Secret)
 
 		if !secrets.IsSupportedSecretType(secret.Type) {
-			glog.V(3).Infof("Ignoring Secret %s/%s of unsupported type %s", secret.Namespace, secret.Name, secret.Type)
+			nl.Tracef(lbc.logger, "Ignoring Secret %s/%s of unsupported type %s", secret.Namespace, secret.Name, secret.Type)
 			continue
 		}
 
-		glog.V(3).Infof("Adding Secret: %s/%s", secret.Namespace, secret.Name)
+		nl.Tracef(lbc.logger, "Adding Secret: %s/%s", secret.Namespace, secret.Name)
 		lbc.secretStore.AddOrUpdateSecret(secret)
 	}
 }
 
 func (lbc *LoadBalancerController) sync(task task) {
-	glog.V(3).Infof("Syncing %v", task.Key)
-	if lbc.spiffeController != nil {
-		lbc.syncLock.Lock()
-		defer lbc.syncLock.Unlock()
-	}
+	l := lbc.loggerForTask(task)
+	nl.Tracef(l, "Syncing %v", task.Key)
+	// syncLock guards the configurator and the secret store, which are also used by the SPIFFE certificate rotation
+	// and the debug endpoint
+	lbc.syncLock.Lock()
+	defer lbc.syncLock.Unlock()
+
+	ctx, span := lbc.tracer.Start(context.Background(), "sync",
+		tracing.String("resource.kind", task.Kind.String()), tracing.String("resource.key", task.Key))
+	defer span.End()
+	ctx = nl.ContextWithLogger(ctx, l)
+	lbc.configurator.SetSyncContext(ctx, task.Kind.String())
+	defer lbc.configurator.SetSyncContext(context.Background(), "")
+
 	switch task.Kind {
 	case ingress:
-		lbc.syncIngress(task)
+		lbc.syncIngress(ctx, task)
 		lbc.updateIngressMetrics()
 		lbc.updateTransportServerMetrics()
 	case configMap:
-		lbc.syncConfigMap(task)
+		if task.Key == lbc.nginxConfigMapKey {
+			lbc.syncConfigMap(ctx, task)
+		} else {
+			lbc.syncErrorPagesConfigMap(ctx, task)
+		}
 	case endpoints:
-		lbc.syncEndpoints(task)
+		lbc.syncEndpoints(ctx, task)
 	case secret:
-		lbc.syncSecret(task)
+		lbc.syncSecret(ctx, task)
 	case service:
-		lbc.syncService(task)
+		lbc.syncService(ctx, task)
 	case virtualserver:
-		lbc.syncVirtualServer(task)
+		lbc.syncVirtualServer(ctx, task)
 		lbc.updateVirtualServerMetrics()
 		lbc.updateTransportServerMetrics()
 	case virtualServerRoute:
-		lbc.syncVirtualServerRoute(task)
+		lbc.syncVirtualServerRoute(ctx, task)
 		lbc.updateVirtualServerMetrics()
 	case globalConfiguration:
-		lbc.syncGlobalConfiguration(task)
+		lbc.syncGlobalConfiguration(ctx, task)
 		lbc.updateTransportServerMetrics()
 	case transportserver:
-		lbc.syncTransportServer(task)
+		lbc.syncTransportServer(ctx, task)
 		lbc.updateTransportServerMetrics()
 	case policy:
-		lbc.syncPolicy(task)
+		lbc.syncPolicy(ctx, task)
+	case staticResponse:
+		lbc.syncStaticResponse(ctx, task)
 	case appProtectPolicy:
-		lbc.syncAppProtectPolicy(task)
+		lbc.syncAppProtectPolicy(ctx, task)
 	case appProtectLogConf:
-		lbc.syncAppProtectLogConf(task)
+		lbc.syncAppProtectLogConf(ctx, task)
 	case appProtectUserSig:
-		lbc.syncAppProtectUserSig(task)
+		lbc.syncAppProtectUserSig(ctx, task)
 	case appProtectDosPolicy:
-		lbc.syncAppProtectDosPolicy(task)
+		lbc.syncAppProtectDosPolicy(ctx, task)
 	case appProtectDosLogConf:
-		lbc.syncAppProtectDosLogConf(task)
+		lbc.syncAppProtectDosLogConf(ctx, task)
 	case appProtectDosProtectedResource:
-		lbc.syncDosProtectedResource(task)
+		lbc.syncDosProtectedResource(ctx, task)
 	case ingressLink:
-		lbc.syncIngressLink(task)
+		lbc.syncIngressLink(ctx, task)
 	}
 
 	if !lbc.isNginxReady && lbc.syncQueue.Len() == 0 {
@@ -805,13 +891,25 @@ func (lbc *LoadBalancerController) sync(task task) {
 		lbc.updateAllConfigs()
 
 		lbc.isNginxReady = true
-		glog.V(3).Infof("NGINX is ready")
+		nl.Tracef(lbc.logger, "NGINX is ready")
 	}
 }
 
-func (lbc *LoadBalancerController) syncIngressLink(task task) {
+// loggerForTask returns a logger that adds the task key and the kind, namespace and name of the resource of the task
+// to the messages.
+func (lbc *LoadBalancerController) loggerForTask(task task) *slog.Logger {
+	namespace, name, err := cache.SplitMetaNamespaceKey(task.Key)
+	if err != nil {
+		namespace, name = "", task.Key
+	}
+
+	return nl.WithResource(lbc.logger, task.Kind.String(), namespace, name).With(nl.TaskKeyKey, task.Key)
+}
+
+func (lbc *LoadBalancerController) syncIngressLink(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(2).Infof("Adding, Updating or Deleting IngressLink: %v", key)
+	nl.Debugf(l, "Adding, Updating or Deleting IngressLink: %v", key)
 
 	obj, exists, err := lbc.ingressLinkLister.GetByKey(key)
 	if err != nil {
@@ -829,13 +927,13 @@ func (lbc *LoadBalancerController) syncIngressLink(task task) {
 		// spec.virtualServerAddress contains the IP of the BIG-IP device
 		ip, found, err := unstructured.NestedString(link.Object, "spec", "virtualServerAddress")
 		if err != nil {
-			glog.Errorf("Failed to get virtualServerAddress from IngressLink %s: %v", key, err)
+			nl.Errorf(l, "Failed to get virtualServerAddress from IngressLink %s: %v", key, err)
 			lbc.statusUpdater.ClearStatusFromIngressLink()
 		} else if !found {
-			glog.Errorf("virtualServerAddress is not found in IngressLink %s", key)
+			nl.Errorf(l, "virtualServerAddress is not found in IngressLink %s", key)
 			lbc.statusUpdater.ClearStatusFromIngressLink()
 		} else if ip == "" {
-			glog.Warningf("IngressLink %s has the empty virtualServerAddress field", key)
+			nl.Warnf(l, "IngressLink %s has the empty virtualServerAddress field", key)
 			lbc.statusUpdater.ClearStatusFromIngressLink()
 		} else {
 			lbc.statusUpdater.SaveStatusFromIngressLink(ip)
@@ -845,27 +943,28 @@ func (lbc *LoadBalancerController) syncIngressLink(task task) {
 	if lbc.reportStatusEnabled() {
 		ingresses := lbc.configuration.GetResourcesWithFilter(resourceFilter{Ingresses: true})
 
-		glog.V(3).Infof("Updating status for %v Ingresses", len(ingresses))
+		nl.Tracef(l, "Updating status for %v Ingresses", len(ingresses))
 
 		err := lbc.statusUpdater.UpdateExternalEndpointsForResources(ingresses)
 		if err != nil {
-			glog.Errorf("Error updating ingress status in syncIngressLink: %v", err)
+			nl.Errorf(l, "Error updating ingress status in syncIngressLink: %v", err)
 		}
 	}
 
 	if lbc.areCustomResourcesEnabled && lbc.reportCustomResourceStatusEnabled() {
 		virtualServers := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true})
 
-		glog.V(3).Infof("Updating status for %v VirtualServers", len(virtualServers))
+		nl.Tracef(l, "Updating status for %v VirtualServers", len(virtualServers))
 
 		err := lbc.statusUpdater.UpdateExternalEndpointsForResources(virtualServers)
 		if err != nil {
-			glog.V(3).Infof("Error updating VirtualServer/VirtualServerRoute status in syncIngressLink: %v", err)
+			nl.Tracef(l, "Error updating VirtualServer/VirtualServerRoute status in syncIngressLink: %v", err)
 		}
 	}
 }
 
-func (lbc *LoadBalancerController) syncPolicy(task task) {
+func (lbc *LoadBalancerController) syncPolicy(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, polExists, err := lbc.policyLister.GetByKey(key)
 	if err != nil {
@@ -873,7 +972,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 		return
 	}
 
-	glog.V(2).Infof("Adding, Updating or Deleting Policy: %v\n", key)
+	nl.Debugf(l, "Adding, Updating or Deleting Policy: %v\n", key)
 
 	if polExists && lbc.HasCorrectIngressClass(obj) {
 		pol := obj.(*conf_v1.Policy)
@@ -885,7 +984,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 			if lbc.reportCustomResourceStatusEnabled() {
 				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateInvalid, "Rejected", msg)
 				if err != nil {
-					glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
+					nl.Tracef(l, "Failed to update policy %s status: %v", key, err)
 				}
 			}
 		} else {
@@ -895,7 +994,7 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 			if lbc.reportCustomResourceStatusEnabled() {
 				err = lbc.statusUpdater.UpdatePolicyStatus(pol, conf_v1.StateValid, "AddedOrUpdated", msg)
 				if err != nil {
-					glog.V(3).Infof("Failed to update policy %s status: %v", key, err)
+					nl.Tracef(l, "Failed to update policy %s status: %v", key, err)
 				}
 			}
 		}
@@ -918,7 +1017,52 @@ func (lbc *LoadBalancerController) syncPolicy(task task) {
 	// Note: updating the status of a policy based on a reload is not needed.
 }
 
-func (lbc *LoadBalancerController) syncTransportServer(task task) {
+func (lbc *LoadBalancerController) syncStaticResponse(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
+	key := task.Key
+	obj, srExists, err := lbc.staticResponseLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	// it is safe to ignore the error
+	namespace, name, _ := ParseNamespaceName(key)
+
+	// StaticResponses don't have an ingress class, so only the controller that handles
+	// the Ingress resources referencing a StaticResponse reports events for it.
+	resources := lbc.configuration.FindResourcesForStaticResponse(namespace, name)
+	if len(resources) == 0 {
+		return
+	}
+
+	nl.Debugf(l, "Found %v Resources with StaticResponse %v", len(resources), key)
+
+	if srExists {
+		sr := obj.(*conf_v1alpha1.StaticResponse)
+		err := validation.ValidateStaticResponse(sr, lbc.isNginxPlus)
+		if err != nil {
+			msg := fmt.Sprintf("StaticResponse %v/%v is invalid and was rejected: %v", sr.Namespace, sr.Name, err)
+			lbc.recorder.Eventf(sr, api_v1.EventTypeWarning, "Rejected", msg)
+		} else {
+			msg := fmt.Sprintf("StaticResponse %v/%v was added or updated", sr.Namespace, sr.Name)
+			lbc.recorder.Eventf(sr, api_v1.EventTypeNormal, "AddedOrUpdated", msg)
+		}
+	} else {
+		nl.Debugf(l, "Deleting StaticResponse: %v", key)
+	}
+
+	resourceExes := lbc.createExtendedResources(resources)
+	warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateResources(resourceExes)
+	if addOrUpdateErr != nil {
+		nl.Errorf(l, "Error when updating StaticResponse %v: %v", key, addOrUpdateErr)
+	}
+
+	lbc.updateResourcesStatusAndEvents(resources, warnings, addOrUpdateErr)
+}
+
+func (lbc *LoadBalancerController) syncTransportServer(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, tsExists, err := lbc.transportServerLister.GetByKey(key)
 	if err != nil {
@@ -930,19 +1074,27 @@ func (lbc *LoadBalancerController) syncTransportServer(task task) {
 	var problems []ConfigurationProblem
 
 	if !tsExists {
-		glog.V(2).Infof("Deleting TransportServer: %v\n", key)
+		nl.Debugf(l, "Deleting TransportServer: %v\n", key)
+		_, span := tracing.StartSpan(ctx, "Configuration.DeleteTransportServer")
 		changes, problems = lbc.configuration.DeleteTransportServer(key)
+		endConfigurationSpan(span, changes, problems)
 	} else {
-		glog.V(2).Infof("Adding or Updating TransportServer: %v\n", key)
+		nl.Debugf(l, "Adding or Updating TransportServer: %v\n", key)
 		ts := obj.(*conf_v1alpha1.TransportServer)
+		_, span := tracing.StartSpan(ctx, "Configuration.AddOrUpdateTransportServer")
 		changes, problems = lbc.configuration.AddOrUpdateTransportServer(ts)
+		endConfigurationSpan(span, changes, problems)
 	}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.Int("resources.affected", len(changes)))
+	lbc.metricsCollector.ObserveResourcesAffected(task.Kind.String(), len(changes))
+
 	lbc.processChanges(changes)
 	lbc.processProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
+func (lbc *LoadBalancerController) syncGlobalConfiguration(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, gcExists, err := lbc.globalConfigurationLister.GetByKey(key)
 	if err != nil {
@@ -955,11 +1107,11 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 	var validationErr error
 
 	if !gcExists {
-		glog.V(2).Infof("Deleting GlobalConfiguration: %v\n", key)
+		nl.Debugf(l, "Deleting GlobalConfiguration: %v\n", key)
 
 		changes, problems = lbc.configuration.DeleteGlobalConfiguration()
 	} else {
-		glog.V(2).Infof("Adding or Updating GlobalConfiguration: %v\n", key)
+		nl.Debugf(l, "Adding or Updating GlobalConfiguration: %v\n", key)
 
 		gc := obj.(*conf_v1alpha1.GlobalConfiguration)
 		changes, problems, validationErr = lbc.configuration.AddOrUpdateGlobalConfiguration(gc)
@@ -991,7 +1143,8 @@ func (lbc *LoadBalancerController) syncGlobalConfiguration(task task) {
 	lbc.processProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncVirtualServer(task task) {
+func (lbc *LoadBalancerController) syncVirtualServer(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, vsExists, err := lbc.virtualServerLister.GetByKey(key)
 	if err != nil {
@@ -1003,22 +1156,35 @@ func (lbc *LoadBalancerController) syncVirtualServer(task task) {
 	var problems []ConfigurationProblem
 
 	if !vsExists {
-		glog.V(2).Infof("Deleting VirtualServer: %v\n", key)
+		nl.Debugf(l, "Deleting VirtualServer: %v\n", key)
 
+		_, span := tracing.StartSpan(ctx, "Configuration.DeleteVirtualServer")
 		changes, problems = lbc.configuration.DeleteVirtualServer(key)
+		endConfigurationSpan(span, changes, problems)
 	} else {
-		glog.V(2).Infof("Adding or Updating VirtualServer: %v\n", key)
-
 		vs := obj.(*conf_v1.VirtualServer)
+		nl.Debugf(l.With(nl.HostKey, vs.Spec.Host), "Adding or Updating VirtualServer: %v\n", key)
+
+		_, span := tracing.StartSpan(ctx, "Configuration.AddOrUpdateVirtualServer")
 		changes, problems = lbc.configuration.AddOrUpdateVirtualServer(vs)
+		endConfigurationSpan(span, changes, problems)
 	}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.Int("resources.affected", len(changes)))
+	lbc.metricsCollector.ObserveResourcesAffected(task.Kind.String(), len(changes))
+
 	lbc.processChanges(changes)
 	lbc.processProblems(problems)
 }
 
+// endConfigurationSpan ends the span of an update of the Configuration.
+func endConfigurationSpan(span *tracing.Span, changes []ResourceChange, problems []ConfigurationProblem) {
+	span.SetAttributes(tracing.Int("changes", len(changes)), tracing.Int("problems", len(problems)))
+	span.End()
+}
+
 func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProblem) {
-	glog.V(3).Infof("Processing %v problems", len(problems))
+	nl.Tracef(lbc.logger, "Processing %v problems", len(problems))
 
 	for _, p := range problems {
 		eventType := api_v1.EventTypeWarning
@@ -1034,23 +1200,23 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 			case *networking.Ingress:
 				err := lbc.statusUpdater.ClearIngressStatus(*obj)
 				if err != nil {
-					glog.V(3).Infof("Error when updating the status for Ingress %v/%v: %v", obj.Namespace, obj.Name, err)
+					nl.Tracef(lbc.logger, "Error when updating the status for Ingress %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
 			case *conf_v1.VirtualServer:
 				err := lbc.statusUpdater.UpdateVirtualServerStatus(obj, state, p.Reason, p.Message)
 				if err != nil {
-					glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", obj.Namespace, obj.Name, err)
+					nl.Errorf(lbc.logger, "Error when updating the status for VirtualServer %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
 			case *conf_v1alpha1.TransportServer:
 				err := lbc.statusUpdater.UpdateTransportServerStatus(obj, state, p.Reason, p.Message)
 				if err != nil {
-					glog.Errorf("Error when updating the status for TransportServer %v/%v: %v", obj.Namespace, obj.Name, err)
+					nl.Errorf(lbc.logger, "Error when updating the status for TransportServer %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
 			case *conf_v1.VirtualServerRoute:
 				var emptyVSes []*conf_v1.VirtualServer
 				err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(obj, state, p.Reason, p.Message, emptyVSes)
 				if err != nil {
-					glog.Errorf("Error when updating the status for VirtualServerRoute %v/%v: %v", obj.Namespace, obj.Name, err)
+					nl.Errorf(lbc.logger, "Error when updating the status for VirtualServerRoute %v/%v: %v", obj.Namespace, obj.Name, err)
 				}
 			}
 		}
@@ -1058,16 +1224,20 @@ func (lbc *LoadBalancerController) processProblems(problems []ConfigurationProbl
 }
 
 func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
-	glog.V(3).Infof("Processing %v changes", len(changes))
+	nl.Tracef(lbc.logger, "Processing %v changes", len(changes))
 
 	for _, c := range changes {
 		if c.Op == AddOrUpdate {
 			switch impl := c.Resource.(type) {
 			case *VirtualServerConfiguration:
-				vsEx := lbc.createVirtualServerEx(impl.VirtualServer, impl.VirtualServerRoutes)
+				vsEx := lbc.createVirtualServerEx(impl.VirtualServer, impl.VirtualServerRoutes, impl.ValidAliases)
 
 				warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateVirtualServer(vsEx)
 				lbc.updateVirtualServerStatusAndEvents(impl, warnings, addOrUpdateErr)
+
+				if lbc.acmeManager != nil && addOrUpdateErr == nil {
+					lbc.acmeManager.AddOrUpdateVirtualServer(impl.VirtualServer, impl.ValidAliases)
+				}
 			case *IngressConfiguration:
 				if impl.IsMaster {
 					mergeableIng := lbc.createMergeableIngresses(impl)
@@ -1075,8 +1245,7 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 					warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateMergeableIngress(mergeableIng)
 					lbc.updateMergeableIngressStatusAndEvents(impl, warnings, addOrUpdateErr)
 				} else {
-					// for regular Ingress, validMinionPaths is nil
-					ingEx := lbc.createIngressEx(impl.Ingress, impl.ValidHosts, nil)
+					ingEx := lbc.createRegularIngressEx(impl)
 
 					warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateIngress(ingEx)
 					lbc.updateRegularIngressStatusAndEvents(impl, warnings, addOrUpdateErr)
@@ -1094,12 +1263,16 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 
 				deleteErr := lbc.configurator.DeleteVirtualServer(key)
 				if deleteErr != nil {
-					glog.Errorf("Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
+					nl.Errorf(lbc.logger, "Error when deleting configuration for VirtualServer %v: %v", key, deleteErr)
+				}
+
+				if lbc.acmeManager != nil {
+					lbc.acmeManager.DeleteVirtualServer(key)
 				}
 
 				_, vsExists, err := lbc.virtualServerLister.GetByKey(key)
 				if err != nil {
-					glog.Errorf("Error when getting VirtualServer for %v: %v", key, err)
+					nl.Errorf(lbc.logger, "Error when getting VirtualServer for %v: %v", key, err)
 				}
 
 				if vsExists {
@@ -1108,16 +1281,16 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 			case *IngressConfiguration:
 				key := getResourceKey(&impl.Ingress.ObjectMeta)
 
-				glog.V(2).Infof("Deleting Ingress: %v\n", key)
+				nl.Debugf(lbc.logger, "Deleting Ingress: %v\n", key)
 
 				deleteErr := lbc.configurator.DeleteIngress(key)
 				if deleteErr != nil {
-					glog.Errorf("Error when deleting configuration for Ingress %v: %v", key, deleteErr)
+					nl.Errorf(lbc.logger, "Error when deleting configuration for Ingress %v: %v", key, deleteErr)
 				}
 
 				_, ingExists, err := lbc.ingressLister.GetByKeySafe(key)
 				if err != nil {
-					glog.Errorf("Error when getting Ingress for %v: %v", key, err)
+					nl.Errorf(lbc.logger, "Error when getting Ingress for %v: %v", key, err)
 				}
 
 				if ingExists {
@@ -1129,12 +1302,12 @@ func (lbc *LoadBalancerController) processChanges(changes []ResourceChange) {
 				deleteErr := lbc.configurator.DeleteTransportServer(key)
 
 				if deleteErr != nil {
-					glog.Errorf("Error when deleting configuration for TransportServer %v: %v", key, deleteErr)
+					nl.Errorf(lbc.logger, "Error when deleting configuration for TransportServer %v: %v", key, deleteErr)
 				}
 
 				_, tsExists, err := lbc.transportServerLister.GetByKey(key)
 				if err != nil {
-					glog.Errorf("Error when getting TransportServer for %v: %v", key, err)
+					nl.Errorf(lbc.logger, "Error when getting TransportServer for %v: %v", key, err)
 				}
 				if tsExists {
 					lbc.updateTransportServerStatusAndEventsOnDelete(impl, c.Error, deleteErr)
@@ -1175,7 +1348,7 @@ func (lbc *LoadBalancerController) processChangesFromGlobalConfiguration(changes
 }
 
 func (lbc *LoadBalancerController) processAppProtectChanges(changes []appprotect.Change) {
-	glog.V(3).Infof("Processing %v App Protect changes", len(changes))
+	nl.Tracef(lbc.logger, "Processing %v App Protect changes", len(changes))
 
 	for _, c := range changes {
 		if c.Op == appprotect.AddOrUpdate {
@@ -1285,13 +1458,13 @@ func (lbc *LoadBalancerController) processAppProtectUserSigChange(change appprot
 
 	warnings, err := lbc.configurator.RefreshAppProtectUserSigs(change.UserSigs, delPols, allIngExes, allMergeableIngresses, allVsExes)
 	if err != nil {
-		glog.Errorf("Error when refreshing App Protect Policy User defined signatures: %v", err)
+		nl.Errorf(lbc.logger, "Error when refreshing App Protect Policy User defined signatures: %v", err)
 	}
 	lbc.updateResourcesStatusAndEvents(allResources, warnings, err)
 }
 
 func (lbc *LoadBalancerController) processAppProtectProblems(problems []appprotect.Problem) {
-	glog.V(3).Infof("Processing %v App Protect problems", len(problems))
+	nl.Tracef(lbc.logger, "Processing %v App Protect problems", len(problems))
 
 	for _, p := range problems {
 		eventType := api_v1.EventTypeWarning
@@ -1300,13 +1473,13 @@ func (lbc *LoadBalancerController) processAppProtectProblems(problems []appprote
 }
 
 func (lbc *LoadBalancerController) processAppProtectDosChanges(changes []appprotectdos.Change) {
-	glog.V(3).Infof("Processing %v App Protect Dos changes", len(changes))
+	nl.Tracef(lbc.logger, "Processing %v App Protect Dos changes", len(changes))
 
 	for _, c := range changes {
 		if c.Op == appprotectdos.AddOrUpdate {
 			switch impl := c.Resource.(type) {
 			case *appprotectdos.DosProtectedResourceEx:
-				glog.V(3).Infof("handling change UPDATE OR ADD for DOS protected %s/%s", impl.Obj.Namespace, impl.Obj.Name)
+				nl.Tracef(lbc.logger, "handling change UPDATE OR ADD for DOS protected %s/%s", impl.Obj.Namespace, impl.Obj.Name)
 				resources := lbc.configuration.FindResourcesForAppProtectDosProtected(impl.Obj.Namespace, impl.Obj.Name)
 				resourceExes := lbc.createExtendedResources(resources)
 				warnings, err := lbc.configurator.AddOrUpdateResourcesThatUseDosProtected(resourceExes.IngressExes, resourceExes.MergeableIngresses, resourceExes.VirtualServerExes)
@@ -1323,7 +1496,7 @@ func (lbc *LoadBalancerController) processAppProtectDosChanges(changes []appprot
 				lbc.configurator.DeleteAppProtectDosLogConf(impl.Obj)
 
 			case *appprotectdos.DosProtectedResourceEx:
-				glog.V(3).Infof("handling change DELETE for DOS protected %s/%s", impl.Obj.Namespace, impl.Obj.Name)
+				nl.Tracef(lbc.logger, "handling change DELETE for DOS protected %s/%s", impl.Obj.Namespace, impl.Obj.Name)
 				resources := lbc.configuration.FindResourcesForAppProtectDosProtected(impl.Obj.Namespace, impl.Obj.Name)
 				resourceExes := lbc.createExtendedResources(resources)
 				warnings, err := lbc.configurator.AddOrUpdateResourcesThatUseDosProtected(resourceExes.IngressExes, resourceExes.MergeableIngresses, resourceExes.VirtualServerExes)
@@ -1334,7 +1507,7 @@ func (lbc *LoadBalancerController) processAppProtectDosChanges(changes []appprot
 }
 
 func (lbc *LoadBalancerController) processAppProtectDosProblems(problems []appprotectdos.Problem) {
-	glog.V(3).Infof("Processing %v App Protect Dos problems", len(problems))
+	nl.Tracef(lbc.logger, "Processing %v App Protect Dos problems", len(problems))
 
 	for _, p := range problems {
 		eventType := api_v1.EventTypeWarning
@@ -1375,7 +1548,7 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEventsOnDelete(
 		if lbc.reportCustomResourceStatusEnabled() {
 			err := lbc.statusUpdater.UpdateTransportServerStatus(tsConfig.TransportServer, state, eventTitle, msg)
 			if err != nil {
-				glog.Errorf("Error when updating the status for TransportServer %v/%v: %v", tsConfig.TransportServer.Namespace, tsConfig.TransportServer.Name, err)
+				nl.Errorf(lbc.logger, "Error when updating the status for TransportServer %v/%v: %v", tsConfig.TransportServer.Namespace, tsConfig.TransportServer.Name, err)
 			}
 		}
 	}
@@ -1414,7 +1587,7 @@ func (lbc *LoadBalancerController) UpdateVirtualServerStatusAndEventsOnDelete(vs
 		if lbc.reportCustomResourceStatusEnabled() {
 			err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
 			if err != nil {
-				glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
+				nl.Errorf(lbc.logger, "Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
 			}
 		}
 	}
@@ -1448,7 +1621,7 @@ func (lbc *LoadBalancerController) UpdateIngressStatusAndEventsOnDelete(ingConfi
 		if lbc.reportStatusEnabled() {
 			err := lbc.statusUpdater.ClearIngressStatus(*ingConfig.Ingress)
 			if err != nil {
-				glog.V(3).Infof("Error clearing Ingress status: %v", err)
+				nl.Tracef(lbc.logger, "Error clearing Ingress status: %v", err)
 			}
 		}
 	}
@@ -1501,31 +1674,7 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 	lbc.recorder.Eventf(ingConfig.Ingress, eventType, eventTitle, msg)
 
 	for _, fm := range ingConfig.Minions {
-		minionEventType := api_v1.EventTypeNormal
-		minionEventTitle := "AddedOrUpdated"
-		minionEventWarningMessage := ""
-
-		minionChangeWarnings := ingConfig.ChildWarnings[getResourceKey(&fm.Ingress.ObjectMeta)]
-		if len(minionChangeWarnings) > 0 {
-			minionEventType = api_v1.EventTypeWarning
-			minionEventTitle = "AddedOrUpdatedWithWarning"
-			minionEventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(minionChangeWarnings))
-		}
-
-		if messages, ok := warnings[fm.Ingress]; ok {
-			minionEventType = api_v1.EventTypeWarning
-			minionEventTitle = "AddedOrUpdatedWithWarning"
-			minionEventWarningMessage = fmt.Sprintf("%s; with warning(s): %v", minionEventWarningMessage, formatWarningMessages(messages))
-		}
-
-		if operationErr != nil {
-			minionEventType = api_v1.EventTypeWarning
-			minionEventTitle = "AddedOrUpdatedWithError"
-			minionEventWarningMessage = fmt.Sprintf("%s; but was not applied: %v", minionEventWarningMessage, operationErr)
-		}
-
-		minionMsg := fmt.Sprintf("Configuration for %v/%v was added or updated %s", fm.Ingress.Namespace, fm.Ingress.Name, minionEventWarningMessage)
-		lbc.recorder.Eventf(fm.Ingress, minionEventType, minionEventTitle, minionMsg)
+		lbc.updateChildIngressEvents(ingConfig, fm.Ingress, warnings, operationErr)
 	}
 
 	if lbc.reportStatusEnabled() {
@@ -1537,11 +1686,40 @@ func (lbc *LoadBalancerController) updateMergeableIngressStatusAndEvents(ingConf
 
 		err := lbc.statusUpdater.BulkUpdateIngressStatus(ings)
 		if err != nil {
-			glog.V(3).Infof("error updating ing status: %v", err)
+			nl.Tracef(lbc.logger, "error updating ing status: %v", err)
 		}
 	}
 }
 
+// updateChildIngressEvents records the events for a minion or a canary Ingress.
+func (lbc *LoadBalancerController) updateChildIngressEvents(ingConfig *IngressConfiguration, ing *networking.Ingress, warnings configs.Warnings, operationErr error) {
+	eventType := api_v1.EventTypeNormal
+	eventTitle := "AddedOrUpdated"
+	eventWarningMessage := ""
+
+	changeWarnings := ingConfig.ChildWarnings[getResourceKey(&ing.ObjectMeta)]
+	if len(changeWarnings) > 0 {
+		eventType = api_v1.EventTypeWarning
+		eventTitle = "AddedOrUpdatedWithWarning"
+		eventWarningMessage = fmt.Sprintf("with warning(s): %s", formatWarningMessages(changeWarnings))
+	}
+
+	if messages, ok := warnings[ing]; ok {
+		eventType = api_v1.EventTypeWarning
+		eventTitle = "AddedOrUpdatedWithWarning"
+		eventWarningMessage = fmt.Sprintf("%s; with warning(s): %v", eventWarningMessage, formatWarningMessages(messages))
+	}
+
+	if operationErr != nil {
+		eventType = api_v1.EventTypeWarning
+		eventTitle = "AddedOrUpdatedWithError"
+		eventWarningMessage = fmt.Sprintf("%s; but was not applied: %v", eventWarningMessage, operationErr)
+	}
+
+	msg := fmt.Sprintf("Configuration for %v/%v was added or updated %s", ing.Namespace, ing.Name, eventWarningMessage)
+	lbc.recorder.Eventf(ing, eventType, eventTitle, msg)
+}
+
 func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig *IngressConfiguration, warnings configs.Warnings, operationErr error) {
 	eventType := api_v1.EventTypeNormal
 	eventTitle := "AddedOrUpdated"
@@ -1568,10 +1746,27 @@ func (lbc *LoadBalancerController) updateRegularIngressStatusAndEvents(ingConfig
 	msg := fmt.Sprintf("Configuration for %v was added or updated %s", getResourceKey(&ingConfig.Ingress.ObjectMeta), eventWarningMessage)
 	lbc.recorder.Eventf(ingConfig.Ingress, eventType, eventTitle, msg)
 
+	for _, c := range ingConfig.Canaries {
+		lbc.updateChildIngressEvents(ingConfig, c.Ingress, warnings, operationErr)
+	}
+
 	if lbc.reportStatusEnabled() {
-		err := lbc.statusUpdater.UpdateIngressStatus(*ingConfig.Ingress)
+		var err error
+
+		if len(ingConfig.Canaries) == 0 {
+			err = lbc.statusUpdater.UpdateIngressStatus(*ingConfig.Ingress)
+		} else {
+			ings := []networking.Ingress{*ingConfig.Ingress}
+
+			for _, c := range ingConfig.Canaries {
+				ings = append(ings, *c.Ingress)
+			}
+
+			err = lbc.statusUpdater.BulkUpdateIngressStatus(ings)
+		}
+
 		if err != nil {
-			glog.V(3).Infof("error updating ing status: %v", err)
+			nl.Tracef(lbc.logger, "error updating ing status: %v", err)
 		}
 	}
 }
@@ -1602,7 +1797,7 @@ func (lbc *LoadBalancerController) updateTransportServerStatusAndEvents(tsConfig
 	if lbc.reportCustomResourceStatusEnabled() {
 		err := lbc.statusUpdater.UpdateTransportServerStatus(tsConfig.TransportServer, state, eventTitle, msg)
 		if err != nil {
-			glog.Errorf("Error when updating the status for TransportServer %v/%v: %v", tsConfig.TransportServer.Namespace, tsConfig.TransportServer.Name, err)
+			nl.Errorf(lbc.logger, "Error when updating the status for TransportServer %v/%v: %v", tsConfig.TransportServer.Namespace, tsConfig.TransportServer.Name, err)
 		}
 	}
 }
@@ -1640,7 +1835,7 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 	if lbc.reportCustomResourceStatusEnabled() {
 		err := lbc.statusUpdater.UpdateVirtualServerStatus(vsConfig.VirtualServer, state, eventTitle, msg)
 		if err != nil {
-			glog.Errorf("Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
+			nl.Errorf(lbc.logger, "Error when updating the status for VirtualServer %v/%v: %v", vsConfig.VirtualServer.Namespace, vsConfig.VirtualServer.Name, err)
 		}
 	}
 
@@ -1671,13 +1866,14 @@ func (lbc *LoadBalancerController) updateVirtualServerStatusAndEvents(vsConfig *
 			vss := []*conf_v1.VirtualServer{vsConfig.VirtualServer}
 			err := lbc.statusUpdater.UpdateVirtualServerRouteStatusWithReferencedBy(vsr, vsrState, vsrEventTitle, msg, vss)
 			if err != nil {
-				glog.Errorf("Error when updating the status for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+				nl.Errorf(lbc.logger, "Error when updating the status for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 		}
 	}
 }
 
-func (lbc *LoadBalancerController) syncVirtualServerRoute(task task) {
+func (lbc *LoadBalancerController) syncVirtualServerRoute(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, exists, err := lbc.virtualServerRouteLister.GetByKey(key)
 	if err != nil {
@@ -1689,21 +1885,29 @@ func (lbc *LoadBalancerController) syncVirtualServerRoute(task task) {
 	var problems []ConfigurationProblem
 
 	if !exists {
-		glog.V(2).Infof("Deleting VirtualServerRoute: %v\n", key)
+		nl.Debugf(l, "Deleting VirtualServerRoute: %v\n", key)
 
+		_, span := tracing.StartSpan(ctx, "Configuration.DeleteVirtualServerRoute")
 		changes, problems = lbc.configuration.DeleteVirtualServerRoute(key)
+		endConfigurationSpan(span, changes, problems)
 	} else {
-		glog.V(2).Infof("Adding or Updating VirtualServerRoute: %v\n", key)
+		nl.Debugf(l, "Adding or Updating VirtualServerRoute: %v\n", key)
 
 		vsr := obj.(*conf_v1.VirtualServerRoute)
+		_, span := tracing.StartSpan(ctx, "Configuration.AddOrUpdateVirtualServerRoute")
 		changes, problems = lbc.configuration.AddOrUpdateVirtualServerRoute(vsr)
+		endConfigurationSpan(span, changes, problems)
 	}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.Int("resources.affected", len(changes)))
+	lbc.metricsCollector.ObserveResourcesAffected(task.Kind.String(), len(changes))
+
 	lbc.processChanges(changes)
 	lbc.processProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncIngress(task task) {
+func (lbc *LoadBalancerController) syncIngress(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	ing, ingExists, err := lbc.ingressLister.GetByKeySafe(key)
 	if err != nil {
@@ -1715,15 +1919,22 @@ func (lbc *LoadBalancerController) syncIngress(task task) {
 	var problems []ConfigurationProblem
 
 	if !ingExists {
-		glog.V(2).Infof("Deleting Ingress: %v\n", key)
+		nl.Debugf(l, "Deleting Ingress: %v\n", key)
 
+		_, span := tracing.StartSpan(ctx, "Configuration.DeleteIngress")
 		changes, problems = lbc.configuration.DeleteIngress(key)
+		endConfigurationSpan(span, changes, problems)
 	} else {
-		glog.V(2).Infof("Adding or Updating Ingress: %v\n", key)
+		nl.Debugf(l, "Adding or Updating Ingress: %v\n", key)
 
+		_, span := tracing.StartSpan(ctx, "Configuration.AddOrUpdateIngress")
 		changes, problems = lbc.configuration.AddOrUpdateIngress(ing)
+		endConfigurationSpan(span, changes, problems)
 	}
 
+	tracing.SpanFromContext(ctx).SetAttributes(tracing.Int("resources.affected", len(changes)))
+	lbc.metricsCollector.ObserveResourcesAffected(task.Kind.String(), len(changes))
+
 	lbc.processChanges(changes)
 	lbc.processProblems(problems)
 }
@@ -1750,9 +1961,10 @@ func (lbc *LoadBalancerController) updateTransportServerMetrics() {
 	lbc.metricsCollector.SetTransportServers(metrics.TotalTLSPassthrough, metrics.TotalTCP, metrics.TotalUDP)
 }
 
-func (lbc *LoadBalancerController) syncService(task task) {
+func (lbc *LoadBalancerController) syncService(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing service %v", key)
+	nl.Tracef(l, "Syncing service %v", key)
 
 	obj, exists, err := lbc.svcLister.GetByKey(key)
 	if err != nil {
@@ -1776,22 +1988,22 @@ func (lbc *LoadBalancerController) syncService(task task) {
 		if lbc.reportStatusEnabled() {
 			ingresses := lbc.configuration.GetResourcesWithFilter(resourceFilter{Ingresses: true})
 
-			glog.V(3).Infof("Updating status for %v Ingresses", len(ingresses))
+			nl.Tracef(l, "Updating status for %v Ingresses", len(ingresses))
 
 			err := lbc.statusUpdater.UpdateExternalEndpointsForResources(ingresses)
 			if err != nil {
-				glog.Errorf("error updating ingress status in syncService: %v", err)
+				nl.Errorf(l, "error updating ingress status in syncService: %v", err)
 			}
 		}
 
 		if lbc.areCustomResourcesEnabled && lbc.reportCustomResourceStatusEnabled() {
 			virtualServers := lbc.configuration.GetResourcesWithFilter(resourceFilter{VirtualServers: true})
 
-			glog.V(3).Infof("Updating status for %v VirtualServers", len(virtualServers))
+			nl.Tracef(l, "Updating status for %v VirtualServers", len(virtualServers))
 
 			err := lbc.statusUpdater.UpdateExternalEndpointsForResources(virtualServers)
 			if err != nil {
-				glog.V(3).Infof("error updating VirtualServer/VirtualServerRoute status in syncService: %v", err)
+				nl.Tracef(l, "error updating VirtualServer/VirtualServerRoute status in syncService: %v", err)
 			}
 		}
 
@@ -1809,7 +2021,7 @@ func (lbc *LoadBalancerController) syncService(task task) {
 		return
 	}
 
-	glog.V(3).Infof("Updating %v resources", len(resources))
+	nl.Tracef(l, "Updating %v resources", len(resources))
 
 	resourceExes := lbc.createExtendedResources(resources)
 
@@ -1848,7 +2060,8 @@ func (lbc *LoadBalancerController) reportCustomResourceStatusEnabled() bool {
 	return true
 }
 
-func (lbc *LoadBalancerController) syncSecret(task task) {
+func (lbc *LoadBalancerController) syncSecret(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
 	obj, secrExists, err := lbc.secretLister.GetByKey(key)
 	if err != nil {
@@ -1858,14 +2071,15 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 
 	namespace, name, err := ParseNamespaceName(key)
 	if err != nil {
-		glog.Warningf("Secret key %v is invalid: %v", key, err)
+		nl.Warnf(l, "Secret key %v is invalid: %v", key, err)
 		return
 	}
 
 	resources := lbc.configuration.FindResourcesForSecret(namespace, name)
 
+	var secretPols []*conf_v1.Policy
 	if lbc.areCustomResourcesEnabled {
-		secretPols := lbc.getPoliciesForSecret(namespace, name)
+		secretPols = lbc.getPoliciesForSecret(namespace, name)
 		for _, pol := range secretPols {
 			resources = append(resources, lbc.configuration.FindResourcesForPolicy(pol.Namespace, pol.Name)...)
 		}
@@ -1873,26 +2087,29 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 		resources = removeDuplicateResources(resources)
 	}
 
-	glog.V(2).Infof("Found %v Resources with Secret %v", len(resources), key)
+	nl.Debugf(l, "Found %v Resources with Secret %v", len(resources), key)
 
 	if !secrExists {
 		lbc.secretStore.DeleteSecret(key)
 
-		glog.V(2).Infof("Deleting Secret: %v\n", key)
+		nl.Debugf(l, "Deleting Secret: %v\n", key)
 
 		if len(resources) > 0 {
 			lbc.handleRegularSecretDeletion(resources)
 		}
 		if lbc.isSpecialSecret(key) {
-			glog.Warningf("A special TLS Secret %v was removed. Retaining the Secret.", key)
+			nl.Warnf(l, "A special TLS Secret %v was removed. Retaining the Secret.", key)
 		}
 		return
 	}
 
-	glog.V(2).Infof("Adding / Updating Secret: %v\n", key)
+	nl.Debugf(l, "Adding / Updating Secret: %v\n", key)
 
 	secret := obj.(*api_v1.Secret)
 
+	// the secret was valid before the update, so the resources reference the secret file rather than rejecting TLS handshakes.
+	wasValid := lbc.secretStore.GetSecret(key).Error == nil
+
 	lbc.secretStore.AddOrUpdateSecret(secret)
 
 	if lbc.isSpecialSecret(key) {
@@ -1900,9 +2117,112 @@ func (lbc *LoadBalancerController) syncSecret(task task) {
 		// we don't return here in case the special secret is also used in resources.
 	}
 
-	if len(resources) > 0 {
-		lbc.handleSecretUpdate(secret, resources)
+	if len(resources) == 0 {
+		return
+	}
+
+	if lbc.canUpdateSecretWithoutReload(secret, wasValid, secretPols, resources) {
+		// The secret file was already updated on the file system by the secret store.
+		// NGINX loads TLS certs and keys during the handshake, so no config regeneration or reload is required.
+		nl.Tracef(l, "TLS Secret %v was updated without reloading NGINX", key)
+		lbc.metricsCollector.IncNginxReloadsAvoided()
+		return
+	}
+
+	lbc.handleSecretUpdate(secret, resources)
+}
+
+// canUpdateSecretWithoutReload checks if NGINX picks up the update of a Secret without a reload: with the dynamic SSL reload,
+// NGINX loads the TLS certs and keys during the handshake. The Secret must be valid before and after the update,
+// because the config of a resource with an invalid Secret doesn't reference the Secret file. Policies configure
+// their Secrets differently, so their Secrets always require a reload. The same applies to the client certificates
+// of the backends, which NGINX loads only during a reload.
+func (lbc *LoadBalancerController) canUpdateSecretWithoutReload(secret *api_v1.Secret, wasValid bool, secretPols []*conf_v1.Policy, resources []Resource) bool {
+	if !lbc.isDynamicSSLReloadEnabled || !wasValid || len(secretPols) > 0 || secret.Type != api_v1.SecretTypeTLS {
+		return false
+	}
+
+	if isUpstreamTLSSecretOfResources(secret.Name, resources) {
+		return false
+	}
+
+	return lbc.secretStore.GetSecret(getResourceKey(&secret.ObjectMeta)).Error == nil
+}
+
+// isUpstreamTLSSecretOfResources checks if the Secret is referenced by the nginx.org/upstream-tls-secret annotation
+// of one of the Ingress resources or their minions.
+func isUpstreamTLSSecretOfResources(secretName string, resources []Resource) bool {
+	for _, r := range resources {
+		ingConfig, ok := r.(*IngressConfiguration)
+		if !ok {
+			continue
+		}
+
+		if ingConfig.Ingress.Annotations[configs.UpstreamTLSSecretAnnotation] == secretName {
+			return true
+		}
+		for _, minion := range ingConfig.Minions {
+			if minion.Ingress.Annotations[configs.UpstreamTLSSecretAnnotation] == secretName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (lbc *LoadBalancerController) syncErrorPagesConfigMap(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
+	key := task.Key
+	_, cmExists, err := lbc.errorPagesConfigMapLister.GetByKey(key)
+	if err != nil {
+		lbc.syncQueue.Requeue(task, err)
+		return
+	}
+
+	namespace, name, err := ParseNamespaceName(key)
+	if err != nil {
+		nl.Warnf(l, "ConfigMap key %v is invalid: %v", key, err)
+		return
+	}
+
+	resources := lbc.configuration.FindResourcesForConfigMap(namespace, name)
+	if len(resources) == 0 {
+		return
+	}
+
+	nl.Debugf(l, "Found %v Resources with ConfigMap %v", len(resources), key)
+
+	if cmExists {
+		nl.Debugf(l, "Adding / Updating ConfigMap with error pages: %v", key)
+	} else {
+		nl.Debugf(l, "Deleting ConfigMap with error pages: %v", key)
+	}
+
+	resourceExes := lbc.createExtendedResources(resources)
+	warnings, addOrUpdateErr := lbc.configurator.AddOrUpdateResources(resourceExes)
+	if addOrUpdateErr != nil {
+		nl.Errorf(l, "Error when updating ConfigMap %v: %v", key, addOrUpdateErr)
+	}
+
+	lbc.updateResourcesStatusAndEvents(resources, warnings, addOrUpdateErr)
+}
+
+func (lbc *LoadBalancerController) isNginxConfigMap(cm *api_v1.ConfigMap) bool {
+	return getResourceKey(&cm.ObjectMeta) == lbc.nginxConfigMapKey
+}
+
+// isErrorPagesConfigMap checks if the ConfigMap is referenced by the nginx.org/error-pages annotation of an Ingress.
+// The NGINX ConfigMap and the leader election ConfigMap, which is updated every few seconds, are never synced as error pages.
+func (lbc *LoadBalancerController) isErrorPagesConfigMap(cm *api_v1.ConfigMap) bool {
+	if lbc.isNginxConfigMap(cm) || lbc.isLeaderElectionConfigMap(cm) {
+		return false
 	}
+	return len(lbc.configuration.FindResourcesForConfigMap(cm.Namespace, cm.Name)) > 0
+}
+
+func (lbc *LoadBalancerController) isLeaderElectionConfigMap(cm *api_v1.ConfigMap) bool {
+	return lbc.isLeaderElectionEnabled && cm.Namespace == lbc.controllerNamespace && cm.Name == lbc.leaderElectionLockName
 }
 
 func removeDuplicateResources(resources []Resource) []Resource {
@@ -1941,7 +2261,7 @@ func (lbc *LoadBalancerController) handleSecretUpdate(secret *api_v1.Secret, res
 	warnings, addOrUpdateErr = lbc.configurator.AddOrUpdateResources(resourceExes)
 
 	if addOrUpdateErr != nil {
-		glog.Errorf("Error when updating Secret %v: %v", secretNsName, addOrUpdateErr)
+		nl.Errorf(lbc.logger, "Error when updating Secret %v: %v", secretNsName, addOrUpdateErr)
 		lbc.recorder.Eventf(secret, api_v1.EventTypeWarning, "UpdatedWithError", "%v was updated, but not applied: %v", secretNsName, addOrUpdateErr)
 	}
 
@@ -1953,7 +2273,7 @@ func (lbc *LoadBalancerController) handleSpecialSecretUpdate(secret *api_v1.Secr
 	secretNsName := secret.Namespace + "/" + secret.Name
 	err := secrets.ValidateTLSSecret(secret)
 	if err != nil {
-		glog.Errorf("Couldn't validate the special Secret %v: %v", secretNsName, err)
+		nl.Errorf(lbc.logger, "Couldn't validate the special Secret %v: %v", secretNsName, err)
 		lbc.recorder.Eventf(secret, api_v1.EventTypeWarning, "Rejected", "the special Secret %v was rejected, using the previous version: %v", secretNsName, err)
 		return
 	}
@@ -1967,7 +2287,7 @@ func (lbc *LoadBalancerController) handleSpecialSecretUpdate(secret *api_v1.Secr
 
 	err = lbc.configurator.AddOrUpdateSpecialTLSSecrets(secret, specialSecretsToUpdate)
 	if err != nil {
-		glog.Errorf("Error when updating the special Secret %v: %v", secretNsName, err)
+		nl.Errorf(lbc.logger, "Error when updating the special Secret %v: %v", secretNsName, err)
 		lbc.recorder.Eventf(secret, api_v1.EventTypeWarning, "UpdatedWithError", "the special Secret %v was updated, but not applied: %v", secretNsName, err)
 		return
 	}
@@ -1975,6 +2295,25 @@ func (lbc *LoadBalancerController) handleSpecialSecretUpdate(secret *api_v1.Secr
 	lbc.recorder.Eventf(secret, api_v1.EventTypeNormal, "Updated", "the special Secret %v was updated", secretNsName)
 }
 
+// updateVirtualServerCondition sets a condition in the status of a VirtualServer.
+func (lbc *LoadBalancerController) updateVirtualServerCondition(namespace string, name string, condition meta_v1.Condition) {
+	key := namespace + "/" + name
+
+	obj, exists, err := lbc.virtualServerLister.GetByKey(key)
+	if err != nil {
+		nl.Errorf(lbc.logger, "Error when getting VirtualServer %v: %v", key, err)
+		return
+	}
+	if !exists {
+		return
+	}
+
+	err = lbc.statusUpdater.UpdateVirtualServerCondition(obj.(*conf_v1.VirtualServer), condition)
+	if err != nil {
+		nl.Errorf(lbc.logger, "Error when updating the condition %v of VirtualServer %v: %v", condition.Type, key, err)
+	}
+}
+
 func getStatusFromEventTitle(eventTitle string) string {
 	switch eventTitle {
 	case "AddedOrUpdatedWithError", "Rejected", "NoVirtualServersFound", "Missing Secret", "UpdatedWithError":
@@ -1994,7 +2333,7 @@ func (lbc *LoadBalancerController) updateVirtualServersStatusFromEvents() error
 		vs := obj.(*conf_v1.VirtualServer)
 
 		if !lbc.HasCorrectIngressClass(vs) {
-			glog.V(3).Infof("Ignoring VirtualServer %v based on class %v", vs.Name, vs.Spec.IngressClass)
+			nl.Tracef(lbc.logger, "Ignoring VirtualServer %v based on class %v", vs.Name, vs.Spec.IngressClass)
 			continue
 		}
 
@@ -2036,7 +2375,7 @@ func (lbc *LoadBalancerController) updateVirtualServerRoutesStatusFromEvents() e
 		vsr := obj.(*conf_v1.VirtualServerRoute)
 
 		if !lbc.HasCorrectIngressClass(vsr) {
-			glog.V(3).Infof("Ignoring VirtualServerRoute %v based on class %v", vsr.Name, vsr.Spec.IngressClass)
+			nl.Tracef(lbc.logger, "Ignoring VirtualServerRoute %v based on class %v", vsr.Name, vsr.Spec.IngressClass)
 			continue
 		}
 
@@ -2161,6 +2500,17 @@ func (lbc *LoadBalancerController) createMergeableIngresses(ingConfig *IngressCo
 	}
 }
 
+func (lbc *LoadBalancerController) createRegularIngressEx(ingConfig *IngressConfiguration) *configs.IngressEx {
+	// for regular Ingress, validMinionPaths is nil
+	ingEx := lbc.createIngressEx(ingConfig.Ingress, ingConfig.ValidHosts, nil)
+
+	for _, c := range ingConfig.Canaries {
+		ingEx.Canaries = append(ingEx.Canaries, lbc.createIngressEx(c.Ingress, ingConfig.ValidHosts, c.ValidPaths))
+	}
+
+	return ingEx
+}
+
 func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, validHosts map[string]bool, validMinionPaths map[string]bool) *configs.IngressEx {
 	ingEx := &configs.IngressEx{
 		Ingress:          ing,
@@ -2176,12 +2526,25 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 
 		secretRef := lbc.secretStore.GetSecret(secretKey)
 		if secretRef.Error != nil {
-			glog.Warningf("Error trying to get the secret %v for Ingress %v: %v", secretName, ing.Name, secretRef.Error)
+			nl.Warnf(lbc.logger, "Error trying to get the secret %v for Ingress %v: %v", secretName, ing.Name, secretRef.Error)
 		}
 
 		ingEx.SecretRefs[secretName] = secretRef
 	}
 
+	for _, annotation := range []string{configs.UpstreamCASecretAnnotation, configs.UpstreamTLSSecretAnnotation} {
+		if secretName, exists := ingEx.Ingress.Annotations[annotation]; exists {
+			secretKey := ing.Namespace + "/" + secretName
+
+			secretRef := lbc.secretStore.GetSecret(secretKey)
+			if secretRef.Error != nil {
+				nl.Warnf(lbc.logger, "Error trying to get the secret %v for Ingress %v/%v: %v", secretName, ing.Namespace, ing.Name, secretRef.Error)
+			}
+
+			ingEx.SecretRefs[secretName] = secretRef
+		}
+	}
+
 	if lbc.isNginxPlus {
 		if jwtKey, exists := ingEx.Ingress.Annotations[configs.JWTKeyAnnotation]; exists {
 			secretName := jwtKey
@@ -2189,7 +2552,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 
 			secretRef := lbc.secretStore.GetSecret(secretKey)
 			if secretRef.Error != nil {
-				glog.Warningf("Error trying to get the secret %v for Ingress %v/%v: %v", secretName, ing.Namespace, ing.Name, secretRef.Error)
+				nl.Warnf(lbc.logger, "Error trying to get the secret %v for Ingress %v/%v: %v", secretName, ing.Namespace, ing.Name, secretRef.Error)
 			}
 
 			ingEx.SecretRefs[secretName] = secretRef
@@ -2198,7 +2561,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			if apPolicyAntn, exists := ingEx.Ingress.Annotations[configs.AppProtectPolicyAnnotation]; exists {
 				policy, err := lbc.getAppProtectPolicy(ing)
 				if err != nil {
-					glog.Warningf("Error Getting App Protect policy %v for Ingress %v/%v: %v", apPolicyAntn, ing.Namespace, ing.Name, err)
+					nl.Warnf(lbc.logger, "Error Getting App Protect policy %v for Ingress %v/%v: %v", apPolicyAntn, ing.Namespace, ing.Name, err)
 				} else {
 					ingEx.AppProtectPolicy = policy
 				}
@@ -2207,7 +2570,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			if apLogConfAntn, exists := ingEx.Ingress.Annotations[configs.AppProtectLogConfAnnotation]; exists {
 				logConf, err := lbc.getAppProtectLogConfAndDst(ing)
 				if err != nil {
-					glog.Warningf("Error Getting App Protect Log Config %v for Ingress %v/%v: %v", apLogConfAntn, ing.Namespace, ing.Name, err)
+					nl.Warnf(lbc.logger, "Error Getting App Protect Log Config %v for Ingress %v/%v: %v", apLogConfAntn, ing.Namespace, ing.Name, err)
 				} else {
 					ingEx.AppProtectLogs = logConf
 				}
@@ -2218,7 +2581,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			if dosProtectedAnnotationValue, exists := ingEx.Ingress.Annotations[configs.AppProtectDosProtectedAnnotation]; exists {
 				dosResEx, err := lbc.dosConfiguration.GetValidDosEx(ing.Namespace, dosProtectedAnnotationValue)
 				if err != nil {
-					glog.Warningf("Error Getting Dos Protected Resource %v for Ingress %v/%v: %v", dosProtectedAnnotationValue, ing.Namespace, ing.Name, err)
+					nl.Warnf(lbc.logger, "Error Getting Dos Protected Resource %v for Ingress %v/%v: %v", dosProtectedAnnotationValue, ing.Namespace, ing.Name, err)
 				}
 				if dosResEx != nil {
 					ingEx.DosEx = dosResEx
@@ -2227,17 +2590,31 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		}
 	}
 
+	if cmName, exists := ing.Annotations[configs.ErrorPagesAnnotation]; exists && !isMinion(ing) {
+		cm, err := lbc.getErrorPagesConfigMap(ing.Namespace, cmName)
+		if err != nil {
+			nl.Warnf(lbc.logger, "Error getting the ConfigMap %v with error pages for Ingress %v/%v: %v", cmName, ing.Namespace, ing.Name, err)
+		} else {
+			ingEx.ErrorPages = cm
+		}
+	}
+
 	ingEx.Endpoints = make(map[string][]string)
 	ingEx.HealthChecks = make(map[string]*api_v1.Probe)
 	ingEx.ExternalNameSvcs = make(map[string]bool)
 	ingEx.PodsByIP = make(map[string]configs.PodInfo)
+	ingEx.StaticResponses = make(map[string]*conf_v1alpha1.StaticResponse)
 
-	if ing.Spec.DefaultBackend != nil {
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Resource != nil {
+		lbc.addStaticResponseToIngressEx(ingEx, ing.Spec.DefaultBackend.Resource.Name)
+	}
+
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
 		podEndps := []podEndpoint{}
 		var external bool
 		svc, err := lbc.getServiceForIngressBackend(ing.Spec.DefaultBackend, ing.Namespace)
 		if err != nil {
-			glog.V(3).Infof("Error getting service %v: %v", ing.Spec.DefaultBackend.Service.Name, err)
+			nl.Tracef(lbc.logger, "Error getting service %v: %v", ing.Spec.DefaultBackend.Service.Name, err)
 		} else {
 			podEndps, external, err = lbc.getEndpointsForIngressBackend(ing.Spec.DefaultBackend, svc)
 			if err == nil && external && lbc.isNginxPlus {
@@ -2246,7 +2623,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		}
 
 		if err != nil {
-			glog.Warningf("Error retrieving endpoints for the service %v: %v", ing.Spec.DefaultBackend.Service.Name, err)
+			nl.Warnf(lbc.logger, "Error retrieving endpoints for the service %v: %v", ing.Spec.DefaultBackend.Service.Name, err)
 		}
 
 		endps := getIPAddressesFromEndpoints(podEndps)
@@ -2273,7 +2650,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 
 	for _, rule := range ing.Spec.Rules {
 		if !validHosts[rule.Host] {
-			glog.V(3).Infof("Skipping host %s for Ingress %s", rule.Host, ing.Name)
+			nl.Tracef(lbc.logger, "Skipping host %s for Ingress %s", rule.Host, ing.Name)
 			continue
 		}
 
@@ -2285,14 +2662,19 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 		for _, path := range rule.HTTP.Paths {
 			podEndps := []podEndpoint{}
 			if validMinionPaths != nil && !validMinionPaths[path.Path] {
-				glog.V(3).Infof("Skipping path %s for minion Ingress %s", path.Path, ing.Name)
+				nl.Tracef(lbc.logger, "Skipping path %s for minion Ingress %s", path.Path, ing.Name)
+				continue
+			}
+
+			if path.Backend.Resource != nil {
+				lbc.addStaticResponseToIngressEx(ingEx, path.Backend.Resource.Name)
 				continue
 			}
 
 			var external bool
 			svc, err := lbc.getServiceForIngressBackend(&path.Backend, ing.Namespace)
 			if err != nil {
-				glog.V(3).Infof("Error getting service %v: %v", &path.Backend.Service.Name, err)
+				nl.Tracef(lbc.logger, "Error getting service %v: %v", &path.Backend.Service.Name, err)
 			} else {
 				podEndps, external, err = lbc.getEndpointsForIngressBackend(&path.Backend, svc)
 				if err == nil && external && lbc.isNginxPlus {
@@ -2301,7 +2683,7 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 			}
 
 			if err != nil {
-				glog.Warningf("Error retrieving endpoints for the service %v: %v", path.Backend.Service.Name, err)
+				nl.Warnf(lbc.logger, "Error retrieving endpoints for the service %v: %v", path.Backend.Service.Name, err)
 			}
 
 			endps := getIPAddressesFromEndpoints(podEndps)
@@ -2331,6 +2713,30 @@ func (lbc *LoadBalancerController) createIngressEx(ing *networking.Ingress, vali
 	return ingEx
 }
 
+// addStaticResponseToIngressEx adds the StaticResponse referenced by a resource backend of the Ingress to the IngressEx.
+// Missing or invalid StaticResponses are not added.
+func (lbc *LoadBalancerController) addStaticResponseToIngressEx(ingEx *configs.IngressEx, name string) {
+	if _, exists := ingEx.StaticResponses[name]; exists {
+		return
+	}
+
+	ing := ingEx.Ingress
+
+	sr, err := lbc.getStaticResponse(ing.Namespace, name)
+	if err != nil {
+		nl.Warnf(lbc.logger, "Error getting the StaticResponse %v for Ingress %v/%v: %v", name, ing.Namespace, ing.Name, err)
+		return
+	}
+
+	err = validation.ValidateStaticResponse(sr, lbc.isNginxPlus)
+	if err != nil {
+		nl.Warnf(lbc.logger, "StaticResponse %v for Ingress %v/%v is invalid: %v", name, ing.Namespace, ing.Name, err)
+		return
+	}
+
+	ingEx.StaticResponses[name] = sr
+}
+
 func (lbc *LoadBalancerController) getAppProtectLogConfAndDst(ing *networking.Ingress) ([]configs.AppProtectLog, error) {
 	var apLogs []configs.AppProtectLog
 	if _, exists := ing.Annotations[configs.AppProtectLogConfDstAnnotation]; !exists {
@@ -2375,9 +2781,12 @@ func (lbc *LoadBalancerController) getAppProtectPolicy(ing *networking.Ingress)
 	return apPolicy, nil
 }
 
-func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.VirtualServer, virtualServerRoutes []*conf_v1.VirtualServerRoute) *configs.VirtualServerEx {
+func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.VirtualServer, virtualServerRoutes []*conf_v1.VirtualServerRoute,
+	validAliases map[string]bool,
+) *configs.VirtualServerEx {
 	virtualServerEx := configs.VirtualServerEx{
 		VirtualServer:  virtualServer,
+		ValidAliases:   validAliases,
 		SecretRefs:     make(map[string]*secrets.SecretReference),
 		ApPolRefs:      make(map[string]*unstructured.Unstructured),
 		LogConfRefs:    make(map[string]*unstructured.Unstructured),
@@ -2389,7 +2798,7 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 
 		secretRef := lbc.secretStore.GetSecret(secretKey)
 		if secretRef.Error != nil {
-			glog.Warningf("Error trying to get the secret %v for VirtualServer %v: %v", secretKey, virtualServer.Name, secretRef.Error)
+			nl.Warnf(lbc.logger, "Error trying to get the secret %v for VirtualServer %v: %v", secretKey, virtualServer.Name, secretRef.Error)
 		}
 
 		virtualServerEx.SecretRefs[secretKey] = secretRef
@@ -2397,35 +2806,35 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 
 	policies, policyErrors := lbc.getPolicies(virtualServer.Spec.Policies, virtualServer.Namespace)
 	for _, err := range policyErrors {
-		glog.Warningf("Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 
 	err := lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
-		glog.Warningf("Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 	err = lbc.addIngressMTLSSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
-		glog.Warningf("Error getting IngressMTLS secret for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting IngressMTLS secret for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 	err = lbc.addEgressMTLSSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
-		glog.Warningf("Error getting EgressMTLS secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting EgressMTLS secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 	err = lbc.addOIDCSecretRefs(virtualServerEx.SecretRefs, policies)
 	if err != nil {
-		glog.Warningf("Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 
 	err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, policies)
 	if err != nil {
-		glog.Warningf("Error getting App Protect resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+		nl.Warnf(lbc.logger, "Error getting App Protect resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 	}
 
 	if virtualServer.Spec.Dos != "" {
 		dosEx, err := lbc.dosConfiguration.GetValidDosEx(virtualServer.Namespace, virtualServer.Spec.Dos)
 		if err != nil {
-			glog.Warningf("Error getting App Protect Dos resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting App Protect Dos resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 		if dosEx != nil {
 			virtualServerEx.DosProtectedEx[""] = dosEx
@@ -2434,16 +2843,22 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 
 	endpoints := make(map[string][]string)
 	externalNameSvcs := make(map[string]bool)
+	localEndpoints := make(map[string]bool)
 	podsByIP := make(map[string]configs.PodInfo)
 
 	for _, u := range virtualServer.Spec.Upstreams {
+		// upstreams with addresses don't reference any Service
+		if len(u.Addresses) > 0 {
+			continue
+		}
+
 		endpointsKey := configs.GenerateEndpointsKey(virtualServer.Namespace, u.Service, u.Subselector, u.Port)
 
 		var endps []string
 		if u.UseClusterIP {
 			s, err := lbc.getServiceForUpstream(virtualServer.Namespace, u.Service, u.Port)
 			if err != nil {
-				glog.Warningf("Error getting Service for Upstream %v: %v", u.Service, err)
+				nl.Warnf(lbc.logger, "Error getting Service for Upstream %v: %v", u.Service, err)
 			} else {
 				endps = append(endps, fmt.Sprintf("%s:%d", s.Spec.ClusterIP, u.Port))
 			}
@@ -2458,16 +2873,17 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				var external bool
 				podEndps, external, err = lbc.getEndpointsForUpstream(virtualServer.Namespace, u.Service, u.Port)
 
-				if err == nil && external && lbc.isNginxPlus {
+				if err == nil && external {
 					externalNameSvcs[configs.GenerateExternalNameSvcKey(virtualServer.Namespace, u.Service)] = true
 				}
 			}
 
 			if err != nil {
-				glog.Warningf("Error getting Endpoints for Upstream %v: %v", u.Name, err)
+				nl.Warnf(lbc.logger, "Error getting Endpoints for Upstream %v: %v", u.Name, err)
 			}
 
 			endps = getIPAddressesFromEndpoints(podEndps)
+			lbc.addLocalEndpoints(localEndpoints, podEndps)
 
 			if (lbc.isNginxPlus && lbc.isPrometheusEnabled) || lbc.isLatencyMetricsEnabled {
 				for _, endpoint := range podEndps {
@@ -2481,40 +2897,41 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 
 		endpoints[endpointsKey] = endps
 
+		lbc.addEndpointsForAdditionalServices(endpoints, virtualServer.Namespace, u)
 	}
 
 	for _, r := range virtualServer.Spec.Routes {
 		vsRoutePolicies, policyErrors := lbc.getPolicies(r.Policies, virtualServer.Namespace)
 		for _, err := range policyErrors {
-			glog.Warningf("Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting policy for VirtualServer %s/%s: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 		policies = append(policies, vsRoutePolicies...)
 
 		err = lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
 		if err != nil {
-			glog.Warningf("Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting JWT secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 		err = lbc.addEgressMTLSSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
 		if err != nil {
-			glog.Warningf("Error getting EgressMTLS secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting EgressMTLS secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 
 		err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, vsRoutePolicies)
 		if err != nil {
-			glog.Warningf("Error getting WAF policies for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting WAF policies for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 
 		if r.Dos != "" {
 			routeDosEx, err := lbc.dosConfiguration.GetValidDosEx(virtualServer.Namespace, r.Dos)
 			if err != nil {
-				glog.Warningf("Error getting App Protect Dos resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+				nl.Warnf(lbc.logger, "Error getting App Protect Dos resource for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 			}
 			virtualServerEx.DosProtectedEx[r.Path] = routeDosEx
 		}
 
 		err = lbc.addOIDCSecretRefs(virtualServerEx.SecretRefs, vsRoutePolicies)
 		if err != nil {
-			glog.Warningf("Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
+			nl.Warnf(lbc.logger, "Error getting OIDC secrets for VirtualServer %v/%v: %v", virtualServer.Namespace, virtualServer.Name, err)
 		}
 	}
 
@@ -2522,47 +2939,51 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 		for _, sr := range vsr.Spec.Subroutes {
 			vsrSubroutePolicies, policyErrors := lbc.getPolicies(sr.Policies, vsr.Namespace)
 			for _, err := range policyErrors {
-				glog.Warningf("Error getting policy for VirtualServerRoute %s/%s: %v", vsr.Namespace, vsr.Name, err)
+				nl.Warnf(lbc.logger, "Error getting policy for VirtualServerRoute %s/%s: %v", vsr.Namespace, vsr.Name, err)
 			}
 			policies = append(policies, vsrSubroutePolicies...)
 
 			err = lbc.addJWTSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
 			if err != nil {
-				glog.Warningf("Error getting JWT secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+				nl.Warnf(lbc.logger, "Error getting JWT secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
 			err = lbc.addEgressMTLSSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
 			if err != nil {
-				glog.Warningf("Error getting EgressMTLS secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+				nl.Warnf(lbc.logger, "Error getting EgressMTLS secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
 			err = lbc.addOIDCSecretRefs(virtualServerEx.SecretRefs, vsrSubroutePolicies)
 			if err != nil {
-				glog.Warningf("Error getting OIDC secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+				nl.Warnf(lbc.logger, "Error getting OIDC secrets for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
 			err = lbc.addWAFPolicyRefs(virtualServerEx.ApPolRefs, virtualServerEx.LogConfRefs, vsrSubroutePolicies)
 			if err != nil {
-				glog.Warningf("Error getting WAF policies for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+				nl.Warnf(lbc.logger, "Error getting WAF policies for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 			}
 
 			if sr.Dos != "" {
 				routeDosEx, err := lbc.dosConfiguration.GetValidDosEx(vsr.Namespace, sr.Dos)
 				if err != nil {
-					glog.Warningf("Error getting App Protect Dos resource for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
+					nl.Warnf(lbc.logger, "Error getting App Protect Dos resource for VirtualServerRoute %v/%v: %v", vsr.Namespace, vsr.Name, err)
 				}
 				virtualServerEx.DosProtectedEx[sr.Path] = routeDosEx
 			}
 		}
 
 		for _, u := range vsr.Spec.Upstreams {
+			if len(u.Addresses) > 0 {
+				continue
+			}
+
 			endpointsKey := configs.GenerateEndpointsKey(vsr.Namespace, u.Service, u.Subselector, u.Port)
 
 			var endps []string
 			if u.UseClusterIP {
 				s, err := lbc.getServiceForUpstream(vsr.Namespace, u.Service, u.Port)
 				if err != nil {
-					glog.Warningf("Error getting Service for Upstream %v: %v", u.Service, err)
+					nl.Warnf(lbc.logger, "Error getting Service for Upstream %v: %v", u.Service, err)
 				} else {
 					endps = append(endps, fmt.Sprintf("%s:%d", s.Spec.ClusterIP, u.Port))
 				}
@@ -2576,15 +2997,16 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 					var external bool
 					podEndps, external, err = lbc.getEndpointsForUpstream(vsr.Namespace, u.Service, u.Port)
 
-					if err == nil && external && lbc.isNginxPlus {
+					if err == nil && external {
 						externalNameSvcs[configs.GenerateExternalNameSvcKey(vsr.Namespace, u.Service)] = true
 					}
 				}
 				if err != nil {
-					glog.Warningf("Error getting Endpoints for Upstream %v: %v", u.Name, err)
+					nl.Warnf(lbc.logger, "Error getting Endpoints for Upstream %v: %v", u.Name, err)
 				}
 
 				endps = getIPAddressesFromEndpoints(podEndps)
+				lbc.addLocalEndpoints(localEndpoints, podEndps)
 
 				if lbc.isNginxPlus || lbc.isLatencyMetricsEnabled {
 					for _, endpoint := range podEndps {
@@ -2596,18 +3018,57 @@ func (lbc *LoadBalancerController) createVirtualServerEx(virtualServer *conf_v1.
 				}
 			}
 			endpoints[endpointsKey] = endps
+
+			lbc.addEndpointsForAdditionalServices(endpoints, vsr.Namespace, u)
 		}
 	}
 
 	virtualServerEx.Endpoints = endpoints
 	virtualServerEx.VirtualServerRoutes = virtualServerRoutes
 	virtualServerEx.ExternalNameSvcs = externalNameSvcs
+	if lbc.controllerZone != "" {
+		virtualServerEx.LocalEndpoints = localEndpoints
+	}
 	virtualServerEx.Policies = createPolicyMap(policies)
 	virtualServerEx.PodsByIP = podsByIP
 
 	return &virtualServerEx
 }
 
+// addEndpointsForAdditionalServices adds the endpoints of the weighted Services and of the backup Service of the upstream.
+func (lbc *LoadBalancerController) addEndpointsForAdditionalServices(endpoints map[string][]string, namespace string, u conf_v1.Upstream) {
+	services := make([]conf_v1.UpstreamService, 0, len(u.Services)+1)
+	services = append(services, u.Services...)
+	if u.Backup != "" {
+		services = append(services, conf_v1.UpstreamService{Name: u.Backup, Port: u.BackupPort})
+	}
+
+	for _, svc := range services {
+		endpointsKey := configs.GenerateEndpointsKey(namespace, svc.Name, nil, svc.Port)
+
+		if u.UseClusterIP {
+			s, err := lbc.getServiceForUpstream(namespace, svc.Name, svc.Port)
+			if err != nil {
+				nl.Warnf(lbc.logger, "Error getting Service %v for Upstream %v: %v", svc.Name, u.Name, err)
+				continue
+			}
+			endpoints[endpointsKey] = []string{fmt.Sprintf("%s:%d", s.Spec.ClusterIP, svc.Port)}
+			continue
+		}
+
+		podEndps, external, err := lbc.getEndpointsForUpstream(namespace, svc.Name, svc.Port)
+		if err != nil {
+			nl.Warnf(lbc.logger, "Error getting Endpoints of Service %v for Upstream %v: %v", svc.Name, u.Name, err)
+		}
+		if external {
+			nl.Warnf(lbc.logger, "Service %v of Upstream %v is Type ExternalName, which is not supported for weighted or backup Services", svc.Name, u.Name)
+			continue
+		}
+
+		endpoints[endpointsKey] = getIPAddressesFromEndpoints(podEndps)
+	}
+}
+
 func createPolicyMap(policies []*conf_v1.Policy) map[string]*conf_v1.Policy {
 	result := make(map[string]*conf_v1.Policy)
 
@@ -2627,7 +3088,7 @@ func (lbc *LoadBalancerController) getAllPolicies() []*conf_v1.Policy {
 
 		err := validation.ValidatePolicy(pol, lbc.isNginxPlus, lbc.enablePreviewPolicies, lbc.appProtectEnabled)
 		if err != nil {
-			glog.V(3).Infof("Skipping invalid Policy %s/%s: %v", pol.Namespace, pol.Name, err)
+			nl.Tracef(lbc.logger, "Skipping invalid Policy %s/%s: %v", pol.Namespace, pol.Name, err)
 			continue
 		}
 
@@ -2861,16 +3322,23 @@ func isMatchingResourceRef(ownerNs, resRef, key string) bool {
 
 func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf_v1alpha1.TransportServer, listenerPort int) *configs.TransportServerEx {
 	endpoints := make(map[string][]string)
+	externalNameSvcs := make(map[string]bool)
+	localEndpoints := make(map[string]bool)
 	podsByIP := make(map[string]string)
 
 	for _, u := range transportServer.Spec.Upstreams {
+		// upstreams with addresses don't reference any Service
+		if len(u.Addresses) > 0 {
+			continue
+		}
+
 		podEndps, external, err := lbc.getEndpointsForUpstream(transportServer.Namespace, u.Service, uint16(u.Port))
 		if err != nil {
-			glog.Warningf("Error getting Endpoints for Upstream %v: %v", u.Name, err)
+			nl.Warnf(lbc.logger, "Error getting Endpoints for Upstream %v: %v", u.Name, err)
 		}
 
-		if external {
-			glog.Warningf("ExternalName services are not yet supported in TransportServer upstreams")
+		if err == nil && external {
+			externalNameSvcs[configs.GenerateExternalNameSvcKey(transportServer.Namespace, u.Service)] = true
 		}
 
 		// subselector is not supported yet in TransportServer upstreams. That's why we pass "nil" here
@@ -2878,6 +3346,7 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 
 		endps := getIPAddressesFromEndpoints(podEndps)
 		endpoints[endpointsKey] = endps
+		lbc.addLocalEndpoints(localEndpoints, podEndps)
 
 		if lbc.isNginxPlus && lbc.isPrometheusEnabled {
 			for _, endpoint := range podEndps {
@@ -2886,12 +3355,63 @@ func (lbc *LoadBalancerController) createTransportServerEx(transportServer *conf
 		}
 	}
 
-	return &configs.TransportServerEx{
-		ListenerPort:    listenerPort,
-		TransportServer: transportServer,
-		Endpoints:       endpoints,
-		PodsByIP:        podsByIP,
+	transportServerEx := &configs.TransportServerEx{
+		ListenerPort:     listenerPort,
+		TransportServer:  transportServer,
+		Endpoints:        endpoints,
+		ExternalNameSvcs: externalNameSvcs,
+		PodsByIP:         podsByIP,
+	}
+	if lbc.controllerZone != "" {
+		transportServerEx.LocalEndpoints = localEndpoints
+	}
+
+	return transportServerEx
+}
+
+// addLocalEndpoints adds the endpoints in the zone of the Ingress Controller pod.
+func (lbc *LoadBalancerController) addLocalEndpoints(localEndpoints map[string]bool, podEndps []podEndpoint) {
+	if lbc.controllerZone == "" {
+		return
+	}
+
+	for _, endpoint := range podEndps {
+		if endpoint.NodeName != "" && lbc.getZoneForNode(endpoint.NodeName) == lbc.controllerZone {
+			localEndpoints[endpoint.Address] = true
+		}
+	}
+}
+
+// syncEndpointsForNode adds the endpoints with addresses on the node to the sync queue,
+// which updates the resources that use them.
+func (lbc *LoadBalancerController) syncEndpointsForNode(nodeName string) {
+	for _, obj := range lbc.endpointLister.List() {
+		endps := obj.(*api_v1.Endpoints)
+		if hasAddressesOnNode(endps, nodeName) {
+			lbc.AddSyncQueue(endps)
+		}
+	}
+}
+
+func hasAddressesOnNode(endps *api_v1.Endpoints, nodeName string) bool {
+	for _, subset := range endps.Subsets {
+		for _, address := range subset.Addresses {
+			if address.NodeName != nil && *address.NodeName == nodeName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (lbc *LoadBalancerController) getZoneForNode(nodeName string) string {
+	obj, exists, err := lbc.nodeLister.GetByKey(nodeName)
+	if err != nil || !exists {
+		return ""
 	}
+
+	return obj.(*api_v1.Node).Labels[api_v1.LabelTopologyZone]
 }
 
 func (lbc *LoadBalancerController) getEndpointsForUpstream(namespace string, upstreamService string, upstreamPort uint16) (endps []podEndpoint, isExternal bool, err error) {
@@ -2909,6 +3429,12 @@ func (lbc *LoadBalancerController) getEndpointsForUpstream(namespace string, ups
 		},
 	}
 
+	// ExternalName services of VirtualServer and TransportServer upstreams are resolved by NGINX
+	// for both NGINX and NGINX Plus
+	if svc.Spec.Type == api_v1.ServiceTypeExternalName {
+		return lbc.getExternalEndpointsForIngressBackend(backend, svc), true, nil
+	}
+
 	endps, isExternal, err = lbc.getEndpointsForIngressBackend(backend, svc)
 	if err != nil {
 		return nil, false, fmt.Errorf("Error retrieving endpoints for the service %v: %w", upstreamService, err)
@@ -2955,7 +3481,7 @@ func (lbc *LoadBalancerController) getEndpointsForServiceWithSubselector(targetP
 
 	svcEps, err := lbc.endpointLister.GetServiceEndpoints(svc)
 	if err != nil {
-		glog.V(3).Infof("Error getting endpoints for service %s from the cache: %v", svc.Name, err)
+		nl.Tracef(lbc.logger, "Error getting endpoints for service %s from the cache: %v", svc.Name, err)
 		return nil, err
 	}
 
@@ -2975,8 +3501,9 @@ func getEndpointsBySubselectedPods(targetPort int32, pods []*api_v1.Pod, svcEps
 						addr := fmt.Sprintf("%v:%v", pod.Status.PodIP, targetPort)
 						ownerType, ownerName := getPodOwnerTypeAndName(pod)
 						podEnd := podEndpoint{
-							Address: addr,
-							PodName: getPodName(address.TargetRef),
+							Address:  addr,
+							PodName:  getPodName(address.TargetRef),
+							NodeName: pod.Spec.NodeName,
 							MeshPodOwner: configs.MeshPodOwner{
 								OwnerType: ownerType,
 								OwnerName: ownerName,
@@ -3001,7 +3528,7 @@ func getPodName(pod *api_v1.ObjectReference) string {
 func (lbc *LoadBalancerController) getHealthChecksForIngressBackend(backend *networking.IngressBackend, namespace string) *api_v1.Probe {
 	svc, err := lbc.getServiceForIngressBackend(backend, namespace)
 	if err != nil {
-		glog.V(3).Infof("Error getting service %v: %v", backend.Service.Name, err)
+		nl.Tracef(lbc.logger, "Error getting service %v: %v", backend.Service.Name, err)
 		return nil
 	}
 	svcPort := lbc.getServicePortForIngressPort(backend.Service.Port, svc)
@@ -3010,7 +3537,7 @@ func (lbc *LoadBalancerController) getHealthChecksForIngressBackend(backend *net
 	}
 	pods, err := lbc.podLister.ListByNamespace(svc.Namespace, labels.Set(svc.Spec.Selector).AsSelector())
 	if err != nil {
-		glog.V(3).Infof("Error fetching pods for namespace %v: %v", svc.Namespace, err)
+		nl.Tracef(lbc.logger, "Error fetching pods for namespace %v: %v", svc.Namespace, err)
 		return nil
 	}
 	return findProbeForPods(pods, svcPort)
@@ -3068,13 +3595,13 @@ func (lbc *LoadBalancerController) getEndpointsForIngressBackend(backend *networ
 			result = lbc.getExternalEndpointsForIngressBackend(backend, svc)
 			return result, true, nil
 		}
-		glog.V(3).Infof("Error getting endpoints for service %s from the cache: %v", svc.Name, err)
+		nl.Tracef(lbc.logger, "Error getting endpoints for service %s from the cache: %v", svc.Name, err)
 		return nil, false, err
 	}
 
 	result, err = lbc.getEndpointsForPort(endps, backend.Service.Port, svc)
 	if err != nil {
-		glog.V(3).Infof("Error getting endpoints for service %s port %v: %v", svc.Name, configs.GetBackendPortAsString(backend.Service.Port), err)
+		nl.Tracef(lbc.logger, "Error getting endpoints for service %s port %v: %v", svc.Name, configs.GetBackendPortAsString(backend.Service.Port), err)
 		return nil, false, err
 	}
 	return result, false, nil
@@ -3107,6 +3634,9 @@ func (lbc *LoadBalancerController) getEndpointsForPort(endps api_v1.Endpoints, b
 					podEnd := podEndpoint{
 						Address: addr,
 					}
+					if address.NodeName != nil {
+						podEnd.NodeName = *address.NodeName
+					}
 					if address.TargetRef != nil {
 						parentType, parentName := lbc.getPodOwnerTypeAndNameFromAddress(address.TargetRef.Namespace, address.TargetRef.Name)
 						podEnd.OwnerType = parentType
@@ -3126,7 +3656,7 @@ func (lbc *LoadBalancerController) getEndpointsForPort(endps api_v1.Endpoints, b
 func (lbc *LoadBalancerController) getPodOwnerTypeAndNameFromAddress(ns, name string) (parentType, parentName string) {
 	obj, exists, err := lbc.podLister.GetByKey(fmt.Sprintf("%s/%s", ns, name))
 	if err != nil {
-		glog.Warningf("could not get pod by key %s/%s: %v", ns, name, err)
+		nl.Warnf(lbc.logger, "could not get pod by key %s/%s: %v", ns, name, err)
 		return "", ""
 	}
 	if exists {
@@ -3215,6 +3745,38 @@ func (lbc *LoadBalancerController) getServiceForIngressBackend(backend *networki
 	return nil, fmt.Errorf("service %s doesn't exist", svcKey)
 }
 
+func (lbc *LoadBalancerController) getErrorPagesConfigMap(namespace string, name string) (*api_v1.ConfigMap, error) {
+	cmKey := namespace + "/" + name
+	cmObj, cmExists, err := lbc.errorPagesConfigMapLister.GetByKey(cmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmExists {
+		return cmObj.(*api_v1.ConfigMap), nil
+	}
+
+	return nil, fmt.Errorf("configmap %s doesn't exist", cmKey)
+}
+
+func (lbc *LoadBalancerController) getStaticResponse(namespace string, name string) (*conf_v1alpha1.StaticResponse, error) {
+	if !lbc.areCustomResourcesEnabled {
+		return nil, fmt.Errorf("custom resources are disabled")
+	}
+
+	srKey := namespace + "/" + name
+	srObj, srExists, err := lbc.staticResponseLister.GetByKey(srKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if srExists {
+		return srObj.(*conf_v1alpha1.StaticResponse), nil
+	}
+
+	return nil, fmt.Errorf("staticresponse %s doesn't exist", srKey)
+}
+
 // HasCorrectIngressClass checks if resource ingress class annotation (if exists) or ingressClass string for VS/VSR is matching with ingress controller class
 func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool {
 	var class string
@@ -3233,7 +3795,7 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 			class = *obj.Spec.IngressClassName
 		} else {
 			// the annotation takes precedence over the field
-			glog.Warningln("Using the DEPRECATED annotation 'kubernetes.io/ingress.class'. The 'ingressClassName' field will be ignored.")
+			nl.Warn(lbc.logger, "Using the DEPRECATED annotation 'kubernetes.io/ingress.class'. The 'ingressClassName' field will be ignored.")
 		}
 		return class == lbc.ingressClass
 
@@ -3248,7 +3810,7 @@ func (lbc *LoadBalancerController) HasCorrectIngressClass(obj interface{}) bool
 func (lbc *LoadBalancerController) isHealthCheckEnabled(ing *networking.Ingress) bool {
 	if healthCheckEnabled, exists, err := configs.GetMapKeyAsBool(ing.Annotations, "nginx.com/health-checks", ing); exists {
 		if err != nil {
-			glog.Error(err)
+			nl.Error(lbc.logger, err)
 		}
 		return healthCheckEnabled
 	}
@@ -3262,16 +3824,17 @@ func formatWarningMessages(w []string) string {
 func (lbc *LoadBalancerController) syncSVIDRotation(svidResponse *workload.X509SVIDs) {
 	lbc.syncLock.Lock()
 	defer lbc.syncLock.Unlock()
-	glog.V(3).Info("Rotating SPIFFE Certificates")
+	nl.Trace(lbc.logger, "Rotating SPIFFE Certificates")
 	err := lbc.configurator.AddOrUpdateSpiffeCerts(svidResponse)
 	if err != nil {
-		glog.Errorf("failed to rotate SPIFFE certificates: %v", err)
+		nl.Errorf(lbc.logger, "failed to rotate SPIFFE certificates: %v", err)
 	}
 }
 
-func (lbc *LoadBalancerController) syncAppProtectPolicy(task task) {
+func (lbc *LoadBalancerController) syncAppProtectPolicy(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing AppProtectPolicy %v", key)
+	nl.Tracef(l, "Syncing AppProtectPolicy %v", key)
 	obj, polExists, err := lbc.appProtectPolicyLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3282,11 +3845,11 @@ func (lbc *LoadBalancerController) syncAppProtectPolicy(task task) {
 	var problems []appprotect.Problem
 
 	if !polExists {
-		glog.V(2).Infof("Deleting AppProtectPolicy: %v\n", key)
+		nl.Debugf(l, "Deleting AppProtectPolicy: %v\n", key)
 
 		changes, problems = lbc.appProtectConfiguration.DeletePolicy(key)
 	} else {
-		glog.V(2).Infof("Adding or Updating AppProtectPolicy: %v\n", key)
+		nl.Debugf(l, "Adding or Updating AppProtectPolicy: %v\n", key)
 
 		changes, problems = lbc.appProtectConfiguration.AddOrUpdatePolicy(obj.(*unstructured.Unstructured))
 	}
@@ -3295,9 +3858,10 @@ func (lbc *LoadBalancerController) syncAppProtectPolicy(task task) {
 	lbc.processAppProtectProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncAppProtectLogConf(task task) {
+func (lbc *LoadBalancerController) syncAppProtectLogConf(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing AppProtectLogConf %v", key)
+	nl.Tracef(l, "Syncing AppProtectLogConf %v", key)
 	obj, confExists, err := lbc.appProtectLogConfLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3308,11 +3872,11 @@ func (lbc *LoadBalancerController) syncAppProtectLogConf(task task) {
 	var problems []appprotect.Problem
 
 	if !confExists {
-		glog.V(2).Infof("Deleting AppProtectLogConf: %v\n", key)
+		nl.Debugf(l, "Deleting AppProtectLogConf: %v\n", key)
 
 		changes, problems = lbc.appProtectConfiguration.DeleteLogConf(key)
 	} else {
-		glog.V(2).Infof("Adding or Updating AppProtectLogConf: %v\n", key)
+		nl.Debugf(l, "Adding or Updating AppProtectLogConf: %v\n", key)
 
 		changes, problems = lbc.appProtectConfiguration.AddOrUpdateLogConf(obj.(*unstructured.Unstructured))
 	}
@@ -3321,9 +3885,10 @@ func (lbc *LoadBalancerController) syncAppProtectLogConf(task task) {
 	lbc.processAppProtectProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncAppProtectUserSig(task task) {
+func (lbc *LoadBalancerController) syncAppProtectUserSig(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing AppProtectUserSig %v", key)
+	nl.Tracef(l, "Syncing AppProtectUserSig %v", key)
 	obj, sigExists, err := lbc.appProtectUserSigLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3334,11 +3899,11 @@ func (lbc *LoadBalancerController) syncAppProtectUserSig(task task) {
 	var problems []appprotect.Problem
 
 	if !sigExists {
-		glog.V(2).Infof("Deleting AppProtectUserSig: %v\n", key)
+		nl.Debugf(l, "Deleting AppProtectUserSig: %v\n", key)
 
 		change, problems = lbc.appProtectConfiguration.DeleteUserSig(key)
 	} else {
-		glog.V(2).Infof("Adding or Updating AppProtectUserSig: %v\n", key)
+		nl.Debugf(l, "Adding or Updating AppProtectUserSig: %v\n", key)
 
 		change, problems = lbc.appProtectConfiguration.AddOrUpdateUserSig(obj.(*unstructured.Unstructured))
 	}
@@ -3347,9 +3912,10 @@ func (lbc *LoadBalancerController) syncAppProtectUserSig(task task) {
 	lbc.processAppProtectProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncAppProtectDosPolicy(task task) {
+func (lbc *LoadBalancerController) syncAppProtectDosPolicy(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing AppProtectDosPolicy %v", key)
+	nl.Tracef(l, "Syncing AppProtectDosPolicy %v", key)
 	obj, polExists, err := lbc.appProtectDosPolicyLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3360,10 +3926,10 @@ func (lbc *LoadBalancerController) syncAppProtectDosPolicy(task task) {
 	var problems []appprotectdos.Problem
 
 	if !polExists {
-		glog.V(2).Infof("Deleting APDosPolicy: %v\n", key)
+		nl.Debugf(l, "Deleting APDosPolicy: %v\n", key)
 		changes, problems = lbc.dosConfiguration.DeletePolicy(key)
 	} else {
-		glog.V(2).Infof("Adding or Updating APDosPolicy: %v\n", key)
+		nl.Debugf(l, "Adding or Updating APDosPolicy: %v\n", key)
 		changes, problems = lbc.dosConfiguration.AddOrUpdatePolicy(obj.(*unstructured.Unstructured))
 	}
 
@@ -3371,9 +3937,10 @@ func (lbc *LoadBalancerController) syncAppProtectDosPolicy(task task) {
 	lbc.processAppProtectDosProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncAppProtectDosLogConf(task task) {
+func (lbc *LoadBalancerController) syncAppProtectDosLogConf(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing APDosLogConf %v", key)
+	nl.Tracef(l, "Syncing APDosLogConf %v", key)
 	obj, confExists, err := lbc.appProtectDosLogConfLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3384,10 +3951,10 @@ func (lbc *LoadBalancerController) syncAppProtectDosLogConf(task task) {
 	var problems []appprotectdos.Problem
 
 	if !confExists {
-		glog.V(2).Infof("Deleting APDosLogConf: %v\n", key)
+		nl.Debugf(l, "Deleting APDosLogConf: %v\n", key)
 		changes, problems = lbc.dosConfiguration.DeleteLogConf(key)
 	} else {
-		glog.V(2).Infof("Adding or Updating APDosLogConf: %v\n", key)
+		nl.Debugf(l, "Adding or Updating APDosLogConf: %v\n", key)
 		changes, problems = lbc.dosConfiguration.AddOrUpdateLogConf(obj.(*unstructured.Unstructured))
 	}
 
@@ -3395,9 +3962,10 @@ func (lbc *LoadBalancerController) syncAppProtectDosLogConf(task task) {
 	lbc.processAppProtectDosProblems(problems)
 }
 
-func (lbc *LoadBalancerController) syncDosProtectedResource(task task) {
+func (lbc *LoadBalancerController) syncDosProtectedResource(ctx context.Context, task task) {
+	l := nl.LoggerFromContext(ctx)
 	key := task.Key
-	glog.V(3).Infof("Syncing DosProtectedResource %v", key)
+	nl.Tracef(l, "Syncing DosProtectedResource %v", key)
 	obj, confExists, err := lbc.appProtectDosProtectedLister.GetByKey(key)
 	if err != nil {
 		lbc.syncQueue.Requeue(task, err)
@@ -3408,10 +3976,10 @@ func (lbc *LoadBalancerController) syncDosProtectedResource(task task) {
 	var problems []appprotectdos.Problem
 
 	if confExists {
-		glog.V(2).Infof("Adding or Updating DosProtectedResource: %v\n", key)
+		nl.Debugf(l, "Adding or Updating DosProtectedResource: %v\n", key)
 		changes, problems = lbc.dosConfiguration.AddOrUpdateDosProtectedResource(obj.(*v1beta1.DosProtectedResource))
 	} else {
-		glog.V(2).Infof("Deleting DosProtectedResource: %v\n", key)
+		nl.Debugf(l, "Deleting DosProtectedResource: %v\n", key)
 		changes, problems = lbc.dosConfiguration.DeleteProtectedResource(key)
 	}
 
@@ -3427,7 +3995,7 @@ func (lbc *LoadBalancerController) IsNginxReady() bool {
 func (lbc *LoadBalancerController) addInternalRouteServer() {
 	if lbc.internalRoutesEnabled {
 		if err := lbc.configurator.AddInternalRouteConfig(); err != nil {
-			glog.Warningf("failed to configure internal route server: %v", err)
+			nl.Warnf(lbc.logger, "failed to configure internal route server: %v", err)
 		}
 	}
 }
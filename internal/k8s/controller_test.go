@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -404,7 +405,7 @@ func TestFindProbeForPods(t *testing.T) {
 
 func TestGetServicePortForIngressPort(t *testing.T) {
 	fakeClient := fake.NewSimpleClientset()
-	cnf := configs.NewConfigurator(&nginx.LocalManager{}, &configs.StaticConfigParams{}, &configs.ConfigParams{}, &version1.TemplateExecutor{}, &version2.TemplateExecutor{}, false, false, nil, false, nil, false)
+	cnf := configs.NewConfigurator(&nginx.LocalManager{}, &configs.StaticConfigParams{}, &configs.ConfigParams{}, &version1.TemplateExecutor{}, &version2.TemplateExecutor{}, false, false, nil, false, nil, false, nil)
 	lbc := LoadBalancerController{
 		client:           fakeClient,
 		ingressClass:     "nginx",
@@ -2017,3 +2018,358 @@ func TestPreSyncSecrets(t *testing.T) {
 		t.Errorf("GetSecret(%q) returned a reference without an expected error", unsupportedKey)
 	}
 }
+
+func TestCanUpdateSecretWithoutReload(t *testing.T) {
+	validSecret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-secret",
+			Namespace: "default",
+		},
+		Type: api_v1.SecretTypeTLS,
+	}
+	invalidSecret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "invalid-secret",
+			Namespace: "default",
+		},
+		Type: api_v1.SecretTypeTLS,
+	}
+	opaqueSecret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "opaque-secret",
+			Namespace: "default",
+		},
+		Type: api_v1.SecretTypeOpaque,
+	}
+
+	secretStore := secrets.NewFakeSecretsStore(map[string]*secrets.SecretReference{
+		"default/cafe-secret": {
+			Secret: validSecret,
+		},
+		"default/invalid-secret": {
+			Secret: invalidSecret,
+			Error:  errors.New("invalid secret"),
+		},
+		"default/opaque-secret": {
+			Secret: opaqueSecret,
+		},
+	})
+
+	tlsIngress := createTestIngress("tls-ingress", "cafe.example.com")
+	tlsIngress.Spec.TLS = []networking.IngressTLS{{Hosts: []string{"cafe.example.com"}, SecretName: "cafe-secret"}}
+	upstreamTLSIngress := createTestIngress("upstream-tls-ingress", "cafe.example.com")
+	upstreamTLSIngress.Annotations[configs.UpstreamTLSSecretAnnotation] = "cafe-secret"
+	master := createTestIngress("master", "cafe.example.com")
+
+	tests := []struct {
+		dynamicSSLReloadEnabled bool
+		secret                  *api_v1.Secret
+		wasValid                bool
+		secretPols              []*conf_v1.Policy
+		resources               []Resource
+		expected                bool
+		msg                     string
+	}{
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                true,
+			expected:                true,
+			msg:                     "valid TLS secret without policies",
+		},
+		{
+			dynamicSSLReloadEnabled: false,
+			secret:                  validSecret,
+			wasValid:                true,
+			expected:                false,
+			msg:                     "dynamic SSL reload disabled",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                true,
+			secretPols:              []*conf_v1.Policy{{}},
+			expected:                false,
+			msg:                     "secret referenced by a policy",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                true,
+			resources:               []Resource{NewRegularIngressConfiguration(tlsIngress)},
+			expected:                true,
+			msg:                     "secret referenced by the TLS of an Ingress",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                true,
+			resources:               []Resource{NewRegularIngressConfiguration(upstreamTLSIngress)},
+			expected:                false,
+			msg:                     "secret referenced by the upstream TLS annotation of an Ingress",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                true,
+			resources:               []Resource{NewMasterIngressConfiguration(master, []*MinionConfiguration{NewMinionConfiguration(upstreamTLSIngress)}, nil)},
+			expected:                false,
+			msg:                     "secret referenced by the upstream TLS annotation of a minion",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  invalidSecret,
+			wasValid:                true,
+			expected:                false,
+			msg:                     "secret became invalid",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  validSecret,
+			wasValid:                false,
+			expected:                false,
+			msg:                     "secret was invalid",
+		},
+		{
+			dynamicSSLReloadEnabled: true,
+			secret:                  opaqueSecret,
+			wasValid:                true,
+			expected:                false,
+			msg:                     "opaque secret",
+		},
+	}
+
+	for _, test := range tests {
+		lbc := LoadBalancerController{
+			isDynamicSSLReloadEnabled: test.dynamicSSLReloadEnabled,
+			secretStore:               secretStore,
+		}
+
+		result := lbc.canUpdateSecretWithoutReload(test.secret, test.wasValid, test.secretPols, test.resources)
+		if result != test.expected {
+			t.Errorf("canUpdateSecretWithoutReload() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestIsErrorPagesConfigMap(t *testing.T) {
+	configuration := createTestConfiguration()
+	ing := createTestIngress("cafe-ingress", "cafe.example.com")
+	ing.Annotations[configs.ErrorPagesAnnotation] = "error-pages"
+	configuration.AddOrUpdateIngress(ing)
+
+	lbc := LoadBalancerController{
+		configuration:           configuration,
+		nginxConfigMapKey:       "nginx-ingress/nginx-config",
+		isLeaderElectionEnabled: true,
+		controllerNamespace:     "nginx-ingress",
+		leaderElectionLockName:  "nginx-ingress-leader-election",
+	}
+
+	tests := []struct {
+		namespace string
+		name      string
+		expected  bool
+		msg       string
+	}{
+		{
+			namespace: "default",
+			name:      "error-pages",
+			expected:  true,
+			msg:       "ConfigMap referenced by an Ingress",
+		},
+		{
+			namespace: "default",
+			name:      "other",
+			expected:  false,
+			msg:       "ConfigMap not referenced by any Ingress",
+		},
+		{
+			namespace: "nginx-ingress",
+			name:      "error-pages",
+			expected:  false,
+			msg:       "ConfigMap in another namespace",
+		},
+		{
+			namespace: "nginx-ingress",
+			name:      "nginx-config",
+			expected:  false,
+			msg:       "NGINX ConfigMap",
+		},
+		{
+			namespace: "nginx-ingress",
+			name:      "nginx-ingress-leader-election",
+			expected:  false,
+			msg:       "leader election ConfigMap",
+		},
+	}
+
+	for _, test := range tests {
+		cm := &api_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: test.namespace,
+				Name:      test.name,
+			},
+		}
+
+		result := lbc.isErrorPagesConfigMap(cm)
+		if result != test.expected {
+			t.Errorf("isErrorPagesConfigMap() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+// reloadsAvoidedCollector is a fake collector that counts the avoided NGINX reloads.
+type reloadsAvoidedCollector struct {
+	*collectors.ControllerFakeCollector
+	reloadsAvoided int
+}
+
+func (c *reloadsAvoidedCollector) IncNginxReloadsAvoided() {
+	c.reloadsAvoided++
+}
+
+func TestSyncSecretWithDynamicSSLReload(t *testing.T) {
+	tlsSecret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe-secret",
+			Namespace: "default",
+		},
+		Type: api_v1.SecretTypeTLS,
+	}
+
+	secretLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	if err := secretLister.Add(tlsSecret); err != nil {
+		t.Fatalf("Failed to add the secret: %v", err)
+	}
+
+	ing := createTestIngress("cafe-ingress", "cafe.example.com")
+	ing.Spec.TLS = []networking.IngressTLS{{Hosts: []string{"cafe.example.com"}, SecretName: "cafe-secret"}}
+	configuration := createTestConfiguration()
+	configuration.AddOrUpdateIngress(ing)
+
+	metricsCollector := &reloadsAvoidedCollector{ControllerFakeCollector: collectors.NewControllerFakeCollector()}
+
+	// the configurator is not set: the update of the secret must not regenerate the config of the Ingress
+	lbc := LoadBalancerController{
+		isDynamicSSLReloadEnabled: true,
+		secretLister:              secretLister,
+		secretStore: secrets.NewFakeSecretsStore(map[string]*secrets.SecretReference{
+			"default/cafe-secret": {
+				Secret: tlsSecret,
+			},
+		}),
+		configuration:    configuration,
+		metricsCollector: metricsCollector,
+	}
+
+	lbc.syncSecret(context.Background(), task{Kind: secret, Key: "default/cafe-secret"})
+
+	if metricsCollector.reloadsAvoided != 1 {
+		t.Errorf("syncSecret() avoided %d reloads but expected 1", metricsCollector.reloadsAvoided)
+	}
+}
+
+func TestAddLocalEndpoints(t *testing.T) {
+	nodeLister := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for name, zone := range map[string]string{"node-1": "zone-a", "node-2": "zone-b"} {
+		err := nodeLister.Add(&api_v1.Node{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{api_v1.LabelTopologyZone: zone},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to add node %s: %v", name, err)
+		}
+	}
+
+	podEndps := []podEndpoint{
+		{Address: "10.0.0.1:80", NodeName: "node-1"},
+		{Address: "10.0.0.2:80", NodeName: "node-2"},
+		{Address: "10.0.0.3:80", NodeName: "node-3"},
+		{Address: "10.0.0.4:80"},
+	}
+
+	tests := []struct {
+		controllerZone string
+		expected       map[string]bool
+		msg            string
+	}{
+		{
+			controllerZone: "zone-a",
+			expected:       map[string]bool{"10.0.0.1:80": true},
+			msg:            "controller in zone-a",
+		},
+		{
+			controllerZone: "zone-c",
+			expected:       map[string]bool{},
+			msg:            "controller in a zone without endpoints",
+		},
+		{
+			controllerZone: "",
+			expected:       map[string]bool{},
+			msg:            "topology aware routing disabled",
+		},
+	}
+
+	for _, test := range tests {
+		lbc := LoadBalancerController{
+			nodeLister:     nodeLister,
+			controllerZone: test.controllerZone,
+		}
+
+		result := make(map[string]bool)
+		lbc.addLocalEndpoints(result, podEndps)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("addLocalEndpoints() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestHasAddressesOnNode(t *testing.T) {
+	node1 := "node-1"
+	node2 := "node-2"
+	endps := &api_v1.Endpoints{
+		Subsets: []api_v1.EndpointSubset{
+			{
+				Addresses: []api_v1.EndpointAddress{
+					{IP: "10.0.0.1", NodeName: &node1},
+					{IP: "10.0.0.2"},
+				},
+				NotReadyAddresses: []api_v1.EndpointAddress{
+					{IP: "10.0.0.3", NodeName: &node2},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		nodeName string
+		expected bool
+		msg      string
+	}{
+		{
+			nodeName: "node-1",
+			expected: true,
+			msg:      "ready address on the node",
+		},
+		{
+			nodeName: "node-2",
+			expected: false,
+			msg:      "only a not ready address on the node",
+		},
+		{
+			nodeName: "node-3",
+			expected: false,
+			msg:      "no addresses on the node",
+		},
+	}
+
+	for _, test := range tests {
+		result := hasAddressesOnNode(endps, test.nodeName)
+		if result != test.expected {
+			t.Errorf("hasAddressesOnNode() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	api_v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+)
+
+// debugConfiguration is the view of the Configuration exposed by the debug endpoint.
+type debugConfiguration struct {
+	// Hosts maps hosts to the keys with kinds of the resources that own them.
+	Hosts map[string]string `json:"hosts"`
+	// Listeners maps listeners to the keys with kinds of the TransportServers that own them.
+	Listeners map[string]string `json:"listeners"`
+	Problems  []debugProblem    `json:"problems"`
+}
+
+// debugProblem is the view of a ConfigurationProblem exposed by the debug endpoint.
+type debugProblem struct {
+	Resource string `json:"resource"`
+	IsError  bool   `json:"isError"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+	// Conflicts lists the hosts and listeners of the resource owned by other resources.
+	Conflicts []debugConflict `json:"conflicts,omitempty"`
+}
+
+// debugConflict is a host or a listener of a resource owned by another resource.
+type debugConflict struct {
+	Host     string `json:"host,omitempty"`
+	Listener string `json:"listener,omitempty"`
+	Owner    string `json:"owner"`
+}
+
+// debugSecret is the view of a secret of the secret store exposed by the debug endpoint. It doesn't include the secret data.
+type debugSecret struct {
+	Key             string `json:"key"`
+	Type            string `json:"type"`
+	ResourceVersion string `json:"resourceVersion"`
+	Path            string `json:"path,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// debugReferences lists the resources referenced by an Ingress, VirtualServer or TransportServer resource,
+// including the references of its minions and VirtualServerRoutes.
+type debugReferences struct {
+	Services []string `json:"services,omitempty"`
+	Secrets  []string `json:"secrets,omitempty"`
+	Policies []string `json:"policies,omitempty"`
+}
+
+// getDebugConfiguration returns the view of the Configuration for the debug endpoint.
+func (c *Configuration) getDebugConfiguration() debugConfiguration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := debugConfiguration{
+		Hosts:     make(map[string]string),
+		Listeners: make(map[string]string),
+		Problems:  []debugProblem{},
+	}
+
+	for host, r := range c.hosts {
+		result.Hosts[host] = r.GetKeyWithKind()
+	}
+
+	for listener, tsc := range c.listeners {
+		result.Listeners[listener] = tsc.GetKeyWithKind()
+	}
+
+	for _, problems := range []map[string]ConfigurationProblem{c.hostProblems, c.listenerProblems} {
+		for _, key := range getSortedProblemKeys(problems) {
+			p := problems[key]
+			result.Problems = append(result.Problems, debugProblem{
+				Resource:  key,
+				IsError:   p.IsError,
+				Reason:    p.Reason,
+				Message:   p.Message,
+				Conflicts: c.findConflicts(key, p),
+			})
+		}
+	}
+
+	return result
+}
+
+// findConflicts finds the hosts and listeners of the resource of the problem that are owned by other resources.
+func (c *Configuration) findConflicts(key string, problem ConfigurationProblem) []debugConflict {
+	var hosts []string
+	var listener string
+
+	switch obj := problem.Object.(type) {
+	case *networking.Ingress:
+		for _, rule := range obj.Spec.Rules {
+			hosts = append(hosts, rule.Host)
+		}
+	case *conf_v1.VirtualServer:
+		hosts = append(hosts, obj.Spec.Host)
+		hosts = append(hosts, obj.Spec.Aliases...)
+	case *conf_v1alpha1.TransportServer:
+		if obj.Spec.Host != "" {
+			hosts = append(hosts, obj.Spec.Host)
+		} else {
+			listener = obj.Spec.Listener.Name
+		}
+	}
+
+	var conflicts []debugConflict
+
+	for _, h := range hosts {
+		if owner, exists := c.hosts[h]; exists && owner.GetKeyWithKind() != key {
+			conflicts = append(conflicts, debugConflict{Host: h, Owner: owner.GetKeyWithKind()})
+		}
+	}
+
+	if owner, exists := c.listeners[listener]; listener != "" && exists && owner.GetKeyWithKind() != key {
+		conflicts = append(conflicts, debugConflict{Listener: listener, Owner: owner.GetKeyWithKind()})
+	}
+
+	return conflicts
+}
+
+// DebugHandler returns the handler of the debug endpoint, which exposes the in-memory model of the Ingress Controller as JSON:
+// /debug/configuration - the hosts, the listeners and the problems of the Configuration.
+// /debug/secrets - the metadata of the secrets of the secret store.
+// /debug/references - the Services, secrets and Policies referenced by the resources.
+// /debug/config?kind=<kind>&namespace=<namespace>&name=<name> - the generated NGINX config of an Ingress, VirtualServer or TransportServer.
+func (lbc *LoadBalancerController) DebugHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeDebugJSON(w, lbc.configuration.getDebugConfiguration())
+	})
+	mux.HandleFunc("/debug/secrets", func(w http.ResponseWriter, _ *http.Request) {
+		writeDebugJSON(w, lbc.getDebugSecrets())
+	})
+	mux.HandleFunc("/debug/references", func(w http.ResponseWriter, _ *http.Request) {
+		writeDebugJSON(w, lbc.getDebugReferences())
+	})
+	mux.HandleFunc("/debug/config", lbc.handleDebugConfig)
+
+	return mux
+}
+
+func (lbc *LoadBalancerController) getDebugSecrets() []debugSecret {
+	// the secret store is not safe for concurrent use
+	lbc.syncLock.Lock()
+	secretRefs := lbc.secretStore.GetSecrets()
+	lbc.syncLock.Unlock()
+
+	keys := make([]string, 0, len(secretRefs))
+	for k := range secretRefs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]debugSecret, 0, len(keys))
+
+	for _, k := range keys {
+		ref := secretRefs[k]
+
+		s := debugSecret{
+			Key:  k,
+			Path: ref.Path,
+		}
+		if ref.Secret != nil {
+			s.Type = string(ref.Secret.Type)
+			s.ResourceVersion = ref.Secret.ResourceVersion
+		}
+		if ref.Error != nil {
+			s.Error = ref.Error.Error()
+		}
+
+		result = append(result, s)
+	}
+
+	return result
+}
+
+func (lbc *LoadBalancerController) getDebugReferences() map[string]*debugReferences {
+	result := make(map[string]*debugReferences)
+
+	getReferences := func(r Resource) *debugReferences {
+		key := r.GetKeyWithKind()
+		if _, exists := result[key]; !exists {
+			result[key] = &debugReferences{}
+		}
+		return result[key]
+	}
+
+	for _, obj := range lbc.svcLister.List() {
+		svc := obj.(*api_v1.Service)
+		for _, r := range lbc.configuration.FindResourcesForService(svc.Namespace, svc.Name) {
+			refs := getReferences(r)
+			refs.Services = append(refs.Services, getResourceKey(&svc.ObjectMeta))
+		}
+	}
+
+	for _, obj := range lbc.secretLister.List() {
+		secret := obj.(*api_v1.Secret)
+		for _, r := range lbc.configuration.FindResourcesForSecret(secret.Namespace, secret.Name) {
+			refs := getReferences(r)
+			refs.Secrets = append(refs.Secrets, getResourceKey(&secret.ObjectMeta))
+		}
+	}
+
+	if lbc.policyLister != nil {
+		for _, obj := range lbc.policyLister.List() {
+			pol := obj.(*conf_v1.Policy)
+			for _, r := range lbc.configuration.FindResourcesForPolicy(pol.Namespace, pol.Name) {
+				refs := getReferences(r)
+				refs.Policies = append(refs.Policies, getResourceKey(&pol.ObjectMeta))
+			}
+		}
+	}
+
+	for _, refs := range result {
+		sort.Strings(refs.Services)
+		sort.Strings(refs.Secrets)
+		sort.Strings(refs.Policies)
+	}
+
+	return result
+}
+
+func (lbc *LoadBalancerController) handleDebugConfig(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	kind := query.Get("kind")
+	namespace := query.Get("namespace")
+	name := query.Get("name")
+
+	if namespace == "" || name == "" {
+		http.Error(w, "the namespace and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	key := fmt.Sprintf("%s/%s", namespace, name)
+
+	// the configurator is not safe for concurrent use
+	lbc.syncLock.Lock()
+	defer lbc.syncLock.Unlock()
+
+	var content []byte
+	var err error
+
+	switch kind {
+	case ingressKind:
+		content, err = lbc.configurator.GetIngressConfig(key)
+	case virtualServerKind:
+		content, err = lbc.configurator.GetVirtualServerConfig(key)
+	case transportServerKind:
+		content, err = lbc.configurator.GetTransportServerConfig(key)
+	default:
+		http.Error(w, fmt.Sprintf("the kind query parameter must be one of %s, %s or %s", ingressKind, virtualServerKind, transportServerKind),
+			http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("no config for %s %s: %v", kind, key, err), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write(content); err != nil {
+		nl.Errorf(lbc.logger, "Error writing the debug response: %v", err)
+	}
+}
+
+func writeDebugJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		nl.Errorf(slog.Default(), "Error writing the debug response: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetDebugConfiguration(t *testing.T) {
+	configuration := createTestConfiguration()
+
+	vs := createTestVirtualServer("cafe", "cafe.example.com")
+	vs.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	configuration.AddOrUpdateVirtualServer(vs)
+
+	rejectedVS := createTestVirtualServer("cafe-copy", "cafe.example.com")
+	configuration.AddOrUpdateVirtualServer(rejectedVS)
+
+	ing := createTestIngress("tea", "tea.example.com", "cafe.example.com")
+	configuration.AddOrUpdateIngress(ing)
+
+	expected := debugConfiguration{
+		Hosts: map[string]string{
+			"cafe.example.com": "VirtualServer/default/cafe",
+			"tea.example.com":  "Ingress/default/tea",
+		},
+		Listeners: map[string]string{},
+		Problems: []debugProblem{
+			{
+				Resource: "VirtualServer/default/cafe-copy",
+				Reason:   "Rejected",
+				Message:  "Host is taken by another resource",
+				Conflicts: []debugConflict{
+					{
+						Host:  "cafe.example.com",
+						Owner: "VirtualServer/default/cafe",
+					},
+				},
+			},
+		},
+	}
+
+	result := configuration.getDebugConfiguration()
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Errorf("getDebugConfiguration() returned unexpected result (-want +got):\n%s", diff)
+	}
+}
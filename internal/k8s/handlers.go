@@ -2,12 +2,12 @@ package k8s
 
 import (
 	"fmt"
+	"log/slog"
 	"reflect"
 	"sort"
 
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/dos/v1beta1"
 
-	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/secrets"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
@@ -16,6 +16,7 @@ import (
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -25,7 +26,7 @@ func createConfigMapHandlers(lbc *LoadBalancerController, name string) cache.Res
 		AddFunc: func(obj interface{}) {
 			configMap := obj.(*v1.ConfigMap)
 			if configMap.Name == name {
-				glog.V(3).Infof("Adding ConfigMap: %v", configMap.Name)
+				nl.Tracef(lbc.logger, "Adding ConfigMap: %v", configMap.Name)
 				lbc.AddSyncQueue(obj)
 			}
 		},
@@ -34,17 +35,17 @@ func createConfigMapHandlers(lbc *LoadBalancerController, name string) cache.Res
 			if !isConfigMap {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				configMap, ok = deletedState.Obj.(*v1.ConfigMap)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-ConfigMap object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-ConfigMap object: %v", deletedState.Obj)
 					return
 				}
 			}
 			if configMap.Name == name {
-				glog.V(3).Infof("Removing ConfigMap: %v", configMap.Name)
+				nl.Tracef(lbc.logger, "Removing ConfigMap: %v", configMap.Name)
 				lbc.AddSyncQueue(obj)
 			}
 		},
@@ -52,7 +53,7 @@ func createConfigMapHandlers(lbc *LoadBalancerController, name string) cache.Res
 			if !reflect.DeepEqual(old, cur) {
 				configMap := cur.(*v1.ConfigMap)
 				if configMap.Name == name {
-					glog.V(3).Infof("ConfigMap %v changed, syncing", cur.(*v1.ConfigMap).Name)
+					nl.Tracef(lbc.logger, "ConfigMap %v changed, syncing", cur.(*v1.ConfigMap).Name)
 					lbc.AddSyncQueue(cur)
 				}
 			}
@@ -60,12 +61,94 @@ func createConfigMapHandlers(lbc *LoadBalancerController, name string) cache.Res
 	}
 }
 
+// createErrorPagesConfigMapHandlers builds the handler funcs for config maps with custom error pages.
+// Only the ConfigMaps referenced by Ingresses are synced.
+func createErrorPagesConfigMapHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			configMap := obj.(*v1.ConfigMap)
+			if !lbc.isErrorPagesConfigMap(configMap) {
+				return
+			}
+			nl.Tracef(lbc.logger, "Adding ConfigMap: %v", configMap.Name)
+			lbc.AddSyncQueue(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			configMap, isConfigMap := obj.(*v1.ConfigMap)
+			if !isConfigMap {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				configMap, ok = deletedState.Obj.(*v1.ConfigMap)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-ConfigMap object: %v", deletedState.Obj)
+					return
+				}
+			}
+			if !lbc.isErrorPagesConfigMap(configMap) {
+				return
+			}
+			nl.Tracef(lbc.logger, "Removing ConfigMap: %v", configMap.Name)
+			lbc.AddSyncQueue(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			configMap := cur.(*v1.ConfigMap)
+			if !lbc.isErrorPagesConfigMap(configMap) {
+				return
+			}
+			if !reflect.DeepEqual(old, cur) {
+				nl.Tracef(lbc.logger, "ConfigMap %v changed, syncing", configMap.Name)
+				lbc.AddSyncQueue(cur)
+			}
+		},
+	}
+}
+
+// createNodeHandlers builds the handler funcs for nodes. The zone of a node determines which endpoints are in the zone
+// of the Ingress Controller, so the endpoints on a node are synced when the node appears, disappears or changes its zone.
+func createNodeHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			node := obj.(*v1.Node)
+			nl.Tracef(lbc.logger, "Adding node: %v", node.Name)
+			lbc.syncEndpointsForNode(node.Name)
+		},
+		DeleteFunc: func(obj interface{}) {
+			node, isNode := obj.(*v1.Node)
+			if !isNode {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				node, ok = deletedState.Obj.(*v1.Node)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Node object: %v", deletedState.Obj)
+					return
+				}
+			}
+			nl.Tracef(lbc.logger, "Removing node: %v", node.Name)
+			lbc.syncEndpointsForNode(node.Name)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			oldNode := old.(*v1.Node)
+			curNode := cur.(*v1.Node)
+			if oldNode.Labels[v1.LabelTopologyZone] != curNode.Labels[v1.LabelTopologyZone] {
+				nl.Tracef(lbc.logger, "Zone of node %v changed, syncing its endpoints", curNode.Name)
+				lbc.syncEndpointsForNode(curNode.Name)
+			}
+		},
+	}
+}
+
 // createEndpointHandlers builds the handler funcs for endpoints
 func createEndpointHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			endpoint := obj.(*v1.Endpoints)
-			glog.V(3).Infof("Adding endpoints: %v", endpoint.Name)
+			nl.Tracef(lbc.logger, "Adding endpoints: %v", endpoint.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -73,21 +156,21 @@ func createEndpointHandlers(lbc *LoadBalancerController) cache.ResourceEventHand
 			if !isEndpoint {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				endpoint, ok = deletedState.Obj.(*v1.Endpoints)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Endpoints object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Endpoints object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing endpoints: %v", endpoint.Name)
+			nl.Tracef(lbc.logger, "Removing endpoints: %v", endpoint.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			if !reflect.DeepEqual(old, cur) {
-				glog.V(3).Infof("Endpoints %v changed, syncing", cur.(*v1.Endpoints).Name)
+				nl.Tracef(lbc.logger, "Endpoints %v changed, syncing", cur.(*v1.Endpoints).Name)
 				lbc.AddSyncQueue(cur)
 			}
 		},
@@ -99,7 +182,7 @@ func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			ingress := obj.(*networking.Ingress)
-			glog.V(3).Infof("Adding Ingress: %v", ingress.Name)
+			nl.Tracef(lbc.logger, "Adding Ingress: %v", ingress.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -107,23 +190,23 @@ func createIngressHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 			if !isIng {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				ingress, ok = deletedState.Obj.(*networking.Ingress)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Ingress object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Ingress object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing Ingress: %v", ingress.Name)
+			nl.Tracef(lbc.logger, "Removing Ingress: %v", ingress.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		UpdateFunc: func(old, current interface{}) {
 			c := current.(*networking.Ingress)
 			o := old.(*networking.Ingress)
 			if hasChanges(o, c) {
-				glog.V(3).Infof("Ingress %v changed, syncing", c.Name)
+				nl.Tracef(lbc.logger, "Ingress %v changed, syncing", c.Name)
 				lbc.AddSyncQueue(c)
 			}
 		},
@@ -136,10 +219,10 @@ func createSecretHandlers(lbc *LoadBalancerController) cache.ResourceEventHandle
 		AddFunc: func(obj interface{}) {
 			secret := obj.(*v1.Secret)
 			if !secrets.IsSupportedSecretType(secret.Type) {
-				glog.V(3).Infof("Ignoring Secret %v of unsupported type %v", secret.Name, secret.Type)
+				nl.Tracef(lbc.logger, "Ignoring Secret %v of unsupported type %v", secret.Name, secret.Type)
 				return
 			}
-			glog.V(3).Infof("Adding Secret: %v", secret.Name)
+			nl.Tracef(lbc.logger, "Adding Secret: %v", secret.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -147,33 +230,33 @@ func createSecretHandlers(lbc *LoadBalancerController) cache.ResourceEventHandle
 			if !isSecr {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				secret, ok = deletedState.Obj.(*v1.Secret)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Secret object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Secret object: %v", deletedState.Obj)
 					return
 				}
 			}
 			if !secrets.IsSupportedSecretType(secret.Type) {
-				glog.V(3).Infof("Ignoring Secret %v of unsupported type %v", secret.Name, secret.Type)
+				nl.Tracef(lbc.logger, "Ignoring Secret %v of unsupported type %v", secret.Name, secret.Type)
 				return
 			}
 
-			glog.V(3).Infof("Removing Secret: %v", secret.Name)
+			nl.Tracef(lbc.logger, "Removing Secret: %v", secret.Name)
 			lbc.AddSyncQueue(obj)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			// A secret cannot change its type. That's why we only need to check the type of the current secret.
 			curSecret := cur.(*v1.Secret)
 			if !secrets.IsSupportedSecretType(curSecret.Type) {
-				glog.V(3).Infof("Ignoring Secret %v of unsupported type %v", curSecret.Name, curSecret.Type)
+				nl.Tracef(lbc.logger, "Ignoring Secret %v of unsupported type %v", curSecret.Name, curSecret.Type)
 				return
 			}
 
 			if !reflect.DeepEqual(old, cur) {
-				glog.V(3).Infof("Secret %v changed, syncing", cur.(*v1.Secret).Name)
+				nl.Tracef(lbc.logger, "Secret %v changed, syncing", cur.(*v1.Secret).Name)
 				lbc.AddSyncQueue(cur)
 			}
 		},
@@ -194,7 +277,7 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 		AddFunc: func(obj interface{}) {
 			svc := obj.(*v1.Service)
 
-			glog.V(3).Infof("Adding service: %v", svc.Name)
+			nl.Tracef(lbc.logger, "Adding service: %v", svc.Name)
 			lbc.AddSyncQueue(svc)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -202,17 +285,17 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 			if !isSvc {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				svc, ok = deletedState.Obj.(*v1.Service)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Service object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Service object: %v", deletedState.Obj)
 					return
 				}
 			}
 
-			glog.V(3).Infof("Removing service: %v", svc.Name)
+			nl.Tracef(lbc.logger, "Removing service: %v", svc.Name)
 			lbc.AddSyncQueue(svc)
 		},
 		UpdateFunc: func(old, cur interface{}) {
@@ -224,7 +307,7 @@ func createServiceHandlers(lbc *LoadBalancerController) cache.ResourceEventHandl
 				}
 				oldSvc := old.(*v1.Service)
 				if hasServiceChanges(oldSvc, curSvc) {
-					glog.V(3).Infof("Service %v changed, syncing", curSvc.Name)
+					nl.Tracef(lbc.logger, "Service %v changed, syncing", curSvc.Name)
 					lbc.AddSyncQueue(curSvc)
 				}
 			}
@@ -287,7 +370,7 @@ func createVirtualServerHandlers(lbc *LoadBalancerController) cache.ResourceEven
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			vs := obj.(*conf_v1.VirtualServer)
-			glog.V(3).Infof("Adding VirtualServer: %v", vs.Name)
+			nl.Tracef(lbc.logger, "Adding VirtualServer: %v", vs.Name)
 			lbc.AddSyncQueue(vs)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -295,23 +378,23 @@ func createVirtualServerHandlers(lbc *LoadBalancerController) cache.ResourceEven
 			if !isVs {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				vs, ok = deletedState.Obj.(*conf_v1.VirtualServer)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-VirtualServer object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-VirtualServer object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing VirtualServer: %v", vs.Name)
+			nl.Tracef(lbc.logger, "Removing VirtualServer: %v", vs.Name)
 			lbc.AddSyncQueue(vs)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curVs := cur.(*conf_v1.VirtualServer)
 			oldVs := old.(*conf_v1.VirtualServer)
 			if !reflect.DeepEqual(oldVs.Spec, curVs.Spec) {
-				glog.V(3).Infof("VirtualServer %v changed, syncing", curVs.Name)
+				nl.Tracef(lbc.logger, "VirtualServer %v changed, syncing", curVs.Name)
 				lbc.AddSyncQueue(curVs)
 			}
 		},
@@ -322,7 +405,7 @@ func createVirtualServerRouteHandlers(lbc *LoadBalancerController) cache.Resourc
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			vsr := obj.(*conf_v1.VirtualServerRoute)
-			glog.V(3).Infof("Adding VirtualServerRoute: %v", vsr.Name)
+			nl.Tracef(lbc.logger, "Adding VirtualServerRoute: %v", vsr.Name)
 			lbc.AddSyncQueue(vsr)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -330,23 +413,23 @@ func createVirtualServerRouteHandlers(lbc *LoadBalancerController) cache.Resourc
 			if !isVsr {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				vsr, ok = deletedState.Obj.(*conf_v1.VirtualServerRoute)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-VirtualServerRoute object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-VirtualServerRoute object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing VirtualServerRoute: %v", vsr.Name)
+			nl.Tracef(lbc.logger, "Removing VirtualServerRoute: %v", vsr.Name)
 			lbc.AddSyncQueue(vsr)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curVsr := cur.(*conf_v1.VirtualServerRoute)
 			oldVsr := old.(*conf_v1.VirtualServerRoute)
 			if !reflect.DeepEqual(oldVsr.Spec, curVsr.Spec) {
-				glog.V(3).Infof("VirtualServerRoute %v changed, syncing", curVsr.Name)
+				nl.Tracef(lbc.logger, "VirtualServerRoute %v changed, syncing", curVsr.Name)
 				lbc.AddSyncQueue(curVsr)
 			}
 		},
@@ -357,7 +440,7 @@ func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.Resour
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			gc := obj.(*conf_v1alpha1.GlobalConfiguration)
-			glog.V(3).Infof("Adding GlobalConfiguration: %v", gc.Name)
+			nl.Tracef(lbc.logger, "Adding GlobalConfiguration: %v", gc.Name)
 			lbc.AddSyncQueue(gc)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -365,22 +448,22 @@ func createGlobalConfigurationHandlers(lbc *LoadBalancerController) cache.Resour
 			if !isGc {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				gc, ok = deletedState.Obj.(*conf_v1alpha1.GlobalConfiguration)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-GlobalConfiguration object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-GlobalConfiguration object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing GlobalConfiguration: %v", gc.Name)
+			nl.Tracef(lbc.logger, "Removing GlobalConfiguration: %v", gc.Name)
 			lbc.AddSyncQueue(gc)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curGc := cur.(*conf_v1alpha1.GlobalConfiguration)
 			if !reflect.DeepEqual(old, cur) {
-				glog.V(3).Infof("GlobalConfiguration %v changed, syncing", curGc.Name)
+				nl.Tracef(lbc.logger, "GlobalConfiguration %v changed, syncing", curGc.Name)
 				lbc.AddSyncQueue(curGc)
 			}
 		},
@@ -391,7 +474,7 @@ func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEv
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			ts := obj.(*conf_v1alpha1.TransportServer)
-			glog.V(3).Infof("Adding TransportServer: %v", ts.Name)
+			nl.Tracef(lbc.logger, "Adding TransportServer: %v", ts.Name)
 			lbc.AddSyncQueue(ts)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -399,22 +482,22 @@ func createTransportServerHandlers(lbc *LoadBalancerController) cache.ResourceEv
 			if !isTs {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				ts, ok = deletedState.Obj.(*conf_v1alpha1.TransportServer)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-TransportServer object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-TransportServer object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing TransportServer: %v", ts.Name)
+			nl.Tracef(lbc.logger, "Removing TransportServer: %v", ts.Name)
 			lbc.AddSyncQueue(ts)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curTs := cur.(*conf_v1alpha1.TransportServer)
 			if !reflect.DeepEqual(old, cur) {
-				glog.V(3).Infof("TransportServer %v changed, syncing", curTs.Name)
+				nl.Tracef(lbc.logger, "TransportServer %v changed, syncing", curTs.Name)
 				lbc.AddSyncQueue(curTs)
 			}
 		},
@@ -425,7 +508,7 @@ func createPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandle
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pol := obj.(*conf_v1.Policy)
-			glog.V(3).Infof("Adding Policy: %v", pol.Name)
+			nl.Tracef(lbc.logger, "Adding Policy: %v", pol.Name)
 			lbc.AddSyncQueue(pol)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -433,34 +516,69 @@ func createPolicyHandlers(lbc *LoadBalancerController) cache.ResourceEventHandle
 			if !isPol {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				pol, ok = deletedState.Obj.(*conf_v1.Policy)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Policy object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Policy object: %v", deletedState.Obj)
 					return
 				}
 			}
-			glog.V(3).Infof("Removing Policy: %v", pol.Name)
+			nl.Tracef(lbc.logger, "Removing Policy: %v", pol.Name)
 			lbc.AddSyncQueue(pol)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			curPol := cur.(*conf_v1.Policy)
 			oldPol := old.(*conf_v1.Policy)
 			if !reflect.DeepEqual(oldPol.Spec, curPol.Spec) {
-				glog.V(3).Infof("Policy %v changed, syncing", curPol.Name)
+				nl.Tracef(lbc.logger, "Policy %v changed, syncing", curPol.Name)
 				lbc.AddSyncQueue(curPol)
 			}
 		},
 	}
 }
 
+func createStaticResponseHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			sr := obj.(*conf_v1alpha1.StaticResponse)
+			nl.Tracef(lbc.logger, "Adding StaticResponse: %v", sr.Name)
+			lbc.AddSyncQueue(sr)
+		},
+		DeleteFunc: func(obj interface{}) {
+			sr, isSr := obj.(*conf_v1alpha1.StaticResponse)
+			if !isSr {
+				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
+					return
+				}
+				sr, ok = deletedState.Obj.(*conf_v1alpha1.StaticResponse)
+				if !ok {
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-StaticResponse object: %v", deletedState.Obj)
+					return
+				}
+			}
+			nl.Tracef(lbc.logger, "Removing StaticResponse: %v", sr.Name)
+			lbc.AddSyncQueue(sr)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			curSr := cur.(*conf_v1alpha1.StaticResponse)
+			oldSr := old.(*conf_v1alpha1.StaticResponse)
+			if !reflect.DeepEqual(oldSr.Spec, curSr.Spec) {
+				nl.Tracef(lbc.logger, "StaticResponse %v changed, syncing", curSr.Name)
+				lbc.AddSyncQueue(curSr)
+			}
+		},
+	}
+}
+
 func createIngressLinkHandlers(lbc *LoadBalancerController) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			link := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding IngressLink: %v", link.GetName())
+			nl.Tracef(lbc.logger, "Adding IngressLink: %v", link.GetName())
 			lbc.AddSyncQueue(link)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -469,17 +587,17 @@ func createIngressLinkHandlers(lbc *LoadBalancerController) cache.ResourceEventH
 			if !isUnstructured {
 				deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
 				if !ok {
-					glog.V(3).Infof("Error received unexpected object: %v", obj)
+					nl.Tracef(lbc.logger, "Error received unexpected object: %v", obj)
 					return
 				}
 				link, ok = deletedState.Obj.(*unstructured.Unstructured)
 				if !ok {
-					glog.V(3).Infof("Error DeletedFinalStateUnknown contained non-Unstructured object: %v", deletedState.Obj)
+					nl.Tracef(lbc.logger, "Error DeletedFinalStateUnknown contained non-Unstructured object: %v", deletedState.Obj)
 					return
 				}
 			}
 
-			glog.V(3).Infof("Removing IngressLink: %v", link.GetName())
+			nl.Tracef(lbc.logger, "Removing IngressLink: %v", link.GetName())
 			lbc.AddSyncQueue(link)
 		},
 		UpdateFunc: func(old, cur interface{}) {
@@ -487,11 +605,11 @@ func createIngressLinkHandlers(lbc *LoadBalancerController) cache.ResourceEventH
 			curLink := cur.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldLink, curLink)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing IngressLinks: %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing IngressLinks: %v", err)
 				lbc.AddSyncQueue(curLink)
 			}
 			if different {
-				glog.V(3).Infof("IngressLink %v changed, syncing", oldLink.GetName())
+				nl.Tracef(lbc.logger, "IngressLink %v changed, syncing", oldLink.GetName())
 				lbc.AddSyncQueue(curLink)
 			}
 		},
@@ -502,7 +620,7 @@ func createAppProtectPolicyHandlers(lbc *LoadBalancerController) cache.ResourceE
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pol := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding AppProtectPolicy: %v", pol.GetName())
+			nl.Tracef(lbc.logger, "Adding AppProtectPolicy: %v", pol.GetName())
 			lbc.AddSyncQueue(pol)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -510,11 +628,11 @@ func createAppProtectPolicyHandlers(lbc *LoadBalancerController) cache.ResourceE
 			newPol := obj.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldPol, newPol)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing policy %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing policy %v", err)
 				lbc.AddSyncQueue(newPol)
 			}
 			if different {
-				glog.V(3).Infof("ApPolicy %v changed, syncing", oldPol.GetName())
+				nl.Tracef(lbc.logger, "ApPolicy %v changed, syncing", oldPol.GetName())
 				lbc.AddSyncQueue(newPol)
 			}
 		},
@@ -529,7 +647,7 @@ func createAppProtectPolicyHandlers(lbc *LoadBalancerController) cache.ResourceE
 func areResourcesDifferent(oldresource, resource *unstructured.Unstructured) (bool, error) {
 	oldSpec, found, err := unstructured.NestedMap(oldresource.Object, "spec")
 	if !found {
-		glog.V(3).Infof("Warning, oldspec has unexpected format")
+		nl.Tracef(slog.Default(), "Warning, oldspec has unexpected format")
 	}
 	if err != nil {
 		return false, err
@@ -543,7 +661,7 @@ func areResourcesDifferent(oldresource, resource *unstructured.Unstructured) (bo
 	}
 	eq := reflect.DeepEqual(oldSpec, spec)
 	if eq {
-		glog.V(3).Infof("New spec of %v same as old spec", oldresource.GetName())
+		nl.Tracef(slog.Default(), "New spec of %v same as old spec", oldresource.GetName())
 	}
 	return !eq, nil
 }
@@ -552,7 +670,7 @@ func createAppProtectLogConfHandlers(lbc *LoadBalancerController) cache.Resource
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			conf := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding AppProtectLogConf: %v", conf.GetName())
+			nl.Tracef(lbc.logger, "Adding AppProtectLogConf: %v", conf.GetName())
 			lbc.AddSyncQueue(conf)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -560,11 +678,11 @@ func createAppProtectLogConfHandlers(lbc *LoadBalancerController) cache.Resource
 			newConf := obj.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldConf, newConf)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing LogConfs %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing LogConfs %v", err)
 				lbc.AddSyncQueue(newConf)
 			}
 			if different {
-				glog.V(3).Infof("ApLogConf %v changed, syncing", oldConf.GetName())
+				nl.Tracef(lbc.logger, "ApLogConf %v changed, syncing", oldConf.GetName())
 				lbc.AddSyncQueue(newConf)
 			}
 		},
@@ -579,7 +697,7 @@ func createAppProtectUserSigHandlers(lbc *LoadBalancerController) cache.Resource
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			sig := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding AppProtectUserSig: %v", sig.GetName())
+			nl.Tracef(lbc.logger, "Adding AppProtectUserSig: %v", sig.GetName())
 			lbc.AddSyncQueue(sig)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -587,11 +705,11 @@ func createAppProtectUserSigHandlers(lbc *LoadBalancerController) cache.Resource
 			newSig := obj.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldSig, newSig)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing UserSigs %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing UserSigs %v", err)
 				lbc.AddSyncQueue(newSig)
 			}
 			if different {
-				glog.V(3).Infof("ApUserSig %v changed, syncing", oldSig.GetName())
+				nl.Tracef(lbc.logger, "ApUserSig %v changed, syncing", oldSig.GetName())
 				lbc.AddSyncQueue(newSig)
 			}
 		},
@@ -606,7 +724,7 @@ func createAppProtectDosPolicyHandlers(lbc *LoadBalancerController) cache.Resour
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pol := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding AppProtectDosPolicy: %v", pol.GetName())
+			nl.Tracef(lbc.logger, "Adding AppProtectDosPolicy: %v", pol.GetName())
 			lbc.AddSyncQueue(pol)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -614,11 +732,11 @@ func createAppProtectDosPolicyHandlers(lbc *LoadBalancerController) cache.Resour
 			newPol := obj.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldPol, newPol)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing policy %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing policy %v", err)
 				lbc.AddSyncQueue(newPol)
 			}
 			if different {
-				glog.V(3).Infof("ApDosPolicy %v changed, syncing", oldPol.GetName())
+				nl.Tracef(lbc.logger, "ApDosPolicy %v changed, syncing", oldPol.GetName())
 				lbc.AddSyncQueue(newPol)
 			}
 		},
@@ -633,7 +751,7 @@ func createAppProtectDosLogConfHandlers(lbc *LoadBalancerController) cache.Resou
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			conf := obj.(*unstructured.Unstructured)
-			glog.V(3).Infof("Adding AppProtectDosLogConf: %v", conf.GetName())
+			nl.Tracef(lbc.logger, "Adding AppProtectDosLogConf: %v", conf.GetName())
 			lbc.AddSyncQueue(conf)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -641,11 +759,11 @@ func createAppProtectDosLogConfHandlers(lbc *LoadBalancerController) cache.Resou
 			newConf := obj.(*unstructured.Unstructured)
 			different, err := areResourcesDifferent(oldConf, newConf)
 			if err != nil {
-				glog.V(3).Infof("Error when comparing DosLogConfs %v", err)
+				nl.Tracef(lbc.logger, "Error when comparing DosLogConfs %v", err)
 				lbc.AddSyncQueue(newConf)
 			}
 			if different {
-				glog.V(3).Infof("ApDosLogConf %v changed, syncing", oldConf.GetName())
+				nl.Tracef(lbc.logger, "ApDosLogConf %v changed, syncing", oldConf.GetName())
 				lbc.AddSyncQueue(newConf)
 			}
 		},
@@ -660,7 +778,7 @@ func createAppProtectDosProtectedResourceHandlers(lbc *LoadBalancerController) c
 	handlers := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			conf := obj.(*v1beta1.DosProtectedResource)
-			glog.V(3).Infof("Adding DosProtectedResource: %v", conf.GetName())
+			nl.Tracef(lbc.logger, "Adding DosProtectedResource: %v", conf.GetName())
 			lbc.AddSyncQueue(conf)
 		},
 		UpdateFunc: func(oldObj, obj interface{}) {
@@ -668,7 +786,7 @@ func createAppProtectDosProtectedResourceHandlers(lbc *LoadBalancerController) c
 			newConf := obj.(*v1beta1.DosProtectedResource)
 
 			if !reflect.DeepEqual(oldConf.Spec, newConf.Spec) {
-				glog.V(3).Infof("DosProtectedResource %v changed, syncing", oldConf.GetName())
+				nl.Tracef(lbc.logger, "DosProtectedResource %v changed, syncing", oldConf.GetName())
 				lbc.AddSyncQueue(newConf)
 			}
 		},
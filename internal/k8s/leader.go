@@ -5,8 +5,7 @@ import (
 	"os"
 	"time"
 
-	"github.com/golang/glog"
-
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -49,44 +48,44 @@ func newLeaderElector(client kubernetes.Interface, callbacks leaderelection.Lead
 func createLeaderHandler(lbc *LoadBalancerController) leaderelection.LeaderCallbacks {
 	return leaderelection.LeaderCallbacks{
 		OnStartedLeading: func(ctx context.Context) {
-			glog.V(3).Info("started leading")
+			nl.Trace(lbc.logger, "started leading")
 			if lbc.reportIngressStatus {
 				ingresses := lbc.configuration.GetResourcesWithFilter(resourceFilter{Ingresses: true})
 
-				glog.V(3).Infof("Updating status for %v Ingresses", len(ingresses))
+				nl.Tracef(lbc.logger, "Updating status for %v Ingresses", len(ingresses))
 
 				err := lbc.statusUpdater.UpdateExternalEndpointsForResources(ingresses)
 				if err != nil {
-					glog.V(3).Infof("error updating status when starting leading: %v", err)
+					nl.Tracef(lbc.logger, "error updating status when starting leading: %v", err)
 				}
 			}
 
 			if lbc.areCustomResourcesEnabled {
-				glog.V(3).Info("updating VirtualServer and VirtualServerRoutes status")
+				nl.Trace(lbc.logger, "updating VirtualServer and VirtualServerRoutes status")
 
 				err := lbc.updateVirtualServersStatusFromEvents()
 				if err != nil {
-					glog.V(3).Infof("error updating VirtualServers status when starting leading: %v", err)
+					nl.Tracef(lbc.logger, "error updating VirtualServers status when starting leading: %v", err)
 				}
 
 				err = lbc.updateVirtualServerRoutesStatusFromEvents()
 				if err != nil {
-					glog.V(3).Infof("error updating VirtualServerRoutes status when starting leading: %v", err)
+					nl.Tracef(lbc.logger, "error updating VirtualServerRoutes status when starting leading: %v", err)
 				}
 
 				err = lbc.updatePoliciesStatus()
 				if err != nil {
-					glog.V(3).Infof("error updating Policies status when starting leading: %v", err)
+					nl.Tracef(lbc.logger, "error updating Policies status when starting leading: %v", err)
 				}
 
 				err = lbc.updateTransportServersStatusFromEvents()
 				if err != nil {
-					glog.V(3).Infof("error updating TransportServers status when starting leading: %v", err)
+					nl.Tracef(lbc.logger, "error updating TransportServers status when starting leading: %v", err)
 				}
 			}
 		},
 		OnStoppedLeading: func() {
-			glog.V(3).Info("stopped leading")
+			nl.Trace(lbc.logger, "stopped leading")
 		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration"
 	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	networking "k8s.io/api/networking/v1"
@@ -44,7 +45,7 @@ func (rc *secretReferenceChecker) IsReferencedByIngress(secretNamespace string,
 		}
 	}
 
-	return false
+	return isUpstreamTLSSecretReferenced(secretName, ing)
 }
 
 func (rc *secretReferenceChecker) IsReferencedByMinion(secretNamespace string, secretName string, ing *networking.Ingress) bool {
@@ -60,6 +61,16 @@ func (rc *secretReferenceChecker) IsReferencedByMinion(secretNamespace string, s
 		}
 	}
 
+	return isUpstreamTLSSecretReferenced(secretName, ing)
+}
+
+func isUpstreamTLSSecretReferenced(secretName string, ing *networking.Ingress) bool {
+	for _, annotation := range []string{configs.UpstreamCASecretAnnotation, configs.UpstreamTLSSecretAnnotation} {
+		if name, exists := ing.Annotations[annotation]; exists && name == secretName {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -96,7 +107,7 @@ func (rc *serviceReferenceChecker) IsReferencedByIngress(svcNamespace string, sv
 		return false
 	}
 
-	if ing.Spec.DefaultBackend != nil {
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
 		if ing.Spec.DefaultBackend.Service.Name == svcName {
 			return true
 		}
@@ -106,7 +117,7 @@ func (rc *serviceReferenceChecker) IsReferencedByIngress(svcNamespace string, sv
 			continue
 		}
 		for _, p := range rules.IngressRuleValue.HTTP.Paths {
-			if p.Backend.Service.Name == svcName {
+			if p.Backend.Service != nil && p.Backend.Service.Name == svcName {
 				return true
 			}
 		}
@@ -128,7 +139,7 @@ func (rc *serviceReferenceChecker) IsReferencedByVirtualServer(svcNamespace stri
 		if rc.hasClusterIP && u.UseClusterIP {
 			continue
 		}
-		if u.Service == svcName {
+		if isServiceReferencedByUpstream(svcName, u) {
 			return true
 		}
 	}
@@ -145,7 +156,21 @@ func (rc *serviceReferenceChecker) IsReferencedByVirtualServerRoute(svcNamespace
 		if rc.hasClusterIP && u.UseClusterIP {
 			continue
 		}
-		if u.Service == svcName {
+		if isServiceReferencedByUpstream(svcName, u) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isServiceReferencedByUpstream(svcName string, u v1.Upstream) bool {
+	if u.Service == svcName || u.Backup == svcName {
+		return true
+	}
+
+	for _, svc := range u.Services {
+		if svc.Name == svcName {
 			return true
 		}
 	}
@@ -209,6 +234,98 @@ func (rc *policyReferenceChecker) IsReferencedByTransportServer(_ string, _ stri
 	return false
 }
 
+// configMapReferenceChecker is a reference checker for ConfigMaps with custom error pages.
+// Only Regular/Master Ingress can reference those ConfigMaps.
+type configMapReferenceChecker struct{}
+
+func newConfigMapReferenceChecker() *configMapReferenceChecker {
+	return &configMapReferenceChecker{}
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByIngress(cmNamespace string, cmName string, ing *networking.Ingress) bool {
+	if ing.Namespace != cmNamespace {
+		return false
+	}
+
+	if name, exists := ing.Annotations[configs.ErrorPagesAnnotation]; exists {
+		return name == cmName
+	}
+
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByMinion(_ string, _ string, _ *networking.Ingress) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByVirtualServer(_ string, _ string, _ *v1.VirtualServer) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByVirtualServerRoute(_ string, _ string, _ *v1.VirtualServerRoute) bool {
+	return false
+}
+
+func (rc *configMapReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
+	return false
+}
+
+// staticResponseReferenceChecker is a reference checker for StaticResponses.
+// Only Ingress resources can reference StaticResponses, through resource backends.
+type staticResponseReferenceChecker struct{}
+
+func newStaticResponseReferenceChecker() *staticResponseReferenceChecker {
+	return &staticResponseReferenceChecker{}
+}
+
+func (rc *staticResponseReferenceChecker) IsReferencedByIngress(srNamespace string, srName string, ing *networking.Ingress) bool {
+	if ing.Namespace != srNamespace {
+		return false
+	}
+
+	if ing.Spec.DefaultBackend != nil && isStaticResponseBackend(ing.Spec.DefaultBackend) {
+		if ing.Spec.DefaultBackend.Resource.Name == srName {
+			return true
+		}
+	}
+	for _, rules := range ing.Spec.Rules {
+		if rules.IngressRuleValue.HTTP == nil {
+			continue
+		}
+		for _, p := range rules.IngressRuleValue.HTTP.Paths {
+			if isStaticResponseBackend(&p.Backend) && p.Backend.Resource.Name == srName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (rc *staticResponseReferenceChecker) IsReferencedByMinion(srNamespace string, srName string, ing *networking.Ingress) bool {
+	return rc.IsReferencedByIngress(srNamespace, srName, ing)
+}
+
+func (rc *staticResponseReferenceChecker) IsReferencedByVirtualServer(_ string, _ string, _ *v1.VirtualServer) bool {
+	return false
+}
+
+func (rc *staticResponseReferenceChecker) IsReferencedByVirtualServerRoute(_ string, _ string, _ *v1.VirtualServerRoute) bool {
+	return false
+}
+
+func (rc *staticResponseReferenceChecker) IsReferencedByTransportServer(_ string, _ string, _ *conf_v1alpha1.TransportServer) bool {
+	return false
+}
+
+// isStaticResponseBackend checks if the backend references a StaticResponse.
+func isStaticResponseBackend(backend *networking.IngressBackend) bool {
+	return backend.Resource != nil &&
+		backend.Resource.APIGroup != nil &&
+		*backend.Resource.APIGroup == configuration.GroupName &&
+		backend.Resource.Kind == staticResponseKind
+}
+
 // appProtectResourceReferenceChecker is a reference checker for AppProtect related resources.
 // Only Regular/Master Ingress can reference those resources.
 type appProtectResourceReferenceChecker struct {
@@ -6,6 +6,7 @@ import (
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -136,6 +137,68 @@ func TestSecretIsReferencedByIngress(t *testing.T) {
 			expected:        false,
 			msg:             "jwt secret for NGINX OSS is ignored",
 		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        true,
+			msg:             "upstream CA secret is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        true,
+			msg:             "upstream TLS secret is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation:  "test-secret",
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "some-namespace",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        false,
+			msg:             "wrong namespace for upstream secrets",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation:  "test-secret",
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "some-secret",
+			isPlus:          false,
+			expected:        false,
+			msg:             "wrong name for upstream secrets",
+		},
 	}
 
 	for _, test := range tests {
@@ -217,6 +280,68 @@ func TestSecretIsReferencedByMinion(t *testing.T) {
 			expected:        false,
 			msg:             "jwt secret for NGINX OSS is ignored",
 		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        true,
+			msg:             "upstream CA secret is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        true,
+			msg:             "upstream TLS secret is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation:  "test-secret",
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "some-namespace",
+			secretName:      "test-secret",
+			isPlus:          false,
+			expected:        false,
+			msg:             "wrong namespace for upstream secrets",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.UpstreamCASecretAnnotation:  "test-secret",
+						configs.UpstreamTLSSecretAnnotation: "test-secret",
+					},
+				},
+			},
+			secretNamespace: "default",
+			secretName:      "some-secret",
+			isPlus:          false,
+			expected:        false,
+			msg:             "wrong name for upstream secrets",
+		},
 	}
 
 	for _, test := range tests {
@@ -435,6 +560,43 @@ func TestServiceIsReferencedByIngressAndMinion(t *testing.T) {
 			expected:         false,
 			msg:              "wrong namespace for service in a path",
 		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: networking.IngressSpec{
+					DefaultBackend: &networking.IngressBackend{
+						Resource: &api_v1.TypedLocalObjectReference{
+							Kind: "StaticResponse",
+							Name: "test-service",
+						},
+					},
+					Rules: []networking.IngressRule{
+						{
+							IngressRuleValue: networking.IngressRuleValue{
+								HTTP: &networking.HTTPIngressRuleValue{
+									Paths: []networking.HTTPIngressPath{
+										{
+											Backend: networking.IngressBackend{
+												Resource: &api_v1.TypedLocalObjectReference{
+													Kind: "StaticResponse",
+													Name: "test-service",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			serviceNamespace: "default",
+			serviceName:      "test-service",
+			expected:         false,
+			msg:              "resource backends",
+		},
 	}
 
 	for _, test := range tests {
@@ -492,6 +654,78 @@ func TestServiceIsReferencedByVirtualServerAndVirtualServerRoutes(t *testing.T)
 			expected:         true,
 			msg:              "service is referenced in an upstream",
 		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Upstreams: []conf_v1.Upstream{
+						{
+							Service: "primary-service",
+							Backup:  "test-service",
+						},
+					},
+				},
+			},
+			vsr: &conf_v1.VirtualServerRoute{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Upstreams: []conf_v1.Upstream{
+						{
+							Service: "primary-service",
+							Backup:  "test-service",
+						},
+					},
+				},
+			},
+			serviceNamespace: "default",
+			serviceName:      "test-service",
+			expected:         true,
+			msg:              "service is referenced as a backup in an upstream",
+		},
+		{
+			vs: &conf_v1.VirtualServer{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerSpec{
+					Upstreams: []conf_v1.Upstream{
+						{
+							Service: "primary-service",
+							Services: []conf_v1.UpstreamService{
+								{
+									Name: "test-service",
+								},
+							},
+						},
+					},
+				},
+			},
+			vsr: &conf_v1.VirtualServerRoute{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: conf_v1.VirtualServerRouteSpec{
+					Upstreams: []conf_v1.Upstream{
+						{
+							Service: "primary-service",
+							Services: []conf_v1.UpstreamService{
+								{
+									Name: "test-service",
+								},
+							},
+						},
+					},
+				},
+			},
+			serviceNamespace: "default",
+			serviceName:      "test-service",
+			expected:         true,
+			msg:              "service is referenced as a weighted service in an upstream",
+		},
 		{
 			vs: &conf_v1.VirtualServer{
 				ObjectMeta: v1.ObjectMeta{
@@ -1025,6 +1259,267 @@ func TestAppProtectResourceIsReferenced(t *testing.T) {
 	}
 }
 
+func TestConfigMapIsReferencedByIngresses(t *testing.T) {
+	tests := []struct {
+		ing         *networking.Ingress
+		cmNamespace string
+		cmName      string
+		expected    bool
+		msg         string
+	}{
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.ErrorPagesAnnotation: "error-pages",
+					},
+				},
+			},
+			cmNamespace: "default",
+			cmName:      "error-pages",
+			expected:    true,
+			msg:         "configmap is referenced",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.ErrorPagesAnnotation: "error-pages",
+					},
+				},
+			},
+			cmNamespace: "some-namespace",
+			cmName:      "error-pages",
+			expected:    false,
+			msg:         "wrong namespace",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+					Annotations: map[string]string{
+						configs.ErrorPagesAnnotation: "error-pages",
+					},
+				},
+			},
+			cmNamespace: "default",
+			cmName:      "other-error-pages",
+			expected:    false,
+			msg:         "wrong name",
+		},
+		{
+			ing: &networking.Ingress{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			cmNamespace: "default",
+			cmName:      "error-pages",
+			expected:    false,
+			msg:         "no annotation",
+		},
+	}
+
+	for _, test := range tests {
+		rc := newConfigMapReferenceChecker()
+
+		result := rc.IsReferencedByIngress(test.cmNamespace, test.cmName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByIngress() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+
+		result = rc.IsReferencedByMinion(test.cmNamespace, test.cmName, test.ing)
+		if result != false {
+			t.Errorf("IsReferencedByMinion() returned true but expected false for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestConfigMapIsReferenced(t *testing.T) {
+	rc := newConfigMapReferenceChecker()
+
+	result := rc.IsReferencedByVirtualServer("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByVirtualServer() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByVirtualServerRoute("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByVirtualServerRoute() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByTransportServer("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	}
+}
+
+func TestStaticResponseIsReferencedByIngressAndMinion(t *testing.T) {
+	apiGroup := "k8s.nginx.org"
+	invalidAPIGroup := "example.com"
+
+	createIngress := func(defaultBackend *networking.IngressBackend, pathBackend *networking.IngressBackend) *networking.Ingress {
+		ing := &networking.Ingress{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: networking.IngressSpec{
+				DefaultBackend: defaultBackend,
+			},
+		}
+		if pathBackend != nil {
+			ing.Spec.Rules = []networking.IngressRule{
+				{
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Backend: *pathBackend,
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+		return ing
+	}
+
+	tests := []struct {
+		ing         *networking.Ingress
+		srNamespace string
+		srName      string
+		expected    bool
+		msg         string
+	}{
+		{
+			ing: createIngress(&networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "StaticResponse",
+					Name:     "maintenance",
+				},
+			}, nil),
+			srNamespace: "default",
+			srName:      "maintenance",
+			expected:    true,
+			msg:         "static response is referenced in the default backend",
+		},
+		{
+			ing: createIngress(nil, &networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "StaticResponse",
+					Name:     "maintenance",
+				},
+			}),
+			srNamespace: "default",
+			srName:      "maintenance",
+			expected:    true,
+			msg:         "static response is referenced in a path",
+		},
+		{
+			ing: createIngress(nil, &networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "StaticResponse",
+					Name:     "maintenance",
+				},
+			}),
+			srNamespace: "some-namespace",
+			srName:      "maintenance",
+			expected:    false,
+			msg:         "wrong namespace",
+		},
+		{
+			ing: createIngress(nil, &networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "StaticResponse",
+					Name:     "maintenance",
+				},
+			}),
+			srNamespace: "default",
+			srName:      "redirect",
+			expected:    false,
+			msg:         "wrong name",
+		},
+		{
+			ing: createIngress(nil, &networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualServerRoute",
+					Name:     "maintenance",
+				},
+			}),
+			srNamespace: "default",
+			srName:      "maintenance",
+			expected:    false,
+			msg:         "wrong kind",
+		},
+		{
+			ing: createIngress(nil, &networking.IngressBackend{
+				Resource: &api_v1.TypedLocalObjectReference{
+					APIGroup: &invalidAPIGroup,
+					Kind:     "StaticResponse",
+					Name:     "maintenance",
+				},
+			}),
+			srNamespace: "default",
+			srName:      "maintenance",
+			expected:    false,
+			msg:         "wrong api group",
+		},
+		{
+			ing: createIngress(&networking.IngressBackend{
+				Service: &networking.IngressServiceBackend{
+					Name: "maintenance",
+				},
+			}, nil),
+			srNamespace: "default",
+			srName:      "maintenance",
+			expected:    false,
+			msg:         "service backend",
+		},
+	}
+
+	for _, test := range tests {
+		rc := newStaticResponseReferenceChecker()
+
+		result := rc.IsReferencedByIngress(test.srNamespace, test.srName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByIngress() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+
+		// same cases for Minions
+		result = rc.IsReferencedByMinion(test.srNamespace, test.srName, test.ing)
+		if result != test.expected {
+			t.Errorf("IsReferencedByMinion() returned %v but expected %v for the case of %s", result, test.expected, test.msg)
+		}
+	}
+}
+
+func TestStaticResponseIsReferenced(t *testing.T) {
+	rc := newStaticResponseReferenceChecker()
+
+	result := rc.IsReferencedByVirtualServer("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByVirtualServer() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByVirtualServerRoute("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByVirtualServerRoute() returned true but expected false")
+	}
+
+	result = rc.IsReferencedByTransportServer("", "", nil)
+	if result != false {
+		t.Error("IsReferencedByTransportServer() returned true but expected false")
+	}
+}
+
 func TestIsPolicyIsReferenced(t *testing.T) {
 	tests := []struct {
 		policies          []conf_v1.PolicyReference
@@ -25,6 +25,7 @@ type SecretStore interface {
 	AddOrUpdateSecret(secret *api_v1.Secret)
 	DeleteSecret(key string)
 	GetSecret(key string) *SecretReference
+	GetSecrets() map[string]*SecretReference
 }
 
 // LocalSecretStore implements SecretStore interface.
@@ -101,6 +102,20 @@ func (s *LocalSecretStore) GetSecret(key string) *SecretReference {
 	return secretRef
 }
 
+// GetSecrets returns all stored secrets. Unlike GetSecret, it doesn't write the secrets to the file system.
+func (s *LocalSecretStore) GetSecrets() map[string]*SecretReference {
+	return copySecretReferences(s.secrets)
+}
+
+func copySecretReferences(secrets map[string]*SecretReference) map[string]*SecretReference {
+	result := make(map[string]*SecretReference, len(secrets))
+	for k, v := range secrets {
+		ref := *v
+		result[k] = &ref
+	}
+	return result
+}
+
 func getResourceKey(meta *metav1.ObjectMeta) string {
 	return fmt.Sprintf("%s/%s", meta.Namespace, meta.Name)
 }
@@ -150,3 +165,8 @@ func (s *FakeSecretStore) GetSecret(key string) *SecretReference {
 
 	return secretRef
 }
+
+// GetSecrets is a fake implementation of GetSecrets.
+func (s *FakeSecretStore) GetSecrets() map[string]*SecretReference {
+	return copySecretReferences(s.secrets)
+}
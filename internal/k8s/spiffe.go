@@ -3,10 +3,11 @@ package k8s
 import (
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"time"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/spiffe/go-spiffe/workload"
 )
 
@@ -35,7 +36,7 @@ func NewSpiffeController(sync func(*workload.X509SVIDs), spireAgentAddr string)
 // If the certs are not available after 30 seconds an error is returned.
 // On success, calls onStart function and kicks off the Spiffe Controller's run loop.
 func (sc *SpiffeController) Start(stopCh <-chan struct{}, onStart func()) error {
-	glog.V(3).Info("Starting SPIFFE Workload API Client")
+	nl.Trace(slog.Default(), "Starting SPIFFE Workload API Client")
 	err := sc.client.Start()
 	if err != nil {
 		return fmt.Errorf("failed to start Spiffe Workload API Client: %w", err)
@@ -44,7 +45,7 @@ func (sc *SpiffeController) Start(stopCh <-chan struct{}, onStart func()) error
 	duration := 100 * time.Millisecond
 	for {
 		if sc.watcher.synced {
-			glog.V(3).Info("initial SPIFFE trust bundle written to disk")
+			nl.Trace(slog.Default(), "initial SPIFFE trust bundle written to disk")
 			break
 		}
 		select {
@@ -67,7 +68,7 @@ func (sc *SpiffeController) Run(stopCh <-chan struct{}) {
 	<-stopCh
 	err := sc.client.Stop()
 	if err != nil {
-		glog.Errorf("failed to stop Spiffe Workload API Client: %v", err)
+		nl.Errorf(slog.Default(), "failed to stop Spiffe Workload API Client: %v", err)
 	}
 }
 
@@ -80,7 +81,7 @@ type spiffeWatcher struct {
 // UpdateX509SVIDs is run every time an SVID is updated
 func (w *spiffeWatcher) UpdateX509SVIDs(svids *workload.X509SVIDs) {
 	for _, svid := range svids.SVIDs {
-		glog.V(3).Infof("SVID updated for spiffeID: %q", svid.SPIFFEID)
+		nl.Tracef(slog.Default(), "SVID updated for spiffeID: %q", svid.SPIFFEID)
 	}
 	w.sync(svids)
 	w.synced = true
@@ -89,8 +90,8 @@ func (w *spiffeWatcher) UpdateX509SVIDs(svids *workload.X509SVIDs) {
 // OnError is run when the client runs into an error
 func (w *spiffeWatcher) OnError(err error) {
 	if strings.Contains(err.Error(), "PermissionDenied") {
-		glog.V(3).Infof("X509SVIDClient still waiting for certificates: %v", err)
+		nl.Tracef(slog.Default(), "X509SVIDClient still waiting for certificates: %v", err)
 		return
 	}
-	glog.Fatal(err)
+	nl.Fatal(slog.Default(), err)
 }
@@ -3,21 +3,23 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
 	"reflect"
 	"strconv"
 	"strings"
 
-	"github.com/golang/glog"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	k8s_nginx "github.com/nginxinc/kubernetes-ingress/pkg/client/clientset/versioned"
 	api_v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typednetworking "k8s.io/client-go/kubernetes/typed/networking/v1"
 
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -45,6 +47,7 @@ type statusUpdater struct {
 	policyLister             cache.Store
 	confClient               k8s_nginx.Interface
 	hasCorrectIngressClass   func(interface{}) bool
+	logger                   *slog.Logger
 }
 
 func (su *statusUpdater) UpdateExternalEndpointsForResources(resource []Resource) error {
@@ -74,6 +77,10 @@ func (su *statusUpdater) UpdateExternalEndpointsForResource(r Resource) error {
 			ings = append(ings, *fm.Ingress)
 		}
 
+		for _, c := range impl.Canaries {
+			ings = append(ings, *c.Ingress)
+		}
+
 		return su.BulkUpdateIngressStatus(ings)
 	case *VirtualServerConfiguration:
 		failed := false
@@ -113,16 +120,16 @@ func (su *statusUpdater) updateIngressWithStatus(ing networking.Ingress, status
 	// Get an up-to-date Ingress from the Store
 	key, err := su.keyFunc(&ing)
 	if err != nil {
-		glog.V(3).Infof("error getting key for ing: %v", err)
+		nl.Tracef(su.logger, "error getting key for ing: %v", err)
 		return err
 	}
 	ingCopy, exists, err := su.ingressLister.GetByKeySafe(key)
 	if err != nil {
-		glog.V(3).Infof("error getting ing from Store by key: %v", err)
+		nl.Tracef(su.logger, "error getting ing from Store by key: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("ing doesn't exist in Store")
+		nl.Tracef(su.logger, "ing doesn't exist in Store")
 		return nil
 	}
 
@@ -135,14 +142,14 @@ func (su *statusUpdater) updateIngressWithStatus(ing networking.Ingress, status
 	clientIngress := su.client.NetworkingV1().Ingresses(ingCopy.Namespace)
 	_, err = clientIngress.UpdateStatus(context.TODO(), ingCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting ingress status: %v", err)
+		nl.Tracef(su.logger, "error setting ingress status: %v", err)
 		err = su.retryStatusUpdate(clientIngress, ingCopy)
 		if err != nil {
-			glog.V(3).Infof("error retrying status update: %v", err)
+			nl.Tracef(su.logger, "error retrying status update: %v", err)
 			return err
 		}
 	}
-	glog.V(3).Infof("updated status for ing: %v %v", ing.Namespace, ing.Name)
+	nl.Tracef(su.logger, "updated status for ing: %v %v", ing.Namespace, ing.Name)
 	return nil
 }
 
@@ -150,7 +157,7 @@ func (su *statusUpdater) updateIngressWithStatus(ing networking.Ingress, status
 // the External IP field.
 func (su *statusUpdater) BulkUpdateIngressStatus(ings []networking.Ingress) error {
 	if len(ings) < 1 {
-		glog.V(3).Info("no ingresses to update")
+		nl.Trace(su.logger, "no ingresses to update")
 		return nil
 	}
 	failed := false
@@ -172,15 +179,15 @@ func (su *statusUpdater) BulkUpdateIngressStatus(ings []networking.Ingress) erro
 func (su *statusUpdater) retryStatusUpdate(clientIngress typednetworking.IngressInterface, ingCopy *networking.Ingress) error {
 	apiIng, err := clientIngress.Get(context.TODO(), ingCopy.Name, metav1.GetOptions{})
 	if err != nil {
-		glog.V(3).Infof("error getting ingress resource: %v", err)
+		nl.Tracef(su.logger, "error getting ingress resource: %v", err)
 		return err
 	}
 	if !reflect.DeepEqual(ingCopy.Status.LoadBalancer, apiIng.Status.LoadBalancer) {
-		glog.V(3).Infof("retrying update status for ingress: %v, %v", ingCopy.Namespace, ingCopy.Name)
+		nl.Tracef(su.logger, "retrying update status for ingress: %v, %v", ingCopy.Namespace, ingCopy.Name)
 		apiIng.Status.LoadBalancer = ingCopy.Status.LoadBalancer
 		_, err := clientIngress.UpdateStatus(context.TODO(), apiIng, metav1.UpdateOptions{})
 		if err != nil {
-			glog.V(3).Infof("update retry failed: %v", err)
+			nl.Tracef(su.logger, "update retry failed: %v", err)
 		}
 		return err
 	}
@@ -302,7 +309,7 @@ func (su *statusUpdater) SaveStatusFromExternalService(svc *api_v1.Service) {
 	ports := getExternalServicePorts(svc)
 	su.externalServicePorts = ports
 	if su.externalStatusAddress != "" {
-		glog.V(3).Info("skipping external service address/ports - external-status-address is set and takes precedence")
+		nl.Trace(su.logger, "skipping external service address/ports - external-status-address is set and takes precedence")
 		return
 	}
 	su.saveStatus(ips)
@@ -314,7 +321,7 @@ func (su *statusUpdater) SaveStatusFromIngressLink(ip string) {
 	su.bigIPPorts = "[80,443]"
 
 	if su.externalStatusAddress != "" {
-		glog.V(3).Info("skipping IngressLink address - external-status-address is set and takes precedence")
+		nl.Trace(su.logger, "skipping IngressLink address - external-status-address is set and takes precedence")
 		return
 	}
 
@@ -328,7 +335,7 @@ func (su *statusUpdater) ClearStatusFromIngressLink() {
 	su.bigIPPorts = ""
 
 	if su.externalStatusAddress != "" {
-		glog.V(3).Info("skipping IngressLink address - external-status-address is set and takes precedence")
+		nl.Trace(su.logger, "skipping IngressLink address - external-status-address is set and takes precedence")
 		return
 	}
 
@@ -402,11 +409,11 @@ func hasVsStatusChanged(vs *conf_v1.VirtualServer, state string, reason string,
 func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1alpha1.TransportServer, state string, reason string, message string) error {
 	tsLatest, exists, err := su.transportServerLister.Get(ts)
 	if err != nil {
-		glog.V(3).Infof("error getting TransportServer from Store: %v", err)
+		nl.Tracef(su.logger, "error getting TransportServer from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("TransportServer doesn't exist in Store")
+		nl.Tracef(su.logger, "TransportServer doesn't exist in Store")
 		return nil
 	}
 
@@ -421,7 +428,7 @@ func (su *statusUpdater) UpdateTransportServerStatus(ts *conf_v1alpha1.Transport
 
 	_, err = su.confClient.K8sV1alpha1().TransportServers(tsCopy.Namespace).UpdateStatus(context.TODO(), tsCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting TransportServer %v/%v status, retrying: %v", tsCopy.Namespace, tsCopy.Name, err)
+		nl.Tracef(su.logger, "error setting TransportServer %v/%v status, retrying: %v", tsCopy.Namespace, tsCopy.Name, err)
 		return su.retryUpdateTransportServerStatus(tsCopy)
 	}
 	return err
@@ -445,11 +452,11 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 	// Get an up-to-date VirtualServer from the Store
 	vsLatest, exists, err := su.virtualServerLister.Get(vs)
 	if err != nil {
-		glog.V(3).Infof("error getting VirtualServer from Store: %v", err)
+		nl.Tracef(su.logger, "error getting VirtualServer from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("VirtualServer doesn't exist in Store")
+		nl.Tracef(su.logger, "VirtualServer doesn't exist in Store")
 		return nil
 	}
 
@@ -466,12 +473,40 @@ func (su *statusUpdater) UpdateVirtualServerStatus(vs *conf_v1.VirtualServer, st
 
 	_, err = su.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
+		nl.Tracef(su.logger, "error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
 		return su.retryUpdateVirtualServerStatus(vsCopy)
 	}
 	return err
 }
 
+// UpdateVirtualServerCondition sets a condition in the status of a VirtualServer.
+// The status is not updated if the condition hasn't changed.
+func (su *statusUpdater) UpdateVirtualServerCondition(vs *conf_v1.VirtualServer, condition metav1.Condition) error {
+	// Get an up-to-date VirtualServer from the Store
+	vsLatest, exists, err := su.virtualServerLister.Get(vs)
+	if err != nil {
+		nl.Tracef(su.logger, "error getting VirtualServer from Store: %v", err)
+		return err
+	}
+	if !exists {
+		nl.Tracef(su.logger, "VirtualServer doesn't exist in Store")
+		return nil
+	}
+
+	vsCopy := vsLatest.(*conf_v1.VirtualServer).DeepCopy()
+
+	existing := meta.FindStatusCondition(vsCopy.Status.Conditions, condition.Type)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+
+	condition.ObservedGeneration = vsCopy.Generation
+	meta.SetStatusCondition(&vsCopy.Status.Conditions, condition)
+
+	_, err = su.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, metav1.UpdateOptions{})
+	return err
+}
+
 func hasVsrStatusChanged(vsr *conf_v1.VirtualServerRoute, state string, reason string, message string, referencedByString string) bool {
 	if vsr.Status.State != state {
 		return true
@@ -503,11 +538,11 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 	// Get an up-to-date VirtualServerRoute from the Store
 	vsrLatest, exists, err := su.virtualServerRouteLister.Get(vsr)
 	if err != nil {
-		glog.V(3).Infof("error getting VirtualServerRoute from Store: %v", err)
+		nl.Tracef(su.logger, "error getting VirtualServerRoute from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("VirtualServerRoute doesn't exist in Store")
+		nl.Tracef(su.logger, "VirtualServerRoute doesn't exist in Store")
 		return nil
 	}
 
@@ -525,7 +560,7 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatusWithReferencedBy(vsr *con
 
 	_, err = su.confClient.K8sV1().VirtualServerRoutes(vsrCopy.Namespace).UpdateStatus(context.TODO(), vsrCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
+		nl.Tracef(su.logger, "error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
 		return su.retryUpdateVirtualServerRouteStatus(vsrCopy)
 	}
 	return err
@@ -538,11 +573,11 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatus(vsr *conf_v1.VirtualServ
 	// Get an up-to-date VirtualServerRoute from the Store
 	vsrLatest, exists, err := su.virtualServerRouteLister.Get(vsr)
 	if err != nil {
-		glog.V(3).Infof("error getting VirtualServerRoute from Store: %v", err)
+		nl.Tracef(su.logger, "error getting VirtualServerRoute from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("VirtualServerRoute doesn't exist in Store")
+		nl.Tracef(su.logger, "VirtualServerRoute doesn't exist in Store")
 		return nil
 	}
 
@@ -559,7 +594,7 @@ func (su *statusUpdater) UpdateVirtualServerRouteStatus(vsr *conf_v1.VirtualServ
 
 	_, err = su.confClient.K8sV1().VirtualServerRoutes(vsrCopy.Namespace).UpdateStatus(context.TODO(), vsrCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
+		nl.Tracef(su.logger, "error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
 		return su.retryUpdateVirtualServerRouteStatus(vsrCopy)
 	}
 	return err
@@ -569,11 +604,11 @@ func (su *statusUpdater) updateVirtualServerExternalEndpoints(vs *conf_v1.Virtua
 	// Get a pristine VirtualServer from the Store
 	vsLatest, exists, err := su.virtualServerLister.Get(vs)
 	if err != nil {
-		glog.V(3).Infof("error getting VirtualServer from Store: %v", err)
+		nl.Tracef(su.logger, "error getting VirtualServer from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("VirtualServer doesn't exist in Store")
+		nl.Tracef(su.logger, "VirtualServer doesn't exist in Store")
 		return nil
 	}
 
@@ -582,7 +617,7 @@ func (su *statusUpdater) updateVirtualServerExternalEndpoints(vs *conf_v1.Virtua
 
 	_, err = su.confClient.K8sV1().VirtualServers(vsCopy.Namespace).UpdateStatus(context.TODO(), vsCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
+		nl.Tracef(su.logger, "error setting VirtualServer %v/%v status, retrying: %v", vsCopy.Namespace, vsCopy.Name, err)
 		return su.retryUpdateVirtualServerStatus(vsCopy)
 	}
 	return err
@@ -592,11 +627,11 @@ func (su *statusUpdater) updateVirtualServerRouteExternalEndpoints(vsr *conf_v1.
 	// Get an up-to-date VirtualServerRoute from the Store
 	vsrLatest, exists, err := su.virtualServerRouteLister.Get(vsr)
 	if err != nil {
-		glog.V(3).Infof("error getting VirtualServerRoute from Store: %v", err)
+		nl.Tracef(su.logger, "error getting VirtualServerRoute from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("VirtualServerRoute doesn't exist in Store")
+		nl.Tracef(su.logger, "VirtualServerRoute doesn't exist in Store")
 		return nil
 	}
 
@@ -605,7 +640,7 @@ func (su *statusUpdater) updateVirtualServerRouteExternalEndpoints(vsr *conf_v1.
 
 	_, err = su.confClient.K8sV1().VirtualServerRoutes(vsrCopy.Namespace).UpdateStatus(context.TODO(), vsrCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
+		nl.Tracef(su.logger, "error setting VirtualServerRoute %v/%v status, retrying: %v", vsrCopy.Namespace, vsrCopy.Name, err)
 		return su.retryUpdateVirtualServerRouteStatus(vsrCopy)
 	}
 	return err
@@ -635,16 +670,16 @@ func (su *statusUpdater) UpdatePolicyStatus(pol *v1.Policy, state string, reason
 	// Get an up-to-date Policy from the Store
 	polLatest, exists, err := su.policyLister.Get(pol)
 	if err != nil {
-		glog.V(3).Infof("error getting policy from Store: %v", err)
+		nl.Tracef(su.logger, "error getting policy from Store: %v", err)
 		return err
 	}
 	if !exists {
-		glog.V(3).Infof("Policy doesn't exist in Store")
+		nl.Tracef(su.logger, "Policy doesn't exist in Store")
 		return nil
 	}
 
 	if !su.hasCorrectIngressClass(polLatest) {
-		glog.V(3).Infof("ignoring policy with incorrect ingress class")
+		nl.Tracef(su.logger, "ignoring policy with incorrect ingress class")
 		return nil
 	}
 
@@ -660,7 +695,7 @@ func (su *statusUpdater) UpdatePolicyStatus(pol *v1.Policy, state string, reason
 
 	_, err = su.confClient.K8sV1().Policies(polCopy.Namespace).UpdateStatus(context.TODO(), polCopy, metav1.UpdateOptions{})
 	if err != nil {
-		glog.V(3).Infof("error setting Policy %v/%v status, retrying: %v", polCopy.Namespace, polCopy.Name, err)
+		nl.Tracef(su.logger, "error setting Policy %v/%v status, retrying: %v", polCopy.Namespace, polCopy.Name, err)
 		return su.retryUpdatePolicyStatus(polCopy)
 	}
 
@@ -2,13 +2,16 @@ package k8s
 
 import (
 	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/nginxinc/kubernetes-ingress/pkg/apis/dos/v1beta1"
 
-	"github.com/golang/glog"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotect"
 	"github.com/nginxinc/kubernetes-ingress/internal/k8s/appprotectdos"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
 	conf_v1alpha1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
 	v1 "k8s.io/api/core/v1"
@@ -27,15 +30,25 @@ type taskQueue struct {
 	sync func(task)
 	// workerDone is closed when the worker exits
 	workerDone chan struct{}
+	// metricsCollector collects the time from enqueueing a task to completing its sync
+	metricsCollector collectors.ControllerCollector
+	logger           *slog.Logger
+
+	enqueueTimesLock sync.Mutex
+	// enqueueTimes stores the time when a task, which is not being synced yet, was first added to the queue
+	enqueueTimes map[task]time.Time
 }
 
 // newTaskQueue creates a new task queue with the given sync function.
 // The sync function is called for every element inserted into the queue.
-func newTaskQueue(syncFn func(task)) *taskQueue {
+func newTaskQueue(syncFn func(task), metricsCollector collectors.ControllerCollector, logger *slog.Logger) *taskQueue {
 	return &taskQueue{
-		queue:      workqueue.NewNamed("taskQueue"),
-		sync:       syncFn,
-		workerDone: make(chan struct{}),
+		queue:            workqueue.NewNamed("taskQueue"),
+		sync:             syncFn,
+		workerDone:       make(chan struct{}),
+		metricsCollector: metricsCollector,
+		logger:           logger,
+		enqueueTimes:     make(map[task]time.Time),
 	}
 }
 
@@ -48,38 +61,60 @@ func (tq *taskQueue) Run(period time.Duration, stopCh <-chan struct{}) {
 func (tq *taskQueue) Enqueue(obj interface{}) {
 	key, err := keyFunc(obj)
 	if err != nil {
-		glog.V(3).Infof("Couldn't get key for object %v: %v", obj, err)
+		nl.Tracef(tq.logger, "Couldn't get key for object %v: %v", obj, err)
 		return
 	}
 
 	task, err := newTask(key, obj)
 	if err != nil {
-		glog.V(3).Infof("Couldn't create a task for object %v: %v", obj, err)
+		nl.Tracef(tq.logger, "Couldn't create a task for object %v: %v", obj, err)
 		return
 	}
 
-	glog.V(3).Infof("Adding an element with a key: %v", task.Key)
-	tq.queue.Add(task)
+	nl.Tracef(tq.logger, "Adding an element with a key: %v", task.Key)
+	tq.add(task)
+}
+
+// add adds the task to the queue and records the enqueue time unless the task is already queued
+func (tq *taskQueue) add(t task) {
+	tq.enqueueTimesLock.Lock()
+	if _, exists := tq.enqueueTimes[t]; !exists {
+		tq.enqueueTimes[t] = time.Now()
+	}
+	tq.enqueueTimesLock.Unlock()
+
+	tq.queue.Add(t)
+}
+
+// popEnqueueTime returns and forgets the enqueue time of the task
+func (tq *taskQueue) popEnqueueTime(t task) (time.Time, bool) {
+	tq.enqueueTimesLock.Lock()
+	defer tq.enqueueTimesLock.Unlock()
+
+	enqueueTime, exists := tq.enqueueTimes[t]
+	delete(tq.enqueueTimes, t)
+
+	return enqueueTime, exists
 }
 
 // Requeue adds the task to the queue again and logs the given error
 func (tq *taskQueue) Requeue(task task, err error) {
-	glog.Errorf("Requeuing %v, err %v", task.Key, err)
-	tq.queue.Add(task)
+	nl.Errorf(tq.logger, "Requeuing %v, err %v", task.Key, err)
+	tq.add(task)
 }
 
 // Len returns the length of the queue
 func (tq *taskQueue) Len() int {
-	glog.V(3).Infof("The queue has %v element(s)", tq.queue.Len())
+	nl.Tracef(tq.logger, "The queue has %v element(s)", tq.queue.Len())
 	return tq.queue.Len()
 }
 
 // RequeueAfter adds the task to the queue after the given duration
 func (tq *taskQueue) RequeueAfter(t task, err error, after time.Duration) {
-	glog.Errorf("Requeuing %v after %s, err %v", t.Key, after.String(), err)
+	nl.Errorf(tq.logger, "Requeuing %v after %s, err %v", t.Key, after.String(), err)
 	go func(t task, after time.Duration) {
 		time.Sleep(after)
-		tq.queue.Add(t)
+		tq.add(t)
 	}(t, after)
 }
 
@@ -91,8 +126,13 @@ func (tq *taskQueue) worker() {
 			close(tq.workerDone)
 			return
 		}
-		glog.V(3).Infof("Syncing %v", t.(task).Key)
+		nl.Tracef(tq.logger, "Syncing %v", t.(task).Key)
+		// a task added again during its sync gets a new enqueue time
+		enqueueTime, exists := tq.popEnqueueTime(t.(task))
 		tq.sync(t.(task))
+		if exists && tq.metricsCollector != nil {
+			tq.metricsCollector.ObserveSyncLatency(t.(task).Kind.String(), time.Since(enqueueTime))
+		}
 		tq.queue.Done(t)
 	}
 }
@@ -125,8 +165,38 @@ const (
 	appProtectDosLogConf
 	appProtectDosProtectedResource
 	ingressLink
+	staticResponse
 )
 
+var kindNames = map[kind]string{
+	ingress:                        "Ingress",
+	endpoints:                      "Endpoints",
+	configMap:                      "ConfigMap",
+	secret:                         "Secret",
+	service:                        "Service",
+	virtualserver:                  "VirtualServer",
+	virtualServerRoute:             "VirtualServerRoute",
+	globalConfiguration:            "GlobalConfiguration",
+	transportserver:                "TransportServer",
+	policy:                         "Policy",
+	appProtectPolicy:               "APPolicy",
+	appProtectLogConf:              "APLogConf",
+	appProtectUserSig:              "APUserSig",
+	appProtectDosPolicy:            "APDosPolicy",
+	appProtectDosLogConf:           "APDosLogConf",
+	appProtectDosProtectedResource: "DosProtectedResource",
+	ingressLink:                    "IngressLink",
+	staticResponse:                 "StaticResponse",
+}
+
+// String returns the name of the kind of the Kubernetes resources.
+func (k kind) String() string {
+	if name, exists := kindNames[k]; exists {
+		return name
+	}
+	return "Unknown"
+}
+
 // task is an element of a taskQueue
 type task struct {
 	Kind kind
@@ -157,6 +227,8 @@ func newTask(key string, obj interface{}) (task, error) {
 		k = globalConfiguration
 	case *conf_v1alpha1.TransportServer:
 		k = transportserver
+	case *conf_v1alpha1.StaticResponse:
+		k = staticResponse
 	case *v1beta1.DosProtectedResource:
 		k = appProtectDosProtectedResource
 	case *unstructured.Unstructured:
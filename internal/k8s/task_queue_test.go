@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
+	conf_v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type syncLatencyCollector struct {
+	collectors.ControllerFakeCollector
+	kinds []string
+}
+
+func (c *syncLatencyCollector) ObserveSyncLatency(kind string, _ time.Duration) {
+	c.kinds = append(c.kinds, kind)
+}
+
+func TestTaskQueueObservesSyncLatency(t *testing.T) {
+	collector := &syncLatencyCollector{}
+	var synced []task
+
+	tq := newTaskQueue(func(t task) {
+		synced = append(synced, t)
+	}, collector, nil)
+
+	vs := &conf_v1.VirtualServer{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "cafe",
+			Namespace: "default",
+		},
+	}
+
+	tq.Enqueue(vs)
+	firstEnqueueTime := tq.enqueueTimes[task{Kind: virtualserver, Key: "default/cafe"}]
+
+	// the second change of the same resource is merged into the queued task
+	tq.Enqueue(vs)
+	if enqueueTime := tq.enqueueTimes[task{Kind: virtualserver, Key: "default/cafe"}]; enqueueTime != firstEnqueueTime {
+		t.Errorf("Enqueue() of a queued task changed the enqueue time from %v to %v", firstEnqueueTime, enqueueTime)
+	}
+
+	tq.queue.ShutDown()
+	tq.worker()
+
+	if len(synced) != 1 {
+		t.Fatalf("worker() synced %d tasks but expected 1", len(synced))
+	}
+	if len(collector.kinds) != 1 || collector.kinds[0] != "VirtualServer" {
+		t.Errorf("worker() observed the sync latency for kinds %v but expected [VirtualServer]", collector.kinds)
+	}
+	if len(tq.enqueueTimes) != 0 {
+		t.Errorf("worker() left %d enqueue times but expected 0", len(tq.enqueueTimes))
+	}
+}
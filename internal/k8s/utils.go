@@ -18,13 +18,15 @@ package k8s
 
 import (
 	"fmt"
+	"log/slog"
 	"reflect"
 	"strings"
 
-	"github.com/golang/glog"
 	v1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 
+	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -132,6 +134,12 @@ func isMaster(ing *networking.Ingress) bool {
 	return ing.Annotations["nginx.org/mergeable-ingress-type"] == "master"
 }
 
+// isCanary determines if an ingress is a canary or not
+func isCanary(ing *networking.Ingress) bool {
+	canary, err := configs.ParseBool(ing.Annotations["nginx.org/canary"])
+	return err == nil && canary
+}
+
 // hasChanges determines if current ingress has changes compared to old ingress
 func hasChanges(old *networking.Ingress, current *networking.Ingress) bool {
 	old.Status.LoadBalancer.Ingress = current.Status.LoadBalancer.Ingress
@@ -160,7 +168,7 @@ func GetK8sVersion(client kubernetes.Interface) (v *version.Version, err error)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected error parsing running Kubernetes version: %w", err)
 	}
-	glog.V(3).Infof("Kubernetes version: %v", runningVersion)
+	nl.Tracef(slog.Default(), "Kubernetes version: %v", runningVersion)
 
 	return runningVersion, nil
 }
@@ -3,10 +3,13 @@ package k8s
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration"
+	cr_validation "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/validation"
 	networking "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -59,6 +62,24 @@ const (
 	grpcServicesAnnotation                = "nginx.org/grpc-services"
 	rewritesAnnotation                    = "nginx.org/rewrites"
 	stickyCookieServicesAnnotation        = "nginx.com/sticky-cookie-services"
+	sessionAffinityModeAnnotation         = "nginx.org/session-affinity-mode"
+	canaryAnnotation                      = "nginx.org/canary"
+	canaryWeightAnnotation                = "nginx.org/canary-weight"
+	canaryByHeaderAnnotation              = "nginx.org/canary-by-header"
+	canaryByCookieAnnotation              = "nginx.org/canary-by-cookie"
+	pathRegexAnnotation                   = "nginx.org/path-regex"
+	pathOverridesAnnotation               = "nginx.org/path-overrides"
+	limitReqRateAnnotation                = "nginx.org/limit-req-rate"
+	limitReqKeyAnnotation                 = "nginx.org/limit-req-key"
+	limitReqZoneSizeAnnotation            = "nginx.org/limit-req-zone-size"
+	limitReqBurstAnnotation               = "nginx.org/limit-req-burst"
+	limitReqNoDelayAnnotation             = "nginx.org/limit-req-no-delay"
+	limitReqRejectCodeAnnotation          = "nginx.org/limit-req-reject-code"
+	allowListAnnotation                   = "nginx.org/allow-list"
+	denyListAnnotation                    = "nginx.org/deny-list"
+	errorPagesAnnotation                  = "nginx.org/error-pages"
+	upstreamCASecretAnnotation            = "nginx.org/ca"
+	upstreamTLSSecretAnnotation           = "nginx.org/upstream-tls-secret" // #nosec G101
 )
 
 type annotationValidationContext struct {
@@ -261,12 +282,130 @@ var (
 			validateRewriteListAnnotation,
 		},
 		stickyCookieServicesAnnotation: {
-			validatePlusOnlyAnnotation,
+			validateStickyCookieServicesPlusOnlyAnnotation,
 			validateRequiredAnnotation,
 			validateStickyServiceListAnnotation,
 		},
+		sessionAffinityModeAnnotation: {
+			validateRequiredAnnotation,
+			validateSessionAffinityModeAnnotation,
+		},
+		canaryAnnotation: {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+			validateCanaryAnnotation,
+		},
+		canaryWeightAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateCanaryWeightAnnotation,
+		},
+		canaryByHeaderAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateHTTPHeaderNameAnnotation,
+		},
+		canaryByCookieAnnotation: {
+			validateRelatedAnnotation(canaryAnnotation, validateIsTrue),
+			validateRequiredAnnotation,
+			validateCookieNameAnnotation,
+		},
+		pathRegexAnnotation: {
+			validateRequiredAnnotation,
+			validatePathRegexAnnotation,
+		},
+		pathOverridesAnnotation: {
+			validateRequiredAnnotation,
+			validatePathOverridesAnnotation,
+		},
+		limitReqRateAnnotation: {
+			validateRequiredAnnotation,
+			validateLimitReqRateAnnotation,
+		},
+		limitReqKeyAnnotation: {
+			validateRequiredAnnotation,
+			validateLimitReqKeyAnnotation,
+		},
+		limitReqZoneSizeAnnotation: {
+			validateRequiredAnnotation,
+			validateLimitReqZoneSizeAnnotation,
+		},
+		limitReqBurstAnnotation: {
+			validateRequiredAnnotation,
+			validateUint64Annotation,
+		},
+		limitReqNoDelayAnnotation: {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+		},
+		limitReqRejectCodeAnnotation: {
+			validateRequiredAnnotation,
+			validateLimitReqRejectCodeAnnotation,
+		},
+		allowListAnnotation: {
+			validateRequiredAnnotation,
+			validateIPListAnnotation,
+		},
+		denyListAnnotation: {
+			validateRequiredAnnotation,
+			validateDenyListAnnotation,
+			validateIPListAnnotation,
+		},
+		errorPagesAnnotation: {
+			validateRequiredAnnotation,
+			validateResourceNameAnnotation,
+		},
+		upstreamCASecretAnnotation: {
+			validateRequiredAnnotation,
+			validateResourceNameAnnotation,
+		},
+		upstreamTLSSecretAnnotation: {
+			validateRequiredAnnotation,
+			validateResourceNameAnnotation,
+		},
 	}
 	annotationNames = sortedAnnotationNames(annotationValidations)
+
+	// pathOverrideValidations defines the validations of the settings of the nginx.org/path-overrides annotation.
+	pathOverrideValidations = annotationValidationConfig{
+		"proxy-connect-timeout": {
+			validateRequiredAnnotation,
+			validateTimeAnnotation,
+		},
+		"proxy-read-timeout": {
+			validateRequiredAnnotation,
+			validateTimeAnnotation,
+		},
+		"proxy-send-timeout": {
+			validateRequiredAnnotation,
+			validateTimeAnnotation,
+		},
+		"client-max-body-size": {
+			validateRequiredAnnotation,
+			validateOffsetAnnotation,
+		},
+		"proxy-buffering": {
+			validateRequiredAnnotation,
+			validateBoolAnnotation,
+		},
+		"proxy-buffers": {
+			validateRequiredAnnotation,
+			validateProxyBuffersAnnotation,
+		},
+		"proxy-buffer-size": {
+			validateRequiredAnnotation,
+			validateSizeAnnotation,
+		},
+		"proxy-max-temp-file-size": {
+			validateRequiredAnnotation,
+			validateSizeAnnotation,
+		},
+		"rewrite": {
+			validateRequiredAnnotation,
+			validateRewritePathAnnotation,
+		},
+	}
+	pathOverrideNames = sortedAnnotationNames(pathOverrideValidations)
 )
 
 func sortedAnnotationNames(annotationValidations annotationValidationConfig) []string {
@@ -301,11 +440,14 @@ func validateIngress(
 	)...)
 
 	allErrs = append(allErrs, validateIngressSpec(&ing.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateIngressPaths(&ing.Spec, ing.Annotations[pathRegexAnnotation], field.NewPath("spec"))...)
 
 	if isMaster(ing) {
 		allErrs = append(allErrs, validateMasterSpec(&ing.Spec, field.NewPath("spec"))...)
 	} else if isMinion(ing) {
 		allErrs = append(allErrs, validateMinionSpec(&ing.Spec, field.NewPath("spec"))...)
+	} else if isCanary(ing) {
+		allErrs = append(allErrs, validateCanarySpec(&ing.Spec, field.NewPath("spec"))...)
 	}
 
 	return allErrs
@@ -392,6 +534,175 @@ func validateMergeableIngressTypeAnnotation(context *annotationValidationContext
 	return allErrs
 }
 
+func validateCanaryAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, exists := context.annotations[mergeableIngressTypeAnnotation]; exists {
+		return append(allErrs, field.Forbidden(context.fieldPath, fmt.Sprintf("cannot be used with the %s annotation", mergeableIngressTypeAnnotation)))
+	}
+	return allErrs
+}
+
+func validateCanaryWeightAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	weight, err := configs.ParseInt(context.value)
+	if err != nil || weight < 0 || weight > 100 {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be an integer between 0 and 100"))
+	}
+	return allErrs
+}
+
+func validateHTTPHeaderNameAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsHTTPHeaderName(context.value) {
+		allErrs = append(allErrs, field.Invalid(context.fieldPath, context.value, msg))
+	}
+	return allErrs
+}
+
+const (
+	cookieNameFmt    = `[a-zA-Z0-9_]+`
+	cookieNameErrMsg = "must consist of alphanumeric characters or '_'"
+)
+
+var cookieNameRegexp = regexp.MustCompile("^" + cookieNameFmt + "$")
+
+// validateCookieNameAnnotation validates the name of a cookie that is used in the $cookie_name variable of NGINX.
+func validateCookieNameAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !cookieNameRegexp.MatchString(context.value) {
+		msg := validation.RegexError(cookieNameErrMsg, cookieNameFmt, "canary", "use_canary")
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, msg))
+	}
+	return allErrs
+}
+
+var validPathRegexValues = map[string]bool{
+	"case_sensitive":   true,
+	"case_insensitive": true,
+	"exact":            true,
+}
+
+func validatePathRegexAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !validPathRegexValues[context.value] {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be one of: 'case_sensitive', 'case_insensitive' or 'exact'"))
+	}
+	return allErrs
+}
+
+func validatePathOverridesAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	overrides, err := configs.ParsePathOverrides(context.value)
+	if err != nil {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be a JSON object that maps paths to objects with string settings"))
+	}
+
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pathField := context.fieldPath.Key(path)
+		override := overrides[path]
+
+		names := make([]string, 0, len(override))
+		for name := range override {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			validationFuncs, exists := pathOverrideValidations[name]
+			if !exists {
+				allErrs = append(allErrs, field.NotSupported(pathField, name, pathOverrideNames))
+				continue
+			}
+
+			overrideContext := *context
+			overrideContext.name = name
+			overrideContext.value = override[name]
+			overrideContext.fieldPath = pathField.Key(name)
+
+			for _, validationFunc := range validationFuncs {
+				if valErrors := validationFunc(&overrideContext); len(valErrors) > 0 {
+					allErrs = append(allErrs, valErrors...)
+					break
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+const (
+	rewritePathFmt    = `/[^\s{};]*`
+	rewritePathErrMsg = "must start with / and must not include any whitespace character, `{`, `}` or `;`"
+)
+
+var rewritePathRegexp = regexp.MustCompile("^" + rewritePathFmt + "$")
+
+func validateRewritePathAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !rewritePathRegexp.MatchString(context.value) {
+		msg := validation.RegexError(rewritePathErrMsg, rewritePathFmt, "/", "/beans/")
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, msg))
+	}
+	return allErrs
+}
+
+func validateLimitReqRateAnnotation(context *annotationValidationContext) field.ErrorList {
+	return cr_validation.ValidateRate(context.value, context.fieldPath)
+}
+
+func validateLimitReqKeyAnnotation(context *annotationValidationContext) field.ErrorList {
+	return cr_validation.ValidateRateLimitKey(context.value, context.fieldPath, context.isPlus)
+}
+
+func validateLimitReqZoneSizeAnnotation(context *annotationValidationContext) field.ErrorList {
+	return cr_validation.ValidateRateLimitZoneSize(context.value, context.fieldPath)
+}
+
+func validateLimitReqRejectCodeAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	code, err := configs.ParseInt(context.value)
+	if err != nil || code < 400 || code > 599 {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be an integer within the range [400-599]"))
+	}
+	return allErrs
+}
+
+func validateDenyListAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if _, exists := context.annotations[allowListAnnotation]; exists {
+		return append(allErrs, field.Forbidden(context.fieldPath, fmt.Sprintf("cannot be used with the %s annotation", allowListAnnotation)))
+	}
+	return allErrs
+}
+
+func validateIPListAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ips := configs.ParseIPList(context.value)
+	if len(ips) == 0 {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be a comma-separated list of IPs or CIDRs"))
+	}
+	for i, ip := range ips {
+		allErrs = append(allErrs, cr_validation.ValidateIPorCIDR(ip, context.fieldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validateResourceNameAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(context.value) {
+		allErrs = append(allErrs, field.Invalid(context.fieldPath, context.value, msg))
+	}
+	return allErrs
+}
+
 func validateLBMethodAnnotation(context *annotationValidationContext) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -549,9 +860,35 @@ func validateServiceListAnnotation(context *annotationValidationContext) field.E
 
 func validateStickyServiceListAnnotation(context *annotationValidationContext) field.ErrorList {
 	allErrs := field.ErrorList{}
-	if _, err := configs.ParseStickyServiceList(context.value); err != nil {
+	services, err := configs.ParseStickyServiceList(context.value)
+	if err != nil {
 		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be a semicolon-separated list of sticky services"))
 	}
+
+	if context.annotations[sessionAffinityModeAnnotation] == "hash" {
+		for _, params := range services {
+			if _, err := configs.ParseHashSessionCookie(params); err != nil {
+				allErrs = append(allErrs, field.Invalid(context.fieldPath, context.value, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateStickyCookieServicesPlusOnlyAnnotation requires NGINX Plus for the sticky-cookie-services annotation,
+// unless the session affinity mode is hash.
+func validateStickyCookieServicesPlusOnlyAnnotation(context *annotationValidationContext) field.ErrorList {
+	if context.annotations[sessionAffinityModeAnnotation] == "hash" {
+		return nil
+	}
+	return validatePlusOnlyAnnotation(context)
+}
+
+func validateSessionAffinityModeAnnotation(context *annotationValidationContext) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if context.value != "sticky" && context.value != "hash" {
+		return append(allErrs, field.Invalid(context.fieldPath, context.value, "must be one of: 'sticky' or 'hash'"))
+	}
 	return allErrs
 }
 
@@ -626,11 +963,54 @@ func validateIngressSpec(spec *networking.IngressSpec, fieldPath *field.Path) fi
 	return allErrs
 }
 
+// validateIngressPaths validates the paths that the Ingress Controller puts into NGINX quoted strings:
+// regular expressions (the ImplementationSpecific pathType or the nginx.org/path-regex annotation)
+// and exact matches set by the nginx.org/path-regex annotation.
+func validateIngressPaths(spec *networking.IngressSpec, pathRegex string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, r := range spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+
+		for j, path := range r.HTTP.Paths {
+			idxPath := fieldPath.Child("rules").Index(i).Child("http").Child("paths").Index(j).Child("path")
+
+			switch {
+			case pathRegex == "exact":
+				if err := cr_validation.ValidateEscapedString(path.Path, "/tea", "/coffee/mocha"); err != nil {
+					allErrs = append(allErrs, field.Invalid(idxPath, path.Path, err.Error()))
+				}
+			case validPathRegexValues[pathRegex],
+				path.PathType != nil && *path.PathType == networking.PathTypeImplementationSpecific:
+				allErrs = append(allErrs, cr_validation.ValidateRegexPath(path.Path, idxPath)...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateBackend(backend *networking.IngressBackend, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if backend.Resource != nil {
-		return append(allErrs, field.Forbidden(fieldPath.Child("resource"), "resource backends are not supported"))
+	if backend.Resource == nil {
+		return allErrs
+	}
+
+	resourcePath := fieldPath.Child("resource")
+
+	if backend.Resource.APIGroup == nil {
+		return append(allErrs, field.Required(resourcePath.Child("apiGroup"), fmt.Sprintf("must be %s", configuration.GroupName)))
+	}
+
+	if *backend.Resource.APIGroup != configuration.GroupName {
+		allErrs = append(allErrs, field.NotSupported(resourcePath.Child("apiGroup"), *backend.Resource.APIGroup, []string{configuration.GroupName}))
+	}
+
+	if backend.Resource.Kind != staticResponseKind {
+		allErrs = append(allErrs, field.NotSupported(resourcePath.Child("kind"), backend.Resource.Kind, []string{staticResponseKind}))
 	}
 
 	return allErrs
@@ -672,6 +1052,37 @@ func validateMinionSpec(spec *networking.IngressSpec, fieldPath *field.Path) fie
 	return allErrs
 }
 
+func validateCanarySpec(spec *networking.IngressSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(spec.TLS) > 0 {
+		allErrs = append(allErrs, field.TooMany(fieldPath.Child("tls"), len(spec.TLS), 0))
+	}
+
+	if spec.DefaultBackend != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("defaultBackend"), "is not allowed for a canary"))
+	}
+
+	if len(spec.Rules) != 1 {
+		return append(allErrs, field.TooMany(fieldPath.Child("rules"), len(spec.Rules), 1))
+	}
+
+	// the number of paths of the first rule of the spec must be greater than 0
+	if spec.Rules[0].HTTP == nil || len(spec.Rules[0].HTTP.Paths) == 0 {
+		pathsField := fieldPath.Child("rules").Index(0).Child("http").Child("paths")
+		return append(allErrs, field.Required(pathsField, "must include at least one path"))
+	}
+
+	for i, path := range spec.Rules[0].HTTP.Paths {
+		if path.Backend.Resource != nil {
+			resourceField := fieldPath.Child("rules").Index(0).Child("http").Child("paths").Index(i).Child("backend").Child("resource")
+			allErrs = append(allErrs, field.Forbidden(resourceField, "resource backends are not allowed for a canary"))
+		}
+	}
+
+	return allErrs
+}
+
 func getSpecServices(ingressSpec networking.IngressSpec) map[string]bool {
 	services := make(map[string]bool)
 	if ingressSpec.DefaultBackend != nil && ingressSpec.DefaultBackend.Service != nil {
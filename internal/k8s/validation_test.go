@@ -1874,6 +1874,444 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 			},
 			msg: "invalid nginx.com/sticky-cookie-services annotation",
 		},
+		{
+			annotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=service-1 srv_id expires=1h path=/service-1 httponly secure;serviceName=service-2 header=X-Session-ID;serviceName=service-3 client-ip",
+				"nginx.org/session-affinity-mode":  "hash",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.com/sticky-cookie-services annotation in the hash mode in OSS",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=service-1 srv_id",
+				"nginx.org/session-affinity-mode":  "sticky",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.com/sticky-cookie-services: Forbidden: annotation requires NGINX Plus",
+			},
+			msg: "invalid nginx.com/sticky-cookie-services annotation in the sticky mode in OSS",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=service-1 srv_id samesite=lax",
+				"nginx.org/session-affinity-mode":  "hash",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.com/sticky-cookie-services: Invalid value: "serviceName=service-1 srv_id samesite=lax": invalid session persistence cookie parameter "samesite=lax"`,
+			},
+			msg: "invalid nginx.com/sticky-cookie-services annotation in the hash mode",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.com/sticky-cookie-services": "serviceName=service-1 srv_id",
+				"nginx.org/session-affinity-mode":  "ip",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                true,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/session-affinity-mode: Invalid value: "ip": must be one of: 'sticky' or 'hash'`,
+			},
+			msg: "invalid nginx.org/session-affinity-mode annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":           "true",
+				"nginx.org/canary-weight":    "20",
+				"nginx.org/canary-by-header": "X-Canary",
+				"nginx.org/canary-by-cookie": "canary",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/canary annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary": "yes",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary: Invalid value: "yes": must be a boolean`,
+			},
+			msg: "invalid nginx.org/canary annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":                 "true",
+				"nginx.org/mergeable-ingress-type": "minion",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary: Forbidden: cannot be used with the nginx.org/mergeable-ingress-type annotation`,
+			},
+			msg: "invalid nginx.org/canary annotation, mergeable Ingress",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary-weight": "20",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-weight: Forbidden: related annotation nginx.org/canary: must be set`,
+			},
+			msg: "invalid nginx.org/canary-weight annotation, canary is not set",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":        "true",
+				"nginx.org/canary-weight": "101",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-weight: Invalid value: "101": must be an integer between 0 and 100`,
+			},
+			msg: "invalid nginx.org/canary-weight annotation, out of range",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":           "true",
+				"nginx.org/canary-by-header": "X Canary",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-header: Invalid value: "X Canary": a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')`,
+			},
+			msg: "invalid nginx.org/canary-by-header annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/canary":           "true",
+				"nginx.org/canary-by-cookie": "canary-cookie",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/canary-by-cookie: Invalid value: "canary-cookie": must consist of alphanumeric characters or '_' (e.g. 'canary',  or 'use_canary', regex used for validation is '[a-zA-Z0-9_]+')`,
+			},
+			msg: "invalid nginx.org/canary-by-cookie annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "case_insensitive",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/path-regex annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-regex": "prefix",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/path-regex: Invalid value: "prefix": must be one of: 'case_sensitive', 'case_insensitive' or 'exact'`,
+			},
+			msg: "invalid nginx.org/path-regex annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-overrides": `{"/coffee": {"proxy-read-timeout": "2m", "client-max-body-size": "10m", "proxy-buffering": "false", "rewrite": "/beans/"}}`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/path-overrides annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-overrides": `{"/coffee": "2m"}`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/path-overrides: Invalid value: "{\"/coffee\": \"2m\"}": must be a JSON object that maps paths to objects with string settings`,
+			},
+			msg: "invalid nginx.org/path-overrides annotation format",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/path-overrides": `{"/coffee": {"proxy-read-timeout": "two minutes", "rewrite": "beans", "lb-method": "round_robin"}}`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/path-overrides[/coffee]: Unsupported value: "lb-method": supported values: "client-max-body-size", "proxy-buffer-size", "proxy-buffering", "proxy-buffers", "proxy-connect-timeout", "proxy-max-temp-file-size", "proxy-read-timeout", "proxy-send-timeout", "rewrite"`,
+				`annotations.nginx.org/path-overrides[/coffee][proxy-read-timeout]: Invalid value: "two minutes": must be a time`,
+				"annotations.nginx.org/path-overrides[/coffee][rewrite]: Invalid value: \"beans\": must start with / and must not include any whitespace character, `{`, `}` or `;` (e.g. '/',  or '/beans/', regex used for validation is '/[^\\s{};]*')",
+			},
+			msg: "invalid nginx.org/path-overrides annotation settings",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-rate":        "10r/s",
+				"nginx.org/limit-req-key":         "${binary_remote_addr}",
+				"nginx.org/limit-req-zone-size":   "10m",
+				"nginx.org/limit-req-burst":       "20",
+				"nginx.org/limit-req-no-delay":    "true",
+				"nginx.org/limit-req-reject-code": "429",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/limit-req annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-rate": "10 per second",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/limit-req-rate: Invalid value: "10 per second": must consist of numeric characters followed by a valid rate suffix. 'r/s|r/m (e.g. '16r/s',  or '32r/m',  or '64r/s', regex used for validation is '[1-9]\d*r/[sSmM]')`,
+			},
+			msg: "invalid nginx.org/limit-req-rate annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-key": "",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/limit-req-key: Required value",
+			},
+			msg: "empty nginx.org/limit-req-key annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-key": `${binary_remote_addr}"`,
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/limit-req-key: Invalid value: "${binary_remote_addr}\"": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. 'Hello World! \n',  or '\"${request_uri}\" is unavailable. \n', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+			msg: "invalid nginx.org/limit-req-key annotation with unescaped quote",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-zone-size": "16k",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/limit-req-zone-size: Invalid value: "16k": must be greater than 31k`,
+			},
+			msg: "invalid nginx.org/limit-req-zone-size annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/limit-req-reject-code": "200",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/limit-req-reject-code: Invalid value: "200": must be an integer within the range [400-599]`,
+			},
+			msg: "invalid nginx.org/limit-req-reject-code annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/allow-list": "10.0.0.0/8, 192.168.1.1",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/allow-list annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/allow-list": "10.0.0.0/8,example.com",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/allow-list[1]: Invalid value: "example.com": must be a CIDR or IP`,
+			},
+			msg: "invalid nginx.org/allow-list annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/allow-list": "10.0.0.0/8",
+				"nginx.org/deny-list":  "10.0.0.1",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/deny-list: Forbidden: cannot be used with the nginx.org/allow-list annotation",
+			},
+			msg: "nginx.org/deny-list annotation with nginx.org/allow-list",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/error-pages": "cafe-error-pages",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/error-pages annotation",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/error-pages": "",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/error-pages: Required value",
+			},
+			msg: "invalid nginx.org/error-pages annotation, empty",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/error-pages": "Cafe_Error_Pages",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/error-pages: Invalid value: "Cafe_Error_Pages": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+			},
+			msg: "invalid nginx.org/error-pages annotation, invalid name",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/ca":                  "cafe-ca",
+				"nginx.org/upstream-tls-secret": "cafe-client-secret",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors:        nil,
+			msg:                   "valid nginx.org/ca and nginx.org/upstream-tls-secret annotations",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/ca": "",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				"annotations.nginx.org/ca: Required value",
+			},
+			msg: "invalid nginx.org/ca annotation, empty",
+		},
+		{
+			annotations: map[string]string{
+				"nginx.org/upstream-tls-secret": "cafe/client-secret",
+			},
+			specServices:          map[string]bool{},
+			isPlus:                false,
+			appProtectEnabled:     false,
+			appProtectDosEnabled:  false,
+			internalRoutesEnabled: false,
+			expectedErrors: []string{
+				`annotations.nginx.org/upstream-tls-secret: Invalid value: "cafe/client-secret": a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+			},
+			msg: "invalid nginx.org/upstream-tls-secret annotation, invalid name",
+		},
 	}
 
 	for _, test := range tests {
@@ -1897,6 +2335,9 @@ func TestValidateNginxIngressAnnotations(t *testing.T) {
 }
 
 func TestValidateIngressSpec(t *testing.T) {
+	apiGroup := "k8s.nginx.org"
+	invalidAPIGroup := "example.com"
+
 	tests := []struct {
 		spec           *networking.IngressSpec
 		expectedErrors []string
@@ -1939,6 +2380,40 @@ func TestValidateIngressSpec(t *testing.T) {
 			expectedErrors: nil,
 			msg:            "valid input with default backend",
 		},
+		{
+			spec: &networking.IngressSpec{
+				DefaultBackend: &networking.IngressBackend{
+					Resource: &v1.TypedLocalObjectReference{
+						APIGroup: &apiGroup,
+						Kind:     "StaticResponse",
+						Name:     "not-found",
+					},
+				},
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/maintenance",
+										Backend: networking.IngressBackend{
+											Resource: &v1.TypedLocalObjectReference{
+												APIGroup: &apiGroup,
+												Kind:     "StaticResponse",
+												Name:     "maintenance",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: nil,
+			msg:            "valid input with StaticResponse backends",
+		},
 		{
 			spec: &networking.IngressSpec{
 				Rules: []networking.IngressRule{},
@@ -1989,7 +2464,7 @@ func TestValidateIngressSpec(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				"spec.defaultBackend.resource: Forbidden: resource backends are not supported",
+				"spec.defaultBackend.resource.apiGroup: Required value: must be k8s.nginx.org",
 			},
 			msg: "invalid default backend",
 		},
@@ -2014,10 +2489,40 @@ func TestValidateIngressSpec(t *testing.T) {
 				},
 			},
 			expectedErrors: []string{
-				"spec.rules[0].http.path[0].backend.resource: Forbidden: resource backends are not supported",
+				"spec.rules[0].http.path[0].backend.resource.apiGroup: Required value: must be k8s.nginx.org",
 			},
 			msg: "invalid backend",
 		},
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/",
+										Backend: networking.IngressBackend{
+											Resource: &v1.TypedLocalObjectReference{
+												APIGroup: &invalidAPIGroup,
+												Kind:     "VirtualServerRoute",
+												Name:     "coffee",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{
+				`spec.rules[0].http.path[0].backend.resource.apiGroup: Unsupported value: "example.com": supported values: "k8s.nginx.org"`,
+				`spec.rules[0].http.path[0].backend.resource.kind: Unsupported value: "VirtualServerRoute": supported values: "StaticResponse"`,
+			},
+			msg: "unsupported resource backend",
+		},
 	}
 
 	for _, test := range tests {
@@ -2029,6 +2534,102 @@ func TestValidateIngressSpec(t *testing.T) {
 	}
 }
 
+func TestValidateIngressPaths(t *testing.T) {
+	prefix := networking.PathTypePrefix
+	impSpec := networking.PathTypeImplementationSpecific
+	createSpec := func(path string, pathType *networking.PathType) *networking.IngressSpec {
+		return &networking.IngressSpec{
+			Rules: []networking.IngressRule{
+				{
+					Host: "foo.example.com",
+					IngressRuleValue: networking.IngressRuleValue{
+						HTTP: &networking.HTTPIngressRuleValue{
+							Paths: []networking.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: pathType,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		spec           *networking.IngressSpec
+		pathRegex      string
+		expectedErrors []string
+		msg            string
+	}{
+		{
+			spec:           createSpec("/tea[", &prefix),
+			pathRegex:      "",
+			expectedErrors: nil,
+			msg:            "prefix path without path-regex",
+		},
+		{
+			spec:           createSpec("/tea/[a-z]+", &impSpec),
+			pathRegex:      "case_sensitive",
+			expectedErrors: nil,
+			msg:            "valid ImplementationSpecific regex path",
+		},
+		{
+			spec:           createSpec("/coffee/(mocha|latte)", &prefix),
+			pathRegex:      "case_insensitive",
+			expectedErrors: nil,
+			msg:            "valid regex path",
+		},
+		{
+			spec:      createSpec("/tea[", &impSpec),
+			pathRegex: "",
+			expectedErrors: []string{
+				"spec.rules[0].http.paths[0].path: Invalid value: \"/tea[\": must be a valid regular expression: error parsing regexp: missing closing ]: `[`",
+			},
+			msg: "invalid ImplementationSpecific path",
+		},
+		{
+			spec:           createSpec("/tea/[a-z]+", &impSpec),
+			pathRegex:      "",
+			expectedErrors: nil,
+			msg:            "valid ImplementationSpecific path",
+		},
+		{
+			spec:      createSpec("/tea[", &impSpec),
+			pathRegex: "case_sensitive",
+			expectedErrors: []string{
+				"spec.rules[0].http.paths[0].path: Invalid value: \"/tea[\": must be a valid regular expression: error parsing regexp: missing closing ]: `[`",
+			},
+			msg: "invalid regex path",
+		},
+		{
+			spec:      createSpec(`/tea"`, &prefix),
+			pathRegex: "case_sensitive",
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Invalid value: "/tea\"": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. '*.jpg',  or '^/images/image_*.png$', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+			msg: "regex path with unescaped quote",
+		},
+		{
+			spec:      createSpec(`/tea"`, &prefix),
+			pathRegex: "exact",
+			expectedErrors: []string{
+				`spec.rules[0].http.paths[0].path: Invalid value: "/tea\"": must have all '"' (double quotes) escaped and must not end with an unescaped '\' (backslash) (e.g. '/tea',  or '/coffee/mocha', regex used for validation is '([^"\\]|\\.)*')`,
+			},
+			msg: "exact path with unescaped quote",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateIngressPaths(test.spec, test.pathRegex, field.NewPath("spec"))
+		assertion := assertErrors("validateIngressPaths()", test.msg, allErrs, test.expectedErrors)
+		if assertion != "" {
+			t.Error(assertion)
+		}
+	}
+}
+
 func TestValidateMasterSpec(t *testing.T) {
 	tests := []struct {
 		spec           *networking.IngressSpec
@@ -2198,6 +2799,113 @@ func TestValidateMinionSpec(t *testing.T) {
 	}
 }
 
+func TestValidateCanarySpec(t *testing.T) {
+	tests := []struct {
+		spec           *networking.IngressSpec
+		expectedErrors []string
+		msg            string
+	}{
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: nil,
+			msg:            "valid input",
+		},
+		{
+			spec: &networking.IngressSpec{
+				TLS: []networking.IngressTLS{
+					{
+						Hosts: []string{"foo.example.com"},
+					},
+				},
+				DefaultBackend: &networking.IngressBackend{},
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"spec.tls: Too many: 1: must have at most 0 items",
+				"spec.defaultBackend: Forbidden: is not allowed for a canary",
+			},
+			msg: "tls and default backend",
+		},
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+					},
+				},
+			},
+			expectedErrors: []string{
+				"spec.rules[0].http.paths: Required value: must include at least one path",
+			},
+			msg: "no paths",
+		},
+		{
+			spec: &networking.IngressSpec{
+				Rules: []networking.IngressRule{
+					{
+						Host: "foo.example.com",
+						IngressRuleValue: networking.IngressRuleValue{
+							HTTP: &networking.HTTPIngressRuleValue{
+								Paths: []networking.HTTPIngressPath{
+									{
+										Path: "/",
+										Backend: networking.IngressBackend{
+											Resource: &v1.TypedLocalObjectReference{
+												Kind: "StaticResponse",
+												Name: "maintenance",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"spec.rules[0].http.paths[0].backend.resource: Forbidden: resource backends are not allowed for a canary",
+			},
+			msg: "resource backend",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateCanarySpec(test.spec, field.NewPath("spec"))
+		assertion := assertErrors("validateCanarySpec()", test.msg, allErrs, test.expectedErrors)
+		if assertion != "" {
+			t.Error(assertion)
+		}
+	}
+}
+
 func assertErrors(funcName string, msg string, allErrs field.ErrorList, expectedErrors []string) string {
 	errors := errorListToStrings(allErrs)
 	if !reflect.DeepEqual(errors, expectedErrors) {
@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// glogHandler is a slog.Handler that formats the messages like glog:
+//
+//	I1018 15:04:05.000000    1234 controller.go:123] message key1=value1 key2=value2
+//
+// The fields of the message are appended as key=value pairs, so that the messages without fields look exactly like
+// the glog messages.
+type glogHandler struct {
+	level slog.Leveler
+	// prefix is the key=value pairs of the fields added via WithAttrs
+	prefix string
+	// groups is the key prefix of the fields added via WithGroup
+	groups string
+
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func newGlogHandler(w io.Writer, level slog.Leveler) *glogHandler {
+	return &glogHandler{
+		level: level,
+		mu:    &sync.Mutex{},
+		w:     w,
+	}
+}
+
+// Enabled implements the slog.Handler interface.
+func (h *glogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements the slog.Handler interface.
+func (h *glogHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+
+	file, line := "???", 1
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		file, line = filepath.Base(frame.File), frame.Line
+	}
+
+	_, month, day := r.Time.Date()
+	hour, minute, second := r.Time.Clock()
+	fmt.Fprintf(&b, "%c%02d%02d %02d:%02d:%02d.%06d %7d %s:%d] %s",
+		levelChar(r.Level), int(month), day, hour, minute, second, r.Time.Nanosecond()/1000, os.Getpid(), file, line, r.Message)
+
+	b.WriteString(h.prefix)
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&b, h.groups, a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *glogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	for _, a := range attrs {
+		appendAttr(&b, h.groups, a)
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + b.String()
+	return &h2
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *glogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = h.groups + name + "."
+	return &h2
+}
+
+func levelChar(level slog.Level) byte {
+	switch {
+	case level >= LevelFatal:
+		return 'F'
+	case level >= LevelError:
+		return 'E'
+	case level >= LevelWarning:
+		return 'W'
+	default:
+		return 'I'
+	}
+}
+
+func appendAttr(b *strings.Builder, groups string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = groups + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(b, groups, ga)
+		}
+		return
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(groups)
+	b.WriteString(a.Key)
+	b.WriteByte('=')
+	b.WriteString(quoteIfNeeded(a.Value.String()))
+}
+
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
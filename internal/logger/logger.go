@@ -0,0 +1,224 @@
+// Package logger implements structured logging of the Ingress Controller based on log/slog.
+//
+// The printf-style functions of the package, such as Infof or Debugf, log messages with a *slog.Logger,
+// so that the messages include the fields of the logger, such as the kind, namespace and name of a resource.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// The levels of the messages. The levels below Info correspond to the glog verbosity levels, so that the -v
+// command-line argument keeps enabling the same messages:
+// -v=2 enables the Debug messages, -v=3 enables the Trace messages.
+const (
+	LevelTrace   = slog.Level(-8)
+	LevelDebug   = slog.LevelDebug
+	LevelInfo    = slog.LevelInfo
+	LevelWarning = slog.LevelWarn
+	LevelError   = slog.LevelError
+	LevelFatal   = slog.Level(12)
+)
+
+// The formats of the log output.
+const (
+	// FormatGlog formats the messages like glog with the fields appended as key=value pairs.
+	FormatGlog = "glog"
+	// FormatJSON formats every message as a JSON object.
+	FormatJSON = "json"
+	// FormatText formats every message as key=value pairs.
+	FormatText = "text"
+)
+
+// The keys of the fields that describe the object of a message.
+const (
+	KindKey      = "kind"
+	NamespaceKey = "namespace"
+	NameKey      = "name"
+	HostKey      = "host"
+	ReloadIDKey  = "reload_id"
+	TaskKeyKey   = "task_key"
+)
+
+// LevelFromVerbosity returns the level that enables the messages of the glog verbosity level.
+func LevelFromVerbosity(verbosity int) slog.Level {
+	switch {
+	case verbosity >= 3:
+		return LevelTrace
+	case verbosity == 2:
+		return LevelDebug
+	default:
+		return LevelInfo
+	}
+}
+
+// New creates a logger that writes the messages of the level and above in the format to the writer.
+func New(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       level,
+		ReplaceAttr: replaceLevelNames,
+	}
+
+	switch format {
+	case FormatGlog:
+		return slog.New(newGlogHandler(w, level)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported log format %q, must be one of %s, %s or %s", format, FormatGlog, FormatJSON, FormatText)
+}
+
+var levelNames = map[slog.Level]string{
+	LevelTrace: "TRACE",
+	LevelFatal: "FATAL",
+}
+
+func replaceLevelNames(_ []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey {
+		return a
+	}
+
+	if level, ok := a.Value.Any().(slog.Level); ok {
+		if name, exists := levelNames[level]; exists {
+			a.Value = slog.StringValue(name)
+		}
+	}
+
+	return a
+}
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a context that includes the logger.
+func ContextWithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// LoggerFromContext returns the logger included in the context or the default logger.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+
+	return slog.Default()
+}
+
+// With returns a logger that adds the fields to the messages. If the logger is nil, the default logger is used.
+func With(l *slog.Logger, args ...interface{}) *slog.Logger {
+	return orDefault(l).With(args...)
+}
+
+// WithResource returns a logger that adds the kind, namespace and name of a resource to the messages.
+func WithResource(l *slog.Logger, kind string, namespace string, name string) *slog.Logger {
+	return orDefault(l).With(KindKey, kind, NamespaceKey, namespace, NameKey, name)
+}
+
+// Tracef logs a message at the Trace level. If the logger is nil, the default logger is used.
+func Tracef(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelTrace, format, args...)
+}
+
+// Trace logs a message at the Trace level. If the logger is nil, the default logger is used.
+func Trace(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelTrace, args...)
+}
+
+// Debugf logs a message at the Debug level. If the logger is nil, the default logger is used.
+func Debugf(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelDebug, format, args...)
+}
+
+// Debug logs a message at the Debug level. If the logger is nil, the default logger is used.
+func Debug(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelDebug, args...)
+}
+
+// Infof logs a message at the Info level. If the logger is nil, the default logger is used.
+func Infof(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelInfo, format, args...)
+}
+
+// Info logs a message at the Info level. If the logger is nil, the default logger is used.
+func Info(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelInfo, args...)
+}
+
+// Warnf logs a message at the Warning level. If the logger is nil, the default logger is used.
+func Warnf(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelWarning, format, args...)
+}
+
+// Warn logs a message at the Warning level. If the logger is nil, the default logger is used.
+func Warn(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelWarning, args...)
+}
+
+// Errorf logs a message at the Error level. If the logger is nil, the default logger is used.
+func Errorf(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelError, format, args...)
+}
+
+// Error logs a message at the Error level. If the logger is nil, the default logger is used.
+func Error(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelError, args...)
+}
+
+// Fatalf logs a message at the Fatal level and exits with the status 255, like glog.Fatalf.
+// If the logger is nil, the default logger is used.
+func Fatalf(l *slog.Logger, format string, args ...interface{}) {
+	logf(l, LevelFatal, format, args...)
+	os.Exit(255)
+}
+
+// Fatal logs a message at the Fatal level and exits with the status 255, like glog.Fatal.
+// If the logger is nil, the default logger is used.
+func Fatal(l *slog.Logger, args ...interface{}) {
+	logArgs(l, LevelFatal, args...)
+	os.Exit(255)
+}
+
+func logf(l *slog.Logger, level slog.Level, format string, args ...interface{}) {
+	l = orDefault(l)
+	if !l.Enabled(context.Background(), level) {
+		return
+	}
+	write(l, level, fmt.Sprintf(format, args...))
+}
+
+func logArgs(l *slog.Logger, level slog.Level, args ...interface{}) {
+	l = orDefault(l)
+	if !l.Enabled(context.Background(), level) {
+		return
+	}
+	write(l, level, fmt.Sprint(args...))
+}
+
+// write logs the message with the source of the caller of the exported function of the package.
+func write(l *slog.Logger, level slog.Level, msg string) {
+	var pcs [1]uintptr
+	// skip runtime.Callers, write, logf or logArgs and the exported function
+	runtime.Callers(4, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level, strings.TrimSuffix(msg, "\n"), pcs[0])
+	_ = l.Handler().Handle(context.Background(), r)
+}
+
+func orDefault(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
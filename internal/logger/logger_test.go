@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestLevelFromVerbosity(t *testing.T) {
+	tests := []struct {
+		verbosity int
+		expected  string
+	}{
+		{verbosity: 0, expected: "INFO"},
+		{verbosity: 1, expected: "INFO"},
+		{verbosity: 2, expected: "DEBUG"},
+		{verbosity: 3, expected: "DEBUG-4"},
+		{verbosity: 4, expected: "DEBUG-4"},
+	}
+
+	for _, test := range tests {
+		result := LevelFromVerbosity(test.verbosity).String()
+		if result != test.expected {
+			t.Errorf("LevelFromVerbosity(%d) returned %v but expected %v", test.verbosity, result, test.expected)
+		}
+	}
+}
+
+func TestNewWithUnsupportedFormat(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "xml", LevelInfo)
+	if err == nil {
+		t.Errorf("New() returned no error for an unsupported format")
+	}
+}
+
+func TestGlogFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := New(&buf, FormatGlog, LevelDebug)
+	if err != nil {
+		t.Fatalf("New() returned an unexpected error: %v", err)
+	}
+
+	l = WithResource(l, "VirtualServer", "default", "cafe")
+
+	Tracef(l, "not logged at the %s level", "Debug")
+	Debugf(l, "Adding or Updating VirtualServer: %v\n", "default/cafe")
+	Warn(l.With(HostKey, "cafe.example.com"), "host is taken")
+
+	expected := regexp.MustCompile(`^I\d{4} \d{2}:\d{2}:\d{2}\.\d{6} +\d+ logger_test\.go:\d+\] Adding or Updating VirtualServer: default/cafe kind=VirtualServer namespace=default name=cafe
+W\d{4} \d{2}:\d{2}:\d{2}\.\d{6} +\d+ logger_test\.go:\d+\] host is taken kind=VirtualServer namespace=default name=cafe host=cafe\.example\.com
+$`)
+
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("the glog handler produced unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := New(&buf, FormatJSON, LevelTrace)
+	if err != nil {
+		t.Fatalf("New() returned an unexpected error: %v", err)
+	}
+
+	Tracef(l.With(TaskKeyKey, "default/cafe"), "Syncing %v", "default/cafe")
+
+	var msg map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &msg); err != nil {
+		t.Fatalf("the JSON handler produced invalid JSON %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"level":    "TRACE",
+		"msg":      "Syncing default/cafe",
+		"task_key": "default/cafe",
+	}
+	for k, v := range expected {
+		if msg[k] != v {
+			t.Errorf("the JSON message has %v=%v but expected %v", k, msg[k], v)
+		}
+	}
+
+	source, ok := msg["source"].(map[string]interface{})
+	if !ok || !regexp.MustCompile(`logger_test\.go$`).MatchString(source["file"].(string)) {
+		t.Errorf("the JSON message has source %v but expected the caller of Tracef", msg["source"])
+	}
+}
@@ -1,8 +1,15 @@
 package collectors
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"time"
 
-var labelNamesController = []string{"type"}
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	labelNamesController = []string{"type"}
+	labelNamesKind       = []string{"kind"}
+)
 
 // ControllerCollector is an interface for the metrics of the Controller
 type ControllerCollector interface {
@@ -10,6 +17,11 @@ type ControllerCollector interface {
 	SetVirtualServers(count int)
 	SetVirtualServerRoutes(count int)
 	SetTransportServers(tlsPassthroughCount, tcpCount, udpCount int)
+	IncNginxReloadsAvoided()
+	IncNginxReloadsByResourceKind(kind string)
+	ObserveSyncLatency(kind string, latency time.Duration)
+	ObserveConfigGeneration(kind string, duration time.Duration, size int)
+	ObserveResourcesAffected(kind string, count int)
 	Register(registry *prometheus.Registry) error
 }
 
@@ -20,6 +32,12 @@ type ControllerMetricsCollector struct {
 	virtualServersTotal      prometheus.Gauge
 	virtualServerRoutesTotal prometheus.Gauge
 	transportServersTotal    *prometheus.GaugeVec
+	reloadsAvoidedTotal      prometheus.Counter
+	reloadsByKindTotal       *prometheus.CounterVec
+	syncLatency              *prometheus.HistogramVec
+	configGenerationDuration *prometheus.HistogramVec
+	configSize               *prometheus.HistogramVec
+	resourcesAffected        *prometheus.HistogramVec
 }
 
 // NewControllerMetricsCollector creates a new ControllerMetricsCollector
@@ -67,12 +85,81 @@ func NewControllerMetricsCollector(crdsEnabled bool, constLabels map[string]stri
 		)
 	}
 
+	reloadsAvoidedTotal := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name:        "nginx_reloads_avoided_total",
+			Namespace:   metricsNamespace,
+			Help:        "Number of NGINX reloads avoided by updating TLS Secrets without a reload",
+			ConstLabels: constLabels,
+		},
+	)
+
+	reloadsByKindTotal := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "nginx_reloads_by_resource_kind_total",
+			Namespace:   metricsNamespace,
+			Help:        "Number of successful NGINX reloads by the kind of the resource that triggered the reload",
+			ConstLabels: constLabels,
+		},
+		labelNamesKind,
+	)
+
+	syncLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "resource_sync_latency_seconds",
+			Namespace:   metricsNamespace,
+			Help:        "Time from enqueueing a change of a resource to applying it by the kind of the resource",
+			ConstLabels: constLabels,
+			Buckets:     []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		labelNamesKind,
+	)
+
+	configGenerationDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "config_generation_seconds",
+			Namespace:   metricsNamespace,
+			Help:        "Time of generating the NGINX config of a resource from the template by the kind of the resource",
+			ConstLabels: constLabels,
+			Buckets:     []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+		},
+		labelNamesKind,
+	)
+
+	configSize := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "config_size_bytes",
+			Namespace:   metricsNamespace,
+			Help:        "Size in bytes of the generated NGINX config of a resource by the kind of the resource",
+			ConstLabels: constLabels,
+			Buckets:     prometheus.ExponentialBuckets(1024, 4, 8),
+		},
+		labelNamesKind,
+	)
+
+	resourcesAffected := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "resources_affected",
+			Namespace:   metricsNamespace,
+			Help:        "Number of resources affected by a change of a resource by the kind of the changed resource",
+			ConstLabels: constLabels,
+			Buckets:     []float64{0, 1, 2, 5, 10, 25, 50, 100, 250},
+		},
+		labelNamesKind,
+	)
+
 	c := &ControllerMetricsCollector{
 		crdsEnabled:              crdsEnabled,
 		ingressesTotal:           ingResTotal,
 		virtualServersTotal:      vsResTotal,
 		virtualServerRoutesTotal: vsrResTotal,
 		transportServersTotal:    tsResTotal,
+		reloadsAvoidedTotal:      reloadsAvoidedTotal,
+		reloadsByKindTotal:       reloadsByKindTotal,
+		syncLatency:              syncLatency,
+		configGenerationDuration: configGenerationDuration,
+		configSize:               configSize,
+		resourcesAffected:        resourcesAffected,
 	}
 
 	// if we don't set to 0 metrics with the label type, the metrics will not be created initially
@@ -110,9 +197,41 @@ func (cc *ControllerMetricsCollector) SetTransportServers(tlsPassthroughCount, t
 	cc.transportServersTotal.WithLabelValues("udp").Set(float64(udpCount))
 }
 
+// IncNginxReloadsAvoided increments the counter of NGINX reloads avoided by updating TLS Secrets without a reload
+func (cc *ControllerMetricsCollector) IncNginxReloadsAvoided() {
+	cc.reloadsAvoidedTotal.Inc()
+}
+
+// IncNginxReloadsByResourceKind increments the counter of NGINX reloads triggered by a change of a resource of the kind
+func (cc *ControllerMetricsCollector) IncNginxReloadsByResourceKind(kind string) {
+	cc.reloadsByKindTotal.WithLabelValues(kind).Inc()
+}
+
+// ObserveSyncLatency adds an observation of the time from enqueueing a change of a resource of the kind to applying it
+func (cc *ControllerMetricsCollector) ObserveSyncLatency(kind string, latency time.Duration) {
+	cc.syncLatency.WithLabelValues(kind).Observe(latency.Seconds())
+}
+
+// ObserveConfigGeneration adds observations of the time and the size of the generated NGINX config of a resource of the kind
+func (cc *ControllerMetricsCollector) ObserveConfigGeneration(kind string, duration time.Duration, size int) {
+	cc.configGenerationDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	cc.configSize.WithLabelValues(kind).Observe(float64(size))
+}
+
+// ObserveResourcesAffected adds an observation of the number of resources affected by a change of a resource of the kind
+func (cc *ControllerMetricsCollector) ObserveResourcesAffected(kind string, count int) {
+	cc.resourcesAffected.WithLabelValues(kind).Observe(float64(count))
+}
+
 // Describe implements prometheus.Collector interface Describe method
 func (cc *ControllerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	cc.ingressesTotal.Describe(ch)
+	cc.reloadsAvoidedTotal.Describe(ch)
+	cc.reloadsByKindTotal.Describe(ch)
+	cc.syncLatency.Describe(ch)
+	cc.configGenerationDuration.Describe(ch)
+	cc.configSize.Describe(ch)
+	cc.resourcesAffected.Describe(ch)
 	if cc.crdsEnabled {
 		cc.virtualServersTotal.Describe(ch)
 		cc.virtualServerRoutesTotal.Describe(ch)
@@ -123,6 +242,12 @@ func (cc *ControllerMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 // Collect implements the prometheus.Collector interface Collect method
 func (cc *ControllerMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	cc.ingressesTotal.Collect(ch)
+	cc.reloadsAvoidedTotal.Collect(ch)
+	cc.reloadsByKindTotal.Collect(ch)
+	cc.syncLatency.Collect(ch)
+	cc.configGenerationDuration.Collect(ch)
+	cc.configSize.Collect(ch)
+	cc.resourcesAffected.Collect(ch)
 	if cc.crdsEnabled {
 		cc.virtualServersTotal.Collect(ch)
 		cc.virtualServerRoutesTotal.Collect(ch)
@@ -157,3 +282,18 @@ func (cc *ControllerFakeCollector) SetVirtualServerRoutes(_ int) {}
 
 // SetTransportServers implements a fake SetTransportServers
 func (cc *ControllerFakeCollector) SetTransportServers(int, int, int) {}
+
+// IncNginxReloadsAvoided implements a fake IncNginxReloadsAvoided
+func (cc *ControllerFakeCollector) IncNginxReloadsAvoided() {}
+
+// IncNginxReloadsByResourceKind implements a fake IncNginxReloadsByResourceKind
+func (cc *ControllerFakeCollector) IncNginxReloadsByResourceKind(_ string) {}
+
+// ObserveSyncLatency implements a fake ObserveSyncLatency
+func (cc *ControllerFakeCollector) ObserveSyncLatency(_ string, _ time.Duration) {}
+
+// ObserveConfigGeneration implements a fake ObserveConfigGeneration
+func (cc *ControllerFakeCollector) ObserveConfigGeneration(_ string, _ time.Duration, _ int) {}
+
+// ObserveResourcesAffected implements a fake ObserveResourcesAffected
+func (cc *ControllerFakeCollector) ObserveResourcesAffected(_ string, _ int) {}
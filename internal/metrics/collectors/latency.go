@@ -3,11 +3,12 @@ package collectors
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 	"sync"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -141,7 +142,7 @@ func (l *LatencyMetricsCollector) DeleteMetrics(upstreamServerPeerNames []string
 		for _, labelValues := range l.listAndDeleteMetricsPublished(name) {
 			success := l.httpLatency.DeleteLabelValues(labelValues...)
 			if !success {
-				glog.Warningf("could not delete metric for upstream server peer: %s with values: %v", name, labelValues)
+				nl.Warnf(slog.Default(), "could not delete metric for upstream server peer: %s with values: %v", name, labelValues)
 			}
 		}
 	}
@@ -178,12 +179,12 @@ func (l *LatencyMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 func (l *LatencyMetricsCollector) RecordLatency(syslogMsg string) {
 	lm, err := parseMessage(syslogMsg)
 	if err != nil {
-		glog.V(3).Infof("could not parse syslog message: %v", err)
+		nl.Tracef(slog.Default(), "could not parse syslog message: %v", err)
 		return
 	}
 	labelValues, err := l.createLatencyLabelValues(lm)
 	if err != nil {
-		glog.Errorf("cannot record latency for upstream %s and server %s: %v", lm.Upstream, lm.Server, err)
+		nl.Errorf(slog.Default(), "cannot record latency for upstream %s and server %s: %v", lm.Upstream, lm.Server, err)
 		return
 	}
 	l.httpLatency.WithLabelValues(labelValues...).Observe(lm.Latency * 1000)
@@ -3,12 +3,13 @@ package collectors
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -36,7 +37,7 @@ func NewNginxProcessesMetricsCollector(constLabels map[string]string) *NginxProc
 func (pc *NginxProcessesMetricsCollector) updateWorkerProcessCount() {
 	currWorkerProcesses, prevWorkerPrcesses, err := getWorkerProcesses()
 	if err != nil {
-		glog.Errorf("unable to collect process metrics : %v", err)
+		nl.Errorf(slog.Default(), "unable to collect process metrics : %v", err)
 		return
 	}
 	pc.workerProcessTotal.WithLabelValues("current").Set(float64(currWorkerProcesses))
@@ -2,11 +2,12 @@ package metrics
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/nginxinc/kubernetes-ingress/internal/nginx"
 	prometheusClient "github.com/nginxinc/nginx-prometheus-exporter/client"
 	nginxCollector "github.com/nginxinc/nginx-prometheus-exporter/collector"
@@ -47,13 +48,13 @@ func runServer(port string, registry prometheus.Gatherer, prometheusSecret *api_
 			</body>
 			</html>`))
 		if err != nil {
-			glog.Warningf("Error while sending a response for the '/' path: %v", err)
+			nl.Warnf(slog.Default(), "Error while sending a response for the '/' path: %v", err)
 		}
 	})
 	address := fmt.Sprintf(":%v", port)
-	glog.Infof("Starting Prometheus listener on: %v%v", address, metricsEndpoint)
+	nl.Infof(slog.Default(), "Starting Prometheus listener on: %v%v", address, metricsEndpoint)
 	if prometheusSecret == nil {
-		glog.Fatal("Error in Prometheus listener server: ", http.ListenAndServe(address, nil))
+		nl.Fatal(slog.Default(), "Error in Prometheus listener server: ", http.ListenAndServe(address, nil))
 	} else {
 		// Unfortunately, http.ListenAndServeTLS() takes a filename instead of cert/key data, so we
 		// Write the cert and key to a temporary file. We create a unique file name to prevent collisions.
@@ -61,15 +62,15 @@ func runServer(port string, registry prometheus.Gatherer, prometheusSecret *api_
 		keyFileName := "nginx-prometheus.key"
 		certFile, err := writeTempFile(prometheusSecret.Data[api_v1.TLSCertKey], certFileName)
 		if err != nil {
-			glog.Fatal("failed to create cert file for prometheus: %w", err)
+			nl.Fatal(slog.Default(), "failed to create cert file for prometheus: %w", err)
 		}
 
 		keyFile, err := writeTempFile(prometheusSecret.Data[api_v1.TLSPrivateKeyKey], keyFileName)
 		if err != nil {
-			glog.Fatal("failed to create key file for prometheus: %w", err)
+			nl.Fatal(slog.Default(), "failed to create key file for prometheus: %w", err)
 		}
 
-		glog.Fatal("Error in Prometheus listener server: ", http.ListenAndServeTLS(address, certFile.Name(), keyFile.Name(), nil))
+		nl.Fatal(slog.Default(), "Error in Prometheus listener server: ", http.ListenAndServeTLS(address, certFile.Name(), keyFile.Name(), nil))
 	}
 }
 
@@ -2,10 +2,10 @@ package metrics
 
 import (
 	"errors"
+	"log/slog"
 	"net"
 
-	"github.com/golang/glog"
-
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 )
 
@@ -26,13 +26,13 @@ type LatencyMetricsListener struct {
 // NewLatencyMetricsListener returns a LatencyMetricsListener that listens over a unix socket
 // for syslog messages from nginx.
 func NewLatencyMetricsListener(sockPath string, c collectors.LatencyCollector) SyslogListener {
-	glog.Infof("Starting latency metrics server listening on: %s", sockPath)
+	nl.Infof(slog.Default(), "Starting latency metrics server listening on: %s", sockPath)
 	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{
 		Name: sockPath,
 		Net:  "unixgram",
 	})
 	if err != nil {
-		glog.Errorf("Failed to create latency metrics listener: %v. Latency metrics will not be collected.", err)
+		nl.Errorf(slog.Default(), "Failed to create latency metrics listener: %v. Latency metrics will not be collected.", err)
 		return NewSyslogFakeServer()
 	}
 	return &LatencyMetricsListener{conn: conn, addr: sockPath, collector: c}
@@ -45,7 +45,7 @@ func (l LatencyMetricsListener) Run() {
 		n, err := l.conn.Read(buffer)
 		if err != nil {
 			if !isErrorRecoverable(err) {
-				glog.Info("Stopping latency metrics listener")
+				nl.Info(slog.Default(), "Stopping latency metrics listener")
 				return
 			}
 		}
@@ -57,7 +57,7 @@ func (l LatencyMetricsListener) Run() {
 func (l LatencyMetricsListener) Stop() {
 	err := l.conn.Close()
 	if err != nil {
-		glog.Errorf("error closing latency metrics unix connection: %v", err)
+		nl.Errorf(slog.Default(), "error closing latency metrics unix connection: %v", err)
 	}
 }
 
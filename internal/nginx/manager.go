@@ -1,7 +1,9 @@
 package nginx
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
@@ -12,7 +14,8 @@ import (
 
 	"github.com/nginxinc/kubernetes-ingress/internal/metrics/collectors"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+	"github.com/nginxinc/kubernetes-ingress/internal/tracing"
 	"github.com/nginxinc/nginx-plus-go-client/client"
 )
 
@@ -53,6 +56,26 @@ type ServerConfig struct {
 	MaxConns    int
 	FailTimeout string
 	SlowStart   string
+	// Weights holds the weights of the servers. A server without a weight has the default weight.
+	Weights map[ServerKey]int
+	// BackupServers holds the backup servers.
+	BackupServers map[ServerKey]bool
+}
+
+// ServerKey identifies a server of an upstream. The same address can belong to several Services of an upstream,
+// so a server is identified by its Service and address. The Service is empty for the main Service of the upstream.
+type ServerKey struct {
+	Service string
+	Address string
+}
+
+// NewServerKeys creates the keys of the servers of the main Service of an upstream.
+func NewServerKeys(addresses []string) []ServerKey {
+	keys := make([]ServerKey, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, ServerKey{Address: address})
+	}
+	return keys
 }
 
 // The Manager interface updates NGINX configuration, starts, reloads and quits NGINX,
@@ -60,8 +83,10 @@ type ServerConfig struct {
 type Manager interface {
 	CreateMainConfig(content []byte)
 	CreateConfig(name string, content []byte)
+	GetConfig(name string) ([]byte, error)
 	DeleteConfig(name string)
 	CreateStreamConfig(name string, content []byte)
+	GetStreamConfig(name string) ([]byte, error)
 	DeleteStreamConfig(name string)
 	CreateTLSPassthroughHostsConfig(content []byte)
 	CreateSecret(name string, content []byte, mode os.FileMode) string
@@ -70,15 +95,17 @@ type Manager interface {
 	DeleteAppProtectResourceFile(name string)
 	ClearAppProtectFolder(name string)
 	GetFilenameForSecret(name string) string
+	CreateErrorPages(name string, files map[string][]byte) string
+	DeleteErrorPages(name string)
 	CreateDHParam(content string) (string, error)
 	CreateOpenTracingTracerConfig(content string) error
 	Start(done chan error)
 	Version() string
-	Reload(isEndpointsUpdate bool) error
+	Reload(ctx context.Context, isEndpointsUpdate bool) error
 	Quit()
 	UpdateConfigVersionFile(openTracing bool)
 	SetPlusClients(plusClient *client.NginxClient, plusConfigVersionCheckClient *http.Client)
-	UpdateServersInPlus(upstream string, servers []string, config ServerConfig) error
+	UpdateServersInPlus(upstream string, servers []ServerKey, config ServerConfig) error
 	UpdateStreamServersInPlus(upstream string, servers []string) error
 	SetOpenTracing(openTracing bool)
 	AppProtectAgentStart(apaDone chan error, debug bool)
@@ -95,6 +122,7 @@ type LocalManager struct {
 	confdPath                    string
 	streamConfdPath              string
 	secretsPath                  string
+	errorPagesPath               string
 	mainConfFilename             string
 	configVersionFilename        string
 	debug                        bool
@@ -110,19 +138,21 @@ type LocalManager struct {
 	appProtectPluginPid          int
 	appProtectAgentPid           int
 	appProtectDosAgentPid        int
+	logger                       *slog.Logger
 }
 
 // NewLocalManager creates a LocalManager.
-func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector, timeout time.Duration) *LocalManager {
+func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector, timeout time.Duration, logger *slog.Logger) *LocalManager {
 	verifyConfigGenerator, err := newVerifyConfigGenerator()
 	if err != nil {
-		glog.Fatalf("error instantiating a verifyConfigGenerator: %v", err)
+		nl.Fatalf(logger, "error instantiating a verifyConfigGenerator: %v", err)
 	}
 
 	manager := LocalManager{
 		confdPath:                   path.Join(confPath, "conf.d"),
 		streamConfdPath:             path.Join(confPath, "stream-conf.d"),
 		secretsPath:                 path.Join(confPath, "secrets"),
+		errorPagesPath:              path.Join(confPath, "error-pages"),
 		dhparamFilename:             path.Join(confPath, "secrets", "dhparam.pem"),
 		mainConfFilename:            path.Join(confPath, "nginx.conf"),
 		configVersionFilename:       path.Join(confPath, "config-version.conf"),
@@ -132,6 +162,7 @@ func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector
 		configVersion:               0,
 		verifyClient:                newVerifyClient(timeout),
 		metricsCollector:            mc,
+		logger:                      logger,
 	}
 
 	return &manager
@@ -139,12 +170,12 @@ func NewLocalManager(confPath string, debug bool, mc collectors.ManagerCollector
 
 // CreateMainConfig creates the main NGINX configuration file. If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateMainConfig(content []byte) {
-	glog.V(3).Infof("Writing main config to %v", lm.mainConfFilename)
-	glog.V(3).Infof(string(content))
+	nl.Tracef(lm.logger, "Writing main config to %v", lm.mainConfFilename)
+	nl.Tracef(lm.logger, string(content))
 
 	err := createFileAndWrite(lm.mainConfFilename, content)
 	if err != nil {
-		glog.Fatalf("Failed to write main config: %v", err)
+		nl.Fatalf(lm.logger, "Failed to write main config: %v", err)
 	}
 }
 
@@ -154,25 +185,30 @@ func (lm *LocalManager) CreateConfig(name string, content []byte) {
 }
 
 func createConfig(filename string, content []byte) {
-	glog.V(3).Infof("Writing config to %v", filename)
-	glog.V(3).Info(string(content))
+	nl.Tracef(slog.Default(), "Writing config to %v", filename)
+	nl.Trace(slog.Default(), string(content))
 
 	err := createFileAndWrite(filename, content)
 	if err != nil {
-		glog.Fatalf("Failed to write config to %v: %v", filename, err)
+		nl.Fatalf(slog.Default(), "Failed to write config to %v: %v", filename, err)
 	}
 }
 
+// GetConfig returns the content of the configuration file from the conf.d folder.
+func (lm *LocalManager) GetConfig(name string) ([]byte, error) {
+	return os.ReadFile(lm.getFilenameForConfig(name))
+}
+
 // DeleteConfig deletes the configuration file from the conf.d folder.
 func (lm *LocalManager) DeleteConfig(name string) {
 	deleteConfig(lm.getFilenameForConfig(name))
 }
 
 func deleteConfig(filename string) {
-	glog.V(3).Infof("Deleting config from %v", filename)
+	nl.Tracef(slog.Default(), "Deleting config from %v", filename)
 
 	if err := os.Remove(filename); err != nil {
-		glog.Warningf("Failed to delete config from %v: %v", filename, err)
+		nl.Warnf(slog.Default(), "Failed to delete config from %v: %v", filename, err)
 	}
 }
 
@@ -186,6 +222,11 @@ func (lm *LocalManager) CreateStreamConfig(name string, content []byte) {
 	createConfig(lm.getFilenameForStreamConfig(name), content)
 }
 
+// GetStreamConfig returns the content of the configuration file from the stream-conf.d folder.
+func (lm *LocalManager) GetStreamConfig(name string) ([]byte, error) {
+	return os.ReadFile(lm.getFilenameForStreamConfig(name))
+}
+
 // DeleteStreamConfig deletes the configuration file from the stream-conf.d folder.
 func (lm *LocalManager) DeleteStreamConfig(name string) {
 	deleteConfig(lm.getFilenameForStreamConfig(name))
@@ -199,7 +240,7 @@ func (lm *LocalManager) getFilenameForStreamConfig(name string) string {
 // the corresponding unix sockets.
 // If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateTLSPassthroughHostsConfig(content []byte) {
-	glog.V(3).Infof("Writing TLS Passthrough Hosts config file to %v", lm.tlsPassthroughHostsFilename)
+	nl.Tracef(lm.logger, "Writing TLS Passthrough Hosts config file to %v", lm.tlsPassthroughHostsFilename)
 	createConfig(lm.tlsPassthroughHostsFilename, content)
 }
 
@@ -208,7 +249,7 @@ func (lm *LocalManager) CreateTLSPassthroughHostsConfig(content []byte) {
 func (lm *LocalManager) CreateSecret(name string, content []byte, mode os.FileMode) string {
 	filename := lm.GetFilenameForSecret(name)
 
-	glog.V(3).Infof("Writing secret to %v", filename)
+	nl.Tracef(lm.logger, "Writing secret to %v", filename)
 
 	createFileAndWriteAtomically(filename, lm.secretsPath, mode, content)
 
@@ -219,10 +260,10 @@ func (lm *LocalManager) CreateSecret(name string, content []byte, mode os.FileMo
 func (lm *LocalManager) DeleteSecret(name string) {
 	filename := lm.GetFilenameForSecret(name)
 
-	glog.V(3).Infof("Deleting secret from %v", filename)
+	nl.Tracef(lm.logger, "Deleting secret from %v", filename)
 
 	if err := os.Remove(filename); err != nil {
-		glog.Warningf("Failed to delete secret from %v: %v", filename, err)
+		nl.Warnf(lm.logger, "Failed to delete secret from %v: %v", filename, err)
 	}
 }
 
@@ -231,9 +272,41 @@ func (lm *LocalManager) GetFilenameForSecret(name string) string {
 	return path.Join(lm.secretsPath, name)
 }
 
+// CreateErrorPages replaces the files of the custom error pages in the folder with the name.
+// It returns the path of the folder.
+func (lm *LocalManager) CreateErrorPages(name string, files map[string][]byte) string {
+	folder := path.Join(lm.errorPagesPath, name)
+
+	nl.Tracef(lm.logger, "Writing error pages to %v", folder)
+
+	if err := os.RemoveAll(folder); err != nil {
+		nl.Fatalf(lm.logger, "Failed to clear the error pages folder %v: %v", folder, err)
+	}
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		nl.Fatalf(lm.logger, "Failed to create the error pages folder %v: %v", folder, err)
+	}
+
+	for filename, content := range files {
+		if err := createFileAndWrite(path.Join(folder, filename), content); err != nil {
+			nl.Fatalf(lm.logger, "Failed to write the error page %v: %v", filename, err)
+		}
+	}
+
+	return folder
+}
+
+// DeleteErrorPages deletes the folder with the files of the custom error pages.
+func (lm *LocalManager) DeleteErrorPages(name string) {
+	folder := path.Join(lm.errorPagesPath, name)
+
+	if err := os.RemoveAll(folder); err != nil {
+		nl.Warnf(lm.logger, "Failed to delete the error pages folder %v: %v", folder, err)
+	}
+}
+
 // CreateDHParam creates the servers dhparam.pem file. If the file already exists, it will be overridden.
 func (lm *LocalManager) CreateDHParam(content string) (string, error) {
-	glog.V(3).Infof("Writing dhparam file to %v", lm.dhparamFilename)
+	nl.Tracef(lm.logger, "Writing dhparam file to %v", lm.dhparamFilename)
 
 	err := createFileAndWrite(lm.dhparamFilename, []byte(content))
 	if err != nil {
@@ -245,10 +318,10 @@ func (lm *LocalManager) CreateDHParam(content string) (string, error) {
 
 // CreateAppProtectResourceFile writes contents of An App Protect resource to a file
 func (lm *LocalManager) CreateAppProtectResourceFile(name string, content []byte) {
-	glog.V(3).Infof("Writing App Protect Resource to %v", name)
+	nl.Tracef(lm.logger, "Writing App Protect Resource to %v", name)
 	err := createFileAndWrite(name, content)
 	if err != nil {
-		glog.Fatalf("Failed to write App Protect Resource to %v: %v", name, err)
+		nl.Fatalf(lm.logger, "Failed to write App Protect Resource to %v: %v", name, err)
 	}
 }
 
@@ -257,7 +330,7 @@ func (lm *LocalManager) DeleteAppProtectResourceFile(name string) {
 	// This check is done to avoid errors in case eg. a policy is referenced, but it never became valid.
 	if _, err := os.Stat(name); !os.IsNotExist(err) {
 		if err := os.Remove(name); err != nil {
-			glog.Fatalf("Failed to delete App Protect Resource from %v: %v", name, err)
+			nl.Fatalf(lm.logger, "Failed to delete App Protect Resource from %v: %v", name, err)
 		}
 	}
 }
@@ -266,7 +339,7 @@ func (lm *LocalManager) DeleteAppProtectResourceFile(name string) {
 func (lm *LocalManager) ClearAppProtectFolder(name string) {
 	files, err := os.ReadDir(name)
 	if err != nil {
-		glog.Fatalf("Failed to read the App Protect folder %s: %v", name, err)
+		nl.Fatalf(lm.logger, "Failed to read the App Protect folder %s: %v", name, err)
 	}
 	for _, file := range files {
 		lm.DeleteAppProtectResourceFile(fmt.Sprintf("%s/%s", name, file.Name()))
@@ -275,14 +348,14 @@ func (lm *LocalManager) ClearAppProtectFolder(name string) {
 
 // Start starts NGINX.
 func (lm *LocalManager) Start(done chan error) {
-	glog.V(3).Info("Starting nginx")
+	nl.Trace(lm.logger, "Starting nginx")
 
 	binaryFilename := getBinaryFileName(lm.debug)
 	cmd := exec.Command(binaryFilename, "-e", "stderr") // #nosec G204
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Start(); err != nil {
-		glog.Fatalf("Failed to start nginx: %v", err)
+		nl.Fatalf(lm.logger, "Failed to start nginx: %v", err)
 	}
 
 	go func() {
@@ -290,17 +363,25 @@ func (lm *LocalManager) Start(done chan error) {
 	}()
 	err := lm.verifyClient.WaitForCorrectVersion(lm.configVersion)
 	if err != nil {
-		glog.Fatalf("Could not get newest config version: %v", err)
+		nl.Fatalf(lm.logger, "Could not get newest config version: %v", err)
 	}
 }
 
-// Reload reloads NGINX.
-func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
+// Reload reloads NGINX. If the context includes a span, the reload is traced as its child span.
+func (lm *LocalManager) Reload(ctx context.Context, isEndpointsUpdate bool) (err error) {
+	_, span := tracing.StartSpan(ctx, "nginx.reload", tracing.Bool("nginx.reload.endpoints_update", isEndpointsUpdate))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	// write a new config version
 	lm.configVersion++
 	lm.UpdateConfigVersionFile(lm.OpenTracing)
+	span.SetAttributes(tracing.Int("nginx.config_version", lm.configVersion))
 
-	glog.V(3).Infof("Reloading nginx with configVersion: %v", lm.configVersion)
+	l := nl.With(lm.logger, nl.ReloadIDKey, lm.configVersion)
+	nl.Tracef(l, "Reloading nginx with configVersion: %v", lm.configVersion)
 
 	t1 := time.Now()
 
@@ -309,7 +390,7 @@ func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
 		lm.metricsCollector.IncNginxReloadErrors()
 		return fmt.Errorf("nginx reload failed: %w", err)
 	}
-	err := lm.verifyClient.WaitForCorrectVersion(lm.configVersion)
+	err = lm.verifyClient.WaitForCorrectVersion(lm.configVersion)
 	if err != nil {
 		lm.metricsCollector.IncNginxReloadErrors()
 		return fmt.Errorf("could not get newest config version: %w", err)
@@ -319,16 +400,18 @@ func (lm *LocalManager) Reload(isEndpointsUpdate bool) error {
 
 	t2 := time.Now()
 	lm.metricsCollector.UpdateLastReloadTime(t2.Sub(t1))
+	span.SetAttributes(tracing.Int64("nginx.reload.duration_ms", t2.Sub(t1).Milliseconds()))
+	nl.Debugf(l, "NGINX reloaded in %v", t2.Sub(t1))
 	return nil
 }
 
 // Quit shutdowns NGINX gracefully.
 func (lm *LocalManager) Quit() {
-	glog.V(3).Info("Quitting nginx")
+	nl.Trace(lm.logger, "Quitting nginx")
 
 	binaryFilename := getBinaryFileName(lm.debug)
 	if err := shellOut(fmt.Sprintf("%v -s %v", binaryFilename, "quit")); err != nil {
-		glog.Fatalf("Failed to quit nginx: %v", err)
+		nl.Fatalf(lm.logger, "Failed to quit nginx: %v", err)
 	}
 }
 
@@ -337,7 +420,7 @@ func (lm *LocalManager) Version() string {
 	binaryFilename := getBinaryFileName(lm.debug)
 	out, err := exec.Command(binaryFilename, "-v").CombinedOutput()
 	if err != nil {
-		glog.Fatalf("Failed to get nginx version: %v", err)
+		nl.Fatalf(lm.logger, "Failed to get nginx version: %v", err)
 	}
 	return string(out)
 }
@@ -346,11 +429,11 @@ func (lm *LocalManager) Version() string {
 func (lm *LocalManager) UpdateConfigVersionFile(openTracing bool) {
 	cfg, err := lm.verifyConfigGenerator.GenerateVersionConfig(lm.configVersion, openTracing)
 	if err != nil {
-		glog.Fatalf("Error generating config version content: %v", err)
+		nl.Fatalf(lm.logger, "Error generating config version content: %v", err)
 	}
 
-	glog.V(3).Infof("Writing config version to %v", lm.configVersionFilename)
-	glog.V(3).Info(string(cfg))
+	nl.Tracef(lm.logger, "Writing config version to %v", lm.configVersionFilename)
+	nl.Trace(lm.logger, string(cfg))
 
 	createFileAndWriteAtomically(lm.configVersionFilename, path.Dir(lm.configVersionFilename), configFileMode, cfg)
 }
@@ -363,34 +446,81 @@ func (lm *LocalManager) SetPlusClients(plusClient *client.NginxClient, plusConfi
 }
 
 // UpdateServersInPlus updates NGINX Plus servers of the given upstream.
-func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []string, config ServerConfig) error {
+func (lm *LocalManager) UpdateServersInPlus(upstream string, servers []ServerKey, config ServerConfig) error {
 	err := verifyConfigVersion(lm.plusConfigVersionCheckClient, lm.configVersion)
 	if err != nil {
 		return fmt.Errorf("error verifying config version: %w", err)
 	}
 
-	glog.V(3).Infof("API has the correct config version: %v.", lm.configVersion)
+	nl.Tracef(lm.logger, "API has the correct config version: %v.", lm.configVersion)
+
+	upsServers := createUpstreamServers(servers, config)
+
+	added, removed, updated, err := lm.plusClient.UpdateHTTPServers(upstream, upsServers)
+	if err != nil {
+		nl.Tracef(lm.logger, "Couldn't update servers of %v upstream: %v", upstream, err)
+		return fmt.Errorf("error updating servers of %v upstream: %w", upstream, err)
+	}
 
+	nl.Tracef(lm.logger, "Updated servers of %v; Added: %v, Removed: %v, Updated: %v", upstream, added, removed, updated)
+
+	return nil
+}
+
+// createUpstreamServers creates the servers of an upstream for the NGINX Plus API.
+// The API identifies the servers by address, so the servers of different Services with the same address are merged
+// the same way NGINX handles such duplicate servers in the config: a primary server takes precedence over a backup
+// server, and the weights of the primary servers add up.
+func createUpstreamServers(servers []ServerKey, config ServerConfig) []client.UpstreamServer {
 	var upsServers []client.UpstreamServer
+	indexes := make(map[string]int)
+
 	for _, s := range servers {
-		upsServers = append(upsServers, client.UpstreamServer{
-			Server:      s,
+		weight, hasWeight := config.Weights[s]
+		if !hasWeight {
+			weight = 1
+		}
+		backup := config.BackupServers[s]
+
+		if i, exists := indexes[s.Address]; exists {
+			merged := &upsServers[i]
+			isMergedBackup := merged.Backup != nil && *merged.Backup
+
+			switch {
+			case backup && !isMergedBackup:
+				// the primary server takes precedence
+			case !backup && isMergedBackup:
+				merged.Backup = nil
+				merged.Weight = &weight
+			default:
+				mergedWeight := weight + 1
+				if merged.Weight != nil {
+					mergedWeight = weight + *merged.Weight
+				}
+				merged.Weight = &mergedWeight
+			}
+			continue
+		}
+
+		upsServer := client.UpstreamServer{
+			Server:      s.Address,
 			MaxFails:    &config.MaxFails,
 			MaxConns:    &config.MaxConns,
 			FailTimeout: config.FailTimeout,
 			SlowStart:   config.SlowStart,
-		})
-	}
+		}
+		if hasWeight {
+			upsServer.Weight = &weight
+		}
+		if backup {
+			upsServer.Backup = &backup
+		}
 
-	added, removed, updated, err := lm.plusClient.UpdateHTTPServers(upstream, upsServers)
-	if err != nil {
-		glog.V(3).Infof("Couldn't update servers of %v upstream: %v", upstream, err)
-		return fmt.Errorf("error updating servers of %v upstream: %w", upstream, err)
+		indexes[s.Address] = len(upsServers)
+		upsServers = append(upsServers, upsServer)
 	}
 
-	glog.V(3).Infof("Updated servers of %v; Added: %v, Removed: %v, Updated: %v", upstream, added, removed, updated)
-
-	return nil
+	return upsServers
 }
 
 // UpdateStreamServersInPlus updates NGINX Plus stream servers of the given upstream.
@@ -400,7 +530,7 @@ func (lm *LocalManager) UpdateStreamServersInPlus(upstream string, servers []str
 		return fmt.Errorf("error verifying config version: %w", err)
 	}
 
-	glog.V(3).Infof("API has the correct config version: %v.", lm.configVersion)
+	nl.Tracef(lm.logger, "API has the correct config version: %v.", lm.configVersion)
 
 	var upsServers []client.StreamUpstreamServer
 	for _, s := range servers {
@@ -411,18 +541,18 @@ func (lm *LocalManager) UpdateStreamServersInPlus(upstream string, servers []str
 
 	added, removed, updated, err := lm.plusClient.UpdateStreamServers(upstream, upsServers)
 	if err != nil {
-		glog.V(3).Infof("Couldn't update stream servers of %v upstream: %v", upstream, err)
+		nl.Tracef(lm.logger, "Couldn't update stream servers of %v upstream: %v", upstream, err)
 		return fmt.Errorf("error updating stream servers of %v upstream: %w", upstream, err)
 	}
 
-	glog.V(3).Infof("Updated stream servers of %v; Added: %v, Removed: %v, Updated: %v", upstream, added, removed, updated)
+	nl.Tracef(lm.logger, "Updated stream servers of %v; Added: %v, Removed: %v, Updated: %v", upstream, added, removed, updated)
 
 	return nil
 }
 
 // CreateOpenTracingTracerConfig creates a json configuration file for the OpenTracing tracer with the content of the string.
 func (lm *LocalManager) CreateOpenTracingTracerConfig(content string) error {
-	glog.V(3).Infof("Writing OpenTracing tracer config file to %v", jsonFileForOpenTracingTracer)
+	nl.Tracef(lm.logger, "Writing OpenTracing tracer config file to %v", jsonFileForOpenTracingTracer)
 	err := createFileAndWrite(jsonFileForOpenTracingTracer, []byte(content))
 	if err != nil {
 		return fmt.Errorf("Failed to write config file: %w", err)
@@ -463,21 +593,21 @@ func (lm *LocalManager) SetOpenTracing(openTracing bool) {
 // AppProtectAgentStart starts the AppProtect agent
 func (lm *LocalManager) AppProtectAgentStart(apaDone chan error, debug bool) {
 	if debug {
-		glog.V(3).Info("Starting AppProtect Agent in debug mode")
+		nl.Trace(lm.logger, "Starting AppProtect Agent in debug mode")
 		err := os.Remove(appProtectLogConfigFileName)
 		if err != nil {
-			glog.Fatalf("Failed removing App Protect Log configuration file")
+			nl.Fatalf(lm.logger, "Failed removing App Protect Log configuration file")
 		}
 		err = createFileAndWrite(appProtectLogConfigFileName, []byte(appProtectDebugLogConfigFileContent))
 		if err != nil {
-			glog.Fatalf("Failed Writing App Protect Log configuration file")
+			nl.Fatalf(lm.logger, "Failed Writing App Protect Log configuration file")
 		}
 	}
-	glog.V(3).Info("Starting AppProtect Agent")
+	nl.Trace(lm.logger, "Starting AppProtect Agent")
 
 	cmd := exec.Command(appProtectAgentStartCmd)
 	if err := cmd.Start(); err != nil {
-		glog.Fatalf("Failed to start AppProtect Agent: %v", err)
+		nl.Fatalf(lm.logger, "Failed to start AppProtect Agent: %v", err)
 	}
 	lm.appProtectAgentPid = cmd.Process.Pid
 	go func() {
@@ -487,16 +617,16 @@ func (lm *LocalManager) AppProtectAgentStart(apaDone chan error, debug bool) {
 
 // AppProtectAgentQuit gracefully ends AppProtect Agent.
 func (lm *LocalManager) AppProtectAgentQuit() {
-	glog.V(3).Info("Quitting AppProtect Agent")
+	nl.Trace(lm.logger, "Quitting AppProtect Agent")
 	killcmd := fmt.Sprintf("kill %d", lm.appProtectAgentPid)
 	if err := shellOut(killcmd); err != nil {
-		glog.Fatalf("Failed to quit AppProtect Agent: %v", err)
+		nl.Fatalf(lm.logger, "Failed to quit AppProtect Agent: %v", err)
 	}
 }
 
 // AppProtectPluginStart starts the AppProtect plugin.
 func (lm *LocalManager) AppProtectPluginStart(appDone chan error) {
-	glog.V(3).Info("Starting AppProtect Plugin")
+	nl.Trace(lm.logger, "Starting AppProtect Plugin")
 	startupParams := strings.Fields(appPluginParams)
 	cmd := exec.Command(appProtectPluginStartCmd, startupParams...)
 
@@ -506,7 +636,7 @@ func (lm *LocalManager) AppProtectPluginStart(appDone chan error) {
 	cmd.Env = append(cmd.Env, "LD_LIBRARY_PATH=/usr/lib64/bd")
 
 	if err := cmd.Start(); err != nil {
-		glog.Fatalf("Failed to start AppProtect Plugin: %v", err)
+		nl.Fatalf(lm.logger, "Failed to start AppProtect Plugin: %v", err)
 	}
 	lm.appProtectPluginPid = cmd.Process.Pid
 	go func() {
@@ -516,25 +646,25 @@ func (lm *LocalManager) AppProtectPluginStart(appDone chan error) {
 
 // AppProtectPluginQuit gracefully ends AppProtect Agent.
 func (lm *LocalManager) AppProtectPluginQuit() {
-	glog.V(3).Info("Quitting AppProtect Plugin")
+	nl.Trace(lm.logger, "Quitting AppProtect Plugin")
 	killcmd := fmt.Sprintf("kill %d", lm.appProtectPluginPid)
 	if err := shellOut(killcmd); err != nil {
-		glog.Fatalf("Failed to quit AppProtect Plugin: %v", err)
+		nl.Fatalf(lm.logger, "Failed to quit AppProtect Plugin: %v", err)
 	}
 }
 
 // AppProtectDosAgentQuit gracefully ends AppProtect Agent.
 func (lm *LocalManager) AppProtectDosAgentQuit() {
-	glog.V(3).Info("Quitting AppProtectDos Agent")
+	nl.Trace(lm.logger, "Quitting AppProtectDos Agent")
 	killcmd := fmt.Sprintf("kill %d", lm.appProtectDosAgentPid)
 	if err := shellOut(killcmd); err != nil {
-		glog.Fatalf("Failed to quit AppProtect Agent: %v", err)
+		nl.Fatalf(lm.logger, "Failed to quit AppProtect Agent: %v", err)
 	}
 }
 
 // AppProtectDosAgentStart starts the AppProtectDos agent
 func (lm *LocalManager) AppProtectDosAgentStart(apdaDone chan error, debug bool, maxDaemon int, maxWorkers int, memory int) {
-	glog.V(3).Info("Starting AppProtectDos Agent")
+	nl.Trace(lm.logger, "Starting AppProtectDos Agent")
 
 	// Perform installation by adminstall
 	appProtectDosAgentInstallCmdFull := appProtectDosAgentInstallCmd
@@ -554,7 +684,7 @@ func (lm *LocalManager) AppProtectDosAgentStart(apdaDone chan error, debug bool,
 	cmdInstall := exec.Command("sh", "-c", appProtectDosAgentInstallCmdFull)
 
 	if err := cmdInstall.Run(); err != nil {
-		glog.Fatalf("Failed to install AppProtectDos: %v", err)
+		nl.Fatalf(lm.logger, "Failed to install AppProtectDos: %v", err)
 	}
 
 	// case debug add debug flag to admd
@@ -567,7 +697,7 @@ func (lm *LocalManager) AppProtectDosAgentStart(apdaDone chan error, debug bool,
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stdout
 	if err := cmd.Start(); err != nil {
-		glog.Fatalf("Failed to start AppProtectDos Agent: %v", err)
+		nl.Fatalf(lm.logger, "Failed to start AppProtectDos Agent: %v", err)
 	}
 	lm.appProtectDosAgentPid = cmd.Process.Pid
 	go func() {
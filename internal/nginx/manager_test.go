@@ -0,0 +1,91 @@
+package nginx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nginxinc/nginx-plus-go-client/client"
+)
+
+func TestCreateUpstreamServers(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+
+	config := ServerConfig{
+		MaxFails:    1,
+		FailTimeout: "10s",
+		Weights: map[ServerKey]int{
+			{Address: "10.0.0.1:80"}:                      3,
+			{Service: "weighted", Address: "10.0.0.1:80"}: 2,
+			{Service: "weighted", Address: "10.0.0.3:80"}: 2,
+		},
+		BackupServers: map[ServerKey]bool{
+			{Service: "backup", Address: "10.0.0.2:80"}: true,
+			{Service: "backup", Address: "10.0.0.3:80"}: true,
+			{Service: "backup", Address: "10.0.0.4:80"}: true,
+		},
+	}
+
+	tests := []struct {
+		servers  []ServerKey
+		expected []client.UpstreamServer
+		msg      string
+	}{
+		{
+			servers: []ServerKey{
+				{Address: "10.0.0.1:80"},
+				{Address: "10.0.0.2:80"},
+				{Service: "backup", Address: "10.0.0.4:80"},
+			},
+			expected: []client.UpstreamServer{
+				{Server: "10.0.0.1:80", Weight: intPtr(3)},
+				{Server: "10.0.0.2:80"},
+				{Server: "10.0.0.4:80", Backup: boolPtr(true)},
+			},
+			msg: "no collisions",
+		},
+		{
+			servers: []ServerKey{
+				{Address: "10.0.0.1:80"},
+				{Service: "weighted", Address: "10.0.0.1:80"},
+			},
+			expected: []client.UpstreamServer{
+				{Server: "10.0.0.1:80", Weight: intPtr(5)},
+			},
+			msg: "weighted servers with the same address",
+		},
+		{
+			servers: []ServerKey{
+				{Address: "10.0.0.2:80"},
+				{Service: "backup", Address: "10.0.0.2:80"},
+			},
+			expected: []client.UpstreamServer{
+				{Server: "10.0.0.2:80"},
+			},
+			msg: "primary and backup servers with the same address",
+		},
+		{
+			servers: []ServerKey{
+				{Service: "backup", Address: "10.0.0.3:80"},
+				{Service: "weighted", Address: "10.0.0.3:80"},
+			},
+			expected: []client.UpstreamServer{
+				{Server: "10.0.0.3:80", Weight: intPtr(2)},
+			},
+			msg: "backup and primary servers with the same address",
+		},
+	}
+
+	for _, test := range tests {
+		for i := range test.expected {
+			test.expected[i].MaxFails = &config.MaxFails
+			test.expected[i].MaxConns = &config.MaxConns
+			test.expected[i].FailTimeout = config.FailTimeout
+		}
+
+		result := createUpstreamServers(test.servers, config)
+		if diff := cmp.Diff(test.expected, result); diff != "" {
+			t.Errorf("createUpstreamServers() returned unexpected result for the case of %s (-want +got):\n%s", test.msg, diff)
+		}
+	}
+}
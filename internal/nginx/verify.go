@@ -6,12 +6,13 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/golang/glog"
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
 )
 
 // verifyClient is a client for verifying the config version.
@@ -65,15 +66,15 @@ func (c *verifyClient) WaitForCorrectVersion(expectedVersion int) error {
 	startTime := time.Now()
 	endTime := startTime.Add(c.timeout)
 
-	glog.V(3).Infof("Starting poll for updated nginx config")
+	nl.Tracef(slog.Default(), "Starting poll for updated nginx config")
 	for time.Now().Before(endTime) {
 		version, err := c.GetConfigVersion()
 		if err != nil {
-			glog.V(3).Infof("Unable to fetch version: %v", err)
+			nl.Tracef(slog.Default(), "Unable to fetch version: %v", err)
 			continue
 		}
 		if version == expectedVersion {
-			glog.V(3).Infof("success, version %v ensured. took: %v", expectedVersion, time.Since(startTime))
+			nl.Tracef(slog.Default(), "success, version %v ensured. took: %v", expectedVersion, time.Since(startTime))
 			return nil
 		}
 		time.Sleep(interval)
@@ -0,0 +1,299 @@
+// Package tracing implements tracing of the Ingress Controller with spans exported to an OTLP/HTTP endpoint.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	nl "github.com/nginxinc/kubernetes-ingress/internal/logger"
+)
+
+const (
+	defaultExportInterval = 5 * time.Second
+	defaultExportTimeout  = 10 * time.Second
+	defaultServiceName    = "nginx-ingress-controller"
+	maxQueueSize          = 2048
+	maxExportBatchSize    = 512
+)
+
+// Config is the configuration of a Tracer.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint, for example, http://otel-collector:4318/v1/traces.
+	Endpoint string
+	// ServiceName is the value of the service.name attribute of the exported resource.
+	ServiceName string
+	// HTTPClient is the client used to export spans. If nil, a client with a timeout of 10s is used,
+	// so that an unresponsive endpoint doesn't block the export of the following spans.
+	HTTPClient *http.Client
+	// ExportInterval is the interval between exports of the finished spans.
+	ExportInterval time.Duration
+}
+
+// Tracer creates spans and exports them to an OTLP/HTTP endpoint.
+// A nil Tracer is valid and creates no spans.
+type Tracer struct {
+	endpoint       string
+	serviceName    string
+	client         *http.Client
+	exportInterval time.Duration
+
+	mu    sync.Mutex
+	queue []*Span
+	// dropped is the number of spans that were dropped because the queue was full or their export failed.
+	dropped uint64
+	// droppedFromFullQueue is the number of spans dropped because the queue was full since the last Flush.
+	droppedFromFullQueue uint64
+}
+
+// NewTracer creates a new Tracer.
+func NewTracer(cfg Config) *Tracer {
+	t := &Tracer{
+		endpoint:       cfg.Endpoint,
+		serviceName:    cfg.ServiceName,
+		client:         cfg.HTTPClient,
+		exportInterval: cfg.ExportInterval,
+	}
+
+	if t.serviceName == "" {
+		t.serviceName = defaultServiceName
+	}
+	if t.client == nil {
+		t.client = &http.Client{Timeout: defaultExportTimeout}
+	}
+	if t.exportInterval == 0 {
+		t.exportInterval = defaultExportInterval
+	}
+
+	return t
+}
+
+// Run exports the finished spans periodically until the stop channel is closed.
+// The remaining spans are exported before Run returns.
+func (t *Tracer) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(t.exportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.Flush()
+		case <-stopCh:
+			t.Flush()
+			return
+		}
+	}
+}
+
+// Flush exports all finished spans. If an export fails, the spans of the batch are dropped
+// and the remaining spans are exported on the next Flush.
+func (t *Tracer) Flush() {
+	if n, total := t.takeDroppedFromFullQueue(); n > 0 {
+		nl.Warnf(slog.Default(), "Dropped %d spans because the queue of spans was full (%d spans dropped in total)", n, total)
+	}
+
+	for {
+		batch := t.dequeue()
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := t.export(batch); err != nil {
+			total := t.addDropped(uint64(len(batch)))
+			nl.Warnf(slog.Default(), "Failed to export %d spans to %v, the spans are dropped (%d spans dropped in total): %v",
+				len(batch), t.endpoint, total, err)
+			return
+		}
+	}
+}
+
+// DroppedSpans returns the number of spans that were dropped because the queue of spans was full
+// or their export failed.
+func (t *Tracer) DroppedSpans() uint64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.dropped
+}
+
+func (t *Tracer) addDropped(n uint64) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.dropped += n
+	return t.dropped
+}
+
+func (t *Tracer) takeDroppedFromFullQueue() (n uint64, total uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n = t.droppedFromFullQueue
+	t.droppedFromFullQueue = 0
+	return n, t.dropped
+}
+
+// Start creates a new span. If the context includes a span, the new span becomes its child.
+// The returned context includes the new span.
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		start:      time.Now(),
+		attributes: attrs,
+	}
+
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentSpanID = parent.spanID
+	} else {
+		span.traceID = newID(16)
+	}
+	span.spanID = newID(8)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// StartSpan creates a new child span of the span included in the context using the same Tracer.
+// If the context doesn't include a span, no span is created.
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+
+	return parent.tracer.Start(ctx, name, attrs...)
+}
+
+type spanKey struct{}
+
+// SpanFromContext returns the span included in the context or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+func (t *Tracer) enqueue(span *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.queue) >= maxQueueSize {
+		// the dropped spans are reported by the next Flush, so that a full queue doesn't log every span
+		nl.Tracef(slog.Default(), "Dropping span %v: the queue of spans is full", span.name)
+		t.dropped++
+		t.droppedFromFullQueue++
+		return
+	}
+
+	t.queue = append(t.queue, span)
+}
+
+func (t *Tracer) dequeue() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := len(t.queue)
+	if n > maxExportBatchSize {
+		n = maxExportBatchSize
+	}
+
+	batch := t.queue[:n:n]
+	t.queue = t.queue[n:]
+
+	return batch
+}
+
+// Span is an operation of the Ingress Controller.
+// A nil Span is valid and its methods do nothing.
+type Span struct {
+	tracer       *Tracer
+	name         string
+	traceID      string
+	spanID       string
+	parentSpanID string
+	start        time.Time
+	end          time.Time
+	attributes   []Attribute
+	errMessage   string
+	ended        bool
+}
+
+// SetAttributes sets the attributes of the span. The attributes of an ended span can't be changed.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil || s.ended {
+		return
+	}
+
+	s.attributes = append(s.attributes, attrs...)
+}
+
+// RecordError marks the span as failed with the error.
+func (s *Span) RecordError(err error) {
+	if s == nil || s.ended || err == nil {
+		return
+	}
+
+	s.errMessage = err.Error()
+}
+
+// End ends the span and queues it for export.
+func (s *Span) End() {
+	if s == nil || s.ended {
+		return
+	}
+
+	s.ended = true
+	s.end = time.Now()
+	s.tracer.enqueue(s)
+}
+
+func newID(size int) string {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		nl.Errorf(slog.Default(), "Failed to generate a tracing ID: %v", err)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// Attribute is a key-value pair that describes a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int creates an integer attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Int64 creates an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool creates a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
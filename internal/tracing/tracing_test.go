@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type fakeCollector struct {
+	mu       sync.Mutex
+	requests []exportTraceServiceRequest
+}
+
+func (c *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var req exportTraceServiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.mu.Unlock()
+}
+
+func (c *fakeCollector) spans() map[string]otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	spans := make(map[string]otlpSpan)
+	for _, req := range c.requests {
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}
+
+	return spans
+}
+
+func TestTracerExportsSpans(t *testing.T) {
+	collector := &fakeCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	tracer := NewTracer(Config{
+		Endpoint:    server.URL + "/v1/traces",
+		ServiceName: "test-service",
+	})
+
+	ctx, parent := tracer.Start(context.Background(), "sync", String("resource.kind", "VirtualServer"))
+	_, child := StartSpan(ctx, "reload", Bool("nginx.reload.endpoints_update", false))
+	child.SetAttributes(Int("resources.affected", 3))
+	child.RecordError(errors.New("reload failed"))
+	child.End()
+	parent.End()
+
+	tracer.Flush()
+
+	if len(collector.requests) != 1 {
+		t.Fatalf("collector received %d requests but expected 1", len(collector.requests))
+	}
+
+	serviceName := collector.requests[0].ResourceSpans[0].Resource.Attributes[0]
+	if serviceName.Key != "service.name" || *serviceName.Value.StringValue != "test-service" {
+		t.Errorf("exported resource has attribute %+v but expected service.name test-service", serviceName)
+	}
+
+	spans := collector.spans()
+
+	syncSpan, exists := spans["sync"]
+	if !exists {
+		t.Fatalf("span sync was not exported")
+	}
+	reloadSpan, exists := spans["reload"]
+	if !exists {
+		t.Fatalf("span reload was not exported")
+	}
+
+	if len(syncSpan.TraceID) != 32 || len(syncSpan.SpanID) != 16 {
+		t.Errorf("span sync has invalid IDs: traceId %q spanId %q", syncSpan.TraceID, syncSpan.SpanID)
+	}
+	if syncSpan.ParentSpanID != "" {
+		t.Errorf("span sync has parent %q but expected none", syncSpan.ParentSpanID)
+	}
+	if reloadSpan.TraceID != syncSpan.TraceID {
+		t.Errorf("span reload has traceId %q but expected %q", reloadSpan.TraceID, syncSpan.TraceID)
+	}
+	if reloadSpan.ParentSpanID != syncSpan.SpanID {
+		t.Errorf("span reload has parentSpanId %q but expected %q", reloadSpan.ParentSpanID, syncSpan.SpanID)
+	}
+	if reloadSpan.Status == nil || reloadSpan.Status.Code != statusCodeError || reloadSpan.Status.Message != "reload failed" {
+		t.Errorf("span reload has status %+v but expected an error", reloadSpan.Status)
+	}
+
+	expectedAttributes := map[string]string{
+		"nginx.reload.endpoints_update": "false",
+		"resources.affected":            "3",
+	}
+	for _, kv := range reloadSpan.Attributes {
+		var value string
+		switch {
+		case kv.Value.BoolValue != nil:
+			if *kv.Value.BoolValue {
+				value = "true"
+			} else {
+				value = "false"
+			}
+		case kv.Value.IntValue != nil:
+			value = *kv.Value.IntValue
+		}
+
+		if expectedAttributes[kv.Key] != value {
+			t.Errorf("span reload has attribute %v with value %q but expected %q", kv.Key, value, expectedAttributes[kv.Key])
+		}
+	}
+}
+
+func TestStartSpanWithoutParent(t *testing.T) {
+	ctx := context.Background()
+
+	newCtx, span := StartSpan(ctx, "reload")
+	if span != nil {
+		t.Errorf("StartSpan() returned a span for a context without a span")
+	}
+	if newCtx != ctx {
+		t.Errorf("StartSpan() returned a new context for a context without a span")
+	}
+
+	// the methods of a nil span must not panic
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("error"))
+	span.End()
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+
+	_, span := tracer.Start(context.Background(), "sync")
+	if span != nil {
+		t.Errorf("Start() of a nil Tracer returned a span")
+	}
+	if dropped := tracer.DroppedSpans(); dropped != 0 {
+		t.Errorf("DroppedSpans() of a nil Tracer returned %d", dropped)
+	}
+}
+
+func TestFlushDropsSpansOnExportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tracer := NewTracer(Config{
+		Endpoint: server.URL,
+	})
+
+	_, span := tracer.Start(context.Background(), "sync")
+	span.End()
+
+	tracer.Flush()
+
+	if len(tracer.queue) != 0 {
+		t.Errorf("Flush() left %d spans in the queue but expected 0", len(tracer.queue))
+	}
+	if dropped := tracer.DroppedSpans(); dropped != 1 {
+		t.Errorf("DroppedSpans() returned %d but expected 1", dropped)
+	}
+}
+
+func TestEnqueueCountsDroppedSpans(t *testing.T) {
+	tracer := NewTracer(Config{
+		Endpoint: "http://localhost/v1/traces",
+	})
+
+	for i := 0; i < maxQueueSize+3; i++ {
+		_, span := tracer.Start(context.Background(), "sync")
+		span.End()
+	}
+
+	if len(tracer.queue) != maxQueueSize {
+		t.Errorf("the queue has %d spans but expected %d", len(tracer.queue), maxQueueSize)
+	}
+	if dropped := tracer.DroppedSpans(); dropped != 3 {
+		t.Errorf("DroppedSpans() returned %d but expected 3", dropped)
+	}
+
+	n, total := tracer.takeDroppedFromFullQueue()
+	if n != 3 || total != 3 {
+		t.Errorf("takeDroppedFromFullQueue() returned %d and %d but expected 3 and 3", n, total)
+	}
+	if n, _ := tracer.takeDroppedFromFullQueue(); n != 0 {
+		t.Errorf("takeDroppedFromFullQueue() returned %d after the dropped spans were reported but expected 0", n)
+	}
+}
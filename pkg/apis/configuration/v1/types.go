@@ -37,13 +37,69 @@ type VirtualServer struct {
 type VirtualServerSpec struct {
 	IngressClass   string            `json:"ingressClassName"`
 	Host           string            `json:"host"`
+	Aliases        []string          `json:"aliases"`
 	TLS            *TLS              `json:"tls"`
 	Policies       []PolicyReference `json:"policies"`
 	Upstreams      []Upstream        `json:"upstreams"`
 	Routes         []Route           `json:"routes"`
+	Headers        *Headers          `json:"headers"`
+	RequestID      *RequestID        `json:"requestID"`
+	Redirects      *Redirects        `json:"redirects"`
 	HTTPSnippets   string            `json:"http-snippets"`
 	ServerSnippets string            `json:"server-snippets"`
 	Dos            string            `json:"dos"`
+	Tracing        *Tracing          `json:"tracing"`
+}
+
+// Redirects defines the redirects of a VirtualServer that apply to requests before the routes.
+type Redirects struct {
+	CanonicalHost  bool           `json:"canonicalHost"`
+	TrailingSlash  string         `json:"trailingSlash"`
+	LowercasePaths bool           `json:"lowercasePaths"`
+	Code           int            `json:"code"`
+	Paths          []PathRedirect `json:"paths"`
+}
+
+// PathRedirect defines a redirect of the requests for a path.
+type PathRedirect struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Code int    `json:"code"`
+}
+
+// RequestID defines the propagation of the request ID of the requests to a VirtualServer.
+type RequestID struct {
+	Enable bool `json:"enable"`
+}
+
+// Headers defines the request and response headers manipulation for all routes of a VirtualServer or a VirtualServerRoute.
+type Headers struct {
+	RequestHeaders  *ProxyRequestHeaders  `json:"requestHeaders"`
+	ResponseHeaders *ProxyResponseHeaders `json:"responseHeaders"`
+	Security        *SecurityHeaders      `json:"security"`
+}
+
+// SecurityHeaders defines the presets of security response headers.
+type SecurityHeaders struct {
+	HSTS                  *HSTS  `json:"hsts"`
+	FrameOptions          string `json:"frameOptions"`
+	ContentSecurityPolicy string `json:"contentSecurityPolicy"`
+	ReferrerPolicy        string `json:"referrerPolicy"`
+}
+
+// HSTS defines the Strict-Transport-Security response header.
+type HSTS struct {
+	Enable            bool  `json:"enable"`
+	MaxAge            int64 `json:"maxAge"`
+	IncludeSubdomains bool  `json:"includeSubdomains"`
+	Preload           bool  `json:"preload"`
+}
+
+// Tracing defines the OpenTelemetry tracing configuration.
+type Tracing struct {
+	Enable       *bool  `json:"enable"`
+	SamplerRatio string `json:"samplerRatio"`
+	Context      string `json:"context"`
 }
 
 // PolicyReference references a policy by name and an optional namespace.
@@ -58,6 +114,11 @@ type Upstream struct {
 	Service                  string            `json:"service"`
 	Subselector              map[string]string `json:"subselector"`
 	Port                     uint16            `json:"port"`
+	Addresses                []string          `json:"addresses"`
+	Weight                   int               `json:"weight"`
+	Services                 []UpstreamService `json:"services"`
+	Backup                   string            `json:"backup"`
+	BackupPort               uint16            `json:"backupPort"`
 	LBMethod                 string            `json:"lb-method"`
 	FailTimeout              string            `json:"fail-timeout"`
 	MaxFails                 *int              `json:"max-fails"`
@@ -79,10 +140,24 @@ type Upstream struct {
 	Queue                    *UpstreamQueue    `json:"queue"`
 	SessionCookie            *SessionCookie    `json:"sessionCookie"`
 	UseClusterIP             bool              `json:"use-cluster-ip"`
+	TopologyAware            *TopologyAware    `json:"topologyAware"`
 	NTLM                     bool              `json:"ntlm"`
 	Type                     string            `json:"type"`
 }
 
+// UpstreamService defines an additional Service of an Upstream with the weight of its endpoints.
+type UpstreamService struct {
+	Name   string `json:"name"`
+	Port   uint16 `json:"port"`
+	Weight int    `json:"weight"`
+}
+
+// TopologyAware defines the parameters of topology aware routing for an Upstream.
+type TopologyAware struct {
+	Enable       bool `json:"enable"`
+	MinEndpoints int  `json:"minEndpoints"`
+}
+
 // UpstreamBuffers defines Buffer Configuration for an Upstream.
 type UpstreamBuffers struct {
 	Number int    `json:"number"`
@@ -122,6 +197,8 @@ type Header struct {
 }
 
 // SessionCookie defines the parameters for session persistence.
+// The sticky mode (the default) uses the sticky cookie of NGINX Plus.
+// The hash mode uses the consistent hash of a cookie, a header or the client IP, which is also supported by NGINX.
 type SessionCookie struct {
 	Enable   bool   `json:"enable"`
 	Name     string `json:"name"`
@@ -130,6 +207,9 @@ type SessionCookie struct {
 	Domain   string `json:"domain"`
 	HTTPOnly bool   `json:"httpOnly"`
 	Secure   bool   `json:"secure"`
+	Mode     string `json:"mode"`
+	Header   string `json:"header"`
+	ClientIP bool   `json:"clientIP"`
 }
 
 // Route defines a route.
@@ -140,9 +220,39 @@ type Route struct {
 	Action           *Action           `json:"action"`
 	Splits           []Split           `json:"splits"`
 	Matches          []Match           `json:"matches"`
+	SplitKey         *SplitKey         `json:"splitKey"`
+	StickySplits     *StickySplits     `json:"stickySplits"`
+	Proxy            *RouteProxy       `json:"proxy"`
 	ErrorPages       []ErrorPage       `json:"errorPages"`
 	LocationSnippets string            `json:"location-snippets"`
 	Dos              string            `json:"dos"`
+	Tracing          *Tracing          `json:"tracing"`
+}
+
+// RouteProxy defines the proxy settings of a route that override the settings of its upstreams.
+type RouteProxy struct {
+	ProxyConnectTimeout string           `json:"connect-timeout"`
+	ProxyReadTimeout    string           `json:"read-timeout"`
+	ProxySendTimeout    string           `json:"send-timeout"`
+	ClientMaxBodySize   string           `json:"client-max-body-size"`
+	ProxyBuffering      *bool            `json:"buffering"`
+	ProxyBuffers        *UpstreamBuffers `json:"buffers"`
+	ProxyBufferSize     string           `json:"buffer-size"`
+}
+
+// SplitKey defines the key that distributes requests between the splits of a route.
+type SplitKey struct {
+	Cookie     string `json:"cookie"`
+	Header     string `json:"header"`
+	ClientIP   bool   `json:"clientIP"`
+	JWTSubject bool   `json:"jwtSubject"`
+}
+
+// StickySplits defines a cookie that keeps the split key of a client, so that the client is distributed to the same split while the weights of the splits don't change.
+type StickySplits struct {
+	Cookie string `json:"cookie"`
+	Path   string `json:"path"`
+	MaxAge int    `json:"maxAge"`
 }
 
 // Action defines an action.
@@ -200,12 +310,31 @@ type Split struct {
 	Action *Action `json:"action"`
 }
 
+// Operators of a Condition.
+const (
+	// ConditionOperatorExact matches the subject against the value with the NGINX map semantics. It is the default operator.
+	ConditionOperatorExact = "exact"
+	// ConditionOperatorRegex matches when the subject matches the value as a case-sensitive regular expression.
+	ConditionOperatorRegex = "regex"
+	// ConditionOperatorPrefix matches when the subject starts with the value.
+	ConditionOperatorPrefix = "prefix"
+	// ConditionOperatorPresent matches when the subject is not empty.
+	ConditionOperatorPresent = "present"
+	// ConditionOperatorAbsent matches when the subject is empty.
+	ConditionOperatorAbsent = "absent"
+)
+
 // Condition defines a condition in a MatchRule.
 type Condition struct {
 	Header   string `json:"header"`
 	Cookie   string `json:"cookie"`
 	Argument string `json:"argument"`
 	Variable string `json:"variable"`
+	Method   bool   `json:"method"`
+	ClientIP bool   `json:"clientIP"`
+	JWTClaim string `json:"jwtClaim"`
+	Operator string `json:"operator"`
+	Negate   bool   `json:"negate"`
 	Value    string `json:"value"`
 }
 
@@ -238,6 +367,13 @@ type ErrorPageRedirect struct {
 type TLS struct {
 	Secret   string       `json:"secret"`
 	Redirect *TLSRedirect `json:"redirect"`
+	ACME     *ACME        `json:"acme"`
+}
+
+// ACME defines the issuance of a TLS certificate for the host of a VirtualServer by an ACME server.
+// The certificate is stored in the Secret of the TLS.
+type ACME struct {
+	Issuer string `json:"issuer"`
 }
 
 // TLSRedirect defines a redirect for a TLS.
@@ -253,6 +389,7 @@ type VirtualServerStatus struct {
 	Reason            string             `json:"reason"`
 	Message           string             `json:"message"`
 	ExternalEndpoints []ExternalEndpoint `json:"externalEndpoints,omitempty"`
+	Conditions        []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ExternalEndpoint defines the IP and ports used to connect to this resource.
@@ -297,6 +434,7 @@ type VirtualServerRouteSpec struct {
 	Host         string     `json:"host"`
 	Upstreams    []Upstream `json:"upstreams"`
 	Subroutes    []Route    `json:"subroutes"`
+	Headers      *Headers   `json:"headers"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
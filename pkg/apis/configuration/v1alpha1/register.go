@@ -34,6 +34,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GlobalConfigurationList{},
 		&TransportServer{},
 		&TransportServerList{},
+		&StaticResponse{},
+		&StaticResponseList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
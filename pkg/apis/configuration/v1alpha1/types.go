@@ -85,14 +85,22 @@ type TransportServerListener struct {
 
 // Upstream defines an upstream.
 type Upstream struct {
-	Name                string       `json:"name"`
-	Service             string       `json:"service"`
-	Port                int          `json:"port"`
-	FailTimeout         string       `json:"failTimeout"`
-	MaxFails            *int         `json:"maxFails"`
-	MaxConns            *int         `json:"maxConns"`
-	HealthCheck         *HealthCheck `json:"healthCheck"`
-	LoadBalancingMethod string       `json:"loadBalancingMethod"`
+	Name                string         `json:"name"`
+	Service             string         `json:"service"`
+	Port                int            `json:"port"`
+	Addresses           []string       `json:"addresses"`
+	FailTimeout         string         `json:"failTimeout"`
+	MaxFails            *int           `json:"maxFails"`
+	MaxConns            *int           `json:"maxConns"`
+	HealthCheck         *HealthCheck   `json:"healthCheck"`
+	LoadBalancingMethod string         `json:"loadBalancingMethod"`
+	TopologyAware       *TopologyAware `json:"topologyAware"`
+}
+
+// TopologyAware defines the parameters of topology aware routing for an Upstream.
+type TopologyAware struct {
+	Enable       bool `json:"enable"`
+	MinEndpoints int  `json:"minEndpoints"`
 }
 
 // HealthCheck defines the parameters for active Upstream HealthChecks.
@@ -229,3 +237,47 @@ type PolicyList struct {
 
 	Items []Policy `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:validation:Optional
+// +kubebuilder:resource:shortName=sr
+
+// StaticResponse defines the StaticResponse resource.
+// An Ingress references a StaticResponse from a resource backend to return a fixed response or a redirect.
+type StaticResponse struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StaticResponseSpec `json:"spec"`
+}
+
+// StaticResponseSpec is the spec of the StaticResponse resource.
+// Only one of the fields is allowed.
+type StaticResponseSpec struct {
+	Return   *StaticResponseReturn   `json:"return"`
+	Redirect *StaticResponseRedirect `json:"redirect"`
+}
+
+// StaticResponseReturn defines a fixed response.
+type StaticResponseReturn struct {
+	Code int    `json:"code"`
+	Type string `json:"type"`
+	Body string `json:"body"`
+}
+
+// StaticResponseRedirect defines a redirect.
+type StaticResponseRedirect struct {
+	URL  string `json:"url"`
+	Code int    `json:"code"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StaticResponseList is a list of the StaticResponse resources.
+type StaticResponseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []StaticResponse `json:"items"`
+}
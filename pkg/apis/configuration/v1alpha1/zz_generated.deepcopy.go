@@ -406,6 +406,140 @@ func (in *SessionParameters) DeepCopy() *SessionParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticResponse) DeepCopyInto(out *StaticResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticResponse.
+func (in *StaticResponse) DeepCopy() *StaticResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticResponseList) DeepCopyInto(out *StaticResponseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StaticResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticResponseList.
+func (in *StaticResponseList) DeepCopy() *StaticResponseList {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticResponseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StaticResponseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticResponseRedirect) DeepCopyInto(out *StaticResponseRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticResponseRedirect.
+func (in *StaticResponseRedirect) DeepCopy() *StaticResponseRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticResponseRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticResponseReturn) DeepCopyInto(out *StaticResponseReturn) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticResponseReturn.
+func (in *StaticResponseReturn) DeepCopy() *StaticResponseReturn {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticResponseReturn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticResponseSpec) DeepCopyInto(out *StaticResponseSpec) {
+	*out = *in
+	if in.Return != nil {
+		in, out := &in.Return, &out.Return
+		*out = new(StaticResponseReturn)
+		**out = **in
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(StaticResponseRedirect)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticResponseSpec.
+func (in *StaticResponseSpec) DeepCopy() *StaticResponseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticResponseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyAware) DeepCopyInto(out *TopologyAware) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAware.
+func (in *TopologyAware) DeepCopy() *TopologyAware {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyAware)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportServer) DeepCopyInto(out *TransportServer) {
 	*out = *in
@@ -541,6 +675,11 @@ func (in *TransportServerStatus) DeepCopy() *TransportServerStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Upstream) DeepCopyInto(out *Upstream) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.MaxFails != nil {
 		in, out := &in.MaxFails, &out.MaxFails
 		*out = new(int)
@@ -556,6 +695,11 @@ func (in *Upstream) DeepCopyInto(out *Upstream) {
 		*out = new(HealthCheck)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologyAware != nil {
+		in, out := &in.TopologyAware, &out.TopologyAware
+		*out = new(TopologyAware)
+		**out = **in
+	}
 	return
 }
 
@@ -2,10 +2,13 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/nginxinc/kubernetes-ingress/internal/configs"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -208,6 +211,52 @@ func validateSecretName(name string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateUpstreamAddresses validates the host:port addresses of an upstream.
+// NGINX resolves DNS names at runtime, which NGINX OSS supports only for a single address of an upstream.
+func validateUpstreamAddresses(addresses []string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.String{}
+	hasDNSName := false
+
+	for i, address := range addresses {
+		idxPath := fieldPath.Index(i)
+
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, address, "must be an address in the host:port format"))
+			continue
+		}
+
+		if net.ParseIP(host) == nil {
+			hasDNSName = true
+			for _, msg := range validation.IsDNS1123Subdomain(host) {
+				allErrs = append(allErrs, field.Invalid(idxPath, address, msg))
+			}
+		}
+
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath, address, "port must be a number"))
+		} else {
+			for _, msg := range validation.IsValidPortNum(portNum) {
+				allErrs = append(allErrs, field.Invalid(idxPath, address, msg))
+			}
+		}
+
+		if seen.Has(address) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, address))
+		}
+		seen.Insert(address)
+	}
+
+	if !isPlus && hasDNSName && len(addresses) > 1 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "multiple addresses with DNS names are only supported in NGINX Plus"))
+	}
+
+	return allErrs
+}
+
 func mapToPrettyString(m map[string]bool) string {
 	var out []string
 
@@ -319,3 +319,80 @@ func TestValidateOffset(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateUpstreamAddresses(t *testing.T) {
+	tests := []struct {
+		addresses []string
+		isPlus    bool
+		msg       string
+	}{
+		{
+			addresses: []string{"10.0.0.1:80", "10.0.0.2:8080", "[2001:db8::1]:443"},
+			isPlus:    false,
+			msg:       "IP addresses",
+		},
+		{
+			addresses: []string{"example.com:80"},
+			isPlus:    false,
+			msg:       "single DNS name",
+		},
+		{
+			addresses: []string{"example.com:80", "10.0.0.1:80", "backup.example.com:80"},
+			isPlus:    true,
+			msg:       "multiple DNS names in NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateUpstreamAddresses(test.addresses, field.NewPath("addresses"), test.isPlus)
+		if len(allErrs) > 0 {
+			t.Errorf("validateUpstreamAddresses() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateUpstreamAddressesFails(t *testing.T) {
+	tests := []struct {
+		addresses []string
+		isPlus    bool
+		msg       string
+	}{
+		{
+			addresses: []string{"10.0.0.1"},
+			isPlus:    true,
+			msg:       "missing port",
+		},
+		{
+			addresses: []string{"10.0.0.1:http"},
+			isPlus:    true,
+			msg:       "invalid port",
+		},
+		{
+			addresses: []string{"10.0.0.1:0"},
+			isPlus:    true,
+			msg:       "port out of range",
+		},
+		{
+			addresses: []string{"example_com:80"},
+			isPlus:    true,
+			msg:       "invalid DNS name",
+		},
+		{
+			addresses: []string{"10.0.0.1:80", "10.0.0.1:80"},
+			isPlus:    true,
+			msg:       "duplicated address",
+		},
+		{
+			addresses: []string{"example.com:80", "10.0.0.1:80"},
+			isPlus:    false,
+			msg:       "multiple addresses with DNS names in NGINX",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateUpstreamAddresses(test.addresses, field.NewPath("addresses"), test.isPlus)
+		if len(allErrs) == 0 {
+			t.Errorf("validateUpstreamAddresses() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
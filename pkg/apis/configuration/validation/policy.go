@@ -113,14 +113,14 @@ func validateAccessControl(accessControl *v1.AccessControl, fieldPath *field.Pat
 
 	if accessControl.Allow != nil {
 		for i, ipOrCIDR := range accessControl.Allow {
-			allErrs = append(allErrs, validateIPorCIDR(ipOrCIDR, fieldPath.Child("allow").Index(i))...)
+			allErrs = append(allErrs, ValidateIPorCIDR(ipOrCIDR, fieldPath.Child("allow").Index(i))...)
 		}
 		fieldCount++
 	}
 
 	if accessControl.Deny != nil {
 		for i, ipOrCIDR := range accessControl.Deny {
-			allErrs = append(allErrs, validateIPorCIDR(ipOrCIDR, fieldPath.Child("deny").Index(i))...)
+			allErrs = append(allErrs, ValidateIPorCIDR(ipOrCIDR, fieldPath.Child("deny").Index(i))...)
 		}
 		fieldCount++
 	}
@@ -135,9 +135,9 @@ func validateAccessControl(accessControl *v1.AccessControl, fieldPath *field.Pat
 func validateRateLimit(rateLimit *v1.RateLimit, fieldPath *field.Path, isPlus bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateRateLimitZoneSize(rateLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
-	allErrs = append(allErrs, validateRate(rateLimit.Rate, fieldPath.Child("rate"))...)
-	allErrs = append(allErrs, validateRateLimitKey(rateLimit.Key, fieldPath.Child("key"), isPlus)...)
+	allErrs = append(allErrs, ValidateRateLimitZoneSize(rateLimit.ZoneSize, fieldPath.Child("zoneSize"))...)
+	allErrs = append(allErrs, ValidateRate(rateLimit.Rate, fieldPath.Child("rate"))...)
+	allErrs = append(allErrs, ValidateRateLimitKey(rateLimit.Key, fieldPath.Child("key"), isPlus)...)
 
 	if rateLimit.Delay != nil {
 		allErrs = append(allErrs, validatePositiveInt(*rateLimit.Delay, fieldPath.Child("delay"))...)
@@ -398,7 +398,8 @@ const (
 
 var rateRegexp = regexp.MustCompile("^" + rateFmt + "$")
 
-func validateRate(rate string, fieldPath *field.Path) field.ErrorList {
+// ValidateRate validates the rate of a rate limit, for example 10r/s.
+func ValidateRate(rate string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if rate == "" {
@@ -412,7 +413,8 @@ func validateRate(rate string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func validateRateLimitZoneSize(zoneSize string, fieldPath *field.Path) field.ErrorList {
+// ValidateRateLimitZoneSize validates the size of a shared memory zone of a rate limit.
+func ValidateRateLimitZoneSize(zoneSize string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if zoneSize == "" {
@@ -444,7 +446,8 @@ var rateLimitKeyVariables = map[string]bool{
 	"args":               true,
 }
 
-func validateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.ErrorList {
+// ValidateRateLimitKey validates the key of a rate limit.
+func ValidateRateLimitKey(key string, fieldPath *field.Path, isPlus bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if key == "" {
@@ -534,7 +537,8 @@ func validateJWTRealm(realm string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func validateIPorCIDR(ipOrCIDR string, fieldPath *field.Path) field.ErrorList {
+// ValidateIPorCIDR validates an IP address or a CIDR.
+func ValidateIPorCIDR(ipOrCIDR string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	_, _, err := net.ParseCIDR(ipOrCIDR)
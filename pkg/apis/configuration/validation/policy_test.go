@@ -542,9 +542,9 @@ func TestValidateIPorCIDR(t *testing.T) {
 	}
 
 	for _, input := range validInput {
-		allErrs := validateIPorCIDR(input, field.NewPath("ipOrCIDR"))
+		allErrs := ValidateIPorCIDR(input, field.NewPath("ipOrCIDR"))
 		if len(allErrs) > 0 {
-			t.Errorf("validateIPorCIDR(%q) returned errors %v for valid input", input, allErrs)
+			t.Errorf("ValidateIPorCIDR(%q) returned errors %v for valid input", input, allErrs)
 		}
 	}
 
@@ -556,9 +556,9 @@ func TestValidateIPorCIDR(t *testing.T) {
 	}
 
 	for _, input := range invalidInput {
-		allErrs := validateIPorCIDR(input, field.NewPath("ipOrCIDR"))
+		allErrs := ValidateIPorCIDR(input, field.NewPath("ipOrCIDR"))
 		if len(allErrs) == 0 {
-			t.Errorf("validateIPorCIDR(%q) returned no errors for invalid input", input)
+			t.Errorf("ValidateIPorCIDR(%q) returned no errors for invalid input", input)
 		}
 	}
 }
@@ -571,9 +571,9 @@ func TestValidateRate(t *testing.T) {
 	}
 
 	for _, input := range validInput {
-		allErrs := validateRate(input, field.NewPath("rate"))
+		allErrs := ValidateRate(input, field.NewPath("rate"))
 		if len(allErrs) > 0 {
-			t.Errorf("validateRate(%q) returned errors %v for valid input", input, allErrs)
+			t.Errorf("ValidateRate(%q) returned errors %v for valid input", input, allErrs)
 		}
 	}
 
@@ -585,9 +585,9 @@ func TestValidateRate(t *testing.T) {
 	}
 
 	for _, input := range invalidInput {
-		allErrs := validateRate(input, field.NewPath("rate"))
+		allErrs := ValidateRate(input, field.NewPath("rate"))
 		if len(allErrs) == 0 {
-			t.Errorf("validateRate(%q) returned no errors for invalid input", input)
+			t.Errorf("ValidateRate(%q) returned no errors for invalid input", input)
 		}
 	}
 }
@@ -616,18 +616,18 @@ func TestValidateRateLimitZoneSize(t *testing.T) {
 	validInput := []string{"32", "32k", "32K", "10m"}
 
 	for _, test := range validInput {
-		allErrs := validateRateLimitZoneSize(test, field.NewPath("size"))
+		allErrs := ValidateRateLimitZoneSize(test, field.NewPath("size"))
 		if len(allErrs) != 0 {
-			t.Errorf("validateRateLimitZoneSize(%q) returned an error for valid input", test)
+			t.Errorf("ValidateRateLimitZoneSize(%q) returned an error for valid input", test)
 		}
 	}
 
 	invalidInput := []string{"", "31", "31k", "0", "0M"}
 
 	for _, test := range invalidInput {
-		allErrs := validateRateLimitZoneSize(test, field.NewPath("size"))
+		allErrs := ValidateRateLimitZoneSize(test, field.NewPath("size"))
 		if len(allErrs) == 0 {
-			t.Errorf("validateRateLimitZoneSize(%q) didn't return error for invalid input", test)
+			t.Errorf("ValidateRateLimitZoneSize(%q) didn't return error for invalid input", test)
 		}
 	}
 }
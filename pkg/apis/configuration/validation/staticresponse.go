@@ -0,0 +1,44 @@
+package validation
+
+import (
+	v1 "github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1"
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateStaticResponse validates a StaticResponse.
+func ValidateStaticResponse(staticResponse *v1alpha1.StaticResponse, isPlus bool) error {
+	allErrs := validateStaticResponseSpec(&staticResponse.Spec, field.NewPath("spec"), isPlus)
+	return allErrs.ToAggregate()
+}
+
+// validateStaticResponseSpec validates a StaticResponseSpec.
+// The return and the redirect are validated the same way as the return and the redirect actions of a VirtualServer.
+func validateStaticResponseSpec(spec *v1alpha1.StaticResponseSpec, fieldPath *field.Path, isPlus bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if (spec.Return == nil) == (spec.Redirect == nil) {
+		return append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `return` or `redirect`"))
+	}
+
+	vsv := &VirtualServerValidator{isPlus: isPlus}
+
+	if spec.Return != nil {
+		actionReturn := &v1.ActionReturn{
+			Code: spec.Return.Code,
+			Type: spec.Return.Type,
+			Body: spec.Return.Body,
+		}
+		allErrs = append(allErrs, vsv.validateActionReturn(actionReturn, fieldPath.Child("return"), returnBodySpecialVariables, returnBodyVariables)...)
+	}
+
+	if spec.Redirect != nil {
+		actionRedirect := &v1.ActionRedirect{
+			URL:  spec.Redirect.URL,
+			Code: spec.Redirect.Code,
+		}
+		allErrs = append(allErrs, vsv.validateActionRedirect(actionRedirect, fieldPath.Child("redirect"), validRedirectVariableNames)...)
+	}
+
+	return allErrs
+}
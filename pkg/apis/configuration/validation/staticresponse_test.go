@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/nginxinc/kubernetes-ingress/pkg/apis/configuration/v1alpha1"
+)
+
+func TestValidateStaticResponse(t *testing.T) {
+	tests := []struct {
+		staticResponse *v1alpha1.StaticResponse
+		msg            string
+	}{
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Body: "Hello World!",
+					},
+				},
+			},
+			msg: "return with body only",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Code: 503,
+						Type: "application/json",
+						Body: `{\"message\": \"${request_uri} is unavailable\"}`,
+					},
+				},
+			},
+			msg: "return with code, type and a variable",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Redirect: &v1alpha1.StaticResponseRedirect{
+						URL:  "https://${host}/new-path",
+						Code: 308,
+					},
+				},
+			},
+			msg: "redirect",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateStaticResponse(test.staticResponse, false)
+		if err != nil {
+			t.Errorf("ValidateStaticResponse() returned error %v for valid input for the case of %s", err, test.msg)
+		}
+	}
+}
+
+func TestValidateStaticResponseFails(t *testing.T) {
+	tests := []struct {
+		staticResponse *v1alpha1.StaticResponse
+		msg            string
+	}{
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{},
+			},
+			msg: "empty spec",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Body: "Hello World!",
+					},
+					Redirect: &v1alpha1.StaticResponseRedirect{
+						URL: "https://example.com",
+					},
+				},
+			},
+			msg: "both return and redirect",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Code: 301,
+						Body: "Hello World!",
+					},
+				},
+			},
+			msg: "return with a redirect code",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Body: `"Hello World!"`,
+					},
+				},
+			},
+			msg: "return with unescaped quotes",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Return: &v1alpha1.StaticResponseReturn{
+						Body: "${upstream_addr}",
+					},
+				},
+			},
+			msg: "return with an unsupported variable",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Redirect: &v1alpha1.StaticResponseRedirect{
+						URL: "/new-path",
+					},
+				},
+			},
+			msg: "redirect without the protocol",
+		},
+		{
+			staticResponse: &v1alpha1.StaticResponse{
+				Spec: v1alpha1.StaticResponseSpec{
+					Redirect: &v1alpha1.StaticResponseRedirect{
+						URL:  "https://example.com",
+						Code: 200,
+					},
+				},
+			},
+			msg: "redirect with an invalid code",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateStaticResponse(test.staticResponse, false)
+		if err == nil {
+			t.Errorf("ValidateStaticResponse() returned no error for invalid input for the case of %s", test.msg)
+		}
+	}
+}
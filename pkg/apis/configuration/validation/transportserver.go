@@ -167,13 +167,29 @@ func validateTransportServerUpstreams(upstreams []v1alpha1.Upstream, fieldPath *
 			upstreamNames.Insert(u.Name)
 		}
 
-		allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+		if len(u.Addresses) > 0 {
+			if u.Service != "" {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("service"), "can't be used with `addresses`"))
+			}
+			if u.Port != 0 {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("port"), "can't be used with `addresses`"))
+			}
+			if u.TopologyAware != nil {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("topologyAware"), "can't be used with `addresses`"))
+			}
+			allErrs = append(allErrs, validateUpstreamAddresses(u.Addresses, idxPath.Child("addresses"), isPlus)...)
+		} else {
+			allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+			for _, msg := range validation.IsValidPortNum(u.Port) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
+			}
+		}
+
 		allErrs = append(allErrs, validatePositiveIntOrZeroFromPointer(u.MaxFails, idxPath.Child("maxFails"))...)
 		allErrs = append(allErrs, validatePositiveIntOrZeroFromPointer(u.MaxFails, idxPath.Child("maxConns"))...)
 		allErrs = append(allErrs, validateTime(u.FailTimeout, idxPath.Child("failTimeout"))...)
-
-		for _, msg := range validation.IsValidPortNum(u.Port) {
-			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
+		if u.TopologyAware != nil {
+			allErrs = append(allErrs, validatePositiveIntOrZero(u.TopologyAware.MinEndpoints, idxPath.Child("topologyAware", "minEndpoints"))...)
 		}
 
 		allErrs = append(allErrs, validateTSUpstreamHealthChecks(u.HealthCheck, idxPath.Child("healthChecks"))...)
@@ -96,6 +96,18 @@ func TestValidateTransportServerUpstreams(t *testing.T) {
 			},
 			msg: "2 valid upstreams",
 		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:      "upstream1",
+					Addresses: []string{"example.com:53", "10.0.0.1:53"},
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": {},
+			},
+			msg: "upstream with addresses",
+		},
 	}
 
 	for _, test := range tests {
@@ -152,6 +164,19 @@ func TestValidateTransportServerUpstreamsFails(t *testing.T) {
 			},
 			msg: "invalid port",
 		},
+		{
+			upstreams: []v1alpha1.Upstream{
+				{
+					Name:      "upstream1",
+					Service:   "test-1",
+					Addresses: []string{"example.com:53"},
+				},
+			},
+			expectedUpstreamNames: map[string]sets.Empty{
+				"upstream1": {},
+			},
+			msg: "service with addresses",
+		},
 		{
 			upstreams: []v1alpha1.Upstream{
 				{
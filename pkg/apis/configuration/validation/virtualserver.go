@@ -37,8 +37,10 @@ func (vsv *VirtualServerValidator) ValidateVirtualServer(virtualServer *v1.Virtu
 func (vsv *VirtualServerValidator) validateVirtualServerSpec(spec *v1.VirtualServerSpec, fieldPath *field.Path, namespace string) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateHost(spec.Host, fieldPath.Child("host"))...)
+	allErrs = append(allErrs, validateVirtualServerHost(spec.Host, fieldPath.Child("host"))...)
+	allErrs = append(allErrs, validateAliases(spec.Aliases, spec.Host, fieldPath.Child("aliases"))...)
 	allErrs = append(allErrs, validateTLS(spec.TLS, fieldPath.Child("tls"))...)
+	allErrs = append(allErrs, validateACMEHosts(spec, fieldPath)...)
 	allErrs = append(allErrs, validatePolicies(spec.Policies, fieldPath.Child("policies"), namespace)...)
 
 	upstreamErrs, upstreamNames := vsv.validateUpstreams(spec.Upstreams, fieldPath.Child("upstreams"))
@@ -46,7 +48,177 @@ func (vsv *VirtualServerValidator) validateVirtualServerSpec(spec *v1.VirtualSer
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRoutes(spec.Routes, fieldPath.Child("routes"), upstreamNames, namespace)...)
 
+	allErrs = append(allErrs, vsv.validateHeaders(spec.Headers, fieldPath.Child("headers"))...)
+	allErrs = append(allErrs, vsv.validateRedirects(spec.Redirects, spec.Host, fieldPath.Child("redirects"))...)
 	allErrs = append(allErrs, validateDos(vsv.isDosEnabled, spec.Dos, fieldPath.Child("dos"))...)
+	allErrs = append(allErrs, validateTracing(spec.Tracing, fieldPath.Child("tracing"))...)
+
+	return allErrs
+}
+
+func (vsv *VirtualServerValidator) validateHeaders(headers *v1.Headers, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if headers == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, vsv.validateActionProxyRequestHeaders(headers.RequestHeaders, fieldPath.Child("requestHeaders"))...)
+	allErrs = append(allErrs, vsv.validateActionProxyResponseHeaders(headers.ResponseHeaders, fieldPath.Child("responseHeaders"))...)
+	allErrs = append(allErrs, vsv.validateSecurityHeaders(headers.Security, fieldPath.Child("security"))...)
+
+	return allErrs
+}
+
+var validFrameOptions = map[string]bool{
+	"DENY":       true,
+	"SAMEORIGIN": true,
+}
+
+var validReferrerPolicies = map[string]bool{
+	"no-referrer":                     true,
+	"no-referrer-when-downgrade":      true,
+	"origin":                          true,
+	"origin-when-cross-origin":        true,
+	"same-origin":                     true,
+	"strict-origin":                   true,
+	"strict-origin-when-cross-origin": true,
+	"unsafe-url":                      true,
+}
+
+func (vsv *VirtualServerValidator) validateSecurityHeaders(security *v1.SecurityHeaders, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if security == nil {
+		return allErrs
+	}
+
+	if security.HSTS != nil && security.HSTS.MaxAge < 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("hsts", "maxAge"), security.HSTS.MaxAge, "must be greater than or equal to 0"))
+	}
+
+	if security.FrameOptions != "" && !validFrameOptions[security.FrameOptions] {
+		msg := fmt.Sprintf("not a valid frame option. Accepted values are: %v", mapToPrettyString(validFrameOptions))
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("frameOptions"), security.FrameOptions, msg))
+	}
+
+	// the policy is a header value that can't reference NGINX variables
+	allErrs = append(allErrs, validateEscapedStringWithVariables(security.ContentSecurityPolicy, fieldPath.Child("contentSecurityPolicy"),
+		nil, nil, vsv.isPlus)...)
+
+	if security.ReferrerPolicy != "" && !validReferrerPolicies[security.ReferrerPolicy] {
+		msg := fmt.Sprintf("not a valid referrer policy. Accepted values are: %v", mapToPrettyString(validReferrerPolicies))
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("referrerPolicy"), security.ReferrerPolicy, msg))
+	}
+
+	return allErrs
+}
+
+var validTrailingSlashActions = map[string]bool{
+	"add":    true,
+	"remove": true,
+}
+
+func (vsv *VirtualServerValidator) validateRedirects(redirects *v1.Redirects, host string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if redirects == nil {
+		return allErrs
+	}
+
+	if redirects.CanonicalHost && strings.HasPrefix(host, "*.") {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("canonicalHost"), "cannot be used with a wildcard host"))
+	}
+
+	if redirects.TrailingSlash != "" && !validTrailingSlashActions[redirects.TrailingSlash] {
+		msg := fmt.Sprintf("not a valid trailing slash action. Accepted values are: %v", mapToPrettyString(validTrailingSlashActions))
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("trailingSlash"), redirects.TrailingSlash, msg))
+	}
+
+	// lowercasing requires njs, which is only available in NGINX Plus
+	if redirects.LowercasePaths && !vsv.isPlus {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("lowercasePaths"), "is only supported in NGINX Plus"))
+	}
+
+	if redirects.Code != 0 {
+		allErrs = append(allErrs, validateRedirectStatusCode(redirects.Code, fieldPath.Child("code"))...)
+	}
+
+	froms := sets.NewString()
+
+	for i, p := range redirects.Paths {
+		idxPath := fieldPath.Child("paths").Index(i)
+
+		fromErrs := validatePathRedirectFrom(p.From, idxPath.Child("from"))
+		if len(fromErrs) == 0 {
+			if froms.Has(p.From) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("from"), p.From))
+			} else {
+				froms.Insert(p.From)
+			}
+		}
+		allErrs = append(allErrs, fromErrs...)
+
+		allErrs = append(allErrs, validatePathRedirectTo(p.To, strings.HasPrefix(p.From, "~"), idxPath.Child("to"))...)
+
+		if p.Code != 0 {
+			allErrs = append(allErrs, validateRedirectStatusCode(p.Code, idxPath.Child("code"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validatePathRedirectFrom(from string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if from == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+
+	if strings.HasPrefix(from, "~") {
+		return append(allErrs, ValidateRegexPath(from, fieldPath)...)
+	}
+
+	allErrs = append(allErrs, validatePath(from, fieldPath)...)
+	if len(allErrs) == 0 && strings.Contains(from, "\"") {
+		allErrs = append(allErrs, field.Invalid(fieldPath, from, "must not include `\"`"))
+	}
+
+	return allErrs
+}
+
+const (
+	redirectToFmt    = `(/|https?://)[^\s{};"?]*`
+	redirectToErrMsg = "must start with /, http:// or https:// and must not include any whitespace character, `{`, `}`, `;`, `\"` or `?`"
+)
+
+var (
+	redirectToRegexp         = regexp.MustCompile("^" + redirectToFmt + "$")
+	redirectToCaptureRegexp  = regexp.MustCompile(`\$[1-9]`)
+	redirectToVariableRegexp = regexp.MustCompile(`\$`)
+)
+
+func validatePathRedirectTo(to string, isRegex bool, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if to == "" {
+		return append(allErrs, field.Required(fieldPath, ""))
+	}
+
+	if !redirectToRegexp.MatchString(to) {
+		msg := validation.RegexError(redirectToErrMsg, redirectToFmt, "/new-path", "https://example.com/path", "/blog/$1")
+		return append(allErrs, field.Invalid(fieldPath, to, msg))
+	}
+
+	captures := len(redirectToCaptureRegexp.FindAllString(to, -1))
+	if captures != len(redirectToVariableRegexp.FindAllString(to, -1)) {
+		return append(allErrs, field.Invalid(fieldPath, to, "the only variables allowed are the regular expression captures $1-$9"))
+	}
+
+	if captures > 0 && !isRegex {
+		allErrs = append(allErrs, field.Invalid(fieldPath, to, "captures $1-$9 can only be used when from is a regular expression"))
+	}
 
 	return allErrs
 }
@@ -65,6 +237,68 @@ func validateHost(host string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateVirtualServerHost validates the host of a VirtualServer. Unlike the host of a TransportServer,
+// it can be a wildcard host, such as *.example.com.
+func validateVirtualServerHost(host string, fieldPath *field.Path) field.ErrorList {
+	if !strings.HasPrefix(host, "*.") {
+		return validateHost(host, fieldPath)
+	}
+
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsWildcardDNS1123Subdomain(host) {
+		allErrs = append(allErrs, field.Invalid(fieldPath, host, msg))
+	}
+
+	return allErrs
+}
+
+// validateAliases validates the aliases of the host of a VirtualServer.
+func validateAliases(aliases []string, host string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allAliases := sets.String{}
+
+	for i, alias := range aliases {
+		idxPath := fieldPath.Index(i)
+
+		allErrs = append(allErrs, validateVirtualServerHost(alias, idxPath)...)
+
+		if alias == host {
+			allErrs = append(allErrs, field.Invalid(idxPath, alias, "must be different from the host"))
+		} else if allAliases.Has(alias) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, alias))
+		}
+
+		allAliases.Insert(alias)
+	}
+
+	return allErrs
+}
+
+// validateACMEHosts validates that the host and the aliases of a VirtualServer that enables ACME are not wildcard hosts,
+// because the ACME HTTP-01 challenge can't validate them.
+func validateACMEHosts(spec *v1.VirtualServerSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.TLS == nil || spec.TLS.ACME == nil {
+		return allErrs
+	}
+
+	msg := "wildcard hosts are not supported with the ACME certificates"
+
+	if strings.HasPrefix(spec.Host, "*.") {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("host"), spec.Host, msg))
+	}
+
+	for i, alias := range spec.Aliases {
+		if strings.HasPrefix(alias, "*.") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("aliases").Index(i), alias, msg))
+		}
+	}
+
+	return allErrs
+}
+
 func validatePolicies(policies []v1.PolicyReference, fieldPath *field.Path, namespace string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	policyKeys := sets.String{}
@@ -115,6 +349,38 @@ func validateTLS(tls *v1.TLS, fieldPath *field.Path) field.ErrorList {
 
 	allErrs = append(allErrs, validateTLSRedirect(tls.Redirect, fieldPath.Child("redirect"))...)
 
+	allErrs = append(allErrs, validateACME(tls.ACME, tls.Secret, fieldPath)...)
+
+	return allErrs
+}
+
+// validateACME validates the ACME field of a TLS. fieldPath is the path of the TLS.
+func validateACME(acme *v1.ACME, secret string, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if acme == nil {
+		return allErrs
+	}
+
+	if secret == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("secret"), "must be set to store the certificate issued by the ACME server"))
+	}
+
+	issuerPath := fieldPath.Child("acme", "issuer")
+
+	if acme.Issuer == "" {
+		return append(allErrs, field.Required(issuerPath, ""))
+	}
+
+	issuerErrs := validateURL(acme.Issuer, issuerPath)
+	if len(issuerErrs) > 0 {
+		return append(allErrs, issuerErrs...)
+	}
+
+	if !strings.HasPrefix(acme.Issuer, "https://") {
+		allErrs = append(allErrs, field.Invalid(issuerPath, acme.Issuer, "must be the https URL of the directory of an ACME server"))
+	}
+
 	return allErrs
 }
 
@@ -137,6 +403,30 @@ func validateDos(isDosEnabled bool, dos string, fieldPath *field.Path) field.Err
 	return allErrs
 }
 
+func validateTracing(tracing *v1.Tracing, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tracing == nil {
+		return allErrs
+	}
+
+	if tracing.SamplerRatio != "" {
+		if tracing.Enable == nil || !*tracing.Enable {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("samplerRatio"), "requires tracing to be enabled"))
+		} else if _, err := configs.ParseOtelSamplerRatio(tracing.SamplerRatio); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("samplerRatio"), tracing.SamplerRatio, "must be a number between 0 and 1"))
+		}
+	}
+
+	if tracing.Context != "" {
+		if _, err := configs.ParseOtelTraceContext(tracing.Context); err != nil {
+			allErrs = append(allErrs, field.NotSupported(fieldPath.Child("context"), tracing.Context, []string{"extract", "inject", "propagate", "ignore"}))
+		}
+	}
+
+	return allErrs
+}
+
 func validateTLSRedirect(redirect *v1.TLSRedirect, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -315,6 +605,12 @@ func validateGrpcStatus(i *int, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+var validSessionCookieModes = map[string]bool{
+	"":       true,
+	"sticky": true,
+	"hash":   true,
+}
+
 func validateSessionCookie(sc *v1.SessionCookie, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -322,6 +618,32 @@ func validateSessionCookie(sc *v1.SessionCookie, fieldPath *field.Path) field.Er
 		return allErrs
 	}
 
+	if !validSessionCookieModes[sc.Mode] {
+		allErrs = append(allErrs, field.NotSupported(fieldPath.Child("mode"), sc.Mode, []string{"sticky", "hash"}))
+	}
+
+	if sc.Mode != "hash" {
+		if sc.Header != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("header"), "requires the `hash` mode"))
+		}
+		if sc.ClientIP {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("clientIP"), "requires the `hash` mode"))
+		}
+	} else if sc.Header != "" && sc.ClientIP {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("clientIP"), "can't be used with `header`"))
+	}
+
+	if sc.Header != "" {
+		for _, msg := range validation.IsHTTPHeaderName(sc.Header) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("header"), sc.Header, msg))
+		}
+	}
+
+	// In the hash mode with a header or the client IP, the cookie is not used.
+	if sc.Header != "" || sc.ClientIP {
+		return allErrs
+	}
+
 	if sc.Name == "" {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("name"), ""))
 	} else {
@@ -332,10 +654,22 @@ func validateSessionCookie(sc *v1.SessionCookie, fieldPath *field.Path) field.Er
 
 	if sc.Path != "" {
 		allErrs = append(allErrs, validatePath(sc.Path, fieldPath.Child("path"))...)
+		// in the hash mode, the path is a part of the Set-Cookie header value
+		if sc.Mode == "hash" && strings.ContainsAny(sc.Path, `"\`) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("path"), sc.Path, "must not contain '\"' (double quote) or '\\' (backslash)"))
+		}
 	}
 
 	if sc.Expires != "max" {
-		allErrs = append(allErrs, validateTime(sc.Expires, fieldPath.Child("expires"))...)
+		expiresErrs := validateTime(sc.Expires, fieldPath.Child("expires"))
+		allErrs = append(allErrs, expiresErrs...)
+
+		// in the hash mode, the expires becomes the Max-Age of the cookie in seconds, and Max-Age=0 deletes the cookie
+		if sc.Mode == "hash" && sc.Expires != "" && len(expiresErrs) == 0 {
+			if seconds, err := configs.ParseTimeToSeconds(sc.Expires); err != nil || seconds < 1 {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("expires"), sc.Expires, "must be at least 1s in the `hash` mode"))
+			}
+		}
 	}
 
 	if sc.Domain != "" {
@@ -508,7 +842,12 @@ func (vsv *VirtualServerValidator) validateUpstreams(upstreams []v1.Upstream, fi
 			allErrs = append(allErrs, validateLabels(u.Subselector, idxPath.Child("subselector"))...)
 		}
 
-		allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+		if len(u.Addresses) > 0 {
+			allErrs = append(allErrs, validateUpstreamWithAddresses(u, idxPath)...)
+			allErrs = append(allErrs, validateUpstreamAddresses(u.Addresses, idxPath.Child("addresses"), vsv.isPlus)...)
+		} else {
+			allErrs = append(allErrs, validateServiceName(u.Service, idxPath.Child("service"))...)
+		}
 		allErrs = append(allErrs, validateTime(u.ProxyConnectTimeout, idxPath.Child("connect-timeout"))...)
 		allErrs = append(allErrs, validateTime(u.ProxyReadTimeout, idxPath.Child("read-timeout"))...)
 		allErrs = append(allErrs, validateTime(u.ProxySendTimeout, idxPath.Child("send-timeout"))...)
@@ -527,18 +866,136 @@ func (vsv *VirtualServerValidator) validateUpstreams(upstreams []v1.Upstream, fi
 		allErrs = append(allErrs, validateSize(u.ProxyBufferSize, idxPath.Child("buffer-size"))...)
 		allErrs = append(allErrs, validateQueue(u.Queue, idxPath.Child("queue"))...)
 		allErrs = append(allErrs, validateSessionCookie(u.SessionCookie, idxPath.Child("sessionCookie"))...)
+		allErrs = append(allErrs, validateUpstreamForHashSessionCookie(u, idxPath)...)
 		allErrs = append(allErrs, validateUpstreamType(u.Type, idxPath.Child("type"))...)
 
-		for _, msg := range validation.IsValidPortNum(int(u.Port)) {
-			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
+		if len(u.Addresses) == 0 {
+			for _, msg := range validation.IsValidPortNum(int(u.Port)) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), u.Port, msg))
+			}
+			allErrs = append(allErrs, validateAdditionalUpstreamServices(u, idxPath)...)
 		}
 
+		allErrs = append(allErrs, validatePositiveIntOrZero(u.Weight, idxPath.Child("weight"))...)
+		allErrs = append(allErrs, validateTopologyAware(u, idxPath.Child("topologyAware"))...)
 		allErrs = append(allErrs, rejectPlusResourcesInOSS(u, idxPath, vsv.isPlus)...)
 	}
 
 	return allErrs, upstreamNames
 }
 
+func validateTopologyAware(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if u.TopologyAware == nil {
+		return allErrs
+	}
+
+	if u.UseClusterIP {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "can't be used with use-cluster-ip"))
+	}
+	if len(u.Addresses) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "can't be used with `addresses`"))
+	}
+	allErrs = append(allErrs, validatePositiveIntOrZero(u.TopologyAware.MinEndpoints, fieldPath.Child("minEndpoints"))...)
+
+	return allErrs
+}
+
+// validateUpstreamWithAddresses checks that an upstream with addresses doesn't reference any Service.
+func validateUpstreamWithAddresses(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if u.Service != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("service"), "can't be used with `addresses`"))
+	}
+	if u.Port != 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("port"), "can't be used with `addresses`"))
+	}
+	if u.Subselector != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("subselector"), "can't be used with `addresses`"))
+	}
+	if u.UseClusterIP {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("use-cluster-ip"), "can't be used with `addresses`"))
+	}
+	if len(u.Services) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("services"), "can't be used with `addresses`"))
+	}
+	if u.Backup != "" || u.BackupPort != 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backup"), "can't be used with `addresses`"))
+	}
+
+	return allErrs
+}
+
+// validateAdditionalUpstreamServices validates the weighted Services and the backup Service of an upstream.
+// Every Service and port can be used only once in an upstream.
+func validateAdditionalUpstreamServices(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	servicePorts := sets.String{}
+	servicePorts.Insert(fmt.Sprintf("%s:%d", u.Service, u.Port))
+
+	for i, svc := range u.Services {
+		idxPath := fieldPath.Child("services").Index(i)
+
+		allErrs = append(allErrs, validateServiceName(svc.Name, idxPath.Child("name"))...)
+		for _, msg := range validation.IsValidPortNum(int(svc.Port)) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("port"), svc.Port, msg))
+		}
+		allErrs = append(allErrs, validatePositiveIntOrZero(svc.Weight, idxPath.Child("weight"))...)
+
+		servicePort := fmt.Sprintf("%s:%d", svc.Name, svc.Port)
+		if servicePorts.Has(servicePort) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, servicePort))
+		}
+		servicePorts.Insert(servicePort)
+	}
+
+	if u.Backup == "" {
+		if u.BackupPort != 0 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backupPort"), "requires `backup`"))
+		}
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateServiceName(u.Backup, fieldPath.Child("backup"))...)
+	for _, msg := range validation.IsValidPortNum(int(u.BackupPort)) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("backupPort"), u.BackupPort, msg))
+	}
+
+	backupPort := fmt.Sprintf("%s:%d", u.Backup, u.BackupPort)
+	if servicePorts.Has(backupPort) {
+		allErrs = append(allErrs, field.Duplicate(fieldPath.Child("backup"), backupPort))
+	}
+
+	if u.LBMethod != "" && !configs.IsLBMethodCompatibleWithBackup(u.LBMethod) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("lb-method"), u.LBMethod, "is not compatible with `backup`"))
+	}
+
+	return allErrs
+}
+
+// validateUpstreamForHashSessionCookie validates the fields of the upstream that conflict with the load balancing method
+// of the hash mode of the session cookie.
+func validateUpstreamForHashSessionCookie(u v1.Upstream, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if u.SessionCookie == nil || !u.SessionCookie.Enable || u.SessionCookie.Mode != "hash" {
+		return allErrs
+	}
+
+	if u.LBMethod != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("lb-method"), "can't be used with the `hash` mode of `sessionCookie`"))
+	}
+
+	if u.Backup != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backup"), "is not compatible with the `hash` mode of `sessionCookie`"))
+	}
+
+	return allErrs
+}
+
 var validNextUpstreamParams = map[string]bool{
 	"error":          true,
 	"timeout":        true,
@@ -651,10 +1108,25 @@ func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *fiel
 		}
 	}
 
+	if route.SplitKey != nil || route.StickySplits != nil {
+		if !routeHasSplits(route) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "`splitKey` and `stickySplits` require `splits` in the route or in one of its matches"))
+		}
+		allErrs = append(allErrs, vsv.validateSplitKey(route.SplitKey, fieldPath.Child("splitKey"))...)
+		allErrs = append(allErrs, validateStickySplits(route.StickySplits, fieldPath.Child("stickySplits"))...)
+	}
+
 	for i, e := range route.ErrorPages {
 		allErrs = append(allErrs, vsv.validateErrorPage(e, fieldPath.Child("errorPages").Index(i))...)
 	}
 
+	if route.Proxy != nil {
+		if route.Route != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("proxy"), "is not allowed together with `route`"))
+		}
+		allErrs = append(allErrs, validateRouteProxy(route.Proxy, fieldPath.Child("proxy"))...)
+	}
+
 	if route.Route != "" {
 		if isRouteFieldForbidden {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("route"), "is not allowed"))
@@ -674,6 +1146,102 @@ func (vsv *VirtualServerValidator) validateRoute(route v1.Route, fieldPath *fiel
 	}
 
 	allErrs = append(allErrs, validateDos(vsv.isDosEnabled, route.Dos, fieldPath.Child("dos"))...)
+	allErrs = append(allErrs, validateTracing(route.Tracing, fieldPath.Child("tracing"))...)
+
+	return allErrs
+}
+
+func validateRouteProxy(proxy *v1.RouteProxy, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateTime(proxy.ProxyConnectTimeout, fieldPath.Child("connect-timeout"))...)
+	allErrs = append(allErrs, validateTime(proxy.ProxyReadTimeout, fieldPath.Child("read-timeout"))...)
+	allErrs = append(allErrs, validateTime(proxy.ProxySendTimeout, fieldPath.Child("send-timeout"))...)
+	allErrs = append(allErrs, validateOffset(proxy.ClientMaxBodySize, fieldPath.Child("client-max-body-size"))...)
+	allErrs = append(allErrs, validateBuffer(proxy.ProxyBuffers, fieldPath.Child("buffers"))...)
+	allErrs = append(allErrs, validateSize(proxy.ProxyBufferSize, fieldPath.Child("buffer-size"))...)
+
+	return allErrs
+}
+
+func routeHasSplits(route v1.Route) bool {
+	if len(route.Splits) > 0 {
+		return true
+	}
+
+	for _, m := range route.Matches {
+		if len(m.Splits) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (vsv *VirtualServerValidator) validateSplitKey(splitKey *v1.SplitKey, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if splitKey == nil {
+		return allErrs
+	}
+
+	fieldCount := 0
+
+	if splitKey.Cookie != "" {
+		for _, msg := range isCookieName(splitKey.Cookie) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("cookie"), splitKey.Cookie, msg))
+		}
+		fieldCount++
+	}
+
+	if splitKey.Header != "" {
+		for _, msg := range validation.IsHTTPHeaderName(splitKey.Header) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("header"), splitKey.Header, msg))
+		}
+		fieldCount++
+	}
+
+	if splitKey.ClientIP {
+		fieldCount++
+	}
+
+	if splitKey.JWTSubject {
+		if !vsv.isPlus {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("jwtSubject"), "jwtSubject is only supported in NGINX Plus"))
+		}
+		fieldCount++
+	}
+
+	if fieldCount != 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `cookie`, `header`, `clientIP` or `jwtSubject`"))
+	}
+
+	return allErrs
+}
+
+func validateStickySplits(sticky *v1.StickySplits, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sticky == nil {
+		return allErrs
+	}
+
+	if sticky.Cookie == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("cookie"), ""))
+	} else {
+		for _, msg := range isCookieName(sticky.Cookie) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("cookie"), sticky.Cookie, msg))
+		}
+	}
+
+	if sticky.Path != "" {
+		allErrs = append(allErrs, validatePath(sticky.Path, fieldPath.Child("path"))...)
+		if strings.ContainsAny(sticky.Path, `"\`) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("path"), sticky.Path, "must not contain '\"' (double quote) or '\\' (backslash)"))
+		}
+	}
+
+	allErrs = append(allErrs, validatePositiveIntOrZero(sticky.MaxAge, fieldPath.Child("maxAge"))...)
 
 	return allErrs
 }
@@ -1217,7 +1785,7 @@ func validateRoutePath(path string, fieldPath *field.Path) field.ErrorList {
 	}
 
 	if strings.HasPrefix(path, "~") {
-		allErrs = append(allErrs, validateRegexPath(path, fieldPath)...)
+		allErrs = append(allErrs, ValidateRegexPath(path, fieldPath)...)
 	} else if strings.HasPrefix(path, "/") {
 		allErrs = append(allErrs, validatePath(path, fieldPath)...)
 	} else if strings.HasPrefix(path, "=") {
@@ -1229,7 +1797,8 @@ func validateRoutePath(path string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func validateRegexPath(path string, fieldPath *field.Path) field.ErrorList {
+// ValidateRegexPath validates a path that is a regular expression.
+func ValidateRegexPath(path string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if _, err := regexp.Compile(path); err != nil {
@@ -1294,7 +1863,11 @@ func (vsv *VirtualServerValidator) validateMatch(match v1.Match, fieldPath *fiel
 		allErrs = append(allErrs, field.Required(fieldPath.Child("conditions"), "must specify at least one condition"))
 	} else {
 		for i, c := range match.Conditions {
-			allErrs = append(allErrs, validateCondition(c, fieldPath.Child("conditions").Index(i))...)
+			condPath := fieldPath.Child("conditions").Index(i)
+			allErrs = append(allErrs, validateCondition(c, condPath)...)
+			if c.JWTClaim != "" && !vsv.isPlus {
+				allErrs = append(allErrs, field.Forbidden(condPath.Child("jwtClaim"), "jwtClaim is only supported in NGINX Plus"))
+			}
 		}
 	}
 
@@ -1348,12 +1921,86 @@ func validateCondition(condition v1.Condition, fieldPath *field.Path) field.Erro
 		fieldCount++
 	}
 
+	if condition.Method {
+		fieldCount++
+	}
+
+	if condition.ClientIP {
+		fieldCount++
+	}
+
+	if condition.JWTClaim != "" {
+		for _, msg := range isValidSpecialHeaderLikeVariable(condition.JWTClaim) {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("jwtClaim"), condition.JWTClaim, msg))
+		}
+		fieldCount++
+	}
+
 	if fieldCount != 1 {
-		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument` or `variable`"))
+		allErrs = append(allErrs, field.Invalid(fieldPath, "", "must specify exactly one of: `header`, `cookie`, `argument`, `variable`, `method`, `clientIP` or `jwtClaim`"))
 	}
 
-	for _, msg := range isValidMatchValue(condition.Value) {
-		allErrs = append(allErrs, field.Invalid(fieldPath.Child("value"), condition.Value, msg))
+	allErrs = append(allErrs, validateConditionOperator(condition, fieldPath)...)
+
+	return allErrs
+}
+
+var validConditionOperators = map[string]bool{
+	"":                          true,
+	v1.ConditionOperatorExact:   true,
+	v1.ConditionOperatorRegex:   true,
+	v1.ConditionOperatorPrefix:  true,
+	v1.ConditionOperatorPresent: true,
+	v1.ConditionOperatorAbsent:  true,
+}
+
+func validateConditionOperator(condition v1.Condition, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !validConditionOperators[condition.Operator] {
+		msg := fmt.Sprintf("must be one of: `%s`, `%s`, `%s`, `%s` or `%s`", v1.ConditionOperatorExact, v1.ConditionOperatorRegex,
+			v1.ConditionOperatorPrefix, v1.ConditionOperatorPresent, v1.ConditionOperatorAbsent)
+		return append(allErrs, field.Invalid(fieldPath.Child("operator"), condition.Operator, msg))
+	}
+
+	valuePath := fieldPath.Child("value")
+
+	if condition.ClientIP {
+		if condition.Operator != "" && condition.Operator != v1.ConditionOperatorExact {
+			return append(allErrs, field.Invalid(fieldPath.Child("operator"), condition.Operator, "must be `exact` for `clientIP`"))
+		}
+		return append(allErrs, ValidateIPorCIDR(condition.Value, valuePath)...)
+	}
+
+	switch condition.Operator {
+	case v1.ConditionOperatorPresent, v1.ConditionOperatorAbsent:
+		if condition.Method {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("operator"), condition.Operator, "is not supported for `method`"))
+		}
+		if condition.Value != "" {
+			allErrs = append(allErrs, field.Forbidden(valuePath, fmt.Sprintf("must be empty for the `%s` operator", condition.Operator)))
+		}
+	case v1.ConditionOperatorRegex:
+		if condition.Value == "" {
+			return append(allErrs, field.Required(valuePath, "must specify a regular expression"))
+		}
+		if _, err := regexp.Compile(condition.Value); err != nil {
+			return append(allErrs, field.Invalid(valuePath, condition.Value, fmt.Sprintf("must be a valid regular expression: %v", err)))
+		}
+		for _, msg := range isValidMatchValue(condition.Value) {
+			allErrs = append(allErrs, field.Invalid(valuePath, condition.Value, msg))
+		}
+	case v1.ConditionOperatorPrefix:
+		if condition.Value == "" {
+			return append(allErrs, field.Required(valuePath, "must specify a prefix"))
+		}
+		if strings.ContainsAny(condition.Value, `"\`) {
+			return append(allErrs, field.Invalid(valuePath, condition.Value, "must not contain '\"' (double quote) or '\\' (backslash)"))
+		}
+	default:
+		for _, msg := range isValidMatchValue(condition.Value) {
+			allErrs = append(allErrs, field.Invalid(valuePath, condition.Value, msg))
+		}
 	}
 
 	return allErrs
@@ -1447,6 +2094,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSpec(spec *v1.Virtu
 	allErrs = append(allErrs, upstreamErrs...)
 
 	allErrs = append(allErrs, vsv.validateVirtualServerRouteSubroutes(spec.Subroutes, fieldPath.Child("subroutes"), upstreamNames, vsPath, namespace)...)
+	allErrs = append(allErrs, vsv.validateHeaders(spec.Headers, fieldPath.Child("headers"))...)
 
 	return allErrs
 }
@@ -1454,7 +2102,7 @@ func (vsv *VirtualServerValidator) validateVirtualServerRouteSpec(spec *v1.Virtu
 func validateVirtualServerRouteHost(host string, virtualServerHost string, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateHost(host, fieldPath)...)
+	allErrs = append(allErrs, validateVirtualServerHost(host, fieldPath)...)
 
 	if virtualServerHost != "" && host != virtualServerHost {
 		msg := fmt.Sprintf("must be equal to '%s'", virtualServerHost)
@@ -1524,8 +2172,8 @@ func rejectPlusResourcesInOSS(upstream v1.Upstream, idxPath *field.Path, isPlus
 		allErrs = append(allErrs, field.Forbidden(idxPath.Child("slow-start"), "slow start is only supported in NGINX Plus"))
 	}
 
-	if upstream.SessionCookie != nil {
-		allErrs = append(allErrs, field.Forbidden(idxPath.Child("sessionCookie"), "sticky cookies are only supported in NGINX Plus"))
+	if upstream.SessionCookie != nil && upstream.SessionCookie.Mode != "hash" {
+		allErrs = append(allErrs, field.Forbidden(idxPath.Child("sessionCookie"), "sticky cookies are only supported in NGINX Plus, use the `hash` mode instead"))
 	}
 
 	if upstream.Queue != nil {
@@ -94,6 +94,141 @@ func TestValidateHost(t *testing.T) {
 	}
 }
 
+func TestValidateVirtualServerHost(t *testing.T) {
+	validHosts := []string{
+		"example.com",
+		"*.example.com",
+		"*.cafe.example.com",
+	}
+
+	for _, h := range validHosts {
+		allErrs := validateVirtualServerHost(h, field.NewPath("host"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateVirtualServerHost(%q) returned errors %v for valid input", h, allErrs)
+		}
+	}
+
+	invalidHosts := []string{
+		"",
+		"*",
+		"*.",
+		"*example.com",
+		"cafe.*.example.com",
+		"example.*",
+		"*.*.example.com",
+	}
+
+	for _, h := range invalidHosts {
+		allErrs := validateVirtualServerHost(h, field.NewPath("host"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateVirtualServerHost(%q) returned no errors for invalid input", h)
+		}
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	validAliases := [][]string{
+		nil,
+		{"www.example.com"},
+		{"www.example.com", "*.example.org"},
+	}
+
+	for _, aliases := range validAliases {
+		allErrs := validateAliases(aliases, "example.com", field.NewPath("aliases"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateAliases(%v) returned errors %v for valid input", aliases, allErrs)
+		}
+	}
+
+	invalidAliases := []struct {
+		aliases []string
+		msg     string
+	}{
+		{
+			aliases: []string{""},
+			msg:     "empty alias",
+		},
+		{
+			aliases: []string{"-example.com"},
+			msg:     "invalid alias",
+		},
+		{
+			aliases: []string{"example.com"},
+			msg:     "alias equal to the host",
+		},
+		{
+			aliases: []string{"www.example.com", "www.example.com"},
+			msg:     "duplicate alias",
+		},
+	}
+
+	for _, test := range invalidAliases {
+		allErrs := validateAliases(test.aliases, "example.com", field.NewPath("aliases"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateAliases(%v) returned no errors for invalid input for the case of %s", test.aliases, test.msg)
+		}
+	}
+}
+
+func TestValidateACMEHosts(t *testing.T) {
+	acmeTLS := &v1.TLS{
+		Secret: "cafe-secret",
+		ACME: &v1.ACME{
+			Issuer: "https://acme.example.com/directory",
+		},
+	}
+
+	tests := []struct {
+		spec     *v1.VirtualServerSpec
+		valid    bool
+		testCase string
+	}{
+		{
+			spec: &v1.VirtualServerSpec{
+				Host:    "example.com",
+				Aliases: []string{"www.example.com"},
+				TLS:     acmeTLS,
+			},
+			valid:    true,
+			testCase: "exact hosts with ACME",
+		},
+		{
+			spec: &v1.VirtualServerSpec{
+				Host: "*.example.com",
+			},
+			valid:    true,
+			testCase: "wildcard host without ACME",
+		},
+		{
+			spec: &v1.VirtualServerSpec{
+				Host: "*.example.com",
+				TLS:  acmeTLS,
+			},
+			valid:    false,
+			testCase: "wildcard host with ACME",
+		},
+		{
+			spec: &v1.VirtualServerSpec{
+				Host:    "example.com",
+				Aliases: []string{"*.example.com"},
+				TLS:     acmeTLS,
+			},
+			valid:    false,
+			testCase: "wildcard alias with ACME",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateACMEHosts(test.spec, field.NewPath("spec"))
+		if test.valid && len(allErrs) > 0 {
+			t.Errorf("validateACMEHosts() returned errors %v for valid input for the case of %s", allErrs, test.testCase)
+		}
+		if !test.valid && len(allErrs) == 0 {
+			t.Errorf("validateACMEHosts() returned no errors for invalid input for the case of %s", test.testCase)
+		}
+	}
+}
+
 func TestValidateDos(t *testing.T) {
 	validDosResources := []string{
 		"hello",
@@ -146,6 +281,58 @@ func TestValidateDosDisabled(t *testing.T) {
 	}
 }
 
+func TestValidateTracing(t *testing.T) {
+	enable := true
+	disable := false
+
+	validTracing := []*v1.Tracing{
+		nil,
+		{},
+		{
+			Enable: &disable,
+		},
+		{
+			Enable:       &enable,
+			SamplerRatio: "0.1",
+			Context:      "propagate",
+		},
+		{
+			Context: "ignore",
+		},
+	}
+
+	for _, tracing := range validTracing {
+		allErrs := validateTracing(tracing, field.NewPath("tracing"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateTracing(%+v) returned errors %v for valid input", tracing, allErrs)
+		}
+	}
+
+	invalidTracing := []*v1.Tracing{
+		{
+			SamplerRatio: "0.1",
+		},
+		{
+			Enable:       &disable,
+			SamplerRatio: "0.1",
+		},
+		{
+			Enable:       &enable,
+			SamplerRatio: "1.5",
+		},
+		{
+			Context: "on",
+		},
+	}
+
+	for _, tracing := range invalidTracing {
+		allErrs := validateTracing(tracing, field.NewPath("tracing"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateTracing(%+v) returned no errors for invalid input", tracing)
+		}
+	}
+}
+
 func TestValidatePolicies(t *testing.T) {
 	tests := []struct {
 		policies []v1.PolicyReference
@@ -290,6 +477,12 @@ func TestValidateTLS(t *testing.T) {
 				Code:   createPointerFromInt(307),
 			},
 		},
+		{
+			Secret: "my-secret",
+			ACME: &v1.ACME{
+				Issuer: "https://acme-v02.api.letsencrypt.org/directory",
+			},
+		},
 	}
 
 	for _, tls := range validTLSes {
@@ -303,6 +496,27 @@ func TestValidateTLS(t *testing.T) {
 		{
 			Secret: "-",
 		},
+		{
+			ACME: &v1.ACME{
+				Issuer: "https://acme-v02.api.letsencrypt.org/directory",
+			},
+		},
+		{
+			Secret: "my-secret",
+			ACME:   &v1.ACME{},
+		},
+		{
+			Secret: "my-secret",
+			ACME: &v1.ACME{
+				Issuer: "http://pebble:14000/dir",
+			},
+		},
+		{
+			Secret: "my-secret",
+			ACME: &v1.ACME{
+				Issuer: "pebble",
+			},
+		},
 		{
 			Secret: "a/b",
 		},
@@ -394,6 +608,565 @@ func TestValidateUpstreams(t *testing.T) {
 	}
 }
 
+func TestValidateAdditionalUpstreamServices(t *testing.T) {
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+			},
+			msg: "no additional services",
+		},
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Services: []v1.UpstreamService{
+					{
+						Name:   "test-v2",
+						Port:   80,
+						Weight: 1,
+					},
+					{
+						Name: "test",
+						Port: 8080,
+					},
+				},
+				Backup:     "test-fallback",
+				BackupPort: 80,
+				LBMethod:   "least_conn",
+			},
+			msg: "weighted and backup services",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAdditionalUpstreamServices(test.upstream, field.NewPath("upstream"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateAdditionalUpstreamServices() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateAdditionalUpstreamServicesFails(t *testing.T) {
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Services: []v1.UpstreamService{
+					{
+						Name: "@test-v2",
+						Port: 80,
+					},
+				},
+			},
+			msg: "invalid service name",
+		},
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Services: []v1.UpstreamService{
+					{
+						Name: "test-v2",
+					},
+				},
+			},
+			msg: "missing service port",
+		},
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Services: []v1.UpstreamService{
+					{
+						Name:   "test-v2",
+						Port:   80,
+						Weight: -1,
+					},
+				},
+			},
+			msg: "negative weight",
+		},
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Services: []v1.UpstreamService{
+					{
+						Name: "test",
+						Port: 80,
+					},
+				},
+			},
+			msg: "duplicated service",
+		},
+		{
+			upstream: v1.Upstream{
+				Service: "test",
+				Port:    80,
+				Backup:  "test-fallback",
+			},
+			msg: "missing backup port",
+		},
+		{
+			upstream: v1.Upstream{
+				Service:    "test",
+				Port:       80,
+				BackupPort: 80,
+			},
+			msg: "backup port without backup",
+		},
+		{
+			upstream: v1.Upstream{
+				Service:    "test",
+				Port:       80,
+				Backup:     "test",
+				BackupPort: 80,
+			},
+			msg: "backup is the primary service",
+		},
+		{
+			upstream: v1.Upstream{
+				Service:    "test",
+				Port:       80,
+				Backup:     "test-fallback",
+				BackupPort: 80,
+				LBMethod:   "ip_hash",
+			},
+			msg: "lb method incompatible with backup",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateAdditionalUpstreamServices(test.upstream, field.NewPath("upstream"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateAdditionalUpstreamServices() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateHeaders(t *testing.T) {
+	tests := []struct {
+		headers *v1.Headers
+		msg     string
+	}{
+		{
+			headers: nil,
+			msg:     "no headers",
+		},
+		{
+			headers: &v1.Headers{
+				RequestHeaders: &v1.ProxyRequestHeaders{
+					Set: []v1.Header{{Name: "X-Env", Value: "prod"}},
+				},
+				ResponseHeaders: &v1.ProxyResponseHeaders{
+					Hide: []string{"X-Powered-By"},
+				},
+				Security: &v1.SecurityHeaders{
+					HSTS:                  &v1.HSTS{Enable: true, MaxAge: 31536000, IncludeSubdomains: true},
+					FrameOptions:          "SAMEORIGIN",
+					ContentSecurityPolicy: "default-src 'self'; img-src *",
+					ReferrerPolicy:        "strict-origin-when-cross-origin",
+				},
+			},
+			msg: "headers with security presets",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateHeaders(test.headers, field.NewPath("headers"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateHeaders() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateHeadersFails(t *testing.T) {
+	tests := []struct {
+		headers *v1.Headers
+		msg     string
+	}{
+		{
+			headers: &v1.Headers{
+				RequestHeaders: &v1.ProxyRequestHeaders{
+					Set: []v1.Header{{Name: "X Env", Value: "prod"}},
+				},
+			},
+			msg: "invalid request header name",
+		},
+		{
+			headers: &v1.Headers{
+				ResponseHeaders: &v1.ProxyResponseHeaders{
+					Ignore: []string{"X-Powered-By"},
+				},
+			},
+			msg: "invalid ignore header",
+		},
+		{
+			headers: &v1.Headers{
+				Security: &v1.SecurityHeaders{
+					HSTS: &v1.HSTS{Enable: true, MaxAge: -1},
+				},
+			},
+			msg: "negative hsts max age",
+		},
+		{
+			headers: &v1.Headers{
+				Security: &v1.SecurityHeaders{
+					FrameOptions: "ALLOW-FROM https://example.com",
+				},
+			},
+			msg: "invalid frame options",
+		},
+		{
+			headers: &v1.Headers{
+				Security: &v1.SecurityHeaders{
+					ContentSecurityPolicy: "default-src $host",
+				},
+			},
+			msg: "content security policy with a variable",
+		},
+		{
+			headers: &v1.Headers{
+				Security: &v1.SecurityHeaders{
+					ContentSecurityPolicy: `default-src "self"`,
+				},
+			},
+			msg: "content security policy with unescaped double quotes",
+		},
+		{
+			headers: &v1.Headers{
+				Security: &v1.SecurityHeaders{
+					ReferrerPolicy: "never",
+				},
+			},
+			msg: "invalid referrer policy",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateHeaders(test.headers, field.NewPath("headers"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateHeaders() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateRedirects(t *testing.T) {
+	tests := []struct {
+		redirects *v1.Redirects
+		host      string
+		isPlus    bool
+		msg       string
+	}{
+		{
+			redirects: nil,
+			host:      "example.com",
+			msg:       "no redirects",
+		},
+		{
+			redirects: &v1.Redirects{
+				CanonicalHost: true,
+				TrailingSlash: "remove",
+				Code:          308,
+				Paths: []v1.PathRedirect{
+					{From: "/old", To: "/new"},
+					{From: "~ ^/blog/(.*)$", To: "https://blog.example.com/$1", Code: 302},
+					{From: "~*^/docs/(.*)/v([0-9]+)$", To: "/documentation/$2/$1"},
+				},
+			},
+			host: "example.com",
+			msg:  "canonical host, trailing slash and paths",
+		},
+		{
+			redirects: &v1.Redirects{
+				TrailingSlash: "add",
+			},
+			host: "*.example.com",
+			msg:  "trailing slash with a wildcard host",
+		},
+		{
+			redirects: &v1.Redirects{
+				LowercasePaths: true,
+			},
+			host:   "example.com",
+			isPlus: true,
+			msg:    "lowercase paths in NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		vsv := &VirtualServerValidator{isPlus: test.isPlus}
+
+		allErrs := vsv.validateRedirects(test.redirects, test.host, field.NewPath("redirects"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateRedirects() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateRedirectsFails(t *testing.T) {
+	tests := []struct {
+		redirects *v1.Redirects
+		host      string
+		msg       string
+	}{
+		{
+			redirects: &v1.Redirects{
+				CanonicalHost: true,
+			},
+			host: "*.example.com",
+			msg:  "canonical host with a wildcard host",
+		},
+		{
+			redirects: &v1.Redirects{
+				TrailingSlash: "keep",
+			},
+			host: "example.com",
+			msg:  "invalid trailing slash action",
+		},
+		{
+			redirects: &v1.Redirects{
+				LowercasePaths: true,
+			},
+			host: "example.com",
+			msg:  "lowercase paths in NGINX",
+		},
+		{
+			redirects: &v1.Redirects{
+				Code: 200,
+			},
+			host: "example.com",
+			msg:  "invalid code",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "/old", To: "/new", Code: 404}},
+			},
+			host: "example.com",
+			msg:  "invalid path code",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "", To: "/new"}},
+			},
+			host: "example.com",
+			msg:  "missing from",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "old", To: "/new"}},
+			},
+			host: "example.com",
+			msg:  "from without a leading slash",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: `/old"`, To: "/new"}},
+			},
+			host: "example.com",
+			msg:  "from with a double quote",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "~^/old/(.*$", To: "/new/$1"}},
+			},
+			host: "example.com",
+			msg:  "invalid regular expression",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{
+					{From: "/old", To: "/new"},
+					{From: "/old", To: "/newer"},
+				},
+			},
+			host: "example.com",
+			msg:  "duplicate from",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "/old", To: ""}},
+			},
+			host: "example.com",
+			msg:  "missing to",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "/old", To: "new"}},
+			},
+			host: "example.com",
+			msg:  "relative to",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "/old", To: "/new?page=1"}},
+			},
+			host: "example.com",
+			msg:  "to with a query string",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "~^/old/(.*)$", To: "/new/$host"}},
+			},
+			host: "example.com",
+			msg:  "to with a variable",
+		},
+		{
+			redirects: &v1.Redirects{
+				Paths: []v1.PathRedirect{{From: "/old", To: "/new/$1"}},
+			},
+			host: "example.com",
+			msg:  "to with a capture for an exact path",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateRedirects(test.redirects, test.host, field.NewPath("redirects"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateRedirects() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateTopologyAware(t *testing.T) {
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{Service: "test", Port: 80},
+			msg:      "no topology aware routing",
+		},
+		{
+			upstream: v1.Upstream{
+				Service:       "test",
+				Port:          80,
+				TopologyAware: &v1.TopologyAware{Enable: true, MinEndpoints: 2},
+			},
+			msg: "topology aware routing",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTopologyAware(test.upstream, field.NewPath("topologyAware"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateTopologyAware() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateTopologyAwareFails(t *testing.T) {
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{
+				Service:       "test",
+				Port:          80,
+				TopologyAware: &v1.TopologyAware{Enable: true, MinEndpoints: -1},
+			},
+			msg: "negative min endpoints",
+		},
+		{
+			upstream: v1.Upstream{
+				Service:       "test",
+				Port:          80,
+				UseClusterIP:  true,
+				TopologyAware: &v1.TopologyAware{Enable: true},
+			},
+			msg: "use-cluster-ip",
+		},
+		{
+			upstream: v1.Upstream{
+				Addresses:     []string{"10.0.0.1:80"},
+				TopologyAware: &v1.TopologyAware{Enable: true},
+			},
+			msg: "addresses",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateTopologyAware(test.upstream, field.NewPath("topologyAware"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateTopologyAware() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateUpstreamWithAddressesFails(t *testing.T) {
+	tests := []struct {
+		upstream v1.Upstream
+		msg      string
+	}{
+		{
+			upstream: v1.Upstream{
+				Service:   "test",
+				Addresses: []string{"example.com:80"},
+			},
+			msg: "service with addresses",
+		},
+		{
+			upstream: v1.Upstream{
+				Port:      80,
+				Addresses: []string{"example.com:80"},
+			},
+			msg: "port with addresses",
+		},
+		{
+			upstream: v1.Upstream{
+				Subselector: map[string]string{"version": "v1"},
+				Addresses:   []string{"example.com:80"},
+			},
+			msg: "subselector with addresses",
+		},
+		{
+			upstream: v1.Upstream{
+				UseClusterIP: true,
+				Addresses:    []string{"example.com:80"},
+			},
+			msg: "use-cluster-ip with addresses",
+		},
+		{
+			upstream: v1.Upstream{
+				Services:  []v1.UpstreamService{{Name: "test-v2", Port: 80}},
+				Addresses: []string{"example.com:80"},
+			},
+			msg: "services with addresses",
+		},
+		{
+			upstream: v1.Upstream{
+				Backup:     "test-fallback",
+				BackupPort: 80,
+				Addresses:  []string{"example.com:80"},
+			},
+			msg: "backup with addresses",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateUpstreamWithAddresses(test.upstream, field.NewPath("upstream"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateUpstreamWithAddresses() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateUpstreamsFails(t *testing.T) {
 	tests := []struct {
 		upstreams             []v1.Upstream
@@ -885,6 +1658,62 @@ func TestValidateRoute(t *testing.T) {
 			isRouteFieldForbidden: false,
 			msg:                   "valid route with route",
 		},
+		{
+			route: v1.Route{
+				Path: "/",
+				Splits: []v1.Split{
+					{
+						Weight: 90,
+						Action: &v1.Action{
+							Pass: "test-1",
+						},
+					},
+					{
+						Weight: 10,
+						Action: &v1.Action{
+							Pass: "test-2",
+						},
+					},
+				},
+				SplitKey: &v1.SplitKey{
+					Header: "x-user-id",
+				},
+				StickySplits: &v1.StickySplits{
+					Cookie: "canary",
+					MaxAge: 3600,
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test-1": {},
+				"test-2": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid splits with split key and sticky splits",
+		},
+		{
+			route: v1.Route{
+				Path: "/upload",
+				Action: &v1.Action{
+					Pass: "test",
+				},
+				Proxy: &v1.RouteProxy{
+					ProxyConnectTimeout: "30s",
+					ProxyReadTimeout:    "5m",
+					ProxySendTimeout:    "5m",
+					ClientMaxBodySize:   "1g",
+					ProxyBuffers: &v1.UpstreamBuffers{
+						Number: 8,
+						Size:   "16k",
+					},
+					ProxyBufferSize: "16k",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "valid route with proxy settings",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
@@ -1014,9 +1843,54 @@ func TestValidateRouteFails(t *testing.T) {
 				Path:  "/",
 				Route: "default/test",
 			},
-			upstreamNames:         map[string]sets.Empty{},
-			isRouteFieldForbidden: true,
-			msg:                   "route field exists but is forbidden",
+			upstreamNames:         map[string]sets.Empty{},
+			isRouteFieldForbidden: true,
+			msg:                   "route field exists but is forbidden",
+		},
+		{
+			route: v1.Route{
+				Path: "/",
+				Action: &v1.Action{
+					Pass: "test",
+				},
+				StickySplits: &v1.StickySplits{
+					Cookie: "canary",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "sticky splits without splits",
+		},
+		{
+			route: v1.Route{
+				Path: "/upload",
+				Action: &v1.Action{
+					Pass: "test",
+				},
+				Proxy: &v1.RouteProxy{
+					ProxyReadTimeout:  "5x",
+					ClientMaxBodySize: "1gb",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			isRouteFieldForbidden: false,
+			msg:                   "invalid proxy settings",
+		},
+		{
+			route: v1.Route{
+				Path:  "/upload",
+				Route: "upload",
+				Proxy: &v1.RouteProxy{
+					ClientMaxBodySize: "1g",
+				},
+			},
+			upstreamNames:         sets.String{},
+			isRouteFieldForbidden: false,
+			msg:                   "proxy settings with route",
 		},
 	}
 
@@ -1395,9 +2269,9 @@ func TestValidateRegexPath(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		allErrs := validateRegexPath(test.regexPath, field.NewPath("path"))
+		allErrs := ValidateRegexPath(test.regexPath, field.NewPath("path"))
 		if len(allErrs) != 0 {
-			t.Errorf("validateRegexPath(%v) returned errors for valid input for the case of %v", test.regexPath, test.msg)
+			t.Errorf("ValidateRegexPath(%v) returned errors for valid input for the case of %v", test.regexPath, test.msg)
 		}
 	}
 }
@@ -1426,9 +2300,9 @@ func TestValidateRegexPathFails(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		allErrs := validateRegexPath(test.regexPath, field.NewPath("path"))
+		allErrs := ValidateRegexPath(test.regexPath, field.NewPath("path"))
 		if len(allErrs) == 0 {
-			t.Errorf("validateRegexPath(%v) returned no errors for invalid input for the case of %v", test.regexPath, test.msg)
+			t.Errorf("ValidateRegexPath(%v) returned no errors for invalid input for the case of %v", test.regexPath, test.msg)
 		}
 	}
 }
@@ -1638,6 +2512,137 @@ func TestValidateSplitsFails(t *testing.T) {
 	}
 }
 
+func TestValidateSplitKey(t *testing.T) {
+	tests := []struct {
+		splitKey *v1.SplitKey
+		isPlus   bool
+		msg      string
+	}{
+		{
+			splitKey: nil,
+			msg:      "no split key",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				Cookie: "user",
+			},
+			msg: "cookie",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				Header: "x-user-id",
+			},
+			msg: "header",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				ClientIP: true,
+			},
+			msg: "client IP",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				JWTSubject: true,
+			},
+			isPlus: true,
+			msg:    "jwt subject in NGINX Plus",
+		},
+	}
+
+	for _, test := range tests {
+		vsv := &VirtualServerValidator{isPlus: test.isPlus}
+		allErrs := vsv.validateSplitKey(test.splitKey, field.NewPath("splitKey"))
+		if len(allErrs) > 0 {
+			t.Errorf("validateSplitKey() returned errors %v for valid input for the case of %s", allErrs, test.msg)
+		}
+	}
+}
+
+func TestValidateSplitKeyFails(t *testing.T) {
+	tests := []struct {
+		splitKey *v1.SplitKey
+		msg      string
+	}{
+		{
+			splitKey: &v1.SplitKey{},
+			msg:      "empty split key",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				Cookie:   "user",
+				ClientIP: true,
+			},
+			msg: "cookie and client IP",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				Header: "x_user",
+			},
+			msg: "invalid header",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				Cookie: "my-cookie",
+			},
+			msg: "invalid cookie",
+		},
+		{
+			splitKey: &v1.SplitKey{
+				JWTSubject: true,
+			},
+			msg: "jwt subject in NGINX",
+		},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: false}
+
+	for _, test := range tests {
+		allErrs := vsv.validateSplitKey(test.splitKey, field.NewPath("splitKey"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateSplitKey() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
+func TestValidateStickySplitsFails(t *testing.T) {
+	tests := []struct {
+		sticky *v1.StickySplits
+		msg    string
+	}{
+		{
+			sticky: &v1.StickySplits{},
+			msg:    "missing cookie",
+		},
+		{
+			sticky: &v1.StickySplits{
+				Cookie: "my-cookie",
+			},
+			msg: "invalid cookie",
+		},
+		{
+			sticky: &v1.StickySplits{
+				Cookie: "canary",
+				Path:   `/a"b`,
+			},
+			msg: "path with a double quote",
+		},
+		{
+			sticky: &v1.StickySplits{
+				Cookie: "canary",
+				MaxAge: -1,
+			},
+			msg: "negative max age",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateStickySplits(test.sticky, field.NewPath("stickySplits"))
+		if len(allErrs) == 0 {
+			t.Errorf("validateStickySplits() returned no errors for invalid input for the case of %s", test.msg)
+		}
+	}
+}
+
 func TestValidateCondition(t *testing.T) {
 	tests := []struct {
 		condition v1.Condition
@@ -1671,6 +2676,82 @@ func TestValidateCondition(t *testing.T) {
 			},
 			msg: "valid variable",
 		},
+		{
+			condition: v1.Condition{
+				Method: true,
+				Value:  "POST",
+			},
+			msg: "valid method",
+		},
+		{
+			condition: v1.Condition{
+				Method:   true,
+				Operator: v1.ConditionOperatorRegex,
+				Value:    "^(GET|HEAD)$",
+			},
+			msg: "valid method with regex",
+		},
+		{
+			condition: v1.Condition{
+				ClientIP: true,
+				Value:    "10.0.0.0/8",
+			},
+			msg: "valid client CIDR",
+		},
+		{
+			condition: v1.Condition{
+				ClientIP: true,
+				Negate:   true,
+				Value:    "192.168.1.1",
+			},
+			msg: "valid negated client IP",
+		},
+		{
+			condition: v1.Condition{
+				JWTClaim: "group",
+				Value:    "admins",
+			},
+			msg: "valid jwt claim",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-user-group",
+				Operator: v1.ConditionOperatorRegex,
+				Value:    "^beta-",
+			},
+			msg: "valid regex operator",
+		},
+		{
+			condition: v1.Condition{
+				Argument: "version",
+				Operator: v1.ConditionOperatorPrefix,
+				Value:    "v1.",
+			},
+			msg: "valid prefix operator",
+		},
+		{
+			condition: v1.Condition{
+				Cookie:   "session",
+				Operator: v1.ConditionOperatorPresent,
+			},
+			msg: "valid present operator",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-debug",
+				Operator: v1.ConditionOperatorAbsent,
+				Negate:   true,
+			},
+			msg: "valid negated absent operator",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: v1.ConditionOperatorExact,
+				Value:    "v1",
+			},
+			msg: "valid exact operator",
+		},
 	}
 
 	for _, test := range tests {
@@ -1724,6 +2805,82 @@ func TestValidateConditionFails(t *testing.T) {
 			},
 			msg: "invalid variable",
 		},
+		{
+			condition: v1.Condition{
+				Header: "x-version",
+				Method: true,
+				Value:  "POST",
+			},
+			msg: "header and method",
+		},
+		{
+			condition: v1.Condition{
+				JWTClaim: "my-claim",
+				Value:    "yes",
+			},
+			msg: "invalid jwt claim",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: "contains",
+				Value:    "v1",
+			},
+			msg: "invalid operator",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: v1.ConditionOperatorRegex,
+				Value:    "^(v1",
+			},
+			msg: "invalid regex",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: v1.ConditionOperatorRegex,
+			},
+			msg: "empty regex",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: v1.ConditionOperatorPrefix,
+				Value:    `v1"`,
+			},
+			msg: "prefix with a double quote",
+		},
+		{
+			condition: v1.Condition{
+				Header:   "x-version",
+				Operator: v1.ConditionOperatorPresent,
+				Value:    "v1",
+			},
+			msg: "present operator with a value",
+		},
+		{
+			condition: v1.Condition{
+				Method:   true,
+				Operator: v1.ConditionOperatorAbsent,
+			},
+			msg: "absent operator for method",
+		},
+		{
+			condition: v1.Condition{
+				ClientIP: true,
+				Value:    "10.0.0.0/33",
+			},
+			msg: "invalid client CIDR",
+		},
+		{
+			condition: v1.Condition{
+				ClientIP: true,
+				Operator: v1.ConditionOperatorPrefix,
+				Value:    "10.0.",
+			},
+			msg: "prefix operator for client IP",
+		},
 	}
 
 	for _, test := range tests {
@@ -1877,6 +3034,30 @@ func TestValidateMatch(t *testing.T) {
 	}
 }
 
+func TestValidateMatchWithJWTClaimForPlus(t *testing.T) {
+	match := v1.Match{
+		Conditions: []v1.Condition{
+			{
+				JWTClaim: "group",
+				Value:    "admins",
+			},
+		},
+		Action: &v1.Action{
+			Pass: "test",
+		},
+	}
+	upstreamNames := map[string]sets.Empty{
+		"test": {},
+	}
+
+	vsv := &VirtualServerValidator{isPlus: true}
+
+	allErrs := vsv.validateMatch(match, field.NewPath("match"), upstreamNames, "")
+	if len(allErrs) > 0 {
+		t.Errorf("validateMatch() returned errors %v for a jwt claim condition in NGINX Plus", allErrs)
+	}
+}
+
 func TestValidateMatchFails(t *testing.T) {
 	tests := []struct {
 		match         v1.Match
@@ -1957,6 +3138,23 @@ func TestValidateMatchFails(t *testing.T) {
 			},
 			msg: "both splits and action are set",
 		},
+		{
+			match: v1.Match{
+				Conditions: []v1.Condition{
+					{
+						JWTClaim: "group",
+						Value:    "admins",
+					},
+				},
+				Action: &v1.Action{
+					Pass: "test",
+				},
+			},
+			upstreamNames: map[string]sets.Empty{
+				"test": {},
+			},
+			msg: "jwt claim condition in NGINX",
+		},
 	}
 
 	vsv := &VirtualServerValidator{isPlus: false}
@@ -2770,6 +3968,17 @@ func TestRejectPlusResourcesInOSS(t *testing.T) {
 	}
 }
 
+func TestRejectPlusResourcesInOSSWithHashSessionCookie(t *testing.T) {
+	upstream := v1.Upstream{
+		SessionCookie: &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash"},
+	}
+
+	allErrs := rejectPlusResourcesInOSS(upstream, field.NewPath("upstreams"), false)
+	if len(allErrs) != 0 {
+		t.Errorf("rejectPlusResourcesInOSS() returned errors %v for the session cookie with the hash mode", allErrs)
+	}
+}
+
 func TestValidateQueue(t *testing.T) {
 	tests := []struct {
 		upstreamQueue *v1.UpstreamQueue
@@ -2840,6 +4049,22 @@ func TestValidateSessionCookie(t *testing.T) {
 
 			msg: "max valid config",
 		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "sticky"},
+			msg: "valid config with the sticky mode",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash", Path: "/tea", Expires: "1h", Secure: true},
+			msg: "valid config with the hash mode",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "hash", Header: "X-Session-ID"},
+			msg: "valid config with the hash mode and a header",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "hash", ClientIP: true},
+			msg: "valid config with the hash mode and the client IP",
+		},
 	}
 	for _, test := range tests {
 		allErrs := validateSessionCookie(test.sc, field.NewPath("sessionCookie"))
@@ -2874,6 +4099,42 @@ func TestValidateSessionCookieFails(t *testing.T) {
 			sc:  &v1.SessionCookie{Enable: true, Name: "test", Path: "/ coffee"},
 			msg: "invalid path format",
 		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "ip"},
+			msg: "invalid mode",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Header: "X-Session-ID"},
+			msg: "header without the hash mode",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "sticky", ClientIP: true},
+			msg: "client IP without the hash mode",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "hash", Header: "X-Session-ID", ClientIP: true},
+			msg: "both header and client IP",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "hash", Header: "X Session"},
+			msg: "invalid header",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Mode: "hash"},
+			msg: "hash mode without name",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash", Path: `/tea"`},
+			msg: "hash mode with a quote in the path",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash", Expires: "500ms"},
+			msg: "hash mode with expires under 1s",
+		},
+		{
+			sc:  &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash", Expires: "0"},
+			msg: "hash mode with zero expires",
+		},
 	}
 	for _, test := range tests {
 		allErrs := validateSessionCookie(test.sc, field.NewPath("sessionCookie"))
@@ -2883,6 +4144,55 @@ func TestValidateSessionCookieFails(t *testing.T) {
 	}
 }
 
+func TestValidateUpstreamForHashSessionCookie(t *testing.T) {
+	tests := []struct {
+		upstream       v1.Upstream
+		expectedErrors int
+		msg            string
+	}{
+		{
+			upstream: v1.Upstream{
+				LBMethod:      "least_conn",
+				Backup:        "backup-svc",
+				SessionCookie: &v1.SessionCookie{Enable: true, Name: "test"},
+			},
+			expectedErrors: 0,
+			msg:            "sticky mode",
+		},
+		{
+			upstream: v1.Upstream{
+				SessionCookie: &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash"},
+			},
+			expectedErrors: 0,
+			msg:            "hash mode",
+		},
+		{
+			upstream: v1.Upstream{
+				LBMethod:      "least_conn",
+				SessionCookie: &v1.SessionCookie{Enable: true, Name: "test", Mode: "hash"},
+			},
+			expectedErrors: 1,
+			msg:            "hash mode with lb-method",
+		},
+		{
+			upstream: v1.Upstream{
+				LBMethod:      "least_conn",
+				Backup:        "backup-svc",
+				SessionCookie: &v1.SessionCookie{Enable: true, Mode: "hash", ClientIP: true},
+			},
+			expectedErrors: 2,
+			msg:            "hash mode with lb-method and backup",
+		},
+	}
+
+	for _, test := range tests {
+		allErrs := validateUpstreamForHashSessionCookie(test.upstream, field.NewPath("upstreams").Index(0))
+		if len(allErrs) != test.expectedErrors {
+			t.Errorf("validateUpstreamForHashSessionCookie() returned %d errors %v but expected %d for the case of %s", len(allErrs), allErrs, test.expectedErrors, test.msg)
+		}
+	}
+}
+
 func TestValidateRedirectStatusCode(t *testing.T) {
 	tests := []struct {
 		code int
@@ -20,6 +20,10 @@ func (c *FakeK8sV1alpha1) Policies(namespace string) v1alpha1.PolicyInterface {
 	return &FakePolicies{c, namespace}
 }
 
+func (c *FakeK8sV1alpha1) StaticResponses(namespace string) v1alpha1.StaticResponseInterface {
+	return &FakeStaticResponses{c, namespace}
+}
+
 func (c *FakeK8sV1alpha1) TransportServers(namespace string) v1alpha1.TransportServerInterface {
 	return &FakeTransportServers{c, namespace}
 }
@@ -6,4 +6,6 @@ type GlobalConfigurationExpansion interface{}
 
 type PolicyExpansion interface{}
 
+type StaticResponseExpansion interface{}
+
 type TransportServerExpansion interface{}
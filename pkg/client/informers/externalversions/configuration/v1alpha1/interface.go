@@ -12,6 +12,8 @@ type Interface interface {
 	GlobalConfigurations() GlobalConfigurationInformer
 	// Policies returns a PolicyInformer.
 	Policies() PolicyInformer
+	// StaticResponses returns a StaticResponseInformer.
+	StaticResponses() StaticResponseInformer
 	// TransportServers returns a TransportServerInformer.
 	TransportServers() TransportServerInformer
 }
@@ -37,6 +39,11 @@ func (v *version) Policies() PolicyInformer {
 	return &policyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// StaticResponses returns a StaticResponseInformer.
+func (v *version) StaticResponses() StaticResponseInformer {
+	return &staticResponseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TransportServers returns a TransportServerInformer.
 func (v *version) TransportServers() TransportServerInformer {
 	return &transportServerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,14 @@ type PolicyListerExpansion interface{}
 // PolicyNamespaceLister.
 type PolicyNamespaceListerExpansion interface{}
 
+// StaticResponseListerExpansion allows custom methods to be added to
+// StaticResponseLister.
+type StaticResponseListerExpansion interface{}
+
+// StaticResponseNamespaceListerExpansion allows custom methods to be added to
+// StaticResponseNamespaceLister.
+type StaticResponseNamespaceListerExpansion interface{}
+
 // TransportServerListerExpansion allows custom methods to be added to
 // TransportServerLister.
 type TransportServerListerExpansion interface{}